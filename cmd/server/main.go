@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"dz-jobs-api/config"
 	"dz-jobs-api/internal/bootstrap"
 	v1 "dz-jobs-api/internal/routes/api/v1"
@@ -25,8 +26,17 @@ func main() {
 		log.Fatalf("Failed to initialize dependencies: %v", err)
 	}
 
+	// Start background schedulers
+	go deps.JobAlertScheduler.Start(context.Background())
+	go deps.JobLifecycleScheduler.Start(context.Background())
+	go deps.EmailOutboxScheduler.Start(context.Background())
+	go deps.NotificationService.Listen(context.Background())
+
 	// Create server
-	server := bootstrap.CreateServer(appConfig)
+	server, err := bootstrap.CreateServer(appConfig)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
 
 	// Register all routes (public, protected, role-based)
 	v1.RegisterRoutes(
@@ -43,7 +53,23 @@ func main() {
 		deps.PortfolioController,
 		deps.JobController,
 		deps.BookmarksController,
+		deps.ApplicationController,
+		deps.PipelineController,
+		deps.SkillCatalogController,
+		deps.RecommendationController,
+		deps.SavedSearchController,
 		deps.SystemController,
+		deps.JobFeedController,
+		deps.LocationController,
+		deps.JobCategoryController,
+		deps.ScreeningQuestionController,
+		deps.InterviewController,
+		deps.MessageController,
+		deps.NotificationController,
+		deps.NotificationPreferenceController,
+		deps.TwoFactorController,
+		deps.RedisRepository,
+		deps.RateLimiter,
 		appConfig,
 	)
 
@@ -3,6 +3,8 @@ package config
 import (
 	"dz-jobs-api/pkg/utils"
 	"log"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -19,9 +21,12 @@ type AppConfig struct {
 	AccessTokenSecret        string
 	RefreshTokenSecret       string
 	ResetPasswordTokenSecret string
+	UnsubscribeTokenSecret   string
+	TwoFactorSecretKey       string // Encrypts the stored TOTP secrets
 	AccessTokenMaxAge        time.Duration
 	RefreshTokenMaxAge       time.Duration
 	ResetPasswordTokenMaxAge time.Duration
+	TwoFactorTokenMaxAge     time.Duration
 	GoogleClientID           string
 	GoogleClientSecret       string
 	GoogleRedirectURL        string
@@ -39,6 +44,10 @@ type AppConfig struct {
 	VersionURL               string
 	MetricsURL               string
 	ServiceEmail             string
+	JobDefaultLifetime       time.Duration
+	JobRepostCooldown        time.Duration
+	RequireAdminTwoFactor    bool
+	TrustedProxies           []string // Proxies whose X-Forwarded-For header gives the client IP
 }
 
 func LoadConfig() (*AppConfig, error) {
@@ -57,9 +66,12 @@ func LoadConfig() (*AppConfig, error) {
 		AccessTokenSecret:        getEnvOrFatal("ACCESS_TOKEN_SECRET", "string").(string),
 		RefreshTokenSecret:       getEnvOrFatal("REFRESH_TOKEN_SECRET", "string").(string),
 		ResetPasswordTokenSecret: getEnvOrFatal("RESET_PASSWORD_TOKEN_SECRET", "string").(string),
+		UnsubscribeTokenSecret:   getEnvOrFatal("UNSUBSCRIBE_TOKEN_SECRET", "string").(string),
+		TwoFactorSecretKey:       getEnvOrFatal("TWO_FACTOR_SECRET_KEY", "string").(string),
 		AccessTokenMaxAge:        getEnvOrFatal("ACCESS_TOKEN_MAX_AGE", "duration").(time.Duration),
 		RefreshTokenMaxAge:       getEnvOrFatal("REFRESH_TOKEN_MAX_AGE", "duration").(time.Duration),
 		ResetPasswordTokenMaxAge: getEnvOrFatal("RESET_PASSWORD_TOKEN_MAX_AGE", "duration").(time.Duration),
+		TwoFactorTokenMaxAge:     getEnvOrDefault("TWO_FACTOR_TOKEN_MAX_AGE", "duration", 5*time.Minute).(time.Duration),
 		GoogleClientID:           getEnvOrFatal("GOOGLE_CLIENT_ID", "string").(string),
 		GoogleClientSecret:       getEnvOrFatal("GOOGLE_CLIENT_SECRET", "string").(string),
 		GoogleRedirectURL:        getEnvOrFatal("GOOGLE_REDIRECT_URL", "string").(string),
@@ -77,10 +89,26 @@ func LoadConfig() (*AppConfig, error) {
 		VersionURL:               getEnvOrFatal("VERSION_URL", "string").(string),
 		MetricsURL:               getEnvOrFatal("METRICS_URL", "string").(string),
 		ServiceEmail:             getEnvOrFatal("SERVICE_EMAIL", "string").(string),
+		JobDefaultLifetime:       getEnvOrDefault("JOB_DEFAULT_LIFETIME", "duration", 30*24*time.Hour).(time.Duration),
+		JobRepostCooldown:        getEnvOrDefault("JOB_REPOST_COOLDOWN", "duration", 24*time.Hour).(time.Duration),
+		RequireAdminTwoFactor:    getEnvOrDefault("REQUIRE_ADMIN_TWO_FACTOR", "bool", false).(bool),
+		TrustedProxies:           splitList(getEnvOrDefault("TRUSTED_PROXIES", "string", "").(string)),
 	}
 	return config, nil
 }
 
+// splitList splits a comma separated list, skipping empty items. It returns nil
+// for an empty list.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnvOrFatal(key, expectedType string) interface{} {
 	val, err := utils.GetEnv(key, expectedType)
 	if err != nil {
@@ -88,3 +116,10 @@ func getEnvOrFatal(key, expectedType string) interface{} {
 	}
 	return val
 }
+
+func getEnvOrDefault(key, expectedType string, defaultValue interface{}) interface{} {
+	if os.Getenv(key) == "" {
+		return defaultValue
+	}
+	return getEnvOrFatal(key, expectedType)
+}
@@ -19,7 +19,6 @@ require (
 	github.com/swaggo/swag v1.16.4
 	golang.org/x/crypto v0.29.0
 	golang.org/x/oauth2 v0.24.0
-	golang.org/x/time v0.8.0
 )
 
 require (
@@ -1,30 +1,53 @@
 package bootstrap
 
 import (
+	"context"
 	"dz-jobs-api/config"
 	"dz-jobs-api/internal/controllers"
 	"dz-jobs-api/internal/integrations"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
 	"dz-jobs-api/internal/repositories/postgresql"
 	"dz-jobs-api/internal/repositories/redis"
+	"dz-jobs-api/internal/scheduler"
 	"dz-jobs-api/internal/services"
 	"dz-jobs-api/pkg/utils"
 )
 
 type AppDependencies struct {
-	UserController           *controllers.UserController
-	AuthController           *controllers.AuthController
-	RedisClient              *config.RedisConfig
-	CandidateController      *controllers.CandidateController
-	PersonalInfoController   *controllers.CandidatePersonalInfoController
-	EducationController      *controllers.CandidateEducationController
-	ExperienceController     *controllers.CandidateExperienceController
-	SkillsController         *controllers.CandidateSkillsController
-	CertificationsController *controllers.CandidateCertificationsController
-	PortfolioController      *controllers.CandidatePortfolioController
-	RecruiterController      *controllers.RecruiterController
-	JobController            *controllers.JobController
-	BookmarksController      *controllers.BookmarksController
-	SystemController         *controllers.SystemController
+	UserController                   *controllers.UserController
+	AuthController                   *controllers.AuthController
+	RedisClient                      *config.RedisConfig
+	CandidateController              *controllers.CandidateController
+	PersonalInfoController           *controllers.CandidatePersonalInfoController
+	EducationController              *controllers.CandidateEducationController
+	ExperienceController             *controllers.CandidateExperienceController
+	SkillsController                 *controllers.CandidateSkillsController
+	CertificationsController         *controllers.CandidateCertificationsController
+	PortfolioController              *controllers.CandidatePortfolioController
+	RecruiterController              *controllers.RecruiterController
+	JobController                    *controllers.JobController
+	BookmarksController              *controllers.BookmarksController
+	ApplicationController            *controllers.ApplicationController
+	PipelineController               *controllers.PipelineController
+	SkillCatalogController           *controllers.SkillCatalogController
+	RecommendationController         *controllers.RecommendationController
+	SavedSearchController            *controllers.SavedSearchController
+	JobAlertScheduler                *scheduler.JobAlertScheduler
+	JobLifecycleScheduler            *scheduler.JobLifecycleScheduler
+	SystemController                 *controllers.SystemController
+	JobFeedController                *controllers.JobFeedController
+	LocationController               *controllers.LocationController
+	JobCategoryController            *controllers.JobCategoryController
+	ScreeningQuestionController      *controllers.ScreeningQuestionController
+	InterviewController              *controllers.InterviewController
+	MessageController                *controllers.MessageController
+	NotificationController           *controllers.NotificationController
+	NotificationService              *services.NotificationService
+	NotificationPreferenceController *controllers.NotificationPreferenceController
+	TwoFactorController              *controllers.TwoFactorController
+	RedisRepository                  repositoryInterfaces.RedisRepository
+	RateLimiter                      repositoryInterfaces.RateLimiter
+	EmailOutboxScheduler             *scheduler.EmailOutboxScheduler
 }
 
 func InitializeDependencies(cfg *config.AppConfig) (*AppDependencies, error) {
@@ -53,24 +76,58 @@ func InitializeDependencies(cfg *config.AppConfig) (*AppDependencies, error) {
 	recruiterRepo := postgresql.NewRecruiterRepository(dbConfig.DB)
 	jobRepo := postgresql.NewJobRepository(dbConfig.DB)
 	bookmarksRepo := postgresql.NewBookmarskRepository(dbConfig.DB)
+	applicationRepo := postgresql.NewApplicationRepository(dbConfig.DB)
+	pipelineStageRepo := postgresql.NewPipelineStageRepository(dbConfig.DB)
+	skillCatalogRepo := postgresql.NewSkillCatalogRepository(dbConfig.DB)
+	recommendationRepo := postgresql.NewRecommendationRepository(dbConfig.DB)
+	savedSearchRepo := postgresql.NewSavedSearchRepository(dbConfig.DB)
+	jobCategoryRepo := postgresql.NewJobCategoryRepository(dbConfig.DB)
+	screeningQuestionRepo := postgresql.NewScreeningQuestionRepository(dbConfig.DB)
+	interviewRepo := postgresql.NewInterviewRepository(dbConfig.DB)
+	messageRepo := postgresql.NewMessageRepository(dbConfig.DB)
+	notificationRepo := postgresql.NewNotificationRepository(dbConfig.DB)
+	notificationBroker := redis.NewNotificationBroker(redisConfig.Client)
+	rateLimiter := redis.NewRateLimiter(redisConfig.Client)
+	notificationPreferenceRepo := postgresql.NewNotificationPreferenceRepository(dbConfig.DB)
+	emailRepo := postgresql.NewEmailRepository(dbConfig.DB)
+	twoFactorRepo := postgresql.NewTwoFactorRepository(dbConfig.DB)
 
 	// Initialize Services
 	authService := services.NewAuthService(
 		userRepo,
 		redisRepo,
+		twoFactorRepo,
 		cfg,
 	)
+	twoFactorService := services.NewTwoFactorService(twoFactorRepo, userRepo, redisRepo, cfg)
+	if err := twoFactorService.SealStoredSecrets(context.Background()); err != nil {
+		return nil, err
+	}
+	emailService := services.NewEmailService(emailRepo, notificationPreferenceRepo, cfg)
+	notificationService := services.NewNotificationService(notificationRepo, notificationBroker, notificationPreferenceRepo, userRepo, emailService, cfg)
+	notificationPreferenceService := services.NewNotificationPreferenceService(notificationPreferenceRepo, cfg)
 	userService := services.NewUserService(userRepo)
 	candidateService := services.NewCandidateService(candidateRepo, redisRepo, cfg)
 	personalInfoService := services.NewCandidatePersonalInfoService(personalInfoRepo)
 	educationService := services.NewCandidateEducationService(educationRepo, cfg)
 	experienceService := services.NewCandidateExperienceService(experienceRepo)
-	skillsService := services.NewCandidateSkillService(skillsRepo)
+	skillsService := services.NewCandidateSkillService(skillsRepo, skillCatalogRepo)
 	certificationsService := services.NewCandidateCertificationsService(certificationRepo)
 	portfolioService := services.NewCandidatePortfolioService(portfolioRepo)
 	recruiterService := services.NewRecruiterService(recruiterRepo, redisRepo, cfg)
-	jobService := services.NewJobService(jobRepo)
+	jobService := services.NewJobService(jobRepo, skillCatalogRepo, recruiterRepo, jobCategoryRepo, bookmarksRepo, userRepo, notificationService, emailService, cfg)
 	bookmarksService := services.NewBookmarksService(bookmarksRepo)
+	applicationService := services.NewApplicationService(applicationRepo, jobRepo, candidateRepo, pipelineStageRepo, screeningQuestionRepo, userRepo, notificationService)
+	pipelineService := services.NewPipelineService(pipelineStageRepo, applicationRepo, jobRepo, notificationService)
+	skillCatalogService := services.NewSkillCatalogService(skillCatalogRepo)
+	recommendationService := services.NewRecommendationService(recommendationRepo, jobRepo)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, jobRepo, emailService, cfg)
+	jobFeedService := services.NewJobFeedService(jobRepo, recruiterRepo, redisRepo, cfg)
+	locationService := services.NewLocationService()
+	jobCategoryService := services.NewJobCategoryService(jobCategoryRepo)
+	screeningQuestionService := services.NewScreeningQuestionService(screeningQuestionRepo, jobRepo)
+	interviewService := services.NewInterviewService(interviewRepo, applicationRepo, jobRepo, notificationService, emailService, cfg)
+	messageService := services.NewMessageService(messageRepo, applicationRepo, jobRepo, notificationService)
 
 	// Initialize Controllers
 	userController := controllers.NewUserController(userService)
@@ -85,23 +142,62 @@ func InitializeDependencies(cfg *config.AppConfig) (*AppDependencies, error) {
 	recruiterController := controllers.NewRecruiterController(recruiterService)
 	jobController := controllers.NewJobController(jobService)
 	bookmarksController := controllers.NewBookmarksController(bookmarksService)
+	applicationController := controllers.NewApplicationController(applicationService)
+	pipelineController := controllers.NewPipelineController(pipelineService)
+	skillCatalogController := controllers.NewSkillCatalogController(skillCatalogService)
+	recommendationController := controllers.NewRecommendationController(recommendationService)
+	savedSearchController := controllers.NewSavedSearchController(savedSearchService)
+	jobFeedController := controllers.NewJobFeedController(jobFeedService)
+	locationController := controllers.NewLocationController(locationService)
+	jobCategoryController := controllers.NewJobCategoryController(jobCategoryService)
+	screeningQuestionController := controllers.NewScreeningQuestionController(screeningQuestionService)
+	interviewController := controllers.NewInterviewController(interviewService)
+	messageController := controllers.NewMessageController(messageService)
+	notificationController := controllers.NewNotificationController(notificationService)
+	notificationPreferenceController := controllers.NewNotificationPreferenceController(notificationPreferenceService)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService, cfg)
+
+	// Initialize Schedulers
+	jobAlertScheduler := scheduler.NewJobAlertScheduler(savedSearchService)
+	jobLifecycleScheduler := scheduler.NewJobLifecycleScheduler(jobService)
+	emailOutboxScheduler := scheduler.NewEmailOutboxScheduler(emailService)
 	systemController := controllers.NewSystemController(cfg, dbConfig, redisConfig)
 
 	// Return dependencies
 	return &AppDependencies{
-		UserController:           userController,
-		AuthController:           authController,
-		RedisClient:              redisConfig,
-		CandidateController:      candidateController,
-		PersonalInfoController:   personalInfoController,
-		EducationController:      educationController,
-		ExperienceController:     experienceController,
-		SkillsController:         skillsController,
-		CertificationsController: certificationsController,
-		PortfolioController:      portfolioController,
-		RecruiterController:      recruiterController,
-		JobController:            jobController,
-		BookmarksController:      bookmarksController,
-		SystemController:         systemController,
+		UserController:                   userController,
+		AuthController:                   authController,
+		RedisClient:                      redisConfig,
+		CandidateController:              candidateController,
+		PersonalInfoController:           personalInfoController,
+		EducationController:              educationController,
+		ExperienceController:             experienceController,
+		SkillsController:                 skillsController,
+		CertificationsController:         certificationsController,
+		PortfolioController:              portfolioController,
+		RecruiterController:              recruiterController,
+		JobController:                    jobController,
+		BookmarksController:              bookmarksController,
+		ApplicationController:            applicationController,
+		PipelineController:               pipelineController,
+		SkillCatalogController:           skillCatalogController,
+		RecommendationController:         recommendationController,
+		SavedSearchController:            savedSearchController,
+		JobAlertScheduler:                jobAlertScheduler,
+		JobLifecycleScheduler:            jobLifecycleScheduler,
+		SystemController:                 systemController,
+		JobFeedController:                jobFeedController,
+		LocationController:               locationController,
+		JobCategoryController:            jobCategoryController,
+		ScreeningQuestionController:      screeningQuestionController,
+		InterviewController:              interviewController,
+		MessageController:                messageController,
+		NotificationController:           notificationController,
+		NotificationService:              notificationService,
+		NotificationPreferenceController: notificationPreferenceController,
+		TwoFactorController:              twoFactorController,
+		RedisRepository:                  redisRepo,
+		RateLimiter:                      rateLimiter,
+		EmailOutboxScheduler:             emailOutboxScheduler,
 	}, nil
 }
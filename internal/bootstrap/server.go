@@ -3,17 +3,25 @@ package bootstrap
 import (
 	"dz-jobs-api/config"
 	"dz-jobs-api/internal/middlewares"
+	"fmt"
 
 	v1 "dz-jobs-api/internal/routes/api/v1"
 
 	"github.com/gin-gonic/gin"
 )
 
-func CreateServer(appConfig *config.AppConfig) *gin.Engine {
+func CreateServer(appConfig *config.AppConfig) (*gin.Engine, error) {
 	// Set Gin mode
 	gin.SetMode(gin.ReleaseMode)
 	server := gin.Default()
 
+	// The client IP, which rate limits and login throttling count by, is only
+	// taken from X-Forwarded-For behind the configured proxies. Without any, the
+	// header is ignored since clients could set it to whatever they like.
+	if err := server.SetTrustedProxies(appConfig.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	// CORS setup
 	server.Use(config.SetupCORS(appConfig.FrontEndDomain, appConfig.BackEndDomain))
 
@@ -21,10 +29,9 @@ func CreateServer(appConfig *config.AppConfig) *gin.Engine {
 	server.Use(gin.Recovery())
 	server.Use(middlewares.ErrorHandlingMiddleware())
 	server.Use(middlewares.LoggingMiddleware())
-	server.Use(middlewares.RateLimiter(20, 10))
 	server.MaxMultipartMemory = 8 << 20 // 8 MiB
 
 	// Set-up Docs
 	v1.RegisterSwaggerRoutes(server)
-	return server
+	return server, nil
 }
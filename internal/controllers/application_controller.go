@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ApplicationController handles job application API requests
+type ApplicationController struct {
+	service serviceInterfaces.ApplicationService
+}
+
+// NewApplicationController creates a new instance of ApplicationController
+func NewApplicationController(service serviceInterfaces.ApplicationService) *ApplicationController {
+	return &ApplicationController{service: service}
+}
+
+// Apply godoc
+// @Summary Apply to a job
+// @Description Submit an application to an open job, the candidate's current resume is attached to the application.
+// @Description The screening questions of the job are answered in the answers list, every required question must be answered.
+// @Description An answer matching the auto-reject rule of a question moves the application straight to the rejected stage.
+// @Tags Candidates - Applications
+// @Accept json
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param application body request.ApplyToJobRequest true "Application details"
+// @Success 201 {object} response.Response{Data=response.ApplicationResponse} "Application submitted successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "Job is closed and no longer accepts applications"
+// @Failure 400 {object} response.Response "Invalid screening answers"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Confirm your email address first"
+// @Failure 404 {object} response.Response "Job not found"
+// @Failure 404 {object} response.Response "Candidate not found"
+// @Failure 409 {object} response.Response "You have already applied to this job"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications/{jobId} [post]
+func (c *ApplicationController) Apply(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.ApplyToJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	application, err := c.service.Apply(ctx, candidateID, jobID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Application submitted successfully",
+		Data:    response.ToCandidateApplicationResponse(application),
+	})
+}
+
+// GetCandidateApplications godoc
+// @Summary Get my applications
+// @Description Retrieve all applications submitted by the authenticated candidate
+// @Tags Candidates - Applications
+// @Produce json
+// @Param page query request.PageRequest false "Pagination (only created_at sorting is supported)"
+// @Success 200 {object} response.Response{Data=response.ApplicationsResponseData} "Applications retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid pagination parameters"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications [get]
+func (c *ApplicationController) GetCandidateApplications(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var page request.PageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	applications, pageInfo, err := c.service.GetCandidateApplications(ctx, candidateID, page)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Applications retrieved successfully",
+		Data:    response.ToCandidateApplicationsResponse(applications, pageInfo),
+	})
+}
+
+// GetCandidateApplication godoc
+// @Summary Get my application to a job
+// @Description Retrieve the authenticated candidate's application to a specific job
+// @Tags Candidates - Applications
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Success 200 {object} response.Response{Data=response.ApplicationResponse} "Application found"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications/{jobId} [get]
+func (c *ApplicationController) GetCandidateApplication(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	application, err := c.service.GetCandidateApplication(ctx, candidateID, jobID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Application found",
+		Data:    response.ToCandidateApplicationResponse(application),
+	})
+}
+
+// WithdrawApplication godoc
+// @Summary Withdraw an application
+// @Description Withdraw the authenticated candidate's application to a specific job, unless it was hired or rejected
+// @Tags Candidates - Applications
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Success 200 {object} response.Response "Application withdrawn successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 409 {object} response.Response "A hired or rejected application cannot be withdrawn"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications/{jobId} [delete]
+func (c *ApplicationController) WithdrawApplication(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if err := c.service.WithdrawApplication(ctx, candidateID, jobID); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Application withdrawn successfully",
+	})
+}
+
+// GetJobApplications godoc
+// @Summary Get applicants for a job
+// @Description Retrieve all applications submitted to a job owned by the authenticated recruiter.
+// @Description Applicants can be filtered by their answers to the screening questions with repeated answer=question_id:value parameters.
+// @Tags Recruiters - Applications
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param filters query request.JobApplicationFilters false "Answer filters and pagination (only created_at sorting is supported)"
+// @Success 200 {object} response.Response{Data=response.ApplicationsResponseData} "Applications retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "Invalid answer filter"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications [get]
+func (c *ApplicationController) GetJobApplications(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	var filters request.JobApplicationFilters
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	applications, pageInfo, err := c.service.GetJobApplications(ctx, recruiterID, jobID, filters)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Applications retrieved successfully",
+		Data:    response.ToApplicationsResponse(applications, pageInfo),
+	})
+}
+
+// GetJobApplication godoc
+// @Summary Get an applicant for a job
+// @Description Retrieve a single application submitted to a job owned by the authenticated recruiter
+// @Tags Recruiters - Applications
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Success 200 {object} response.Response{Data=response.ApplicationResponse} "Application found"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId} [get]
+func (c *ApplicationController) GetJobApplication(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	applicationID, err := strconv.ParseInt(ctx.Param("applicationId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	application, err := c.service.GetJobApplication(ctx, recruiterID, jobID, applicationID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Application found",
+		Data:    response.ToApplicationResponse(application),
+	})
+}
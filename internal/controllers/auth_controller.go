@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 )
 
@@ -29,14 +30,19 @@ func NewAuthController(service serviceInterfaces.AuthService, config *config.App
 
 // Login godoc
 // @Summary Login user
-// @Description Login user with email and password
+// @Description Login user with email and password, starting a new session for the device.
+// @Description When the user has two-factor authentication enabled no session is started yet, a short-lived two_factor_token cookie is set instead to finish logging in with /auth/2fa/verify.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body request.LoginRequest true "Login request"
 // @Success 200 {object} response.Response{Data=response.UserResponse} "Successfully logged in!"
+// @Success 202 {object} response.Response{Data=response.TwoFactorChallengeResponse} "Two-factor code required"
 // @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Invalid email or password"
+// @Failure 429 {object} response.Response "Too many failed login attempts or account temporarily locked"
 // @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
 // @Router /auth/login [post]
 func (c *AuthController) Login(ctx *gin.Context) {
 	var req request.LoginRequest
@@ -45,12 +51,16 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		ctx.Abort()
 		return
 	}
-	user, accessToken, refreshToken, err := c.authService.Login(ctx,req)
+	user, accessToken, refreshToken, twoFactorToken, err := c.authService.Login(ctx, req, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		_  = ctx.Error(err)
 		ctx.Abort()
 		return
 	}
+	if twoFactorToken != "" {
+		c.requireTwoFactor(ctx, twoFactorToken)
+		return
+	}
 	isProduction := c.config.ServerPort != "9090"
 	utils.SetAuthCookie(ctx, "access_token", accessToken, c.config.AccessTokenMaxAge, c.config.BackEndDomain, isProduction)
 	utils.SetAuthCookie(ctx, "refresh_token", refreshToken, c.config.RefreshTokenMaxAge, c.config.BackEndDomain, isProduction)
@@ -62,12 +72,73 @@ func (c *AuthController) Login(ctx *gin.Context) {
 	})
 }
 
+// VerifyTwoFactor godoc
+// @Summary Verify two-factor code
+// @Description Finish logging in a user with two-factor authentication enabled, with the two_factor_token cookie set by the login and a code from their authenticator app or one of their recovery codes
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyTwoFactorRequest true "Two-factor code or recovery code"
+// @Success 200 {object} response.Response{Data=response.UserResponse} "Successfully logged in!"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Invalid two-factor code"
+// @Failure 429 {object} response.Response "Too many failed login attempts or account temporarily locked"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
+// @Router /auth/2fa/verify [post]
+func (c *AuthController) VerifyTwoFactor(ctx *gin.Context) {
+	twoFactorToken, err := ctx.Cookie("two_factor_token")
+	if err != nil {
+		_ = ctx.Error(utils.NewCustomError(http.StatusUnauthorized, "Two-factor token expired or invalid, log in again"))
+		ctx.Abort()
+		return
+	}
+	var req request.VerifyTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	user, accessToken, refreshToken, err := c.authService.VerifyTwoFactor(ctx, twoFactorToken, req.Code, ctx.Request.UserAgent(), ctx.ClientIP())
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	isProduction := c.config.ServerPort != "9090"
+	utils.SetAuthCookie(ctx, "two_factor_token", "", -1, c.config.BackEndDomain, isProduction)
+	utils.SetAuthCookie(ctx, "access_token", accessToken, c.config.AccessTokenMaxAge, c.config.BackEndDomain, isProduction)
+	utils.SetAuthCookie(ctx, "refresh_token", refreshToken, c.config.RefreshTokenMaxAge, c.config.BackEndDomain, isProduction)
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully logged in!",
+		Data:    response.ToUserResponse(user),
+	})
+}
+
+// requireTwoFactor answers the first step of the login of a user with two-factor
+// authentication enabled, setting the token to finish it with
+func (c *AuthController) requireTwoFactor(ctx *gin.Context, twoFactorToken string) {
+	isProduction := c.config.ServerPort != "9090"
+	utils.SetAuthCookie(ctx, "two_factor_token", twoFactorToken, c.config.TwoFactorTokenMaxAge, c.config.BackEndDomain, isProduction)
+	ctx.JSON(http.StatusAccepted, response.Response{
+		Code:    http.StatusAccepted,
+		Status:  "Accepted",
+		Message: "Two-factor code required",
+		Data:    response.TwoFactorChallengeResponse{TwoFactorRequired: true},
+	})
+}
+
 // RefreshToken godoc
 // @Summary Refresh access token
-// @Description Refresh access token using refresh token
+// @Description Refresh access token using refresh token. The refresh token is rotated, presenting a rotated refresh token again revokes its session.
 // @Tags Auth
 // @Produce json
 // @Success 200 {object} response.Response "Access token refreshed successfully!"
+// @Failure 401 {object} response.Response "Session expired or revoked"
 // @Failure 500 {object} response.Response "An unexpected error occurred"
 // @Router /auth/refresh-token [post]
 func (c *AuthController) RefreshToken(ctx *gin.Context) {
@@ -77,14 +148,8 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 		ctx.Abort()
 		return
 	}
-	userID, userRole, err := c.authService.ValidateToken(ctx,refreshToken)
-	if err != nil {
-		_  = ctx.Error(err)
-		ctx.Abort()
-		return
-	}
 
-	accessToken, err := c.authService.RefreshAccessToken(ctx,userID, userRole, refreshToken)
+	accessToken, newRefreshToken, err := c.authService.RefreshAccessToken(ctx, refreshToken, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		_  = ctx.Error(err)
 		ctx.Abort()
@@ -93,6 +158,7 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 
 	isProduction := c.config.ServerPort != "9090"
 	utils.SetAuthCookie(ctx, "access_token", accessToken, c.config.AccessTokenMaxAge, c.config.BackEndDomain, isProduction)
+	utils.SetAuthCookie(ctx, "refresh_token", newRefreshToken, c.config.RefreshTokenMaxAge, c.config.BackEndDomain, isProduction)
 	ctx.JSON(http.StatusOK, response.Response{
 		Code:    http.StatusOK,
 		Status:  "OK",
@@ -102,7 +168,7 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 
 // Logout godoc
 // @Summary Logout user
-// @Description Logout user and clear cookies
+// @Description Logout user from the current device and clear cookies
 // @Tags Auth
 // @Produce json
 // @Success 200 {object} response.Response "Successfully logged out!"
@@ -115,8 +181,7 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 		ctx.Abort()
 		return
 	}
-	userID, _, _ := c.authService.ValidateToken(ctx,refreshToken)
-	if err := c.authService.Logout(ctx,userID, refreshToken); err != nil {
+	if err := c.authService.Logout(ctx, refreshToken); err != nil {
 		_  = ctx.Error(err)
 		ctx.Abort()
 		return
@@ -132,9 +197,83 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	})
 }
 
+// GetSessions godoc
+// @Summary Get my sessions
+// @Description Retrieve the devices the authenticated user is logged in on, most recently used first
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} response.Response{Data=[]response.SessionResponse} "Sessions retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /auth/sessions [get]
+func (c *AuthController) GetSessions(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	sessions, err := c.authService.GetSessions(ctx, userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Sessions retrieved successfully",
+		Data:    response.ToSessionsResponse(sessions, ctx.GetString("session_id")),
+	})
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Log the authenticated user out of one of their devices, the cookies are cleared when it is the current session.
+// @Description Access tokens already issued to the device stay valid until they expire.
+// @Tags Auth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} response.Response "Session revoked successfully"
+// @Failure 400 {object} response.Response "Invalid session ID"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Session not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /auth/sessions/{sessionId} [delete]
+func (c *AuthController) RevokeSession(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	sessionID, err := uuid.Parse(ctx.Param("sessionId"))
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if err := c.authService.RevokeSession(ctx, userID, sessionID); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if sessionID.String() == ctx.GetString("session_id") {
+		isProduction := c.config.ServerPort != "9090"
+		utils.SetAuthCookie(ctx, "access_token", "", -1, c.config.BackEndDomain, isProduction)
+		utils.SetAuthCookie(ctx, "refresh_token", "", -1, c.config.BackEndDomain, isProduction)
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Session revoked successfully",
+	})
+}
+
 // Register godoc
 // @Summary Register user
-// @Description Register a new user
+// @Description Register a new candidate or recruiter. A code confirming the email address is emailed, the account cannot post jobs or apply to them before it is confirmed.
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -165,6 +304,72 @@ func (c *AuthController) Register(ctx *gin.Context) {
 	})
 }
 
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirm the email address of an account with the code emailed on registration
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyEmailRequest true "Verify email request"
+// @Success 200 {object} response.Response "Email verified successfully!"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Invalid OTP"
+// @Failure 429 {object} response.Response "Too many failed attempts"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
+// @Router /auth/verify-email [post]
+func (c *AuthController) VerifyEmail(ctx *gin.Context) {
+	var req request.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	if err := c.authService.VerifyEmail(ctx, req.Email, req.OTP, ctx.ClientIP()); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Email verified successfully!",
+	})
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Email a new code confirming the address of an account not confirmed yet, at most once a minute.
+// @Description The answer is the same whether or not such an account exists.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ResendVerificationRequest true "Resend verification request"
+// @Success 200 {object} response.Response "Verification email sent if the account awaits confirmation"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 429 {object} response.Response "A verification email was sent recently"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
+// @Router /auth/resend-verification [post]
+func (c *AuthController) ResendVerification(ctx *gin.Context) {
+	var req request.ResendVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	if err := c.authService.ResendVerificationEmail(ctx, req.Email); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "If an account with this address awaits confirmation, a verification email was sent",
+	})
+}
+
 // SendResetOTP godoc
 // @Summary Send OTP for password reset
 // @Description Send OTP to user's email for password reset
@@ -174,7 +379,9 @@ func (c *AuthController) Register(ctx *gin.Context) {
 // @Param request body request.SendOTPRequest true "Send OTP request"
 // @Success 200 {object} response.Response "OTP sent successfully!"
 // @Failure 400 {object} response.Response "Invalid input"
+// @Failure 429 {object} response.Response "Too many codes requested"
 // @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
 // @Router /auth/send-reset-otp [post]
 func (c *AuthController) SendResetOTP(ctx *gin.Context) {
 	var req request.SendOTPRequest
@@ -183,7 +390,7 @@ func (c *AuthController) SendResetOTP(ctx *gin.Context) {
 		ctx.Abort()
 		return
 	}
-	err := c.authService.SendOTP(ctx,req.Email)
+	err := c.authService.SendOTP(ctx,req.Email, ctx.ClientIP())
 	if err != nil {
 		_  = ctx.Error(err)
 		ctx.Abort()
@@ -205,7 +412,10 @@ func (c *AuthController) SendResetOTP(ctx *gin.Context) {
 // @Param request body request.VerifyOTPRequest true "Verify OTP request"
 // @Success 200 {object} response.Response "OTP verify successfully!"
 // @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Invalid OTP, invalidated after 5 wrong guesses"
+// @Failure 429 {object} response.Response "Too many failed attempts"
 // @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
 // @Router /auth/verify-otp [post]
 func (c *AuthController) VerifyOTP(ctx *gin.Context) {
 	var req request.VerifyOTPRequest
@@ -214,7 +424,7 @@ func (c *AuthController) VerifyOTP(ctx *gin.Context) {
 		ctx.Abort()
 		return
 	}
-	resetToken, err := c.authService.VerifyOTP(ctx,req.Email, req.OTP)
+	resetToken, err := c.authService.VerifyOTP(ctx,req.Email, req.OTP, ctx.ClientIP())
 	if err != nil {
 		_  = ctx.Error(err)
 		return
@@ -308,13 +518,15 @@ func (c *AuthController) GoogleCallbackConnect(ctx *gin.Context) {
 		return
 	}
 
-	user, accessToken, refreshToken, connect, err := c.authService.GoogleConnect(ctx,code, role)
+	user, accessToken, refreshToken, twoFactorToken, connect, err := c.authService.GoogleConnect(ctx, code, role, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		_  = ctx.Error(err)
 		ctx.Abort()
 		return
 	}
-	if connect == "register" {
+	if connect == "login" && twoFactorToken != "" {
+		c.requireTwoFactor(ctx, twoFactorToken)
+	} else if connect == "register" {
 		ctx.JSON(http.StatusOK, response.Response{
 			Code:    http.StatusOK,
 			Status:  "OK",
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"dz-jobs-api/internal/dto/request"
 	"dz-jobs-api/internal/dto/response"
 	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
 	"net/http"
@@ -66,6 +67,7 @@ func (c *BookmarksController) AddBookmark(ctx *gin.Context) {
 // @Description Retrieve a list of jobs bookmarked by a candidate by candidate ID
 // @Tags Candidates - Bookmarks
 // @Produce json
+// @Param page query request.PageRequest false "Pagination and sorting"
 // @Success 200 {object} response.Response{Data=response.JobsResponseData} "Jobs bookmarked retrieved successfully"
 // @Failure 400 {object} response.Response "Invalid input"
 // @Failure 401 {object} response.Response "Unauthorized"
@@ -76,7 +78,13 @@ func (c *BookmarksController) AddBookmark(ctx *gin.Context) {
 func (c *BookmarksController) GetBookmarks(ctx *gin.Context) {
 	userID := ctx.MustGet("candidate_id")
 	candidateID, _ := uuid.Parse(userID.(string))
-	jobs, err := c.service.GetBookmarks(ctx, candidateID)
+	var page request.PageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	jobs, pageInfo, err := c.service.GetBookmarks(ctx, candidateID, page)
 	if err != nil {
 		_ = ctx.Error(err)
 		return
@@ -86,7 +94,7 @@ func (c *BookmarksController) GetBookmarks(ctx *gin.Context) {
 		Code:    http.StatusOK,
 		Status:  "OK",
 		Message: "Jobs bookmarked retrieved successfully",
-		Data:    response.ToJobsResponse(jobs),
+		Data:    response.ToJobsResponse(jobs, pageInfo),
 	})
 }
 
@@ -0,0 +1,467 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// InterviewController handles interview scheduling API requests
+type InterviewController struct {
+	service serviceInterfaces.InterviewService
+}
+
+// NewInterviewController creates a new instance of InterviewController
+func NewInterviewController(service serviceInterfaces.InterviewService) *InterviewController {
+	return &InterviewController{service: service}
+}
+
+// ProposeInterview godoc
+// @Summary Propose an interview
+// @Description Invite the candidate of an application to an interview, proposing the slots the candidate can choose from.
+// @Description The candidate is notified by email, the calendar invites are sent once a slot is selected.
+// @Tags Recruiters - Interviews
+// @Accept json
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Param interview body request.InterviewProposalRequest true "Proposed slots and interview details"
+// @Success 201 {object} response.Response{Data=response.InterviewResponse} "Interview proposed successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/interviews [post]
+func (c *InterviewController) ProposeInterview(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	applicationID, err := strconv.ParseInt(ctx.Param("applicationId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.InterviewProposalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	interview, err := c.service.ProposeInterview(ctx, recruiterID, jobID, applicationID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Interview proposed successfully",
+		Data:    response.ToInterviewResponse(interview),
+	})
+}
+
+// GetApplicationInterviews godoc
+// @Summary Get the interviews of an application
+// @Description Retrieve every interview of an application to a job owned by the authenticated recruiter, cancelled ones included
+// @Tags Recruiters - Interviews
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Success 200 {object} response.Response{Data=response.InterviewsResponseData} "Interviews retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/interviews [get]
+func (c *InterviewController) GetApplicationInterviews(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	applicationID, err := strconv.ParseInt(ctx.Param("applicationId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	interviews, err := c.service.GetApplicationInterviews(ctx, recruiterID, jobID, applicationID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Interviews retrieved successfully",
+		Data:    response.ToInterviewsResponse(interviews),
+	})
+}
+
+// GetRecruiterInterviews godoc
+// @Summary Get my upcoming interviews
+// @Description Retrieve the upcoming interviews of the authenticated recruiter, along with the ones waiting for the candidate to select a slot
+// @Tags Recruiters - Interviews
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.InterviewsResponseData} "Interviews retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/interviews [get]
+func (c *InterviewController) GetRecruiterInterviews(ctx *gin.Context) {
+	c.getUpcomingInterviews(ctx, "recruiter_id")
+}
+
+// GetRecruiterInterview godoc
+// @Summary Get an interview
+// @Description Retrieve an interview of one of the applications to the jobs of the authenticated recruiter
+// @Tags Recruiters - Interviews
+// @Produce json
+// @Param interviewId path int true "Interview ID"
+// @Success 200 {object} response.Response{Data=response.InterviewResponse} "Interview retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Interview not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/interviews/{interviewId} [get]
+func (c *InterviewController) GetRecruiterInterview(ctx *gin.Context) {
+	c.getInterview(ctx, "recruiter_id")
+}
+
+// RescheduleInterview godoc
+// @Summary Reschedule an interview
+// @Description Propose new slots and details for an interview, the candidate selects one of the new slots again.
+// @Description The calendar invites of a scheduled interview are cancelled.
+// @Tags Recruiters - Interviews
+// @Accept json
+// @Produce json
+// @Param interviewId path int true "Interview ID"
+// @Param interview body request.InterviewProposalRequest true "New slots and interview details"
+// @Success 200 {object} response.Response{Data=response.InterviewResponse} "Interview rescheduled successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "The interview is cancelled and cannot be rescheduled"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Only the recruiter can reschedule an interview"
+// @Failure 404 {object} response.Response "Interview not found"
+// @Failure 409 {object} response.Response "The interview was changed by someone else, please retry"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/interviews/{interviewId}/reschedule [put]
+func (c *InterviewController) RescheduleInterview(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	interviewID, err := strconv.ParseInt(ctx.Param("interviewId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.InterviewProposalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	interview, err := c.service.RescheduleInterview(ctx, recruiterID, interviewID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Interview rescheduled successfully",
+		Data:    response.ToInterviewResponse(interview),
+	})
+}
+
+// CancelRecruiterInterview godoc
+// @Summary Cancel an interview
+// @Description Cancel an interview, both participants are notified and the calendar invites of a scheduled interview are cancelled
+// @Tags Recruiters - Interviews
+// @Accept json
+// @Produce json
+// @Param interviewId path int true "Interview ID"
+// @Param cancellation body request.CancelInterviewRequest false "Cancellation reason"
+// @Success 200 {object} response.Response{Data=response.InterviewResponse} "Interview cancelled successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "The interview is already cancelled"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Interview not found"
+// @Failure 409 {object} response.Response "The interview was changed by someone else, please retry"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/interviews/{interviewId}/cancel [put]
+func (c *InterviewController) CancelRecruiterInterview(ctx *gin.Context) {
+	c.cancelInterview(ctx, "recruiter_id")
+}
+
+// GetCandidateInterviews godoc
+// @Summary Get my upcoming interviews
+// @Description Retrieve the upcoming interviews of the authenticated candidate, along with the ones waiting for a slot to be selected
+// @Tags Candidates - Interviews
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.InterviewsResponseData} "Interviews retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/interviews [get]
+func (c *InterviewController) GetCandidateInterviews(ctx *gin.Context) {
+	c.getUpcomingInterviews(ctx, "candidate_id")
+}
+
+// GetCandidateInterview godoc
+// @Summary Get an interview
+// @Description Retrieve an interview the authenticated candidate is invited to, with its proposed slots
+// @Tags Candidates - Interviews
+// @Produce json
+// @Param interviewId path int true "Interview ID"
+// @Success 200 {object} response.Response{Data=response.InterviewResponse} "Interview retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Interview not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/interviews/{interviewId} [get]
+func (c *InterviewController) GetCandidateInterview(ctx *gin.Context) {
+	c.getInterview(ctx, "candidate_id")
+}
+
+// SelectSlot godoc
+// @Summary Select an interview slot
+// @Description Select one of the proposed slots of an interview, the interview is then scheduled and the calendar invites are sent by email
+// @Tags Candidates - Interviews
+// @Accept json
+// @Produce json
+// @Param interviewId path int true "Interview ID"
+// @Param slot body request.SelectInterviewSlotRequest true "Selected slot"
+// @Success 200 {object} response.Response{Data=response.InterviewResponse} "Interview scheduled successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "The slot is not one of the proposed slots"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Interview not found"
+// @Failure 409 {object} response.Response "The interview was changed by someone else, please retry"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/interviews/{interviewId}/slot [put]
+func (c *InterviewController) SelectSlot(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	interviewID, err := strconv.ParseInt(ctx.Param("interviewId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.SelectInterviewSlotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	interview, err := c.service.SelectSlot(ctx, candidateID, interviewID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Interview scheduled successfully",
+		Data:    response.ToInterviewResponse(interview),
+	})
+}
+
+// CancelCandidateInterview godoc
+// @Summary Cancel an interview
+// @Description Cancel an interview the authenticated candidate is invited to, the recruiter is notified by email
+// @Tags Candidates - Interviews
+// @Accept json
+// @Produce json
+// @Param interviewId path int true "Interview ID"
+// @Param cancellation body request.CancelInterviewRequest false "Cancellation reason"
+// @Success 200 {object} response.Response{Data=response.InterviewResponse} "Interview cancelled successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "The interview is already cancelled"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Interview not found"
+// @Failure 409 {object} response.Response "The interview was changed by someone else, please retry"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/interviews/{interviewId}/cancel [put]
+func (c *InterviewController) CancelCandidateInterview(ctx *gin.Context) {
+	c.cancelInterview(ctx, "candidate_id")
+}
+
+// CreateCalendarFeed godoc
+// @Summary Create my interview calendar feed
+// @Description Create the secret iCalendar feed URL listing the upcoming interviews of the authenticated user, to subscribe to from a calendar app. The URL is only shown once, creating the feed again gives it a new URL and the previous one stops working
+// @Tags Interviews
+// @Produce json
+// @Success 201 {object} response.Response{Data=response.CalendarFeedResponse} "Calendar feed created successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /interviews/calendar-feed [post]
+func (c *InterviewController) CreateCalendarFeed(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	url, err := c.service.CreateCalendarFeed(ctx, userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Calendar feed created successfully",
+		Data:    response.CalendarFeedResponse{URL: url},
+	})
+}
+
+// GetCalendarFeed godoc
+// @Summary Interview calendar feed
+// @Description RFC 5545 iCalendar feed of the scheduled upcoming interviews of the user owning the secret token
+// @Tags Interviews
+// @Produce text/calendar
+// @Param token path string true "Secret feed token, optionally followed by .ics"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404 {object} response.Response "Calendar feed not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /calendar/{token} [get]
+func (c *InterviewController) GetCalendarFeed(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	calendar, err := c.service.GetCalendarFeed(ctx, token)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+func (c *InterviewController) getUpcomingInterviews(ctx *gin.Context, userKey string) {
+	userID, err := uuid.Parse(ctx.MustGet(userKey).(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	interviews, err := c.service.GetUpcomingInterviews(ctx, userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Interviews retrieved successfully",
+		Data:    response.ToInterviewsResponse(interviews),
+	})
+}
+
+func (c *InterviewController) getInterview(ctx *gin.Context, userKey string) {
+	userID, err := uuid.Parse(ctx.MustGet(userKey).(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	interviewID, err := strconv.ParseInt(ctx.Param("interviewId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	interview, err := c.service.GetInterview(ctx, userID, interviewID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Interview retrieved successfully",
+		Data:    response.ToInterviewResponse(interview),
+	})
+}
+
+func (c *InterviewController) cancelInterview(ctx *gin.Context, userKey string) {
+	userID, err := uuid.Parse(ctx.MustGet(userKey).(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	interviewID, err := strconv.ParseInt(ctx.Param("interviewId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.CancelInterviewRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+	}
+
+	interview, err := c.service.CancelInterview(ctx, userID, interviewID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Interview cancelled successfully",
+		Data:    response.ToInterviewResponse(interview),
+	})
+}
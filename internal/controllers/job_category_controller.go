@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobCategoryController handles job categories API requests
+type JobCategoryController struct {
+	service serviceInterfaces.JobCategoryService
+}
+
+// NewJobCategoryController creates a new instance of JobCategoryController
+func NewJobCategoryController(service serviceInterfaces.JobCategoryService) *JobCategoryController {
+	return &JobCategoryController{service: service}
+}
+
+// GetCategories godoc
+// @Summary List job categories
+// @Description Get the job categories tree, the top level categories are the industries and their children the specialities. Jobs can be searched by the ID or slug of a category with the category filter.
+// @Tags Categories
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.JobCategoriesResponseData} "Categories retrieved successfully"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /categories [get]
+func (c *JobCategoryController) GetCategories(ctx *gin.Context) {
+	categories, err := c.service.GetCategoryTree(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Categories retrieved successfully",
+		Data:    response.ToJobCategoriesResponse(categories),
+	})
+}
+
+// CreateCategory godoc
+// @Summary Create a job category
+// @Description Add a job category, under a parent category or at the top level. Its slug is derived from its name.
+// @Tags Admin - Categories
+// @Accept json
+// @Produce json
+// @Param category body request.JobCategoryRequest true "Category"
+// @Success 201 {object} response.Response{Data=response.JobCategoryResponse} "Category created successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "A category with this name already exists"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/categories [post]
+func (c *JobCategoryController) CreateCategory(ctx *gin.Context) {
+	var req request.JobCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	category, err := c.service.CreateCategory(ctx, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Category created successfully",
+		Data:    response.ToJobCategoryResponse(category),
+	})
+}
+
+// UpdateCategory godoc
+// @Summary Update a job category
+// @Description Rename a job category or move it under another parent, along with its subcategories
+// @Tags Admin - Categories
+// @Accept json
+// @Produce json
+// @Param categoryId path int true "Category ID"
+// @Param category body request.JobCategoryRequest true "Category"
+// @Success 200 {object} response.Response{Data=response.JobCategoryResponse} "Category updated successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Category not found"
+// @Failure 409 {object} response.Response "A category with this name already exists"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/categories/{categoryId} [put]
+func (c *JobCategoryController) UpdateCategory(ctx *gin.Context) {
+	categoryID, err := strconv.ParseInt(ctx.Param("categoryId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.JobCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	category, err := c.service.UpdateCategory(ctx, categoryID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Category updated successfully",
+		Data:    response.ToJobCategoryResponse(category),
+	})
+}
+
+// DeleteCategory godoc
+// @Summary Delete a job category
+// @Description Delete a job category without subcategories, its jobs are left without a category
+// @Tags Admin - Categories
+// @Produce json
+// @Param categoryId path int true "Category ID"
+// @Success 200 {object} response.Response "Category deleted successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Category not found"
+// @Failure 409 {object} response.Response "A category with subcategories cannot be deleted"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/categories/{categoryId} [delete]
+func (c *JobCategoryController) DeleteCategory(ctx *gin.Context) {
+	categoryID, err := strconv.ParseInt(ctx.Param("categoryId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if err := c.service.DeleteCategory(ctx, categoryID); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Category deleted successfully",
+	})
+}
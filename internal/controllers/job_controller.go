@@ -4,6 +4,9 @@ import (
 	"dz-jobs-api/internal/dto/request"
 	"dz-jobs-api/internal/dto/response"
 	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -11,6 +14,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// jsonLDMIME is the media type of schema.org structured data
+const jsonLDMIME = "application/ld+json"
+
 // JobController handles job-related API requests
 type JobController struct {
 	jobService serviceInterfaces.JobService
@@ -25,7 +31,7 @@ func NewJobController(service serviceInterfaces.JobService) *JobController {
 
 // PostNewJob godoc
 // @Summary Post a new job
-// @Description Allows recruiters to post a new job (NOTE: salary range must be in this format: 'min - max DZD')
+// @Description Allows recruiters to post a new job, the salary is given as salary_min/salary_max with a currency (DZD by default) and a period (monthly by default)
 // @Tags Recruiters - Jobs
 // @Accept json
 // @Produce json
@@ -33,7 +39,7 @@ func NewJobController(service serviceInterfaces.JobService) *JobController {
 // @Success 201 {object} response.Response{Data=response.JobResponse} "Job posted successfully"
 // @Failure 400 {object} response.Response "Invalid input"
 // @Failure 401 {object} response.Response "Unauthorized"
-// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 403 {object} response.Response "Confirm your email address first"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /recruiters/jobs [post]
 func (c *JobController) PostNewJob(ctx *gin.Context) {
@@ -108,6 +114,7 @@ func (c *JobController) GetJobDetails(ctx *gin.Context) {
 // @Tags Recruiters - Jobs
 // @Produce json
 // @Param status query string true "Job status (e.g., open, closed)"
+// @Param page query request.PageRequest false "Pagination and sorting"
 // @Success 200 {object} response.Response{Data=response.JobsResponseData} "Jobs retrieved successfully"
 // @Failure 400 {object} response.Response "Invalid input"
 // @Failure 401 {object} response.Response "Unauthorized"
@@ -122,7 +129,13 @@ func (c *JobController) GetJobListingsByStatus(ctx *gin.Context) {
 		_  = ctx.Error(err)
 		return
 	}
-	jobs, err := c.jobService.GetJobListingsByStatus(ctx,ctx.Query("status"), recruiterID)
+	var page request.PageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		_  = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	jobs, pageInfo, err := c.jobService.GetJobListingsByStatus(ctx,ctx.Query("status"), recruiterID, page)
 	if err != nil {
 		_  = ctx.Error(err)
 		return
@@ -132,7 +145,7 @@ func (c *JobController) GetJobListingsByStatus(ctx *gin.Context) {
 		Code:    http.StatusOK,
 		Status:  "OK",
 		Message: "Jobs retrieved successfully",
-		Data:    response.ToJobsResponse(jobs),
+		Data:    response.ToJobsResponse(jobs, pageInfo),
 	})
 }
 
@@ -228,15 +241,19 @@ func (c *JobController) DeactivateJob(ctx *gin.Context) {
 
 // RepostJob godoc
 // @Summary Repost a job
-// @Description Repost a deactivated job by jobId
+// @Description Reopen a job by jobId as newly published. The job runs for the default lifetime unless expires_at is sent, and cannot be reposted again before the repost cooldown has passed.
 // @Tags Recruiters - Jobs
+// @Accept json
 // @Produce json
 // @Param jobId path int true "Job ID"
+// @Param request body request.RepostJobRequest false "New expiry of the job"
 // @Success 200 {object} response.Response{Data=response.JobResponse} "Job reposted successfully"
 // @Failure 400 {object} response.Response "Invalid input"
 // @Failure 401 {object} response.Response "Unauthorized"
 // @Failure 403 {object} response.Response "You do not own this Job"
 // @Failure 404 {object} response.Response "Job not found"
+// @Failure 409 {object} response.Response "Only open or closed jobs can be reposted"
+// @Failure 429 {object} response.Response "Job was reposted too recently"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /recruiters/jobs/{jobId}/repost [put]
 func (c *JobController) RepostJob(ctx *gin.Context) {
@@ -253,7 +270,14 @@ func (c *JobController) RepostJob(ctx *gin.Context) {
 		_  = ctx.Error(err)
 		return
 	}
-	updatedJob, err := c.jobService.RepostJob(ctx,jobID, recruiterID)
+	// The body is optional
+	var req request.RepostJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	updatedJob, err := c.jobService.RepostJob(ctx, jobID, recruiterID, req)
 	if err != nil {
 		_  = ctx.Error(err)
 		ctx.Abort()
@@ -308,14 +332,22 @@ func (c *JobController) DeleteJob(ctx *gin.Context) {
 
 // GetAllJobs godoc
 // @Summary Get all jobs
-// @Description Retrieve all jobs in the system
+// @Description Retrieve all jobs in the system, one page at a time
 // @Tags Jobs
 // @Produce json
+// @Param page query request.PageRequest false "Pagination and sorting"
 // @Success 200 {object} response.Response{Data=response.JobsResponseData} "Jobs retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid pagination parameters"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /jobs [get]
 func (c *JobController) GetAllJobs(ctx *gin.Context) {
-	jobs, err := c.jobService.GetAllJobs(ctx)
+	var page request.PageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		_  = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	jobs, pageInfo, err := c.jobService.GetAllJobs(ctx, page)
 	if err != nil {
 		_  = ctx.Error(err)
 		return
@@ -325,18 +357,18 @@ func (c *JobController) GetAllJobs(ctx *gin.Context) {
 		Code:    http.StatusOK,
 		Status:  "OK",
 		Message: "Jobs retrieved successfully",
-		Data:    response.ToJobsResponse(jobs),
+		Data:    response.ToJobsResponse(jobs, pageInfo),
 	})
 }
 
 // SearchJobs godoc
 // @Summary Search for jobs
-// @Description Search for jobs using various filters
+// @Description Search for jobs using various filters, results are paginated. The keyword is matched with full-text search (supports "quoted phrases", OR and -exclusion), results are then ordered by relevance and carry a highlighted snippet of the description. The response also counts all the matching jobs by category, job type, wilaya, salary bucket and status, for filter sidebars.
 // @Tags Jobs
 // @Accept json
 // @Produce json
 // @Param filters query request.JobFilters true "Job search filters"
-// @Success 200 {object} response.Response{Data=response.JobsResponseData} "Jobs found successfully"
+// @Success 200 {object} response.Response{Data=response.JobSearchResponseData} "Jobs found successfully"
 // @Failure 400 {object} response.Response "Invalid input"
 // @Failure 404 {object} response.Response "Jobs not found"
 // @Failure 500 {object} response.Response "Internal server error"
@@ -349,7 +381,7 @@ func (c *JobController) SearchJobs(ctx *gin.Context) {
 		return
 	}
 
-	jobs, err := c.jobService.SearchJobs(ctx,filters)
+	jobs, pageInfo, facets, err := c.jobService.SearchJobs(ctx,filters)
 	if err != nil {
 		_  = ctx.Error(err)
 		return
@@ -359,17 +391,20 @@ func (c *JobController) SearchJobs(ctx *gin.Context) {
 		Code:    http.StatusOK,
 		Status:  "OK",
 		Message: "Jobs found successfully",
-		Data:    response.ToJobsResponse(jobs),
+		Data:    response.ToJobSearchResponse(jobs, pageInfo, facets),
 	})
 }
 
 // GetJobDetailsPublic godoc
 // @Summary Get job details
-// @Description Retrieve the details of a specific job by jobId
+// @Description Retrieve the details of a specific job by jobId. With format=jsonld or an Accept: application/ld+json header the job is rendered as a schema.org JobPosting for structured data consumers such as Google for Jobs.
 // @Tags Jobs
 // @Produce json
+// @Produce application/ld+json
 // @Param jobId path int true "Job ID"
+// @Param format query string false "Response format" Enums(json, jsonld)
 // @Success 200 {object} response.Response{Data=response.JobResponse} "Job details found"
+// @Success 200 {object} response.JobPostingResponse "schema.org JobPosting, with format=jsonld"
 // @Failure 400 {object} response.Response "Invalid input"
 // @Failure 404 {object} response.Response "Job not found"
 // @Failure 500 {object} response.Response "Internal server error"
@@ -382,6 +417,24 @@ func (c *JobController) GetJobDetailsPublic(ctx *gin.Context) {
 		ctx.Abort()
 		return
 	}
+	var query request.JobDetailsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.Header("Vary", "Accept")
+	if query.Format == "jsonld" || (query.Format == "" && ctx.NegotiateFormat(gin.MIMEJSON, jsonLDMIME) == jsonLDMIME) {
+		posting, err := c.jobService.GetJobPosting(ctx, jobID)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.Header("Content-Type", jsonLDMIME+"; charset=utf-8")
+		ctx.JSON(http.StatusOK, response.ToJobPostingResponse(posting))
+		return
+	}
+
 	job, err := c.jobService.GetJobDetailsPublic(ctx,jobID)
 	if err != nil {
 		_  = ctx.Error(err)
@@ -394,3 +447,287 @@ func (c *JobController) GetJobDetailsPublic(ctx *gin.Context) {
 		Data:    response.ToJobResponse(job),
 	})
 }
+
+// GetJobRevisions godoc
+// @Summary Get job revisions
+// @Description Retrieve the revisions of a job, latest first. A revision is recorded each time the content of the job changes.
+// @Tags Recruiters - Jobs
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Success 200 {object} response.Response{Data=response.JobRevisionsResponseData} "Job revisions retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this Job"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /recruiters/jobs/{jobId}/revisions [get]
+func (c *JobController) GetJobRevisions(ctx *gin.Context) {
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	revisions, err := c.jobService.GetJobRevisions(ctx, jobID, recruiterID)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Job revisions retrieved successfully",
+		Data:    response.ToJobRevisionsResponse(revisions),
+	})
+}
+
+// GetJobRevision godoc
+// @Summary Get a job revision
+// @Description Retrieve the content of a job as it was at a given revision
+// @Tags Recruiters - Jobs
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} response.Response{Data=response.JobRevisionResponse} "Job revision found"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this Job"
+// @Failure 404 {object} response.Response "Job revision not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /recruiters/jobs/{jobId}/revisions/{revision} [get]
+func (c *JobController) GetJobRevision(ctx *gin.Context) {
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	revision, err := strconv.Atoi(ctx.Param("revision"))
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	jobRevision, err := c.jobService.GetJobRevision(ctx, jobID, revision, recruiterID)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Job revision found",
+		Data:    response.ToJobRevisionResponse(jobRevision),
+	})
+}
+
+// DiffJobRevisions godoc
+// @Summary Compare two job revisions
+// @Description List the fields of a job that changed from one revision to another
+// @Tags Recruiters - Jobs
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param from query int true "Revision to compare from"
+// @Param to query int true "Revision to compare to"
+// @Success 200 {object} response.Response{Data=response.JobRevisionDiffResponseData} "Job revisions compared successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this Job"
+// @Failure 404 {object} response.Response "Job revision not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /recruiters/jobs/{jobId}/revisions/diff [get]
+func (c *JobController) DiffJobRevisions(ctx *gin.Context) {
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var query request.JobRevisionDiffQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	changes, err := c.jobService.DiffJobRevisions(ctx, jobID, query, recruiterID)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Job revisions compared successfully",
+		Data:    response.ToJobRevisionDiffResponse(query.From, query.To, changes),
+	})
+}
+
+// RestoreJobRevision godoc
+// @Summary Restore a job revision
+// @Description Bring the content of a job back to a previous revision, recorded as a new revision. The status and schedule of the job are not changed.
+// @Tags Recruiters - Jobs
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} response.Response{Data=response.JobResponse} "Job revision restored successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this Job"
+// @Failure 404 {object} response.Response "Job revision not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /recruiters/jobs/{jobId}/revisions/{revision}/restore [post]
+func (c *JobController) RestoreJobRevision(ctx *gin.Context) {
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	revision, err := strconv.Atoi(ctx.Param("revision"))
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	job, err := c.jobService.RestoreJobRevision(ctx, jobID, revision, recruiterID)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Job revision restored successfully",
+		Data:    response.ToJobResponse(job),
+	})
+}
+
+// ImportJobs godoc
+// @Summary Import jobs
+// @Description Post many jobs at once from a CSV file with a header row or a JSON Lines file, at most 500 jobs and 5 MB. Every row is checked like a posted job and reported on; the valid rows are posted even when others are not. With dry_run nothing is posted.
+// @Tags Recruiters - Jobs
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or JSON Lines file"
+// @Param query query request.JobImportQuery false "Import options"
+// @Success 200 {object} response.Response{Data=response.JobImportResponseData} "Jobs imported"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Confirm your email address first"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /recruiters/jobs/import [post]
+func (c *JobController) ImportJobs(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var query request.JobImportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	// A missing file is reported by the service
+	file, _ := ctx.FormFile("file")
+
+	results, err := c.jobService.ImportJobs(ctx, recruiterID, file, query.Format, query.DryRun)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	message := "Jobs imported"
+	if query.DryRun {
+		message = "Jobs checked, nothing was imported"
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: message,
+		Data:    response.ToJobImportResponse(results, query.DryRun),
+	})
+}
+
+// ExportJobs godoc
+// @Summary Export jobs
+// @Description Download the recruiter's jobs as CSV (default) or JSON Lines, optionally filtered by status. The CSV columns can be imported back.
+// @Tags Recruiters - Jobs
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param query query request.JobExportQuery false "Export options"
+// @Success 200 {file} file "Jobs export"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /recruiters/jobs/export [get]
+func (c *JobController) ExportJobs(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var query request.JobExportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	format := query.Format
+	if format == "" {
+		format = "csv"
+	}
+
+	started := false
+	exporter := response.NewJobExportWriter(ctx.Writer, format, func() {
+		started = true
+		contentType := "text/csv; charset=utf-8"
+		if format == "jsonl" {
+			contentType = "application/x-ndjson"
+		}
+		ctx.Header("Content-Type", contentType)
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="jobs.%s"`, format))
+	})
+	err = c.jobService.ExportJobs(ctx, recruiterID, query.Status, exporter.Write)
+	if err == nil {
+		err = exporter.Close()
+	}
+	// Once the export has started the status is sent and the error cannot be reported
+	if err != nil && !started {
+		_ = ctx.Error(err)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobFeedController serves the job feeds and the sitemap
+type JobFeedController struct {
+	service serviceInterfaces.JobFeedService
+}
+
+// NewJobFeedController creates a new instance of JobFeedController
+func NewJobFeedController(service serviceInterfaces.JobFeedService) *JobFeedController {
+	return &JobFeedController{service: service}
+}
+
+// GetRSSFeed godoc
+// @Summary RSS feed of open jobs
+// @Description RSS 2.0 feed of the open jobs matching the same filters as the job search, newest first. Feeds are cached for 15 minutes and honour If-Modified-Since.
+// @Tags Jobs - Feeds
+// @Produce application/rss+xml
+// @Param filters query request.JobFilters false "Job search filters"
+// @Success 200 {string} string "RSS feed"
+// @Success 304 "Not modified"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /jobs/feed.rss [get]
+func (c *JobFeedController) GetRSSFeed(ctx *gin.Context) {
+	c.serveJobFeed(ctx, "rss", "application/rss+xml; charset=utf-8")
+}
+
+// GetAtomFeed godoc
+// @Summary Atom feed of open jobs
+// @Description Atom feed of the open jobs matching the same filters as the job search, newest first. Feeds are cached for 15 minutes and honour If-Modified-Since.
+// @Tags Jobs - Feeds
+// @Produce application/atom+xml
+// @Param filters query request.JobFilters false "Job search filters"
+// @Success 200 {string} string "Atom feed"
+// @Success 304 "Not modified"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /jobs/feed.atom [get]
+func (c *JobFeedController) GetAtomFeed(ctx *gin.Context) {
+	c.serveJobFeed(ctx, "atom", "application/atom+xml; charset=utf-8")
+}
+
+// GetIndeedFeed godoc
+// @Summary Indeed XML feed of open jobs
+// @Description Indeed-style XML feed of the open jobs matching the same filters as the job search, for job aggregators. Feeds are cached for 15 minutes and honour If-Modified-Since.
+// @Tags Jobs - Feeds
+// @Produce application/xml
+// @Param filters query request.JobFilters false "Job search filters"
+// @Success 200 {string} string "Indeed XML feed"
+// @Success 304 "Not modified"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /jobs/feed.xml [get]
+func (c *JobFeedController) GetIndeedFeed(ctx *gin.Context) {
+	c.serveJobFeed(ctx, "indeed", "application/xml; charset=utf-8")
+}
+
+// GetSitemap godoc
+// @Summary Sitemap of public job pages
+// @Description sitemaps.org sitemap listing the public page of every open job on the front end
+// @Tags Jobs - Feeds
+// @Produce application/xml
+// @Success 200 {string} string "Sitemap"
+// @Success 304 "Not modified"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /sitemap.xml [get]
+func (c *JobFeedController) GetSitemap(ctx *gin.Context) {
+	feed, err := c.service.GetSitemap(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	writeFeed(ctx, feed, "application/xml; charset=utf-8")
+}
+
+func (c *JobFeedController) serveJobFeed(ctx *gin.Context, format, contentType string) {
+	var filters request.JobFilters
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	feed, err := c.service.GetJobFeed(ctx, format, filters)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	writeFeed(ctx, feed, contentType)
+}
+
+// writeFeed sends a feed with its Last-Modified time, or 304 Not Modified when
+// the client already has it
+func writeFeed(ctx *gin.Context, feed *utils.FeedCache, contentType string) {
+	ctx.Header("Cache-Control", "public, max-age=900")
+	if !feed.LastModified.IsZero() {
+		lastModified := feed.LastModified.UTC().Truncate(time.Second)
+		ctx.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+		if since, err := http.ParseTime(ctx.GetHeader("If-Modified-Since")); err == nil && !lastModified.After(since) {
+			ctx.Status(http.StatusNotModified)
+			return
+		}
+	}
+	ctx.Data(http.StatusOK, contentType, []byte(feed.Body))
+}
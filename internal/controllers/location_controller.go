@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocationController serves the reference list of the wilayas and communes
+type LocationController struct {
+	service serviceInterfaces.LocationService
+}
+
+// NewLocationController creates a new instance of LocationController
+func NewLocationController(service serviceInterfaces.LocationService) *LocationController {
+	return &LocationController{service: service}
+}
+
+// GetWilayas godoc
+// @Summary List the wilayas
+// @Description List the 58 wilayas of Algeria with their official code, their names in French, Arabic and English and the centroid of their seat
+// @Tags Locations
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.WilayasResponseData} "Wilayas retrieved successfully"
+// @Router /locations/wilayas [get]
+func (c *LocationController) GetWilayas(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Wilayas retrieved successfully",
+		Data:    response.ToWilayasResponse(c.service.GetWilayas(ctx)),
+	})
+}
+
+// GetWilaya godoc
+// @Summary Get a wilaya and its communes
+// @Description Retrieve a wilaya, given by code or by name in French, Arabic or English, along with its communes
+// @Tags Locations
+// @Produce json
+// @Param wilaya path string true "Wilaya code or name"
+// @Success 200 {object} response.Response{Data=response.WilayaResponse} "Wilaya found"
+// @Failure 404 {object} response.Response "Wilaya not found"
+// @Router /locations/wilayas/{wilaya} [get]
+func (c *LocationController) GetWilaya(ctx *gin.Context) {
+	wilaya, err := c.service.GetWilaya(ctx, ctx.Param("wilaya"))
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Wilaya found",
+		Data:    response.ToWilayaResponse(wilaya, true),
+	})
+}
+
+// SearchLocations godoc
+// @Summary Resolve a location
+// @Description Find the wilaya, and the commune if any, named by a free text location such as "Alger", "Algiers" or "الجزائر"
+// @Tags Locations
+// @Produce json
+// @Param q query string true "Location"
+// @Success 200 {object} response.Response{Data=response.LocationSearchResponse} "Location found"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 404 {object} response.Response "Location not found"
+// @Router /locations/search [get]
+func (c *LocationController) SearchLocations(ctx *gin.Context) {
+	wilaya, commune, err := c.service.SearchLocations(ctx, ctx.Query("q"))
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Location found",
+		Data:    response.ToLocationSearchResponse(wilaya, commune),
+	})
+}
@@ -0,0 +1,468 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MessageController handles the API requests of the message threads of applications
+type MessageController struct {
+	service serviceInterfaces.MessageService
+}
+
+// NewMessageController creates a new instance of MessageController
+func NewMessageController(service serviceInterfaces.MessageService) *MessageController {
+	return &MessageController{service: service}
+}
+
+// threadResolver reads the thread a request is about from its path and the
+// authenticated user
+type threadResolver func(ctx *gin.Context) (request.MessageThread, error)
+
+// GetCandidateConversations godoc
+// @Summary Get my conversations
+// @Description Retrieve the message threads of the authenticated candidate's applications, the most recently active first, with their unread message counts
+// @Tags Candidates - Messages
+// @Produce json
+// @Param page query request.PageRequest false "Pagination (only created_at sorting is supported, by last message)"
+// @Success 200 {object} response.Response{Data=response.ConversationsResponseData} "Conversations retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid pagination parameters"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/conversations [get]
+func (c *MessageController) GetCandidateConversations(ctx *gin.Context) {
+	c.getConversations(ctx, "candidate_id")
+}
+
+// GetCandidateMessages godoc
+// @Summary Get the messages of my application
+// @Description Retrieve the messages exchanged with the recruiter about the authenticated candidate's application to a job, newest first
+// @Tags Candidates - Messages
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param page query request.PageRequest false "Pagination (only created_at sorting is supported)"
+// @Success 200 {object} response.Response{Data=response.MessagesResponseData} "Messages retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications/{jobId}/messages [get]
+func (c *MessageController) GetCandidateMessages(ctx *gin.Context) {
+	c.getMessages(ctx, candidateThread)
+}
+
+// SendCandidateMessage godoc
+// @Summary Send a message about my application
+// @Description Send a message to the recruiter of a job the authenticated candidate applied to, with up to 5 PDF or image attachments of 10 MB at most
+// @Tags Candidates - Messages
+// @Accept multipart/form-data
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param body formData string false "Message text"
+// @Param attachments formData file false "Attachments"
+// @Success 201 {object} response.Response{Data=response.MessageResponse} "Message sent successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications/{jobId}/messages [post]
+func (c *MessageController) SendCandidateMessage(ctx *gin.Context) {
+	c.sendMessage(ctx, candidateThread)
+}
+
+// MarkCandidateMessagesRead godoc
+// @Summary Mark the messages of my application as read
+// @Description Set the read receipts of the messages the recruiter sent about the authenticated candidate's application to a job
+// @Tags Candidates - Messages
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Success 200 {object} response.Response{Data=response.MessagesReadResponse} "Messages marked as read"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications/{jobId}/messages/read [put]
+func (c *MessageController) MarkCandidateMessagesRead(ctx *gin.Context) {
+	c.markMessagesRead(ctx, candidateThread)
+}
+
+// ReportCandidateMessage godoc
+// @Summary Report a message
+// @Description Report a message the recruiter sent about the authenticated candidate's application, for an admin to review
+// @Tags Candidates - Messages
+// @Accept json
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param messageId path int true "Message ID"
+// @Param report body request.ReportMessageRequest true "Report reason"
+// @Success 201 {object} response.Response{Data=response.MessageReportResponse} "Message reported successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Message not found"
+// @Failure 409 {object} response.Response "You already reported this message"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/applications/{jobId}/messages/{messageId}/report [post]
+func (c *MessageController) ReportCandidateMessage(ctx *gin.Context) {
+	c.reportMessage(ctx, candidateThread)
+}
+
+// GetRecruiterConversations godoc
+// @Summary Get my conversations
+// @Description Retrieve the message threads of the applications to the authenticated recruiter's jobs, the most recently active first, with their unread message counts
+// @Tags Recruiters - Messages
+// @Produce json
+// @Param page query request.PageRequest false "Pagination (only created_at sorting is supported, by last message)"
+// @Success 200 {object} response.Response{Data=response.ConversationsResponseData} "Conversations retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid pagination parameters"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/conversations [get]
+func (c *MessageController) GetRecruiterConversations(ctx *gin.Context) {
+	c.getConversations(ctx, "recruiter_id")
+}
+
+// GetRecruiterMessages godoc
+// @Summary Get the messages of an application
+// @Description Retrieve the messages exchanged with the candidate of an application to a job owned by the authenticated recruiter, newest first
+// @Tags Recruiters - Messages
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Param page query request.PageRequest false "Pagination (only created_at sorting is supported)"
+// @Success 200 {object} response.Response{Data=response.MessagesResponseData} "Messages retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/messages [get]
+func (c *MessageController) GetRecruiterMessages(ctx *gin.Context) {
+	c.getMessages(ctx, recruiterThread)
+}
+
+// SendRecruiterMessage godoc
+// @Summary Send a message to an applicant
+// @Description Send a message to the candidate of an application to a job owned by the authenticated recruiter, with up to 5 PDF or image attachments of 10 MB at most
+// @Tags Recruiters - Messages
+// @Accept multipart/form-data
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Param body formData string false "Message text"
+// @Param attachments formData file false "Attachments"
+// @Success 201 {object} response.Response{Data=response.MessageResponse} "Message sent successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/messages [post]
+func (c *MessageController) SendRecruiterMessage(ctx *gin.Context) {
+	c.sendMessage(ctx, recruiterThread)
+}
+
+// MarkRecruiterMessagesRead godoc
+// @Summary Mark the messages of an application as read
+// @Description Set the read receipts of the messages the candidate of an application sent to the authenticated recruiter
+// @Tags Recruiters - Messages
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Success 200 {object} response.Response{Data=response.MessagesReadResponse} "Messages marked as read"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/messages/read [put]
+func (c *MessageController) MarkRecruiterMessagesRead(ctx *gin.Context) {
+	c.markMessagesRead(ctx, recruiterThread)
+}
+
+// ReportRecruiterMessage godoc
+// @Summary Report a message
+// @Description Report a message the candidate of an application sent to the authenticated recruiter, for an admin to review
+// @Tags Recruiters - Messages
+// @Accept json
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Param messageId path int true "Message ID"
+// @Param report body request.ReportMessageRequest true "Report reason"
+// @Success 201 {object} response.Response{Data=response.MessageReportResponse} "Message reported successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Message not found"
+// @Failure 409 {object} response.Response "You already reported this message"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/messages/{messageId}/report [post]
+func (c *MessageController) ReportRecruiterMessage(ctx *gin.Context) {
+	c.reportMessage(ctx, recruiterThread)
+}
+
+// GetMessageReports godoc
+// @Summary Get the message reports
+// @Description Retrieve the reported messages, newest reports first, along with the content of the messages
+// @Tags Admin - Messages
+// @Produce json
+// @Param status query string false "Report status" Enums(open, dismissed, removed)
+// @Param page query request.PageRequest false "Pagination (only created_at sorting is supported)"
+// @Success 200 {object} response.Response{Data=response.MessageReportsResponseData} "Message reports retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/message-reports [get]
+func (c *MessageController) GetMessageReports(ctx *gin.Context) {
+	var filters request.MessageReportFilters
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	reports, pageInfo, err := c.service.GetMessageReports(ctx, filters)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Message reports retrieved successfully",
+		Data:    response.ToMessageReportsResponse(reports, pageInfo),
+	})
+}
+
+// ReviewMessageReport godoc
+// @Summary Review a message report
+// @Description Close an open report, either dismissing it or removing the message. A removed message no longer shows its text and attachments, and the other reports of the message are closed with it.
+// @Tags Admin - Messages
+// @Accept json
+// @Produce json
+// @Param reportId path int true "Report ID"
+// @Param review body request.ReviewMessageReportRequest true "Review"
+// @Success 200 {object} response.Response{Data=response.MessageReportResponse} "Message report reviewed successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Message report not found"
+// @Failure 409 {object} response.Response "The report was already reviewed"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/message-reports/{reportId} [put]
+func (c *MessageController) ReviewMessageReport(ctx *gin.Context) {
+	adminID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	reportID, err := strconv.ParseInt(ctx.Param("reportId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.ReviewMessageReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	report, err := c.service.ReviewMessageReport(ctx, adminID, reportID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Message report reviewed successfully",
+		Data:    response.ToMessageReportResponse(report),
+	})
+}
+
+func (c *MessageController) getConversations(ctx *gin.Context, userKey string) {
+	userID, err := uuid.Parse(ctx.MustGet(userKey).(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var page request.PageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	conversations, pageInfo, unread, err := c.service.GetConversations(ctx, userID, page)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Conversations retrieved successfully",
+		Data:    response.ToConversationsResponse(conversations, pageInfo, unread),
+	})
+}
+
+func (c *MessageController) getMessages(ctx *gin.Context, resolveThread threadResolver) {
+	thread, err := resolveThread(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var page request.PageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	messages, pageInfo, err := c.service.GetMessages(ctx, thread, page)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Messages retrieved successfully",
+		Data:    response.ToMessagesResponse(messages, pageInfo),
+	})
+}
+
+func (c *MessageController) sendMessage(ctx *gin.Context, resolveThread threadResolver) {
+	thread, err := resolveThread(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.SendMessageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var files []*multipart.FileHeader
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		files = form.File["attachments"]
+	}
+
+	message, err := c.service.SendMessage(ctx, thread, req, files)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Message sent successfully",
+		Data:    response.ToMessageResponse(message),
+	})
+}
+
+func (c *MessageController) markMessagesRead(ctx *gin.Context, resolveThread threadResolver) {
+	thread, err := resolveThread(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	count, err := c.service.MarkMessagesRead(ctx, thread)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Messages marked as read",
+		Data:    response.MessagesReadResponse{ReadCount: count},
+	})
+}
+
+func (c *MessageController) reportMessage(ctx *gin.Context, resolveThread threadResolver) {
+	thread, err := resolveThread(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	messageID, err := strconv.ParseInt(ctx.Param("messageId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.ReportMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	report, err := c.service.ReportMessage(ctx, thread, messageID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Message reported successfully",
+		Data:    response.ToMessageReportResponse(report),
+	})
+}
+
+func candidateThread(ctx *gin.Context) (request.MessageThread, error) {
+	thread := request.MessageThread{Role: "candidate"}
+	var err error
+	if thread.UserID, err = uuid.Parse(ctx.MustGet("candidate_id").(string)); err != nil {
+		return thread, err
+	}
+	thread.JobID, err = strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	return thread, err
+}
+
+func recruiterThread(ctx *gin.Context) (request.MessageThread, error) {
+	thread := request.MessageThread{Role: "recruiter"}
+	var err error
+	if thread.UserID, err = uuid.Parse(ctx.MustGet("recruiter_id").(string)); err != nil {
+		return thread, err
+	}
+	if thread.JobID, err = strconv.ParseInt(ctx.Param("jobId"), 10, 64); err != nil {
+		return thread, err
+	}
+	thread.ApplicationID, err = strconv.ParseInt(ctx.Param("applicationId"), 10, 64)
+	return thread, err
+}
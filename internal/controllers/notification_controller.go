@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	"dz-jobs-api/internal/models"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// notificationHeartbeatInterval keeps idle notification streams from being
+	// closed by proxies
+	notificationHeartbeatInterval = 25 * time.Second
+	// notificationStreamLifetime bounds how long a notification stream stays open,
+	// the client reconnects and its access token is checked again
+	notificationStreamLifetime = 30 * time.Minute
+)
+
+// NotificationController handles notification center API requests
+type NotificationController struct {
+	service serviceInterfaces.NotificationService
+}
+
+// NewNotificationController creates a new instance of NotificationController
+func NewNotificationController(service serviceInterfaces.NotificationService) *NotificationController {
+	return &NotificationController{service: service}
+}
+
+// GetNotifications godoc
+// @Summary Get my notifications
+// @Description Retrieve the notifications of the authenticated user, newest first, along with how many of them are unread
+// @Tags Notifications
+// @Produce json
+// @Param filters query request.NotificationFilters false "Read state and type filters and pagination (only created_at sorting is supported)"
+// @Success 200 {object} response.Response{Data=response.NotificationsResponseData} "Notifications retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notifications [get]
+func (c *NotificationController) GetNotifications(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var filters request.NotificationFilters
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	notifications, pageInfo, unread, err := c.service.GetNotifications(ctx, userID, filters)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Notifications retrieved successfully",
+		Data:    response.ToNotificationsResponse(notifications, pageInfo, unread),
+	})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Description Mark a notification of the authenticated user as read
+// @Tags Notifications
+// @Produce json
+// @Param notificationId path int true "Notification ID"
+// @Success 200 {object} response.Response{Data=response.NotificationResponse} "Notification marked as read"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Notification not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notifications/{notificationId}/read [put]
+func (c *NotificationController) MarkNotificationRead(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	notificationID, err := strconv.ParseInt(ctx.Param("notificationId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	notification, err := c.service.MarkNotificationRead(ctx, userID, notificationID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Notification marked as read",
+		Data:    response.ToNotificationResponse(notification),
+	})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all my notifications as read
+// @Description Mark every unread notification of the authenticated user as read
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.NotificationsReadResponse} "Notifications marked as read"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notifications/read-all [put]
+func (c *NotificationController) MarkAllNotificationsRead(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	count, err := c.service.MarkAllNotificationsRead(ctx, userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Notifications marked as read",
+		Data:    response.NotificationsReadResponse{ReadCount: count},
+	})
+}
+
+// StreamNotifications godoc
+// @Summary Stream my notifications
+// @Description Open a server-sent events stream pushing the notifications of the authenticated user as `notification` events, authenticated by the access_token cookie.
+// @Description A reconnecting client first receives the notifications it missed after the one in the Last-Event-ID header.
+// @Description The stream is closed after 30 minutes and clients are expected to reconnect.
+// @Tags Notifications
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "ID of the last notification received"
+// @Param last_event_id query int false "ID of the last notification received, for clients that cannot set headers"
+// @Success 200 {string} string "Stream of notification events"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notifications/stream [get]
+func (c *NotificationController) StreamNotifications(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+	var lastID int64
+	if lastEventID != "" {
+		lastID, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+	}
+
+	// Subscribe before catching up so nothing published in between is missed,
+	// notifications received twice are skipped by their ID
+	notifications, unsubscribe := c.service.Subscribe(userID)
+	defer unsubscribe()
+
+	var missed []*models.Notification
+	if lastID > 0 {
+		missed, err = c.service.GetMissedNotifications(ctx, userID, lastID)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	send := func(notification *models.Notification) bool {
+		if notification.ID <= lastID {
+			return true
+		}
+		if err := writeNotificationEvent(ctx.Writer, notification); err != nil {
+			return false
+		}
+		lastID = notification.ID
+		return true
+	}
+	for _, notification := range missed {
+		if !send(notification) {
+			return
+		}
+	}
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(notificationHeartbeatInterval)
+	defer heartbeat.Stop()
+	lifetime := time.NewTimer(notificationStreamLifetime)
+	defer lifetime.Stop()
+
+	// Errors are not reported with ctx.Error once the stream started, the status
+	// is already sent
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-lifetime.C:
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(ctx.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case notification, ok := <-notifications:
+			// The stream fell behind and was closed, the client reconnects
+			if !ok || !send(notification) {
+				return
+			}
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+// writeNotificationEvent writes a notification as a server-sent event, its ID is
+// the Last-Event-ID the client sends back when it reconnects
+func writeNotificationEvent(w io.Writer, notification *models.Notification) error {
+	data, err := json.Marshal(response.ToNotificationResponse(notification))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: notification\ndata: %s\n\n", notification.ID, data)
+	return err
+}
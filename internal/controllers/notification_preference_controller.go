@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	"dz-jobs-api/internal/helpers"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// NotificationPreferenceController handles notification preferences API requests
+type NotificationPreferenceController struct {
+	service serviceInterfaces.NotificationPreferenceService
+}
+
+// NewNotificationPreferenceController creates a new instance of NotificationPreferenceController
+func NewNotificationPreferenceController(service serviceInterfaces.NotificationPreferenceService) *NotificationPreferenceController {
+	return &NotificationPreferenceController{service: service}
+}
+
+// GetPreferences godoc
+// @Summary Get my notification preferences
+// @Description Retrieve, for every event, the channels the authenticated user is told about it on, along with the quiet hours and digest mode of the user
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.NotificationPreferencesResponse} "Notification preferences retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notification-preferences [get]
+func (c *NotificationPreferenceController) GetPreferences(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	preferences, err := c.service.GetPreferences(ctx, userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Notification preferences retrieved successfully",
+		Data:    response.ToNotificationPreferencesResponse(preferences),
+	})
+}
+
+// UpdatePreferences godoc
+// @Summary Update my notification preferences
+// @Description Turn the email or in-app channel of events on or off, the channels not listed keep their setting.
+// @Description The quiet hours and digest mode are replaced: emails are held back during quiet hours, and the emails of low priority events are batched in a daily digest when digest is set.
+// @Description Security emails such as password reset codes are always sent.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param preferences body request.UpdateNotificationPreferencesRequest true "Notification preferences"
+// @Success 200 {object} response.Response{Data=response.NotificationPreferencesResponse} "Notification preferences updated successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notification-preferences [put]
+func (c *NotificationPreferenceController) UpdatePreferences(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var req request.UpdateNotificationPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	preferences, err := c.service.UpdatePreferences(ctx, userID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Notification preferences updated successfully",
+		Data:    response.ToNotificationPreferencesResponse(preferences),
+	})
+}
+
+// ConfirmUnsubscribe godoc
+// @Summary Confirm unsubscribing from notification emails
+// @Description Show the page of the unsubscribe link of an email, asking to confirm turning off the emails of its event, or of every event for the link of a digest.
+// @Description Opening the link does not unsubscribe, so that mail scanners following links do not unsubscribe anyone.
+// @Tags Notifications
+// @Produce html
+// @Param token query string true "Unsubscribe token"
+// @Success 200 {string} string "Unsubscribe confirmation page"
+// @Failure 400 {object} response.Response "Unsubscribe token is required"
+// @Failure 404 {object} response.Response "Invalid unsubscribe link"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notification-preferences/unsubscribe [get]
+func (c *NotificationPreferenceController) ConfirmUnsubscribe(ctx *gin.Context) {
+	token := ctx.Query("token")
+	description, err := c.service.DescribeUnsubscribe(ctx, token)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	c.renderUnsubscribePage(ctx, helpers.UnsubscribePage{Token: token, Description: description})
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from notification emails
+// @Description Turn off the emails of the event an email was sent for with the signed token from its unsubscribe link, or of every event for the link of a digest.
+// @Description Posted by the confirmation page and by mail clients supporting RFC 8058 one-click unsubscribe, which the List-Unsubscribe-Post header of the emails announces.
+// @Tags Notifications
+// @Accept x-www-form-urlencoded
+// @Produce json,html
+// @Param token query string true "Unsubscribe token"
+// @Success 200 {object} response.Response "Unsubscribed successfully"
+// @Failure 400 {object} response.Response "Unsubscribe token is required"
+// @Failure 404 {object} response.Response "Invalid unsubscribe link"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /notification-preferences/unsubscribe [post]
+func (c *NotificationPreferenceController) Unsubscribe(ctx *gin.Context) {
+	if err := c.service.Unsubscribe(ctx, ctx.Query("token")); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	// The confirmation page gets a page back, mail clients the JSON response
+	if ctx.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		c.renderUnsubscribePage(ctx, helpers.UnsubscribePage{Unsubscribed: true})
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Unsubscribed successfully",
+	})
+}
+
+func (c *NotificationPreferenceController) renderUnsubscribePage(ctx *gin.Context, page helpers.UnsubscribePage) {
+	html, err := helpers.RenderUnsubscribePage(page)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Referrer-Policy", "no-referrer")
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", html)
+}
@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PipelineController handles hiring pipeline API requests
+type PipelineController struct {
+	service serviceInterfaces.PipelineService
+}
+
+// NewPipelineController creates a new instance of PipelineController
+func NewPipelineController(service serviceInterfaces.PipelineService) *PipelineController {
+	return &PipelineController{service: service}
+}
+
+// GetStages godoc
+// @Summary Get pipeline stages
+// @Description Retrieve the hiring pipeline stages of the authenticated recruiter (the default stages if none were defined)
+// @Tags Recruiters - Pipeline
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.PipelineStagesResponseData} "Pipeline stages retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/pipeline-stages [get]
+func (c *PipelineController) GetStages(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	stages, err := c.service.GetStages(ctx, recruiterID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Pipeline stages retrieved successfully",
+		Data:    response.ToPipelineStagesResponse(stages),
+	})
+}
+
+// UpdateStages godoc
+// @Summary Define pipeline stages
+// @Description Replace the hiring pipeline stages of the authenticated recruiter, stages are ordered as sent. Stages that still hold applications cannot be removed
+// @Tags Recruiters - Pipeline
+// @Accept json
+// @Produce json
+// @Param stages body request.UpdatePipelineStagesRequest true "Ordered pipeline stages"
+// @Success 200 {object} response.Response{Data=response.PipelineStagesResponseData} "Pipeline stages updated successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "A removed stage still holds applications"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/pipeline-stages [put]
+func (c *PipelineController) UpdateStages(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var req request.UpdatePipelineStagesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	stages, err := c.service.UpdateStages(ctx, recruiterID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Pipeline stages updated successfully",
+		Data:    response.ToPipelineStagesResponse(stages),
+	})
+}
+
+// MoveApplication godoc
+// @Summary Move an applicant to another stage
+// @Description Move an application through the hiring pipeline, the move is recorded in the application history
+// @Tags Recruiters - Pipeline
+// @Accept json
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Param stage body request.MoveApplicationRequest true "Target stage"
+// @Success 200 {object} response.Response{Data=response.ApplicationResponse} "Application moved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "Invalid stage transition"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 409 {object} response.Response "Application was moved by someone else, please retry"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/stage [put]
+func (c *PipelineController) MoveApplication(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	applicationID, err := strconv.ParseInt(ctx.Param("applicationId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.MoveApplicationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	application, err := c.service.MoveApplication(ctx, recruiterID, jobID, applicationID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Application moved successfully",
+		Data:    response.ToApplicationResponse(application),
+	})
+}
+
+// GetApplicationTransitions godoc
+// @Summary Get application history
+// @Description Retrieve the stage transition history of an application
+// @Tags Recruiters - Pipeline
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param applicationId path int true "Application ID"
+// @Success 200 {object} response.Response{Data=response.ApplicationTransitionsResponseData} "Application history retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Application not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/applications/{applicationId}/transitions [get]
+func (c *PipelineController) GetApplicationTransitions(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	applicationID, err := strconv.ParseInt(ctx.Param("applicationId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	transitions, err := c.service.GetApplicationTransitions(ctx, recruiterID, jobID, applicationID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Application history retrieved successfully",
+		Data:    response.ToApplicationTransitionsResponse(transitions),
+	})
+}
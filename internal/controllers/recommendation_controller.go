@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RecommendationController handles candidate and job matching API requests
+type RecommendationController struct {
+	service serviceInterfaces.RecommendationService
+}
+
+// NewRecommendationController creates a new instance of RecommendationController
+func NewRecommendationController(service serviceInterfaces.RecommendationService) *RecommendationController {
+	return &RecommendationController{service: service}
+}
+
+// GetJobRecommendations godoc
+// @Summary Get recommended jobs
+// @Description Rank the open jobs the candidate has not applied to against their skills, address, experience and preferred job type, each job comes with its score breakdown
+// @Tags Candidates - Recommendations
+// @Produce json
+// @Param limit query int false "Number of jobs returned (1-50, default 10)"
+// @Param job_type query string false "Preferred job type (full-time, part-time, freelance)"
+// @Success 200 {object} response.Response{Data=response.JobRecommendationsResponseData} "Recommended jobs retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Candidate not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/recommendations [get]
+func (c *RecommendationController) GetJobRecommendations(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var query request.RecommendationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	recommendations, err := c.service.GetJobRecommendations(ctx, candidateID, query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Recommended jobs retrieved successfully",
+		Data:    response.ToJobRecommendationsResponse(recommendations),
+	})
+}
+
+// GetJobCandidateMatches godoc
+// @Summary Get matching candidates
+// @Description Rank the candidates who have not applied to the job against its required skills, location and title, each candidate comes with their score breakdown
+// @Tags Recruiters - Recommendations
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param limit query int false "Number of candidates returned (1-50, default 10)"
+// @Success 200 {object} response.Response{Data=response.CandidateMatchesResponseData} "Matching candidates retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 404 {object} response.Response "Job not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/matches [get]
+func (c *RecommendationController) GetJobCandidateMatches(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var query request.RecommendationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	matches, err := c.service.GetJobCandidateMatches(ctx, recruiterID, jobID, query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Matching candidates retrieved successfully",
+		Data:    response.ToCandidateMatchesResponse(matches),
+	})
+}
@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SavedSearchController handles saved search and job alert API requests
+type SavedSearchController struct {
+	service serviceInterfaces.SavedSearchService
+}
+
+// NewSavedSearchController creates a new instance of SavedSearchController
+func NewSavedSearchController(service serviceInterfaces.SavedSearchService) *SavedSearchController {
+	return &SavedSearchController{service: service}
+}
+
+// CreateSavedSearch godoc
+// @Summary Save a job search
+// @Description Save a set of job search filters, the new jobs matching them are emailed to the candidate at the chosen frequency (instant, daily or weekly)
+// @Tags Candidates - Saved Searches
+// @Accept json
+// @Produce json
+// @Param search body request.SavedSearchRequest true "Saved search"
+// @Success 201 {object} response.Response{Data=response.SavedSearchResponse} "Search saved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Too many saved searches"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/saved-searches [post]
+func (c *SavedSearchController) CreateSavedSearch(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var req request.SavedSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	search, err := c.service.CreateSavedSearch(ctx, candidateID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Search saved successfully",
+		Data:    response.ToSavedSearchResponse(search),
+	})
+}
+
+// GetSavedSearches godoc
+// @Summary Get saved searches
+// @Description Retrieve the saved job searches of the authenticated candidate
+// @Tags Candidates - Saved Searches
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.SavedSearchesResponseData} "Saved searches retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/saved-searches [get]
+func (c *SavedSearchController) GetSavedSearches(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	searches, err := c.service.GetSavedSearches(ctx, candidateID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Saved searches retrieved successfully",
+		Data:    response.ToSavedSearchesResponse(searches),
+	})
+}
+
+// UpdateSavedSearch godoc
+// @Summary Update a saved search
+// @Description Replace the name, filters, alert frequency and alert state of a saved search
+// @Tags Candidates - Saved Searches
+// @Accept json
+// @Produce json
+// @Param searchId path int true "Saved search ID"
+// @Param search body request.SavedSearchRequest true "Saved search"
+// @Success 200 {object} response.Response{Data=response.SavedSearchResponse} "Saved search updated successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Saved search not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/saved-searches/{searchId} [put]
+func (c *SavedSearchController) UpdateSavedSearch(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	searchID, err := strconv.ParseInt(ctx.Param("searchId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.SavedSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	search, err := c.service.UpdateSavedSearch(ctx, candidateID, searchID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Saved search updated successfully",
+		Data:    response.ToSavedSearchResponse(search),
+	})
+}
+
+// DeleteSavedSearch godoc
+// @Summary Delete a saved search
+// @Description Delete a saved search and stop its alerts
+// @Tags Candidates - Saved Searches
+// @Produce json
+// @Param searchId path int true "Saved search ID"
+// @Success 200 {object} response.Response "Saved search deleted successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Saved search not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /candidates/saved-searches/{searchId} [delete]
+func (c *SavedSearchController) DeleteSavedSearch(ctx *gin.Context) {
+	userID := ctx.MustGet("candidate_id")
+	candidateID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	searchID, err := strconv.ParseInt(ctx.Param("searchId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if err := c.service.DeleteSavedSearch(ctx, candidateID, searchID); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Saved search deleted successfully",
+	})
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from job alerts
+// @Description Stop the email alerts of a saved search with the token from the alert email, POST supports one-click unsubscribe from mail clients
+// @Tags Saved Searches
+// @Produce json
+// @Param token query string true "Unsubscribe token"
+// @Success 200 {object} response.Response "Unsubscribed successfully"
+// @Failure 400 {object} response.Response "Unsubscribe token is required"
+// @Failure 404 {object} response.Response "Invalid unsubscribe link"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /saved-searches/unsubscribe [get]
+// @Router /saved-searches/unsubscribe [post]
+func (c *SavedSearchController) Unsubscribe(ctx *gin.Context) {
+	if err := c.service.Unsubscribe(ctx, ctx.Query("token")); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Unsubscribed successfully",
+	})
+}
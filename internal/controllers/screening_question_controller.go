@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ScreeningQuestionController handles job screening question API requests
+type ScreeningQuestionController struct {
+	service serviceInterfaces.ScreeningQuestionService
+}
+
+// NewScreeningQuestionController creates a new instance of ScreeningQuestionController
+func NewScreeningQuestionController(service serviceInterfaces.ScreeningQuestionService) *ScreeningQuestionController {
+	return &ScreeningQuestionController{service: service}
+}
+
+// GetJobQuestions godoc
+// @Summary Get the screening questions of a job
+// @Description Retrieve the questions a candidate answers when applying to a job, without their auto-reject rules
+// @Tags Jobs
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Success 200 {object} response.Response{Data=response.ScreeningQuestionsResponseData} "Screening questions retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 404 {object} response.Response "Job not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /jobs/{jobId}/questions [get]
+func (c *ScreeningQuestionController) GetJobQuestions(ctx *gin.Context) {
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	questions, err := c.service.GetJobQuestions(ctx, jobID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Screening questions retrieved successfully",
+		Data:    response.ToPublicScreeningQuestionsResponse(questions),
+	})
+}
+
+// GetRecruiterJobQuestions godoc
+// @Summary Get the screening questions of my job
+// @Description Retrieve the screening questions of a job owned by the authenticated recruiter, with their auto-reject rules
+// @Tags Recruiters - Jobs
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Success 200 {object} response.Response{Data=response.ScreeningQuestionsResponseData} "Screening questions retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/questions [get]
+func (c *ScreeningQuestionController) GetRecruiterJobQuestions(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	questions, err := c.service.GetRecruiterJobQuestions(ctx, recruiterID, jobID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Screening questions retrieved successfully",
+		Data:    response.ToScreeningQuestionsResponse(questions),
+	})
+}
+
+// UpdateJobQuestions godoc
+// @Summary Define the screening questions of my job
+// @Description Replace the screening questions of a job owned by the authenticated recruiter, questions are ordered as sent.
+// @Description Send the question_id of a current question to update it and keep the answers already given to it.
+// @Description Yes/no and choice questions can reject answers, number questions can reject values below or above a bound.
+// @Tags Recruiters - Jobs
+// @Accept json
+// @Produce json
+// @Param jobId path int true "Job ID"
+// @Param questions body request.UpdateScreeningQuestionsRequest true "Ordered screening questions"
+// @Success 200 {object} response.Response{Data=response.ScreeningQuestionsResponseData} "Screening questions updated successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 400 {object} response.Response "Invalid screening questions"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "You do not own this job"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /recruiters/jobs/{jobId}/questions [put]
+func (c *ScreeningQuestionController) UpdateJobQuestions(ctx *gin.Context) {
+	userID := ctx.MustGet("recruiter_id")
+	recruiterID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.UpdateScreeningQuestionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	questions, err := c.service.UpdateJobQuestions(ctx, recruiterID, jobID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Screening questions updated successfully",
+		Data:    response.ToScreeningQuestionsResponse(questions),
+	})
+}
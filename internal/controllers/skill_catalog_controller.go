@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SkillCatalogController handles skills catalog API requests
+type SkillCatalogController struct {
+	service serviceInterfaces.SkillCatalogService
+}
+
+// NewSkillCatalogController creates a new instance of SkillCatalogController
+func NewSkillCatalogController(service serviceInterfaces.SkillCatalogService) *SkillCatalogController {
+	return &SkillCatalogController{service: service}
+}
+
+// SearchSkills godoc
+// @Summary Autocomplete skills
+// @Description Search the skills catalog by name or alias prefix, exact matches come first
+// @Tags Skills
+// @Produce json
+// @Param q query string false "Name or alias prefix"
+// @Param limit query int false "Maximum number of skills returned (1-50, default 10)"
+// @Success 200 {object} response.Response{Data=response.SkillCatalogResponseData} "Skills retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /skills [get]
+func (c *SkillCatalogController) SearchSkills(ctx *gin.Context) {
+	var query request.SkillSearchQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	skills, err := c.service.SearchSkills(ctx, query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Skills retrieved successfully",
+		Data:    response.ToSkillCatalogListResponse(skills),
+	})
+}
+
+// CreateSkill godoc
+// @Summary Create a catalog skill
+// @Description Add a skill with its category and aliases to the skills catalog
+// @Tags Admin - Skills
+// @Accept json
+// @Produce json
+// @Param skill body request.SkillCatalogRequest true "Skill"
+// @Success 201 {object} response.Response{Data=response.SkillCatalogResponse} "Skill created successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "A skill with this name or alias already exists"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/skills [post]
+func (c *SkillCatalogController) CreateSkill(ctx *gin.Context) {
+	var req request.SkillCatalogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	skill, err := c.service.CreateSkill(ctx, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response{
+		Code:    http.StatusCreated,
+		Status:  "Created",
+		Message: "Skill created successfully",
+		Data:    response.ToSkillCatalogResponse(skill),
+	})
+}
+
+// UpdateSkill godoc
+// @Summary Update a catalog skill
+// @Description Rename a catalog skill, change its category and replace its aliases
+// @Tags Admin - Skills
+// @Accept json
+// @Produce json
+// @Param skillId path int true "Skill ID"
+// @Param skill body request.SkillCatalogRequest true "Skill"
+// @Success 200 {object} response.Response{Data=response.SkillCatalogResponse} "Skill updated successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Skill not found"
+// @Failure 409 {object} response.Response "A skill with this name or alias already exists"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/skills/{skillId} [put]
+func (c *SkillCatalogController) UpdateSkill(ctx *gin.Context) {
+	skillID, err := strconv.ParseInt(ctx.Param("skillId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.SkillCatalogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	skill, err := c.service.UpdateSkill(ctx, skillID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Skill updated successfully",
+		Data:    response.ToSkillCatalogResponse(skill),
+	})
+}
+
+// MergeSkills godoc
+// @Summary Merge duplicate skills
+// @Description Merge duplicate skills into the given skill: their names become aliases of it and the candidates and jobs referencing them are moved to it
+// @Tags Admin - Skills
+// @Accept json
+// @Produce json
+// @Param skillId path int true "ID of the skill to keep"
+// @Param skills body request.MergeSkillsRequest true "Duplicate skills"
+// @Success 200 {object} response.Response{Data=response.SkillCatalogResponse} "Skills merged successfully"
+// @Failure 400 {object} response.Response "Invalid input"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Skill not found"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /admin/skills/{skillId}/merge [post]
+func (c *SkillCatalogController) MergeSkills(ctx *gin.Context) {
+	skillID, err := strconv.ParseInt(ctx.Param("skillId"), 10, 64)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	var req request.MergeSkillsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	skill, err := c.service.MergeSkills(ctx, skillID, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Skills merged successfully",
+		Data:    response.ToSkillCatalogResponse(skill),
+	})
+}
@@ -20,7 +20,7 @@ func NewCandidateSkillsController(service serviceInterfaces.CandidateSkillsServi
 
 // AddSkill godoc
 // @Summary Add a new skill
-// @Description Add a new skill for a candidate by candidate ID
+// @Description Add a new skill for a candidate by candidate ID, the skill is matched against the skills catalog (names and aliases) and added to it if unknown
 // @Tags Candidates - Skills
 // @Accept json
 // @Produce json
@@ -97,7 +97,7 @@ func (c *CandidateSkillsController) GetSkills(ctx *gin.Context) {
 
 // DeleteSkill godoc
 // @Summary Delete skill
-// @Description Delete a skill by candidate ID and skill name
+// @Description Delete a skill by candidate ID and skill name or alias
 // @Tags Candidates - Skills
 // @Produce json
 // @Param skillName path string true "Skill name"
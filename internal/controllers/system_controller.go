@@ -110,8 +110,9 @@ func (c *SystemController) GetHealth(ctx *gin.Context) {
 func (c *SystemController) GetMetrics(ctx *gin.Context) {
 	uptime, requestCount, errorRate := utils.GetMetrics()
 	ctx.JSON(http.StatusOK, response.MetricsResponse{
-		Uptime:       uptime,
-		RequestCount: requestCount,
-		ErrorRate:    errorRate,
+		Uptime:            uptime,
+		RequestCount:      requestCount,
+		ErrorRate:         errorRate,
+		RateLimitFailures: utils.GetRateLimitFailures(),
 	})
 }
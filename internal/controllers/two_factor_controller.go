@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/dto/response"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TwoFactorController handles two-factor authentication enrollment API requests
+type TwoFactorController struct {
+	service serviceInterfaces.TwoFactorService
+	config  *config.AppConfig
+}
+
+// NewTwoFactorController creates a new instance of TwoFactorController
+func NewTwoFactorController(service serviceInterfaces.TwoFactorService, config *config.AppConfig) *TwoFactorController {
+	return &TwoFactorController{
+		service: service,
+		config:  config,
+	}
+}
+
+// GetTwoFactor godoc
+// @Summary Get my two-factor authentication status
+// @Description Retrieve whether two-factor authentication is enabled for the authenticated user, the number of recovery codes left, and whether the account is required to use it
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.TwoFactorStatusResponse} "Two-factor status retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /auth/2fa [get]
+func (c *TwoFactorController) GetTwoFactor(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	twoFactor, recoveryCodesLeft, err := c.service.GetTwoFactor(ctx, userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	required := ctx.GetString("role") == "admin" && c.config.RequireAdminTwoFactor
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Two-factor status retrieved successfully",
+		Data:    response.ToTwoFactorStatusResponse(twoFactor, recoveryCodesLeft, required),
+	})
+}
+
+// SetupTwoFactor godoc
+// @Summary Set up two-factor authentication
+// @Description Generate a new TOTP secret for the authenticated user, returned with its otpauth:// URI to show as a QR code.
+// @Description Two-factor authentication is enabled once confirmed with a first code from the authenticator app, setting it up again before that replaces the secret.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} response.Response{Data=response.TwoFactorSetupResponse} "Two-factor secret generated successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "Two-factor authentication is already enabled"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /auth/2fa/setup [post]
+func (c *TwoFactorController) SetupTwoFactor(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	secret, uri, err := c.service.SetupTwoFactor(ctx, userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Two-factor secret generated successfully",
+		Data: response.TwoFactorSetupResponse{
+			Secret:     secret,
+			OTPAuthURI: uri,
+		},
+	})
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Confirm two-factor authentication
+// @Description Enable two-factor authentication with a first code from the authenticator app, and get the one-time recovery codes of the account. They are not shown again.
+// @Description The current session is not upgraded, logging in again with a code is needed where two-factor authentication is required.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ConfirmTwoFactorRequest true "Code from the authenticator app"
+// @Success 200 {object} response.Response{Data=response.TwoFactorRecoveryCodesResponse} "Two-factor authentication enabled successfully"
+// @Failure 400 {object} response.Response "Invalid two-factor code"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "Two-factor authentication is already enabled"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /auth/2fa/confirm [post]
+func (c *TwoFactorController) ConfirmTwoFactor(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var req request.ConfirmTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	recoveryCodes, err := c.service.ConfirmTwoFactor(ctx, userID, req.Code)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Two-factor authentication enabled successfully",
+		Data:    response.TwoFactorRecoveryCodesResponse{RecoveryCodes: recoveryCodes},
+	})
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Turn two-factor authentication off, after authenticating again with the password and a code from the authenticator app or a recovery code.
+// @Description Admin accounts cannot turn it off when admins are required to use it.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.DisableTwoFactorRequest true "Password and two-factor code"
+// @Success 200 {object} response.Response "Two-factor authentication disabled successfully"
+// @Failure 400 {object} response.Response "Two-factor authentication is not enabled"
+// @Failure 401 {object} response.Response "Invalid password or two-factor code"
+// @Failure 403 {object} response.Response "Two-factor authentication is required for admin accounts"
+// @Failure 429 {object} response.Response "Too many failed login attempts"
+// @Failure 500 {object} response.Response "An unexpected error occurred"
+// @Router /auth/2fa/disable [post]
+func (c *TwoFactorController) DisableTwoFactor(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	var req request.DisableTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if err := c.service.DisableTwoFactor(ctx, userID, req.Password, req.Code, ctx.ClientIP()); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Two-factor authentication disabled successfully",
+	})
+}
@@ -26,7 +26,7 @@ func NewUserController(service serviceInterfaces.UserService) *UserController {
 // @Tags Admin - Users
 // @Accept json
 // @Produce json
-// @Param user body request.CreateUsersRequest true "User request"
+// @Param user body request.AdminCreateUserRequest true "User request"
 // @Success 201 {object} response.Response{Data=response.UserResponse} "User created successfully"
 // @Failure 400 {object} response.Response "Invalid input"
 // @Failure 400 {object} response.Response "Invalid user ID"
@@ -36,7 +36,7 @@ func NewUserController(service serviceInterfaces.UserService) *UserController {
 // @Failure 500 {object} response.Response "An unexpected error occurred"
 // @Router /admin/users [post]
 func (c *UserController) CreateUser(ctx *gin.Context) {
-	var req request.CreateUsersRequest
+	var req request.AdminCreateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		_  = ctx.Error(err)
 		ctx.Abort()
@@ -132,16 +132,24 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 
 // GetAllUsers godoc
 // @Summary Get all users
-// @Description Get all users
+// @Description Get all users, one page at a time (only created_at sorting is supported)
 // @Tags Admin - Users
 // @Produce json
+// @Param page query request.PageRequest false "Pagination"
 // @Success 200 {object} response.Response{Data=response.UsersResponseData} "Users retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid pagination parameters"
 // @Failure 401 {object} response.Response "Unauthorized"
 // @Failure 403 {object} response.Response "Forbidden"
 // @Failure 500 {object} response.Response "An unexpected error occurred"
 // @Router /admin/users [get]
 func (c *UserController) GetAllUsers(ctx *gin.Context) {
-	users, err := c.userService.GetAllUsers(ctx)
+	var page request.PageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		_  = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	users, pageInfo, err := c.userService.GetAllUsers(ctx, page)
 	if err != nil {
 		_  = ctx.Error(err)
 		return
@@ -150,7 +158,7 @@ func (c *UserController) GetAllUsers(ctx *gin.Context) {
 		Code:    http.StatusOK,
 		Status:  "OK",
 		Message: "Users retrieved successfully",
-		Data:    response.ToUsersResponse(users),
+		Data:    response.ToUsersResponse(users, pageInfo),
 	})
 }
 
@@ -0,0 +1,54 @@
+package request
+
+type ApplyToJobRequest struct {
+	CoverLetter string                   `json:"cover_letter,omitempty" binding:"omitempty,max=5000"`
+	Answers     []ScreeningAnswerRequest `json:"answers,omitempty" binding:"omitempty,dive"` // Answers to the screening questions of the job
+}
+
+type PipelineStageRequest struct {
+	Name     string `json:"name" binding:"required,max=50"`
+	Category string `json:"category" binding:"required,oneof=applied review interview offer hired rejected"`
+}
+
+type UpdatePipelineStagesRequest struct {
+	Stages []PipelineStageRequest `json:"stages" binding:"required,min=1,dive"`
+}
+
+type MoveApplicationRequest struct {
+	Stage  string `json:"stage" binding:"required"`
+	Reason string `json:"reason,omitempty" binding:"omitempty,max=1000"`
+}
+
+// ScreeningQuestionRequest is a question of a job. A question sent with the ID of
+// one of the current questions updates it, the others are created.
+type ScreeningQuestionRequest struct {
+	QuestionID    *int64   `json:"question_id,omitempty"`
+	Type          string   `json:"type" binding:"required,oneof=yes_no number single_choice multiple_choice text"`
+	Prompt        string   `json:"prompt" binding:"required,max=500"`
+	Required      bool     `json:"required"`
+	Options       []string `json:"options,omitempty" binding:"omitempty,max=20,dive,required,max=200"` // Choice questions only
+	RejectAnswers []string `json:"reject_answers,omitempty" binding:"omitempty,dive,required,max=200"` // "yes" or "no", or options
+	RejectBelow   *float64 `json:"reject_below,omitempty"`                                             // Number questions only
+	RejectAbove   *float64 `json:"reject_above,omitempty"`
+}
+
+type UpdateScreeningQuestionsRequest struct {
+	Questions []ScreeningQuestionRequest `json:"questions" binding:"max=30,dive"`
+}
+
+// ScreeningAnswerRequest answers a screening question with true or false, a
+// number, one of its options, a list of its options or a text, by question type
+type ScreeningAnswerRequest struct {
+	QuestionID int64       `json:"question_id" binding:"required"`
+	Answer     interface{} `json:"answer" swaggertype:"object"`
+}
+
+// JobApplicationFilters selects the applicants of a job by their answers to its
+// screening questions, each filter being "question_id:value". Number questions
+// take a range such as "12:3..", "12:..5" or "12:2..5", multiple choice questions
+// match the answers including the option and text questions the ones containing
+// the value.
+type JobApplicationFilters struct {
+	Answers []string `form:"answer"`
+	PageRequest
+}
@@ -0,0 +1,22 @@
+package request
+
+import "time"
+
+// InterviewProposalRequest proposes the slots an interview can start at, the
+// candidate then selects one of them. An interview needs a location, a video
+// link or both.
+type InterviewProposalRequest struct {
+	Slots           []time.Time `json:"slots" binding:"required,min=1,max=10"`
+	DurationMinutes int         `json:"duration_minutes" binding:"required,min=15,max=480"`
+	Location        string      `json:"location,omitempty" binding:"omitempty,max=255"`
+	VideoLink       string      `json:"video_link,omitempty" binding:"omitempty,url,max=500"`
+	Notes           string      `json:"notes,omitempty" binding:"omitempty,max=2000"`
+}
+
+type SelectInterviewSlotRequest struct {
+	SlotID int64 `json:"slot_id" binding:"required"`
+}
+
+type CancelInterviewRequest struct {
+	Reason string `json:"reason,omitempty" binding:"omitempty,max=1000"`
+}
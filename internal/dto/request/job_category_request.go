@@ -0,0 +1,8 @@
+package request
+
+// JobCategoryRequest creates or updates a job category, a category without a
+// parent is a top level category
+type JobCategoryRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	ParentID *int64 `json:"parent_id,omitempty" binding:"omitempty,min=1"`
+}
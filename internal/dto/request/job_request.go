@@ -1,5 +1,7 @@
 package request
 
+import "time"
+
 //	type PostNewJobRequest struct {
 //		Title          string `json:"title" validate:"required"`
 //		Description    string `json:"description" validate:"required"`
@@ -10,22 +12,46 @@ package request
 //		JobType        string `json:"job_type" validate:"required,oneof=full-time part-time freelance remote"`
 //	}
 type PostNewJobRequest struct {
-	Title          string `json:"title" binding:"required"`
-	Description    string `json:"description" binding:"required"`
-	Location       string `json:"location,omitempty"`
-	SalaryRange    string `json:"salary_range,omitempty" binding:"omitempty"`
-	RequiredSkills string `json:"required_skills,omitempty"`
-	Status         string `json:"status" binding:"required,oneof=open closed"`
-	JobType        string `json:"job_type" binding:"required,oneof=full-time part-time freelance remote"`
+	Title          string     `json:"title" binding:"required"`
+	Description    string     `json:"description" binding:"required"`
+	Location       string     `json:"location,omitempty"`
+	SalaryMin      *float64   `json:"salary_min,omitempty" binding:"omitempty,gte=0"`
+	SalaryMax      *float64   `json:"salary_max,omitempty" binding:"omitempty,gte=0"`
+	Currency       string     `json:"currency,omitempty" binding:"omitempty,oneof=DZD EUR USD"`
+	Period         string     `json:"period,omitempty" binding:"omitempty,oneof=monthly yearly hourly"`
+	RequiredSkills string     `json:"required_skills,omitempty"`
+	Status         string     `json:"status" binding:"required,oneof=draft scheduled open closed"`
+	JobType        string     `json:"job_type" binding:"required,oneof=full-time part-time freelance"`
+	WilayaCode     *int       `json:"wilaya_code,omitempty" binding:"omitempty,min=1,max=58"`
+	CommuneCode    *int       `json:"commune_code,omitempty" binding:"omitempty,min=1"`
+	WorkMode       string     `json:"work_mode,omitempty" binding:"omitempty,oneof=onsite remote hybrid"`
+	CategoryID     *int64     `json:"category_id,omitempty" binding:"omitempty,min=1"`
+	PublishAt      *time.Time `json:"publish_at,omitempty"` // Required for scheduled jobs
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"` // Defaults to the configured job lifetime
 }
 type EditJobRequest struct {
-	Title          string `json:"title,omitempty"`
-	Description    string `json:"description,omitempty"`
-	Location       string `json:"location,omitempty"`
-	SalaryRange    string `json:"salary_range,omitempty"`
-	RequiredSkills string `json:"required_skills,omitempty"`
-	Status         string `json:"status,omitempty" binding:"omitempty,oneof=open closed"`
-	JobType        string `json:"job_type,omitempty" binding:"omitempty,oneof=full-time part-time freelance remote"`
+	Title          string     `json:"title,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	Location       string     `json:"location,omitempty"`
+	SalaryMin      *float64   `json:"salary_min,omitempty" binding:"omitempty,gte=0"`
+	SalaryMax      *float64   `json:"salary_max,omitempty" binding:"omitempty,gte=0"`
+	Currency       string     `json:"currency,omitempty" binding:"omitempty,oneof=DZD EUR USD"`
+	Period         string     `json:"period,omitempty" binding:"omitempty,oneof=monthly yearly hourly"`
+	RequiredSkills string     `json:"required_skills,omitempty"`
+	Status         string     `json:"status,omitempty" binding:"omitempty,oneof=draft scheduled open closed"`
+	JobType        string     `json:"job_type,omitempty" binding:"omitempty,oneof=full-time part-time freelance"`
+	WilayaCode     *int       `json:"wilaya_code,omitempty" binding:"omitempty,min=1,max=58"`
+	CommuneCode    *int       `json:"commune_code,omitempty" binding:"omitempty,min=1"`
+	WorkMode       string     `json:"work_mode,omitempty" binding:"omitempty,oneof=onsite remote hybrid"`
+	CategoryID     *int64     `json:"category_id,omitempty" binding:"omitempty,min=1"`
+	PublishAt      *time.Time `json:"publish_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// RepostJobRequest is the optional body of a repost, the job runs for the
+// configured lifetime when ExpiresAt is not set
+type RepostJobRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 type JobFilters struct {
@@ -33,7 +59,39 @@ type JobFilters struct {
 	Location       string   `form:"location"`
 	SalaryRangeMin float64  `form:"min_salary"`
 	SalaryRangeMax float64  `form:"max_salary"`
+	Currency       string   `form:"currency" binding:"omitempty,oneof=DZD EUR USD"`         // Required, with period, by the salary filters and sort
+	Period         string   `form:"period" binding:"omitempty,oneof=monthly yearly hourly"` // Required, with currency, by the salary filters and sort
 	RequiredSkills []string `form:"required_skills"`
-	Keyword        string   `form:"keyword"`
+	Keyword        string   `form:"keyword"` // Full-text query, supports "quoted phrases", OR and -exclusion
 	JobType        string   `form:"job_type"`
+	Wilaya         string   `form:"wilaya"`                                      // Code or name in French, Arabic or English
+	Commune        string   `form:"commune"`                                     // Code or name
+	RadiusKm       float64  `form:"radius_km" binding:"omitempty,gt=0,lte=1000"` // Distance from the centroid of the wilaya or commune
+	WorkMode       string   `form:"work_mode" binding:"omitempty,oneof=onsite remote hybrid"`
+	Category       string   `form:"category"` // ID or slug, subcategories included
+	PageRequest
+	PublishedAfter  *time.Time `form:"-"` // Set by the saved search alerts only
+	PublishedBefore *time.Time `form:"-"`
+}
+
+// JobDetailsQuery selects how public job details are rendered, the format can
+// also be negotiated with an Accept: application/ld+json header
+type JobDetailsQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json jsonld"`
+}
+
+// JobRevisionDiffQuery selects the two revisions of a job to compare
+type JobRevisionDiffQuery struct {
+	From int `form:"from" binding:"required,gte=1"`
+	To   int `form:"to" binding:"required,gte=1"`
+}
+
+type JobImportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv jsonl"` // Guessed from the file extension when not set
+	DryRun bool   `form:"dry_run"`
+}
+
+type JobExportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv jsonl"`
+	Status string `form:"status" binding:"omitempty,oneof=draft scheduled open closed"`
 }
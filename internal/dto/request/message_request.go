@@ -0,0 +1,38 @@
+package request
+
+import "github.com/google/uuid"
+
+// SendMessageRequest is the text of a message, sent as a multipart form along with
+// the files of its "attachments" field. A message needs a text, an attachment or
+// both.
+type SendMessageRequest struct {
+	Body string `form:"body" json:"body" binding:"omitempty,max=5000"`
+}
+
+type ReportMessageRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// MessageReportFilters selects the message reports in a status, all of them
+// when it is empty
+type MessageReportFilters struct {
+	Status string `form:"status" binding:"omitempty,oneof=open dismissed removed"`
+	PageRequest
+}
+
+// ReviewMessageReportRequest closes a report, either dismissing it or removing
+// the reported message
+type ReviewMessageReportRequest struct {
+	Action string `json:"action" binding:"required,oneof=dismiss remove"`
+	Note   string `json:"note,omitempty" binding:"omitempty,max=1000"`
+}
+
+// MessageThread identifies the thread of an application for one of its two
+// participants. Candidates reach their thread by the job they applied to,
+// recruiters by their job and the application to it.
+type MessageThread struct {
+	UserID        uuid.UUID
+	Role          string // candidate or recruiter
+	JobID         int64
+	ApplicationID int64 // Only set for recruiters
+}
@@ -0,0 +1,16 @@
+package request
+
+// UpdateNotificationPreferencesRequest replaces the quiet hours and digest mode of
+// a user and changes the channels listed, the other channels keep their setting
+type UpdateNotificationPreferencesRequest struct {
+	Channels        []NotificationChannelPreferenceRequest `json:"channels" binding:"omitempty,dive"`
+	QuietHoursStart string                                 `json:"quiet_hours_start,omitempty" binding:"omitempty,datetime=15:04"` // Algiers time
+	QuietHoursEnd   string                                 `json:"quiet_hours_end,omitempty" binding:"omitempty,datetime=15:04"`   // Algiers time
+	Digest          bool                                   `json:"digest"`
+}
+
+type NotificationChannelPreferenceRequest struct {
+	Type    string `json:"type" binding:"required"`
+	Channel string `json:"channel" binding:"required,oneof=email in_app"`
+	Enabled *bool  `json:"enabled" binding:"required"`
+}
@@ -0,0 +1,9 @@
+package request
+
+// NotificationFilters selects the notifications of a user, only the unread ones
+// when Unread is set
+type NotificationFilters struct {
+	Unread bool   `form:"unread"`
+	Type   string `form:"type" binding:"omitempty,oneof=application_received application_status_changed interview_proposed interview_scheduled interview_cancelled message_received bookmarked_job_closing job_expiring"`
+	PageRequest
+}
@@ -0,0 +1,11 @@
+package request
+
+// PageRequest holds the paging parameters shared by every list endpoint. When a
+// cursor is given, offset is ignored and the sort order is taken from the cursor.
+type PageRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=created_at salary title relevance"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
@@ -8,6 +8,8 @@ type AddPersonalInfoRequest struct {
 	DateOfBirth string `json:"date_of_birth"`
 	Gender      string `json:"gender" binding:"required,oneof=male female"`
 	Bio         string `json:"bio"`
+	WilayaCode  *int   `json:"wilaya_code,omitempty" binding:"omitempty,min=1,max=58"`
+	CommuneCode *int   `json:"commune_code,omitempty" binding:"omitempty,min=1"`
 }
 
 type UpdatePersonalInfoRequest struct {
@@ -18,4 +20,6 @@ type UpdatePersonalInfoRequest struct {
 	DateOfBirth string `json:"date_of_birth" binding:"omitempty"`
 	Gender      string `json:"gender" binding:"omitempty,oneof=male female"`
 	Bio         string `json:"bio" binding:"omitempty"`
+	WilayaCode  *int   `json:"wilaya_code,omitempty" binding:"omitempty,min=1,max=58"`
+	CommuneCode *int   `json:"commune_code,omitempty" binding:"omitempty,min=1"`
 }
@@ -0,0 +1,8 @@
+package request
+
+// RecommendationQuery holds the parameters of the matching endpoints. JobType is
+// the job type the candidate prefers, it is only used for job recommendations.
+type RecommendationQuery struct {
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=50"`
+	JobType string `form:"job_type" binding:"omitempty,oneof=full-time part-time freelance"`
+}
@@ -0,0 +1,25 @@
+package request
+
+// SavedSearchFilters are the JobFilters a saved search runs with
+type SavedSearchFilters struct {
+	Location       string   `json:"location,omitempty"`
+	SalaryRangeMin float64  `json:"min_salary,omitempty" binding:"gte=0"`
+	SalaryRangeMax float64  `json:"max_salary,omitempty" binding:"gte=0"`
+	Currency       string   `json:"currency,omitempty" binding:"omitempty,oneof=DZD EUR USD"`
+	Period         string   `json:"period,omitempty" binding:"omitempty,oneof=monthly yearly hourly"`
+	RequiredSkills []string `json:"required_skills,omitempty"`
+	Keyword        string   `json:"keyword,omitempty"`
+	JobType        string   `json:"job_type,omitempty" binding:"omitempty,oneof=full-time part-time freelance"`
+	Wilaya         string   `json:"wilaya,omitempty"`
+	Commune        string   `json:"commune,omitempty"`
+	RadiusKm       float64  `json:"radius_km,omitempty" binding:"omitempty,gt=0,lte=1000"`
+	WorkMode       string   `json:"work_mode,omitempty" binding:"omitempty,oneof=onsite remote hybrid"`
+	Category       string   `json:"category,omitempty"`
+}
+
+type SavedSearchRequest struct {
+	Name          string             `json:"name" binding:"required,max=100"`
+	Frequency     string             `json:"frequency" binding:"required,oneof=instant daily weekly"`
+	Filters       SavedSearchFilters `json:"filters"`
+	AlertsEnabled *bool              `json:"alerts_enabled,omitempty"` // Defaults to true
+}
@@ -3,3 +3,18 @@ package request
 type AddSkillRequest struct {
 	Skill string `json:"skill" binding:"required"`
 }
+
+type SkillSearchQuery struct {
+	Q     string `form:"q"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type SkillCatalogRequest struct {
+	Name     string   `json:"name" binding:"required,max=100"`
+	Category string   `json:"category" binding:"omitempty,max=50"`
+	Aliases  []string `json:"aliases" binding:"omitempty,dive,required,max=100"`
+}
+
+type MergeSkillsRequest struct {
+	SourceIDs []int64 `json:"source_ids" binding:"required,min=1"`
+}
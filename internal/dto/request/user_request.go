@@ -4,7 +4,15 @@ type CreateUsersRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=8"`
-	Role     string `json:"role" binding:"required,min=4"`
+	Role     string `json:"role" binding:"required,oneof=candidate recruiter"`
+}
+
+// AdminCreateUserRequest is a user created by an admin, who may create admins too
+type AdminCreateUserRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	Role     string `json:"role" binding:"required,oneof=candidate recruiter admin"`
 }
 
 type UpdateUserRequest struct {
@@ -0,0 +1,76 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApplicationResponse struct {
+	ID          int64     `json:"application_id"`
+	JobID       int64     `json:"job_id"`
+	JobRevision int       `json:"job_revision"`
+	CandidateID uuid.UUID `json:"candidate_id"`
+	Resume      string    `json:"resume"`
+	CoverLetter string    `json:"cover_letter,omitempty"`
+	Stage       string    `json:"stage,omitempty"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Answers []ScreeningAnswerResponse `json:"answers,omitempty"`
+}
+
+func ToApplicationResponse(application *models.Application) ApplicationResponse {
+	return ApplicationResponse{
+		ID:          application.ID,
+		JobID:       application.JobID,
+		JobRevision: application.JobRevision,
+		CandidateID: application.CandidateID,
+		Resume:      application.Resume,
+		CoverLetter: application.CoverLetter,
+		Stage:       application.Stage,
+		Status:      application.Status,
+		CreatedAt:   application.CreatedAt,
+		UpdatedAt:   application.UpdatedAt,
+		Answers:     ToScreeningAnswersResponse(application.Answers),
+	}
+}
+
+// ToCandidateApplicationResponse hides the recruiter's internal stage name and
+// which answers knocked the candidate out, and only exposes the candidate-facing status
+func ToCandidateApplicationResponse(application *models.Application) ApplicationResponse {
+	applicationResponse := ToApplicationResponse(application)
+	applicationResponse.Stage = ""
+	for i := range applicationResponse.Answers {
+		applicationResponse.Answers[i].Knockout = false
+	}
+	return applicationResponse
+}
+
+type ApplicationsResponseData struct {
+	Total        int                   `json:"total"`
+	Applications []ApplicationResponse `json:"applications"`
+	Pagination   PaginationResponse    `json:"pagination"`
+}
+
+func ToApplicationsResponse(applications []*models.Application, page *models.PageInfo) ApplicationsResponseData {
+	var applicationResponses []ApplicationResponse
+	for _, application := range applications {
+		applicationResponses = append(applicationResponses, ToApplicationResponse(application))
+	}
+	return ApplicationsResponseData{
+		Total:        page.Total,
+		Applications: applicationResponses,
+		Pagination:   ToPaginationResponse(page),
+	}
+}
+
+func ToCandidateApplicationsResponse(applications []*models.Application, page *models.PageInfo) ApplicationsResponseData {
+	applicationsResponse := ToApplicationsResponse(applications, page)
+	for i := range applicationsResponse.Applications {
+		applicationsResponse.Applications[i].Stage = ""
+	}
+	return applicationsResponse
+}
@@ -0,0 +1,86 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InterviewResponse struct {
+	ID              int64                   `json:"interview_id"`
+	ApplicationID   int64                   `json:"application_id"`
+	JobID           int64                   `json:"job_id"`
+	JobTitle        string                  `json:"job_title"`
+	CompanyName     string                  `json:"company_name"`
+	CandidateID     uuid.UUID               `json:"candidate_id"`
+	CandidateName   string                  `json:"candidate_name"`
+	Status          string                  `json:"status"`
+	DurationMinutes int                     `json:"duration_minutes"`
+	Location        string                  `json:"location,omitempty"`
+	VideoLink       string                  `json:"video_link,omitempty"`
+	Notes           string                  `json:"notes,omitempty"`
+	ScheduledAt     *time.Time              `json:"scheduled_at,omitempty"`
+	EndsAt          *time.Time              `json:"ends_at,omitempty"`
+	Slots           []InterviewSlotResponse `json:"slots"`
+	CancelReason    string                  `json:"cancel_reason,omitempty"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+}
+
+type InterviewSlotResponse struct {
+	ID       int64     `json:"slot_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+type InterviewsResponseData struct {
+	Total      int                 `json:"total"`
+	Interviews []InterviewResponse `json:"interviews"`
+}
+
+type CalendarFeedResponse struct {
+	URL string `json:"url"`
+}
+
+func ToInterviewResponse(interview *models.Interview) InterviewResponse {
+	slots := make([]InterviewSlotResponse, 0, len(interview.Slots))
+	for _, slot := range interview.Slots {
+		slots = append(slots, InterviewSlotResponse{
+			ID:       slot.ID,
+			StartsAt: slot.StartsAt,
+			EndsAt:   slot.StartsAt.Add(time.Duration(interview.DurationMinutes) * time.Minute),
+		})
+	}
+	return InterviewResponse{
+		ID:              interview.ID,
+		ApplicationID:   interview.ApplicationID,
+		JobID:           interview.JobID,
+		JobTitle:        interview.JobTitle,
+		CompanyName:     interview.CompanyName,
+		CandidateID:     interview.CandidateID,
+		CandidateName:   interview.CandidateName,
+		Status:          interview.Status,
+		DurationMinutes: interview.DurationMinutes,
+		Location:        interview.Location,
+		VideoLink:       interview.VideoLink,
+		Notes:           interview.Notes,
+		ScheduledAt:     interview.ScheduledAt,
+		EndsAt:          interview.EndsAt(),
+		Slots:           slots,
+		CancelReason:    interview.CancelReason,
+		CreatedAt:       interview.CreatedAt,
+		UpdatedAt:       interview.UpdatedAt,
+	}
+}
+
+func ToInterviewsResponse(interviews []*models.Interview) InterviewsResponseData {
+	var interviewResponses []InterviewResponse
+	for _, interview := range interviews {
+		interviewResponses = append(interviewResponses, ToInterviewResponse(interview))
+	}
+	return InterviewsResponseData{
+		Total:      len(interviewResponses),
+		Interviews: interviewResponses,
+	}
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+)
+
+type JobCategoryResponse struct {
+	ID        int64                 `json:"category_id"`
+	ParentID  *int64                `json:"parent_id,omitempty"`
+	Name      string                `json:"name"`
+	Slug      string                `json:"slug"`
+	CreatedAt time.Time             `json:"created_at"`
+	Children  []JobCategoryResponse `json:"children,omitempty"`
+}
+
+func ToJobCategoryResponse(category *models.JobCategory) JobCategoryResponse {
+	res := JobCategoryResponse{
+		ID:        category.ID,
+		ParentID:  category.ParentID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		CreatedAt: category.CreatedAt,
+	}
+	for _, child := range category.Children {
+		res.Children = append(res.Children, ToJobCategoryResponse(child))
+	}
+	return res
+}
+
+type JobCategoriesResponseData struct {
+	Total      int                   `json:"total"`
+	Categories []JobCategoryResponse `json:"categories"`
+}
+
+// ToJobCategoriesResponse renders the top level categories with their
+// subcategories nested, Total counts the top level categories
+func ToJobCategoriesResponse(categories []*models.JobCategory) JobCategoriesResponseData {
+	categoryResponses := make([]JobCategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		categoryResponses = append(categoryResponses, ToJobCategoryResponse(category))
+	}
+	return JobCategoriesResponseData{
+		Total:      len(categories),
+		Categories: categoryResponses,
+	}
+}
@@ -0,0 +1,107 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// JobExportColumns is the header of the CSV job exports
+var JobExportColumns = []string{
+	"job_id", "title", "description", "location", "salary_min", "salary_max", "currency", "period",
+	"required_skills", "status", "job_type", "wilaya_code", "commune_code", "work_mode", "category_id", "publish_at", "published_at", "expires_at", "created_at", "updated_at",
+}
+
+// JobExportWriter streams jobs as CSV or as JSON Lines of JobResponse. start is
+// called before anything is written, so that an error raised before the first
+// job can still be sent as a JSON response.
+type JobExportWriter struct {
+	format  string
+	csv     *csv.Writer
+	json    *json.Encoder
+	start   func()
+	started bool
+}
+
+func NewJobExportWriter(w io.Writer, format string, start func()) *JobExportWriter {
+	return &JobExportWriter{
+		format: format,
+		csv:    csv.NewWriter(w),
+		json:   json.NewEncoder(w),
+		start:  start,
+	}
+}
+
+func (e *JobExportWriter) Write(job *models.Job) error {
+	if err := e.begin(); err != nil {
+		return err
+	}
+	if e.format == "jsonl" {
+		return e.json.Encode(ToJobResponse(job))
+	}
+	return e.csv.Write(jobExportRecord(job))
+}
+
+// Close writes the CSV header of an empty export and flushes the buffered rows
+func (e *JobExportWriter) Close() error {
+	if err := e.begin(); err != nil {
+		return err
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *JobExportWriter) begin() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	e.start()
+	if e.format == "jsonl" {
+		return nil
+	}
+	return e.csv.Write(JobExportColumns)
+}
+
+// jobExportRecord returns the CSV values of job in the order of JobExportColumns
+func jobExportRecord(job *models.Job) []string {
+	return []string{
+		strconv.FormatInt(job.ID, 10), job.Title, job.Description, job.Location,
+		formatOptionalFloat(job.SalaryMin), formatOptionalFloat(job.SalaryMax), job.Currency, job.Period,
+		job.RequiredSkills, job.Status, job.JobType,
+		formatOptionalInt(job.WilayaCode), formatOptionalInt(job.CommuneCode), job.WorkMode, formatOptionalInt64(job.CategoryID),
+		formatOptionalTime(job.PublishAt), formatOptionalTime(job.PublishedAt), formatOptionalTime(job.ExpiresAt),
+		job.CreatedAt.Format(time.RFC3339), job.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func formatOptionalInt64(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
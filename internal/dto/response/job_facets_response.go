@@ -0,0 +1,58 @@
+package response
+
+import "dz-jobs-api/internal/models"
+
+type JobFacetValueResponse struct {
+	Value  string `json:"value"`
+	Label  string `json:"label,omitempty"`
+	Parent string `json:"parent,omitempty"` // Parent category, categories only
+	Count  int    `json:"count"`
+}
+
+// JobFacetsResponse counts the jobs matching a search by value of each filter,
+// the values can be sent back as the category, job_type, wilaya, status and
+// min_salary/max_salary filters
+type JobFacetsResponse struct {
+	Category []JobFacetValueResponse `json:"category"`
+	JobType  []JobFacetValueResponse `json:"job_type"`
+	Wilaya   []JobFacetValueResponse `json:"wilaya"`
+	Salary   []JobFacetValueResponse `json:"salary"`
+	Status   []JobFacetValueResponse `json:"status"`
+}
+
+func ToJobFacetsResponse(facets *models.JobFacets) JobFacetsResponse {
+	return JobFacetsResponse{
+		Category: toJobFacetValuesResponse(facets.Categories),
+		JobType:  toJobFacetValuesResponse(facets.JobTypes),
+		Wilaya:   toJobFacetValuesResponse(facets.Wilayas),
+		Salary:   toJobFacetValuesResponse(facets.SalaryBuckets),
+		Status:   toJobFacetValuesResponse(facets.Statuses),
+	}
+}
+
+func toJobFacetValuesResponse(values []models.JobFacetValue) []JobFacetValueResponse {
+	responses := make([]JobFacetValueResponse, 0, len(values))
+	for _, value := range values {
+		responses = append(responses, JobFacetValueResponse{
+			Value:  value.Value,
+			Label:  value.Label,
+			Parent: value.Parent,
+			Count:  value.Count,
+		})
+	}
+	return responses
+}
+
+// JobSearchResponseData is a page of search results along with the facet counts
+// of all the jobs matching the search
+type JobSearchResponseData struct {
+	JobsResponseData
+	Facets JobFacetsResponse `json:"facets"`
+}
+
+func ToJobSearchResponse(jobs []*models.Job, page *models.PageInfo, facets *models.JobFacets) JobSearchResponseData {
+	return JobSearchResponseData{
+		JobsResponseData: ToJobsResponse(jobs, page),
+		Facets:           ToJobFacetsResponse(facets),
+	}
+}
@@ -0,0 +1,44 @@
+package response
+
+import "dz-jobs-api/internal/models"
+
+type JobImportRowResponse struct {
+	Line   int      `json:"line"`
+	Status string   `json:"status"`
+	JobID  int64    `json:"job_id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type JobImportResponseData struct {
+	DryRun  bool                   `json:"dry_run"`
+	Total   int                    `json:"total"`
+	Valid   int                    `json:"valid"`
+	Created int                    `json:"created"`
+	Invalid int                    `json:"invalid"`
+	Failed  int                    `json:"failed"`
+	Rows    []JobImportRowResponse `json:"rows"`
+}
+
+func ToJobImportResponse(results []models.JobImportResult, dryRun bool) JobImportResponseData {
+	data := JobImportResponseData{DryRun: dryRun, Total: len(results), Rows: []JobImportRowResponse{}}
+	for _, result := range results {
+		switch result.Status {
+		case "valid":
+			data.Valid++
+		case "created":
+			data.Valid++
+			data.Created++
+		case "invalid":
+			data.Invalid++
+		case "failed":
+			data.Failed++
+		}
+		data.Rows = append(data.Rows, JobImportRowResponse{
+			Line:   result.Line,
+			Status: result.Status,
+			JobID:  result.JobID,
+			Errors: result.Errors,
+		})
+	}
+	return data
+}
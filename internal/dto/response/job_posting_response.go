@@ -0,0 +1,164 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"strconv"
+	"time"
+)
+
+// JobPostingResponse is a job rendered as a schema.org JobPosting in JSON-LD
+type JobPostingResponse struct {
+	Context                       string                `json:"@context"`
+	Type                          string                `json:"@type"`
+	Title                         string                `json:"title"`
+	Description                   string                `json:"description"`
+	Identifier                    SchemaPropertyValue   `json:"identifier"`
+	URL                           string                `json:"url"`
+	DatePosted                    string                `json:"datePosted"`
+	ValidThrough                  string                `json:"validThrough,omitempty"`
+	EmploymentType                string                `json:"employmentType,omitempty"`
+	HiringOrganization            SchemaOrganization    `json:"hiringOrganization"`
+	JobLocation                   *SchemaPlace          `json:"jobLocation,omitempty"`
+	JobLocationType               string                `json:"jobLocationType,omitempty"`
+	ApplicantLocationRequirements *SchemaCountry        `json:"applicantLocationRequirements,omitempty"`
+	BaseSalary                    *SchemaMonetaryAmount `json:"baseSalary,omitempty"`
+	Skills                        string                `json:"skills,omitempty"`
+	Industry                      string                `json:"industry,omitempty"`
+	OccupationalCategory          string                `json:"occupationalCategory,omitempty"`
+}
+
+type SchemaPropertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type SchemaOrganization struct {
+	Type   string `json:"@type"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs,omitempty"`
+	Logo   string `json:"logo,omitempty"`
+}
+
+type SchemaPlace struct {
+	Type    string              `json:"@type"`
+	Address SchemaPostalAddress `json:"address"`
+}
+
+type SchemaPostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+type SchemaCountry struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type SchemaMonetaryAmount struct {
+	Type     string                  `json:"@type"`
+	Currency string                  `json:"currency"`
+	Value    SchemaQuantitativeValue `json:"value"`
+}
+
+type SchemaQuantitativeValue struct {
+	Type     string   `json:"@type"`
+	Value    *float64 `json:"value,omitempty"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	UnitText string   `json:"unitText,omitempty"`
+}
+
+// schemaEmploymentTypes maps the job types to schema.org employment types. Remote
+// jobs have no employment type of their own, they are marked as telecommute jobs.
+var schemaEmploymentTypes = map[string]string{
+	"full-time": "FULL_TIME",
+	"part-time": "PART_TIME",
+	"freelance": "CONTRACTOR",
+}
+
+var schemaSalaryUnits = map[string]string{
+	"hourly":  "HOUR",
+	"monthly": "MONTH",
+	"yearly":  "YEAR",
+}
+
+func ToJobPostingResponse(posting *models.JobPosting) JobPostingResponse {
+	job, recruiter := posting.Job, posting.Recruiter
+
+	datePosted := job.CreatedAt
+	if job.PublishedAt != nil {
+		datePosted = *job.PublishedAt
+	}
+
+	res := JobPostingResponse{
+		Context:     "https://schema.org",
+		Type:        "JobPosting",
+		Title:       job.Title,
+		Description: job.Description,
+		Identifier: SchemaPropertyValue{
+			Type:  "PropertyValue",
+			Name:  recruiter.CompanyName,
+			Value: strconv.FormatInt(job.ID, 10),
+		},
+		URL:            posting.URL,
+		DatePosted:     datePosted.UTC().Format(time.RFC3339),
+		EmploymentType: schemaEmploymentTypes[job.JobType],
+		HiringOrganization: SchemaOrganization{
+			Type:   "Organization",
+			Name:   recruiter.CompanyName,
+			SameAs: recruiter.CompanyWebsite,
+			Logo:   recruiter.CompanyLogo,
+		},
+		Skills: job.RequiredSkills,
+	}
+
+	// A closed job must not look valid anymore, it ends when it was closed
+	validThrough := job.ExpiresAt
+	if job.Status == "closed" && (validThrough == nil || validThrough.After(job.UpdatedAt)) {
+		validThrough = &job.UpdatedAt
+	}
+	if validThrough != nil {
+		res.ValidThrough = validThrough.UTC().Format(time.RFC3339)
+	}
+
+	if job.WorkMode == "remote" {
+		res.JobLocationType = "TELECOMMUTE"
+		res.ApplicantLocationRequirements = &SchemaCountry{Type: "Country", Name: "Algeria"}
+	}
+	address := SchemaPostalAddress{Type: "PostalAddress", AddressLocality: job.Location, AddressCountry: "DZ"}
+	if posting.Commune != nil {
+		address.AddressLocality = posting.Commune.Name.FR
+	}
+	if posting.Wilaya != nil {
+		address.AddressRegion = posting.Wilaya.Name.FR
+	}
+	if address.AddressLocality == "" && address.AddressRegion == "" {
+		address.AddressLocality = recruiter.CompanyLocation
+	}
+	if address.AddressLocality != "" || address.AddressRegion != "" {
+		res.JobLocation = &SchemaPlace{Type: "Place", Address: address}
+	}
+
+	// The top level category is the industry of the job, its own category the occupation
+	if len(posting.Categories) > 0 {
+		res.Industry = posting.Categories[0].Name
+		res.OccupationalCategory = posting.Categories[len(posting.Categories)-1].Name
+	}
+
+	if job.SalaryMin != nil || job.SalaryMax != nil {
+		value := SchemaQuantitativeValue{Type: "QuantitativeValue", UnitText: schemaSalaryUnits[job.Period]}
+		switch {
+		case job.SalaryMin != nil && job.SalaryMax != nil && *job.SalaryMin != *job.SalaryMax:
+			value.MinValue, value.MaxValue = job.SalaryMin, job.SalaryMax
+		case job.SalaryMin != nil:
+			value.Value = job.SalaryMin
+		default:
+			value.Value = job.SalaryMax
+		}
+		res.BaseSalary = &SchemaMonetaryAmount{Type: "MonetaryAmount", Currency: job.Currency, Value: value}
+	}
+	return res
+}
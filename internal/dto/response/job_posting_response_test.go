@@ -0,0 +1,100 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToJobPostingResponse(t *testing.T) {
+	publishedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	expiresAt := publishedAt.AddDate(0, 1, 0)
+	salaryMin, salaryMax := 80000.0, 120000.0
+	newPosting := func() *models.JobPosting {
+		return &models.JobPosting{
+			Job: &models.Job{
+				ID:             7,
+				Title:          "Go developer",
+				Description:    "Build APIs",
+				SalaryMin:      &salaryMin,
+				SalaryMax:      &salaryMax,
+				Currency:       "DZD",
+				Period:         "monthly",
+				RequiredSkills: "Go, PostgreSQL",
+				Status:         "open",
+				JobType:        "full-time",
+				WorkMode:       "onsite",
+				CreatedAt:      publishedAt.Add(-time.Hour),
+				UpdatedAt:      publishedAt,
+				PublishedAt:    &publishedAt,
+				ExpiresAt:      &expiresAt,
+			},
+			Recruiter: &models.Recruiter{CompanyName: "Yassir", CompanyWebsite: "https://yassir.com", CompanyLocation: "Alger"},
+			URL:       "https://dzjobs.example/jobs/7",
+			Wilaya:    &models.Wilaya{Code: 16, Name: models.LocationName{FR: "Alger"}},
+			Categories: []*models.JobCategory{
+				{ID: 1, Name: "Information technology"},
+				{ID: 2, Name: "Software development"},
+			},
+		}
+	}
+
+	t.Run("Open job", func(t *testing.T) {
+		res := ToJobPostingResponse(newPosting())
+
+		assert.Equal(t, "JobPosting", res.Type)
+		assert.Equal(t, "7", res.Identifier.Value)
+		assert.Equal(t, "2024-03-01T08:00:00Z", res.DatePosted)
+		assert.Equal(t, "2024-04-01T08:00:00Z", res.ValidThrough)
+		assert.Equal(t, "FULL_TIME", res.EmploymentType)
+		assert.Equal(t, SchemaOrganization{Type: "Organization", Name: "Yassir", SameAs: "https://yassir.com"}, res.HiringOrganization)
+		assert.Equal(t, "Alger", res.JobLocation.Address.AddressRegion)
+		assert.Equal(t, "DZ", res.JobLocation.Address.AddressCountry)
+		assert.Empty(t, res.JobLocationType)
+		assert.Equal(t, "Information technology", res.Industry)
+		assert.Equal(t, "Software development", res.OccupationalCategory)
+		assert.Equal(t, "DZD", res.BaseSalary.Currency)
+		assert.Equal(t, SchemaQuantitativeValue{Type: "QuantitativeValue", MinValue: &salaryMin, MaxValue: &salaryMax, UnitText: "MONTH"}, res.BaseSalary.Value)
+	})
+
+	t.Run("Closed job valid until it was closed", func(t *testing.T) {
+		posting := newPosting()
+		posting.Job.Status = "closed"
+		posting.Job.UpdatedAt = publishedAt.AddDate(0, 0, 3)
+
+		res := ToJobPostingResponse(posting)
+		assert.Equal(t, "2024-03-04T08:00:00Z", res.ValidThrough)
+	})
+
+	t.Run("Remote freelance job with a single salary", func(t *testing.T) {
+		posting := newPosting()
+		posting.Job.JobType, posting.Job.WorkMode = "freelance", "remote"
+		posting.Job.SalaryMax, posting.Job.PublishedAt, posting.Job.ExpiresAt = nil, nil, nil
+		posting.Wilaya, posting.Categories = nil, nil
+
+		res := ToJobPostingResponse(posting)
+		assert.Equal(t, "CONTRACTOR", res.EmploymentType)
+		assert.Equal(t, "TELECOMMUTE", res.JobLocationType)
+		assert.Equal(t, "Algeria", res.ApplicantLocationRequirements.Name)
+		assert.Equal(t, "2024-03-01T07:00:00Z", res.DatePosted)
+		assert.Empty(t, res.ValidThrough)
+		assert.Equal(t, "Alger", res.JobLocation.Address.AddressLocality)
+		assert.Equal(t, &salaryMin, res.BaseSalary.Value.Value)
+		assert.Nil(t, res.BaseSalary.Value.MinValue)
+	})
+
+	t.Run("JSON-LD keys", func(t *testing.T) {
+		body, err := json.Marshal(ToJobPostingResponse(newPosting()))
+		assert.NoError(t, err)
+
+		var doc map[string]any
+		assert.NoError(t, json.Unmarshal(body, &doc))
+		assert.Equal(t, "https://schema.org", doc["@context"])
+		assert.Contains(t, doc, "hiringOrganization")
+		assert.Contains(t, doc, "baseSalary")
+		assert.Contains(t, doc, "validThrough")
+	})
+}
@@ -8,47 +8,77 @@ import (
 )
 
 type JobResponse struct {
-	ID             int64     `json:"job_id"`
-	Title          string    `json:"title"`
-	Description    string    `json:"description"`
-	Location       string    `json:"location,omitempty"`
-	SalaryRange    string    `json:"salary_range,omitempty"`
-	RequiredSkills string    `json:"required_skills,omitempty"`
-	RecruiterID    uuid.UUID `json:"recruiter_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	Status         string    `json:"status"`
-	JobType        string    `json:"job_type"`
+	ID                int64      `json:"job_id"`
+	Title             string     `json:"title"`
+	Description       string     `json:"description"`
+	Location          string     `json:"location,omitempty"`
+	SalaryMin         *float64   `json:"salary_min,omitempty"`
+	SalaryMax         *float64   `json:"salary_max,omitempty"`
+	Currency          string     `json:"currency"`
+	Period            string     `json:"period"`
+	SalaryNeedsReview bool       `json:"salary_needs_review,omitempty"` // The old free text salary could not be migrated
+	RequiredSkills    string     `json:"required_skills,omitempty"`
+	RecruiterID       uuid.UUID  `json:"recruiter_id"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	Status            string     `json:"status"`
+	JobType           string     `json:"job_type"`
+	WilayaCode        *int       `json:"wilaya_code,omitempty"`
+	CommuneCode       *int       `json:"commune_code,omitempty"`
+	Latitude          *float64   `json:"latitude,omitempty"`
+	Longitude         *float64   `json:"longitude,omitempty"`
+	WorkMode          string     `json:"work_mode"`
+	CategoryID        *int64     `json:"category_id,omitempty"`
+	PublishAt         *time.Time `json:"publish_at,omitempty"`
+	PublishedAt       *time.Time `json:"published_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	Snippet           string     `json:"snippet,omitempty"`
 }
 
 func ToJobResponse(job *models.Job) JobResponse {
 	return JobResponse{
-		ID:             job.ID,
-		Title:          job.Title,
-		Description:    job.Description,
-		Location:       job.Location,
-		SalaryRange:    job.SalaryRange,
-		RequiredSkills: job.RequiredSkills,
-		RecruiterID:    job.RecruiterID,
-		CreatedAt:      job.CreatedAt,
-		UpdatedAt:      job.UpdatedAt,
-		Status:         job.Status,
-		JobType:        job.JobType,
+		ID:                job.ID,
+		Title:             job.Title,
+		Description:       job.Description,
+		Location:          job.Location,
+		SalaryMin:         job.SalaryMin,
+		SalaryMax:         job.SalaryMax,
+		Currency:          job.Currency,
+		Period:            job.Period,
+		SalaryNeedsReview: job.SalaryNeedsReview,
+		RequiredSkills:    job.RequiredSkills,
+		RecruiterID:       job.RecruiterID,
+		CreatedAt:         job.CreatedAt,
+		UpdatedAt:         job.UpdatedAt,
+		Status:            job.Status,
+		JobType:           job.JobType,
+		WilayaCode:        job.WilayaCode,
+		CommuneCode:       job.CommuneCode,
+		Latitude:          job.Latitude,
+		Longitude:         job.Longitude,
+		WorkMode:          job.WorkMode,
+		CategoryID:        job.CategoryID,
+		PublishAt:         job.PublishAt,
+		PublishedAt:       job.PublishedAt,
+		ExpiresAt:         job.ExpiresAt,
+		Snippet:           job.Snippet,
 	}
 }
 
 type JobsResponseData struct {
-	Total int           `json:"total"`
-	Jobs  []JobResponse `json:"jobs"`
+	Total      int                `json:"total"`
+	Jobs       []JobResponse      `json:"jobs"`
+	Pagination PaginationResponse `json:"pagination"`
 }
 
-func ToJobsResponse(jobs []*models.Job) JobsResponseData {
+func ToJobsResponse(jobs []*models.Job, page *models.PageInfo) JobsResponseData {
 	var jobResponses []JobResponse
 	for _, job := range jobs {
 		jobResponses = append(jobResponses, ToJobResponse(job))
 	}
 	return JobsResponseData{
-		Total: len(jobs),
-		Jobs:  jobResponses,
+		Total:      page.Total,
+		Jobs:       jobResponses,
+		Pagination: ToPaginationResponse(page),
 	}
 }
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobRevisionResponse struct {
+	JobID        int64              `json:"job_id"`
+	Revision     int                `json:"revision"`
+	Snapshot     models.JobSnapshot `json:"snapshot"`
+	EditedBy     uuid.UUID          `json:"edited_by"`
+	RestoredFrom *int               `json:"restored_from,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+func ToJobRevisionResponse(revision *models.JobRevision) JobRevisionResponse {
+	return JobRevisionResponse{
+		JobID:        revision.JobID,
+		Revision:     revision.Revision,
+		Snapshot:     revision.Snapshot,
+		EditedBy:     revision.EditedBy,
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
+
+type JobRevisionsResponseData struct {
+	Total     int                   `json:"total"`
+	Revisions []JobRevisionResponse `json:"revisions"`
+}
+
+func ToJobRevisionsResponse(revisions []*models.JobRevision) JobRevisionsResponseData {
+	var revisionResponses []JobRevisionResponse
+	for _, revision := range revisions {
+		revisionResponses = append(revisionResponses, ToJobRevisionResponse(revision))
+	}
+	return JobRevisionsResponseData{
+		Total:     len(revisions),
+		Revisions: revisionResponses,
+	}
+}
+
+type JobFieldChangeResponse struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type JobRevisionDiffResponseData struct {
+	From    int                      `json:"from"`
+	To      int                      `json:"to"`
+	Changes []JobFieldChangeResponse `json:"changes"`
+}
+
+func ToJobRevisionDiffResponse(from, to int, changes []models.JobFieldChange) JobRevisionDiffResponseData {
+	changeResponses := []JobFieldChangeResponse{}
+	for _, change := range changes {
+		changeResponses = append(changeResponses, JobFieldChangeResponse{Field: change.Field, From: change.From, To: change.To})
+	}
+	return JobRevisionDiffResponseData{
+		From:    from,
+		To:      to,
+		Changes: changeResponses,
+	}
+}
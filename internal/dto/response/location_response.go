@@ -0,0 +1,88 @@
+package response
+
+import "dz-jobs-api/internal/models"
+
+type LocationNameResponse struct {
+	FR string `json:"fr"`
+	AR string `json:"ar"`
+	EN string `json:"en"`
+}
+
+type CommuneResponse struct {
+	Code       int                  `json:"code"`
+	WilayaCode int                  `json:"wilaya_code"`
+	Name       LocationNameResponse `json:"name"`
+	Latitude   float64              `json:"latitude"`
+	Longitude  float64              `json:"longitude"`
+}
+
+type WilayaResponse struct {
+	Code      int                  `json:"code"`
+	Name      LocationNameResponse `json:"name"`
+	Latitude  float64              `json:"latitude"`
+	Longitude float64              `json:"longitude"`
+	Communes  []CommuneResponse    `json:"communes,omitempty"`
+}
+
+type WilayasResponseData struct {
+	Total   int              `json:"total"`
+	Wilayas []WilayaResponse `json:"wilayas"`
+}
+
+// LocationSearchResponse is the wilaya, and the commune if any, named by a free
+// text location
+type LocationSearchResponse struct {
+	Wilaya  WilayaResponse   `json:"wilaya"`
+	Commune *CommuneResponse `json:"commune,omitempty"`
+}
+
+func toLocationNameResponse(name models.LocationName) LocationNameResponse {
+	return LocationNameResponse{FR: name.FR, AR: name.AR, EN: name.EN}
+}
+
+func ToCommuneResponse(commune *models.Commune) CommuneResponse {
+	return CommuneResponse{
+		Code:       commune.Code,
+		WilayaCode: commune.WilayaCode,
+		Name:       toLocationNameResponse(commune.Name),
+		Latitude:   commune.Latitude,
+		Longitude:  commune.Longitude,
+	}
+}
+
+// ToWilayaResponse renders a wilaya, with its communes when withCommunes is set
+func ToWilayaResponse(wilaya *models.Wilaya, withCommunes bool) WilayaResponse {
+	res := WilayaResponse{
+		Code:      wilaya.Code,
+		Name:      toLocationNameResponse(wilaya.Name),
+		Latitude:  wilaya.Latitude,
+		Longitude: wilaya.Longitude,
+	}
+	if withCommunes {
+		res.Communes = make([]CommuneResponse, 0, len(wilaya.Communes))
+		for i := range wilaya.Communes {
+			res.Communes = append(res.Communes, ToCommuneResponse(&wilaya.Communes[i]))
+		}
+	}
+	return res
+}
+
+func ToWilayasResponse(wilayas []models.Wilaya) WilayasResponseData {
+	wilayaResponses := make([]WilayaResponse, 0, len(wilayas))
+	for i := range wilayas {
+		wilayaResponses = append(wilayaResponses, ToWilayaResponse(&wilayas[i], false))
+	}
+	return WilayasResponseData{
+		Total:   len(wilayas),
+		Wilayas: wilayaResponses,
+	}
+}
+
+func ToLocationSearchResponse(wilaya *models.Wilaya, commune *models.Commune) LocationSearchResponse {
+	res := LocationSearchResponse{Wilaya: ToWilayaResponse(wilaya, false)}
+	if commune != nil {
+		communeResponse := ToCommuneResponse(commune)
+		res.Commune = &communeResponse
+	}
+	return res
+}
@@ -0,0 +1,170 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageResponse struct {
+	ID            int64                       `json:"message_id"`
+	ApplicationID int64                       `json:"application_id"`
+	SenderID      uuid.UUID                   `json:"sender_id"`
+	SenderRole    string                      `json:"sender_role"`
+	Body          string                      `json:"body"`
+	Attachments   []MessageAttachmentResponse `json:"attachments"`
+	Removed       bool                        `json:"removed"`
+	ReadAt        *time.Time                  `json:"read_at,omitempty"`
+	CreatedAt     time.Time                   `json:"created_at"`
+}
+
+type MessageAttachmentResponse struct {
+	ID          int64  `json:"attachment_id"`
+	URL         string `json:"url"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type MessagesResponseData struct {
+	Total      int                `json:"total"`
+	Messages   []MessageResponse  `json:"messages"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type MessagesReadResponse struct {
+	ReadCount int64 `json:"read_count"`
+}
+
+type ConversationResponse struct {
+	ApplicationID int64     `json:"application_id"`
+	JobID         int64     `json:"job_id"`
+	JobTitle      string    `json:"job_title"`
+	CompanyName   string    `json:"company_name"`
+	CandidateID   uuid.UUID `json:"candidate_id"`
+	CandidateName string    `json:"candidate_name"`
+	LastMessageAt time.Time `json:"last_message_at"`
+	UnreadCount   int       `json:"unread_count"`
+}
+
+type ConversationsResponseData struct {
+	Total         int                    `json:"total"`
+	UnreadTotal   int                    `json:"unread_total"`
+	Conversations []ConversationResponse `json:"conversations"`
+	Pagination    PaginationResponse     `json:"pagination"`
+}
+
+type MessageReportResponse struct {
+	ID         int64           `json:"report_id"`
+	ReportedBy uuid.UUID       `json:"reported_by"`
+	Reason     string          `json:"reason"`
+	Status     string          `json:"status"`
+	ReviewedBy *uuid.UUID      `json:"reviewed_by,omitempty"`
+	ReviewNote string          `json:"review_note,omitempty"`
+	ReviewedAt *time.Time      `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Message    MessageResponse `json:"message"`
+}
+
+type MessageReportsResponseData struct {
+	Total      int                     `json:"total"`
+	Reports    []MessageReportResponse `json:"reports"`
+	Pagination PaginationResponse      `json:"pagination"`
+}
+
+// ToMessageResponse hides the text and attachments of a message an admin removed
+func ToMessageResponse(message *models.Message) MessageResponse {
+	messageResponse := toMessageContentResponse(message)
+	if messageResponse.Removed {
+		messageResponse.Body = ""
+		messageResponse.Attachments = []MessageAttachmentResponse{}
+	}
+	return messageResponse
+}
+
+func ToMessagesResponse(messages []*models.Message, page *models.PageInfo) MessagesResponseData {
+	var messageResponses []MessageResponse
+	for _, message := range messages {
+		messageResponses = append(messageResponses, ToMessageResponse(message))
+	}
+	return MessagesResponseData{
+		Total:      page.Total,
+		Messages:   messageResponses,
+		Pagination: ToPaginationResponse(page),
+	}
+}
+
+func ToConversationsResponse(conversations []*models.Conversation, page *models.PageInfo, unreadTotal int) ConversationsResponseData {
+	var conversationResponses []ConversationResponse
+	for _, conversation := range conversations {
+		conversationResponses = append(conversationResponses, ConversationResponse{
+			ApplicationID: conversation.ApplicationID,
+			JobID:         conversation.JobID,
+			JobTitle:      conversation.JobTitle,
+			CompanyName:   conversation.CompanyName,
+			CandidateID:   conversation.CandidateID,
+			CandidateName: conversation.CandidateName,
+			LastMessageAt: conversation.LastMessageAt,
+			UnreadCount:   conversation.UnreadCount,
+		})
+	}
+	return ConversationsResponseData{
+		Total:         page.Total,
+		UnreadTotal:   unreadTotal,
+		Conversations: conversationResponses,
+		Pagination:    ToPaginationResponse(page),
+	}
+}
+
+// ToMessageReportResponse shows the reported message as it was sent, even once
+// removed, for admins to review
+func ToMessageReportResponse(report *models.MessageReport) MessageReportResponse {
+	return MessageReportResponse{
+		ID:         report.ID,
+		ReportedBy: report.ReportedBy,
+		Reason:     report.Reason,
+		Status:     report.Status,
+		ReviewedBy: report.ReviewedBy,
+		ReviewNote: report.ReviewNote,
+		ReviewedAt: report.ReviewedAt,
+		CreatedAt:  report.CreatedAt,
+		Message:    toMessageContentResponse(report.Message),
+	}
+}
+
+func ToMessageReportsResponse(reports []*models.MessageReport, page *models.PageInfo) MessageReportsResponseData {
+	var reportResponses []MessageReportResponse
+	for _, report := range reports {
+		reportResponses = append(reportResponses, ToMessageReportResponse(report))
+	}
+	return MessageReportsResponseData{
+		Total:      page.Total,
+		Reports:    reportResponses,
+		Pagination: ToPaginationResponse(page),
+	}
+}
+
+func toMessageContentResponse(message *models.Message) MessageResponse {
+	attachments := make([]MessageAttachmentResponse, 0, len(message.Attachments))
+	for _, attachment := range message.Attachments {
+		attachments = append(attachments, MessageAttachmentResponse{
+			ID:          attachment.ID,
+			URL:         attachment.URL,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+		})
+	}
+	return MessageResponse{
+		ID:            message.ID,
+		ApplicationID: message.ApplicationID,
+		SenderID:      message.SenderID,
+		SenderRole:    message.SenderRole,
+		Body:          message.Body,
+		Attachments:   attachments,
+		Removed:       message.RemovedAt != nil,
+		ReadAt:        message.ReadAt,
+		CreatedAt:     message.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package response
+
+import (
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"time"
+)
+
+type NotificationPreferencesResponse struct {
+	Events     []NotificationEventPreferenceResponse `json:"events"`
+	QuietHours *QuietHoursResponse                   `json:"quiet_hours"`
+	Digest     bool                                  `json:"digest"`
+	UpdatedAt  *time.Time                            `json:"updated_at,omitempty"`
+}
+
+// NotificationEventPreferenceResponse tells on which of the channels an event is
+// sent on the user wants to be told about it
+type NotificationEventPreferenceResponse struct {
+	Type        string          `json:"type"`
+	Channels    map[string]bool `json:"channels"`
+	LowPriority bool            `json:"low_priority"`
+}
+
+// QuietHoursResponse is a "15:04" time of day in Algiers time
+type QuietHoursResponse struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func ToNotificationPreferencesResponse(preferences *models.NotificationPreferences) NotificationPreferencesResponse {
+	preferencesResponse := NotificationPreferencesResponse{
+		Digest:    preferences.Digest,
+		UpdatedAt: preferences.UpdatedAt,
+	}
+	for _, event := range models.NotificationEvents {
+		channels := map[string]bool{}
+		for _, channel := range event.Channels {
+			channels[channel] = preferences.Allows(event.Type, channel)
+		}
+		preferencesResponse.Events = append(preferencesResponse.Events, NotificationEventPreferenceResponse{
+			Type:        event.Type,
+			Channels:    channels,
+			LowPriority: event.LowPriority,
+		})
+	}
+	if preferences.QuietHoursStart != nil && preferences.QuietHoursEnd != nil {
+		preferencesResponse.QuietHours = &QuietHoursResponse{
+			Start: helpers.FormatClock(*preferences.QuietHoursStart),
+			End:   helpers.FormatClock(*preferences.QuietHoursEnd),
+		}
+	}
+	return preferencesResponse
+}
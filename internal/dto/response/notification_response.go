@@ -0,0 +1,54 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+)
+
+type NotificationResponse struct {
+	ID        int64                   `json:"notification_id"`
+	Type      string                  `json:"type"`
+	Title     string                  `json:"title"`
+	Body      string                  `json:"body"`
+	Data      models.NotificationData `json:"data"`
+	Read      bool                    `json:"read"`
+	ReadAt    *time.Time              `json:"read_at,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+type NotificationsResponseData struct {
+	Total         int                    `json:"total"`
+	UnreadCount   int                    `json:"unread_count"`
+	Notifications []NotificationResponse `json:"notifications"`
+	Pagination    PaginationResponse     `json:"pagination"`
+}
+
+type NotificationsReadResponse struct {
+	ReadCount int64 `json:"read_count"`
+}
+
+func ToNotificationResponse(notification *models.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      notification.Data,
+		Read:      notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func ToNotificationsResponse(notifications []*models.Notification, page *models.PageInfo, unreadCount int) NotificationsResponseData {
+	var notificationResponses []NotificationResponse
+	for _, notification := range notifications {
+		notificationResponses = append(notificationResponses, ToNotificationResponse(notification))
+	}
+	return NotificationsResponseData{
+		Total:         page.Total,
+		UnreadCount:   unreadCount,
+		Notifications: notificationResponses,
+		Pagination:    ToPaginationResponse(page),
+	}
+}
@@ -15,6 +15,8 @@ type PersonalInfoResponse struct {
 	DateOfBirth string    `json:"date_of_birth"`
 	Gender      string    `json:"gender"`
 	Bio         string    `json:"bio"`
+	WilayaCode  *int      `json:"wilaya_code,omitempty"`
+	CommuneCode *int      `json:"commune_code,omitempty"`
 }
 
 func ToPersonalInfoResponse(info *models.CandidatePersonalInfo) PersonalInfoResponse {
@@ -27,5 +29,7 @@ func ToPersonalInfoResponse(info *models.CandidatePersonalInfo) PersonalInfoResp
 		DateOfBirth: info.DateOfBirth,
 		Gender:      info.Gender,
 		Bio:         info.Bio,
+		WilayaCode:  info.WilayaCode,
+		CommuneCode: info.CommuneCode,
 	}
 }
@@ -0,0 +1,72 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PipelineStageResponse struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Position int    `json:"position"`
+}
+
+type PipelineStagesResponseData struct {
+	Total  int                     `json:"total"`
+	Stages []PipelineStageResponse `json:"stages"`
+}
+
+func ToPipelineStagesResponse(stages []models.PipelineStage) PipelineStagesResponseData {
+	var stageResponses []PipelineStageResponse
+	for _, stage := range stages {
+		stageResponses = append(stageResponses, PipelineStageResponse{
+			Name:     stage.Name,
+			Category: stage.Category,
+			Position: stage.Position,
+		})
+	}
+	return PipelineStagesResponseData{
+		Total:  len(stages),
+		Stages: stageResponses,
+	}
+}
+
+type ApplicationTransitionResponse struct {
+	ID            int64     `json:"transition_id"`
+	ApplicationID int64     `json:"application_id"`
+	FromStage     string    `json:"from_stage"`
+	ToStage       string    `json:"to_stage"`
+	MovedBy       uuid.UUID `json:"moved_by"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func ToApplicationTransitionResponse(transition *models.ApplicationTransition) ApplicationTransitionResponse {
+	return ApplicationTransitionResponse{
+		ID:            transition.ID,
+		ApplicationID: transition.ApplicationID,
+		FromStage:     transition.FromStage,
+		ToStage:       transition.ToStage,
+		MovedBy:       transition.MovedBy,
+		Reason:        transition.Reason,
+		CreatedAt:     transition.CreatedAt,
+	}
+}
+
+type ApplicationTransitionsResponseData struct {
+	Total       int                             `json:"total"`
+	Transitions []ApplicationTransitionResponse `json:"transitions"`
+}
+
+func ToApplicationTransitionsResponse(transitions []*models.ApplicationTransition) ApplicationTransitionsResponseData {
+	var transitionResponses []ApplicationTransitionResponse
+	for _, transition := range transitions {
+		transitionResponses = append(transitionResponses, ToApplicationTransitionResponse(transition))
+	}
+	return ApplicationTransitionsResponseData{
+		Total:       len(transitions),
+		Transitions: transitionResponses,
+	}
+}
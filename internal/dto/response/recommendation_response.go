@@ -0,0 +1,90 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+type MatchCriterionResponse struct {
+	Criterion string  `json:"criterion"`
+	Score     float64 `json:"score"`
+	MaxScore  float64 `json:"max_score"`
+	Detail    string  `json:"detail"`
+}
+
+type MatchScoreResponse struct {
+	Score         int                      `json:"score"`
+	MatchedSkills []string                 `json:"matched_skills"`
+	MissingSkills []string                 `json:"missing_skills"`
+	Breakdown     []MatchCriterionResponse `json:"breakdown"`
+}
+
+func ToMatchScoreResponse(match models.MatchScore) MatchScoreResponse {
+	breakdown := make([]MatchCriterionResponse, 0, len(match.Breakdown))
+	for _, criterion := range match.Breakdown {
+		breakdown = append(breakdown, MatchCriterionResponse{
+			Criterion: criterion.Criterion,
+			Score:     math.Round(criterion.Score*10) / 10,
+			MaxScore:  criterion.MaxScore,
+			Detail:    criterion.Detail,
+		})
+	}
+	return MatchScoreResponse{
+		Score:         match.Score,
+		MatchedSkills: match.MatchedSkills,
+		MissingSkills: match.MissingSkills,
+		Breakdown:     breakdown,
+	}
+}
+
+type JobRecommendationResponse struct {
+	Job   JobResponse        `json:"job"`
+	Match MatchScoreResponse `json:"match"`
+}
+
+type JobRecommendationsResponseData struct {
+	Total           int                         `json:"total"`
+	Recommendations []JobRecommendationResponse `json:"recommendations"`
+}
+
+func ToJobRecommendationsResponse(recommendations []*models.JobRecommendation) JobRecommendationsResponseData {
+	recommendationResponses := make([]JobRecommendationResponse, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		recommendationResponses = append(recommendationResponses, JobRecommendationResponse{
+			Job:   ToJobResponse(recommendation.Job),
+			Match: ToMatchScoreResponse(recommendation.Match),
+		})
+	}
+	return JobRecommendationsResponseData{
+		Total:           len(recommendations),
+		Recommendations: recommendationResponses,
+	}
+}
+
+type CandidateMatchResponse struct {
+	CandidateID uuid.UUID          `json:"candidate_id"`
+	Name        string             `json:"name"`
+	Match       MatchScoreResponse `json:"match"`
+}
+
+type CandidateMatchesResponseData struct {
+	Total      int                      `json:"total"`
+	Candidates []CandidateMatchResponse `json:"candidates"`
+}
+
+func ToCandidateMatchesResponse(matches []*models.CandidateMatch) CandidateMatchesResponseData {
+	matchResponses := make([]CandidateMatchResponse, 0, len(matches))
+	for _, match := range matches {
+		matchResponses = append(matchResponses, CandidateMatchResponse{
+			CandidateID: match.Candidate.CandidateID,
+			Name:        match.Candidate.Name,
+			Match:       ToMatchScoreResponse(match.Match),
+		})
+	}
+	return CandidateMatchesResponseData{
+		Total:      len(matches),
+		Candidates: matchResponses,
+	}
+}
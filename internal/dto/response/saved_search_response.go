@@ -0,0 +1,49 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"encoding/json"
+	"time"
+)
+
+type SavedSearchResponse struct {
+	ID            int64           `json:"saved_search_id"`
+	Name          string          `json:"name"`
+	Filters       json.RawMessage `json:"filters" swaggertype:"object"`
+	Frequency     string          `json:"frequency"`
+	AlertsEnabled bool            `json:"alerts_enabled"`
+	LastRunAt     *time.Time      `json:"last_run_at,omitempty"`
+	NextRunAt     time.Time       `json:"next_run_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+func ToSavedSearchResponse(search *models.SavedSearch) SavedSearchResponse {
+	return SavedSearchResponse{
+		ID:            search.ID,
+		Name:          search.Name,
+		Filters:       search.Filters,
+		Frequency:     search.Frequency,
+		AlertsEnabled: search.AlertsEnabled,
+		LastRunAt:     search.LastRunAt,
+		NextRunAt:     search.NextRunAt,
+		CreatedAt:     search.CreatedAt,
+		UpdatedAt:     search.UpdatedAt,
+	}
+}
+
+type SavedSearchesResponseData struct {
+	Total         int                   `json:"total"`
+	SavedSearches []SavedSearchResponse `json:"saved_searches"`
+}
+
+func ToSavedSearchesResponse(searches []*models.SavedSearch) SavedSearchesResponseData {
+	searchResponses := make([]SavedSearchResponse, 0, len(searches))
+	for _, search := range searches {
+		searchResponses = append(searchResponses, ToSavedSearchResponse(search))
+	}
+	return SavedSearchesResponseData{
+		Total:         len(searches),
+		SavedSearches: searchResponses,
+	}
+}
@@ -0,0 +1,77 @@
+package response
+
+import "dz-jobs-api/internal/models"
+
+type ScreeningQuestionResponse struct {
+	ID            int64    `json:"question_id"`
+	Position      int      `json:"position"`
+	Type          string   `json:"type"`
+	Prompt        string   `json:"prompt"`
+	Required      bool     `json:"required"`
+	Options       []string `json:"options,omitempty"`
+	RejectAnswers []string `json:"reject_answers,omitempty"`
+	RejectBelow   *float64 `json:"reject_below,omitempty"`
+	RejectAbove   *float64 `json:"reject_above,omitempty"`
+}
+
+type ScreeningQuestionsResponseData struct {
+	Total     int                         `json:"total"`
+	Questions []ScreeningQuestionResponse `json:"questions"`
+}
+
+func ToScreeningQuestionsResponse(questions []models.ScreeningQuestion) ScreeningQuestionsResponseData {
+	var questionResponses []ScreeningQuestionResponse
+	for _, question := range questions {
+		questionResponses = append(questionResponses, ScreeningQuestionResponse{
+			ID:            question.ID,
+			Position:      question.Position,
+			Type:          question.Type,
+			Prompt:        question.Prompt,
+			Required:      question.Required,
+			Options:       question.Options,
+			RejectAnswers: question.RejectAnswers,
+			RejectBelow:   question.RejectBelow,
+			RejectAbove:   question.RejectAbove,
+		})
+	}
+	return ScreeningQuestionsResponseData{
+		Total:     len(questionResponses),
+		Questions: questionResponses,
+	}
+}
+
+// ToPublicScreeningQuestionsResponse hides the auto-reject rules from candidates
+func ToPublicScreeningQuestionsResponse(questions []models.ScreeningQuestion) ScreeningQuestionsResponseData {
+	questionsResponse := ToScreeningQuestionsResponse(questions)
+	for i := range questionsResponse.Questions {
+		question := &questionsResponse.Questions[i]
+		question.RejectAnswers, question.RejectBelow, question.RejectAbove = nil, nil, nil
+	}
+	return questionsResponse
+}
+
+type ScreeningAnswerResponse struct {
+	QuestionID *int64   `json:"question_id"` // Null once the question is removed from the job
+	Type       string   `json:"type"`
+	Prompt     string   `json:"prompt"`
+	Value      string   `json:"value,omitempty"`
+	Number     *float64 `json:"number,omitempty"`
+	Choices    []string `json:"choices,omitempty"`
+	Knockout   bool     `json:"knockout,omitempty"`
+}
+
+func ToScreeningAnswersResponse(answers []models.ScreeningAnswer) []ScreeningAnswerResponse {
+	var answerResponses []ScreeningAnswerResponse
+	for _, answer := range answers {
+		answerResponses = append(answerResponses, ScreeningAnswerResponse{
+			QuestionID: answer.QuestionID,
+			Type:       answer.Type,
+			Prompt:     answer.Prompt,
+			Value:      answer.Value,
+			Number:     answer.Number,
+			Choices:    answer.Choices,
+			Knockout:   answer.Knockout,
+		})
+	}
+	return answerResponses
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+)
+
+type SkillCatalogResponse struct {
+	ID        int64     `json:"skill_id"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Aliases   []string  `json:"aliases,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToSkillCatalogResponse(skill *models.Skill) SkillCatalogResponse {
+	return SkillCatalogResponse{
+		ID:        skill.ID,
+		Name:      skill.Name,
+		Category:  skill.Category,
+		Aliases:   skill.Aliases,
+		CreatedAt: skill.CreatedAt,
+	}
+}
+
+type SkillCatalogResponseData struct {
+	Total  int                    `json:"total"`
+	Skills []SkillCatalogResponse `json:"skills"`
+}
+
+func ToSkillCatalogListResponse(skills []*models.Skill) SkillCatalogResponseData {
+	skillResponses := make([]SkillCatalogResponse, 0, len(skills))
+	for _, skill := range skills {
+		skillResponses = append(skillResponses, ToSkillCatalogResponse(skill))
+	}
+	return SkillCatalogResponseData{
+		Total:  len(skills),
+		Skills: skillResponses,
+	}
+}
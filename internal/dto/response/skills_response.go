@@ -7,14 +7,18 @@ import (
 )
 
 type SkillResponse struct {
-	ID    uuid.UUID `json:"candidate_id"`
-	Skill string    `json:"skill"`
+	ID       uuid.UUID `json:"candidate_id"`
+	SkillID  int64     `json:"skill_id"`
+	Skill    string    `json:"skill"`
+	Category string    `json:"category"`
 }
 
 func ToSkillResponse(skill *models.CandidateSkills) SkillResponse {
 	return SkillResponse{
-		ID:    skill.ID,
-		Skill: skill.Skill,
+		ID:       skill.ID,
+		SkillID:  skill.SkillID,
+		Skill:    skill.Skill,
+		Category: skill.Category,
 	}
 }
 
@@ -25,9 +25,10 @@ type HealthResponse struct {
 }
 
 type MetricsResponse struct {
-	Uptime       string `json:"uptime"`
-	RequestCount string `json:"request_count"`
-	ErrorRate    string `json:"error_rate"`
+	Uptime            string `json:"uptime"`
+	RequestCount      string `json:"request_count"`
+	ErrorRate         string `json:"error_rate"`
+	RateLimitFailures int    `json:"rate_limit_failures"`
 }
 
 func AggregateHealthStatus(status map[string]string) string {
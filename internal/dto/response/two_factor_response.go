@@ -0,0 +1,38 @@
+package response
+
+import (
+	"dz-jobs-api/internal/models"
+	"time"
+)
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool `json:"two_factor_required"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int        `json:"recovery_codes_left"`
+	Required          bool       `json:"required"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func ToTwoFactorStatusResponse(twoFactor *models.TwoFactor, recoveryCodesLeft int, required bool) TwoFactorStatusResponse {
+	status := TwoFactorStatusResponse{
+		Enabled:           twoFactor.Enabled(),
+		RecoveryCodesLeft: recoveryCodesLeft,
+		Required:          required,
+	}
+	if twoFactor.Enabled() {
+		status.EnabledAt = twoFactor.EnabledAt
+	}
+	return status
+}
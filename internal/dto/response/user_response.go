@@ -8,37 +8,41 @@ import (
 )
 
 type UserResponse struct {
-	ID        uuid.UUID `json:"user_id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID            uuid.UUID `json:"user_id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
 }
 
 func ToUserResponse(user *models.User) UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
 	}
 }
 
 type UsersResponseData struct {
-	Total int            `json:"total"`
-	Users []UserResponse `json:"users"`
+	Total      int                `json:"total"`
+	Users      []UserResponse     `json:"users"`
+	Pagination PaginationResponse `json:"pagination"`
 }
 
-func ToUsersResponse(users []*models.User) UsersResponseData {
+func ToUsersResponse(users []*models.User, page *models.PageInfo) UsersResponseData {
 	var userResponses []UserResponse
 	for _, user := range users {
 		userResponses = append(userResponses, ToUserResponse(user))
 	}
 	return UsersResponseData{
-		Total: len(users),
-		Users: userResponses,
+		Total:      page.Total,
+		Users:      userResponses,
+		Pagination: ToPaginationResponse(page),
 	}
 }
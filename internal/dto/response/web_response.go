@@ -1,8 +1,26 @@
 package response
 
+import "dz-jobs-api/internal/models"
+
 type Response struct {
 	Code    int         `json:"code"`
 	Status  string      `json:"status"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 }
+
+type PaginationResponse struct {
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+func ToPaginationResponse(page *models.PageInfo) PaginationResponse {
+	return PaginationResponse{
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+}
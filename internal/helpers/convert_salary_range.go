@@ -1,5 +1,10 @@
 package helpers
 
+import (
+	"errors"
+	"fmt"
+)
+
 // import (
 // 	"fmt"
 // 	"regexp"
@@ -7,21 +12,51 @@ package helpers
 // 	"github.com/go-playground/validator/v10"
 // )
 
-func ConvertSalaryRange(min, max float64) (string, []interface{}) {
+// ConvertSalaryRange returns the condition matching jobs whose salary lies within
+// [min, max], a zero bound is ignored
+func ConvertSalaryRange(min, max float64, paramCount int) (string, []interface{}) {
 	if min > 0 && max > 0 {
-		return `
-			CAST(split_part(salary_range, ' ', 1) AS float) >= $1 
-			AND CAST(split_part(salary_range, ' ', 3) AS float) <= $2
-		`, []interface{}{min, max}
+		return fmt.Sprintf("salary_min >= $%d AND salary_max <= $%d", paramCount, paramCount+1), []interface{}{min, max}
 	} else if min > 0 {
-		return `
-			CAST(split_part(salary_range, ' ', 1) AS float) >= $1
-		`, []interface{}{min}
+		return fmt.Sprintf("salary_min >= $%d", paramCount), []interface{}{min}
 	} else {
-		return `
-			CAST(split_part(salary_range, ' ', 3) AS float) <= $1
-		`, []interface{}{max}
+		return fmt.Sprintf("salary_max <= $%d", paramCount), []interface{}{max}
+	}
+}
+
+// SalaryFilterError is returned for a salary filter or sort a search cannot honour
+type SalaryFilterError struct {
+	Reason string
+}
+
+func (e *SalaryFilterError) Error() string {
+	return "invalid salary filter: " + e.Reason
+}
+
+// ValidateSalaryFilter checks that a search filtering or sorting by salary also
+// selects a currency and a period, salaries are only comparable within both
+func ValidateSalaryFilter(min, max float64, sort, currency, period string) error {
+	if min <= 0 && max <= 0 && sort != "salary" {
+		return nil
+	}
+	if currency == "" || period == "" {
+		return &SalaryFilterError{Reason: "min_salary, max_salary and sort=salary require a currency and a period"}
+	}
+	return nil
+}
+
+// SalarySortExpr returns the salary sort key of the jobs table aliased by prefix,
+// jobs without a salary sort as 0. It is only offered to searches selecting a
+// currency and a period.
+func SalarySortExpr(prefix string) string {
+	return fmt.Sprintf("COALESCE(%ssalary_min, 0)", prefix)
+}
+
+func ValidateSalary(min, max *float64) error {
+	if min != nil && max != nil && *min > *max {
+		return errors.New("salary_min cannot be greater than salary_max")
 	}
+	return nil
 }
 
 // var Validate *validator.Validate
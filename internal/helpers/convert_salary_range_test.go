@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSalaryFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		sort     string
+		currency string
+		period   string
+		wantErr  bool
+	}{
+		{"No salary filter", 0, 0, "created_at", "", "", false},
+		{"Minimum without currency", 50000, 0, "", "", "monthly", true},
+		{"Maximum without period", 0, 90000, "", "DZD", "", true},
+		{"Salary sort without currency and period", 0, 0, "salary", "", "", true},
+		{"Range in a currency and period", 50000, 90000, "salary", "DZD", "monthly", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSalaryFilter(tt.min, tt.max, tt.sort, tt.currency, tt.period)
+			if tt.wantErr {
+				var salaryErr *SalaryFilterError
+				assert.ErrorAs(t, err, &salaryErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
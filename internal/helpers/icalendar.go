@@ -0,0 +1,207 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// icalLineLength is the maximum length in octets of an iCalendar content line,
+// longer lines are folded (RFC 5545 section 3.1)
+const icalLineLength = 75
+
+// icalTimeFormat is the UTC date-time form of RFC 5545
+const icalTimeFormat = "20060102T150405Z"
+
+// AlgiersTime is the time zone interviews are presented in, Algeria keeps UTC+1
+// all year long
+var AlgiersTime = time.FixedZone("Africa/Algiers", 60*60)
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// CalendarEvent is an event of an iCalendar document
+type CalendarEvent struct {
+	UID          string
+	Sequence     int
+	Status       string // CONFIRMED or CANCELLED
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Organizer    CalendarAttendee
+	Attendees    []CalendarAttendee
+	Created      time.Time
+	LastModified time.Time
+}
+
+type CalendarAttendee struct {
+	Name  string
+	Email string
+}
+
+// RenderCalendar renders events as an RFC 5545 iCalendar document. method is the
+// iTIP method of the invites sent by email (REQUEST or CANCEL), feeds subscribed
+// to have none. name is the display name of a feed.
+func RenderCalendar(name, method string, events []CalendarEvent, now time.Time) []byte {
+	var calendar strings.Builder
+	writeLine := func(line string) {
+		calendar.WriteString(foldICalLine(line))
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//Dz Jobs//Interviews//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	if method != "" {
+		writeLine("METHOD:" + method)
+	}
+	if name != "" {
+		writeLine("X-WR-CALNAME:" + escapeICalText(name))
+	}
+	for _, event := range events {
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + event.UID)
+		writeLine("DTSTAMP:" + formatICalTime(now))
+		writeLine(fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+		writeLine("DTSTART:" + formatICalTime(event.Start))
+		writeLine("DTEND:" + formatICalTime(event.End))
+		writeLine("SUMMARY:" + escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeLine("DESCRIPTION:" + escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine("LOCATION:" + escapeICalText(event.Location))
+		}
+		if event.URL != "" {
+			writeLine("URL:" + icalRawValue(event.URL))
+		}
+		writeLine("STATUS:" + event.Status)
+		if event.Organizer.Email != "" {
+			writeLine("ORGANIZER" + icalCommonName(event.Organizer.Name) + ":mailto:" + icalRawValue(event.Organizer.Email))
+		}
+		for _, attendee := range event.Attendees {
+			writeLine("ATTENDEE" + icalCommonName(attendee.Name) +
+				";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED;RSVP=FALSE:mailto:" + icalRawValue(attendee.Email))
+		}
+		if !event.Created.IsZero() {
+			writeLine("CREATED:" + formatICalTime(event.Created))
+		}
+		if !event.LastModified.IsZero() {
+			writeLine("LAST-MODIFIED:" + formatICalTime(event.LastModified))
+		}
+		writeLine("END:VEVENT")
+	}
+	writeLine("END:VCALENDAR")
+	return []byte(calendar.String())
+}
+
+// InterviewCalendarEvent is the event of a scheduled interview, identified by
+// domain so that every invite and feed entry of the interview updates the same
+// calendar event
+func InterviewCalendarEvent(interview *models.Interview, domain string) CalendarEvent {
+	event := CalendarEvent{
+		UID:          fmt.Sprintf("interview-%d@%s", interview.ID, domain),
+		Sequence:     interview.Sequence,
+		Status:       "CONFIRMED",
+		Summary:      fmt.Sprintf("Interview for %s at %s", interview.JobTitle, interview.CompanyName),
+		Location:     interview.Location,
+		URL:          interview.VideoLink,
+		Organizer:    CalendarAttendee{Name: interview.CompanyName, Email: interview.RecruiterEmail},
+		Attendees:    []CalendarAttendee{{Name: interview.CandidateName, Email: interview.CandidateEmail}},
+		Created:      interview.CreatedAt,
+		LastModified: interview.UpdatedAt,
+	}
+	if interview.Status == "cancelled" {
+		event.Status = "CANCELLED"
+	}
+	if event.Location == "" {
+		event.Location = interview.VideoLink
+	}
+	if interview.ScheduledAt != nil {
+		event.Start, event.End = *interview.ScheduledAt, *interview.EndsAt()
+	}
+
+	var description []string
+	if interview.VideoLink != "" {
+		description = append(description, "Video link: "+interview.VideoLink)
+	}
+	if interview.Notes != "" {
+		description = append(description, interview.Notes)
+	}
+	if interview.Status == "cancelled" && interview.CancelReason != "" {
+		description = append(description, "Cancelled: "+interview.CancelReason)
+	}
+	event.Description = strings.Join(description, "\n\n")
+	return event
+}
+
+// FormatInterviewTime presents the start of an interview in Algiers time, such as
+// "Monday 2 June 2025 at 14:30"
+func FormatInterviewTime(t time.Time) string {
+	return t.In(AlgiersTime).Format("Monday 2 January 2006 at 15:04")
+}
+
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format(icalTimeFormat)
+}
+
+func escapeICalText(text string) string {
+	return icalTextEscaper.Replace(text)
+}
+
+// icalRawValue drops the control characters of a value written without escaping,
+// such as a URI, so that it cannot end its content line
+func icalRawValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// icalCommonName is the CN parameter of a calendar user, quoted since names may
+// hold separators. Double quotes and control characters, line breaks included,
+// cannot appear in a quoted parameter value, the latter are replaced by spaces.
+func icalCommonName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == '"':
+			return -1
+		case unicode.IsControl(r):
+			return ' '
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + name + `"`
+}
+
+// foldICalLine ends a content line with CRLF, splitting it into lines of at most
+// icalLineLength octets, continuation lines starting with a space. Lines are never
+// split inside a UTF-8 sequence.
+func foldICalLine(line string) string {
+	var folded strings.Builder
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		folded.WriteString(line[:cut])
+		folded.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalLineLength - 1
+	}
+	folded.WriteString(line)
+	folded.WriteString("\r\n")
+	return folded.String()
+}
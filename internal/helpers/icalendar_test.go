@@ -0,0 +1,91 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeICalText(t *testing.T) {
+	t.Run("Separators and backslashes", func(t *testing.T) {
+		assert.Equal(t, `a\\b\; c\, d`, escapeICalText(`a\b; c, d`))
+	})
+
+	t.Run("Line breaks", func(t *testing.T) {
+		assert.Equal(t, `one\ntwo\nthree\nfour`, escapeICalText("one\r\ntwo\nthree\rfour"))
+	})
+}
+
+func TestICalCommonName(t *testing.T) {
+	t.Run("Quoted name", func(t *testing.T) {
+		assert.Equal(t, `;CN="Doe, Jane"`, icalCommonName("Doe, Jane"))
+	})
+
+	t.Run("Double quotes dropped", func(t *testing.T) {
+		assert.Equal(t, `;CN="Jane Doe"`, icalCommonName(`"Jane" Doe`))
+	})
+
+	t.Run("Control characters replaced", func(t *testing.T) {
+		assert.Equal(t, `;CN="Eve  ATTENDEE:mailto:eve@example.com"`, icalCommonName("Eve\r\nATTENDEE:mailto:eve@example.com"))
+	})
+
+	t.Run("Empty name", func(t *testing.T) {
+		assert.Equal(t, "", icalCommonName(" \n\"\" "))
+	})
+}
+
+func TestFoldICalLine(t *testing.T) {
+	t.Run("Short line", func(t *testing.T) {
+		assert.Equal(t, "SUMMARY:Interview\r\n", foldICalLine("SUMMARY:Interview"))
+	})
+
+	t.Run("Long line", func(t *testing.T) {
+		line := "DESCRIPTION:" + strings.Repeat("x", 200)
+		folded := foldICalLine(line)
+
+		lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+		assert.Greater(t, len(lines), 1)
+		for i, l := range lines {
+			assert.LessOrEqual(t, len(l), icalLineLength)
+			if i > 0 {
+				assert.True(t, strings.HasPrefix(l, " "), "Continuation lines should start with a space")
+			}
+		}
+		assert.Equal(t, line, strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""))
+	})
+
+	t.Run("Multi-byte characters kept whole", func(t *testing.T) {
+		line := "LOCATION:" + strings.Repeat("الجزائر ", 20)
+		folded := foldICalLine(line)
+
+		for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(l), icalLineLength)
+			assert.True(t, utf8.ValidString(l), "Lines should not be split inside a UTF-8 sequence")
+		}
+		assert.Equal(t, line, strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""))
+	})
+}
+
+func TestRenderCalendarInjection(t *testing.T) {
+	start := time.Date(2025, 6, 2, 13, 30, 0, 0, time.UTC)
+	event := CalendarEvent{
+		UID:       "interview-1@example.com",
+		Status:    "CONFIRMED",
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Summary:   "Interview\r\nATTENDEE:mailto:summary@example.com",
+		URL:       "https://meet.example.com/1\r\nATTENDEE:mailto:url@example.com",
+		Organizer: CalendarAttendee{Name: "Acme\nATTENDEE:mailto:organizer@example.com", Email: "hr@example.com"},
+		Attendees: []CalendarAttendee{{Name: "Eve\r\nATTENDEE:mailto:eve@example.com", Email: "candidate@example.com"}},
+	}
+
+	calendar := strings.ReplaceAll(string(RenderCalendar("", "REQUEST", []CalendarEvent{event}, start)), "\r\n ", "")
+	for _, line := range strings.Split(calendar, "\r\n") {
+		assert.NotContains(t, line, "\n", "Content lines should only end with CRLF")
+		assert.False(t, strings.HasPrefix(line, "ATTENDEE:"), "No content line should be injected: %q", line)
+	}
+	assert.Contains(t, calendar, "ATTENDEE;CN=\"Eve  ATTENDEE:mailto:eve@example.com\";ROLE=REQ-PARTICIPANT")
+}
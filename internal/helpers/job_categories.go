@@ -0,0 +1,113 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CategoryTree indexes the job categories by ID to walk their hierarchy
+type CategoryTree struct {
+	Roots []*models.JobCategory
+	byID  map[int64]*models.JobCategory
+}
+
+// NewCategoryTree links the categories to their children, categories are kept in
+// the order they are given
+func NewCategoryTree(categories []*models.JobCategory) *CategoryTree {
+	tree := &CategoryTree{Roots: []*models.JobCategory{}, byID: make(map[int64]*models.JobCategory, len(categories))}
+	for _, category := range categories {
+		category.Children = []*models.JobCategory{}
+		tree.byID[category.ID] = category
+	}
+	for _, category := range categories {
+		parent := tree.parent(category)
+		if parent == nil {
+			tree.Roots = append(tree.Roots, category)
+			continue
+		}
+		parent.Children = append(parent.Children, category)
+	}
+	return tree
+}
+
+// Get returns the category with the given ID, or nil if there is none
+func (t *CategoryTree) Get(id int64) *models.JobCategory {
+	return t.byID[id]
+}
+
+// Path returns the category with the given ID preceded by its ancestors, from
+// the top level category down
+func (t *CategoryTree) Path(id int64) []*models.JobCategory {
+	var path []*models.JobCategory
+	for category := t.byID[id]; category != nil && len(path) <= len(t.byID); category = t.parent(category) {
+		path = append([]*models.JobCategory{category}, path...)
+	}
+	return path
+}
+
+// IsWithin tells whether the category with the given ID is ancestorID or one of
+// its descendants
+func (t *CategoryTree) IsWithin(id, ancestorID int64) bool {
+	for _, category := range t.Path(id) {
+		if category.ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *CategoryTree) parent(category *models.JobCategory) *models.JobCategory {
+	if category.ParentID == nil {
+		return nil
+	}
+	return t.byID[*category.ParentID]
+}
+
+// CategorySlug turns a category name into the slug it can be searched by, such
+// as "sales-marketing" for "Sales & Marketing"
+func CategorySlug(name string) string {
+	words := strings.FieldsFunc(accentFolder.Replace(strings.ToLower(name)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
+
+// CategoryFacets rolls the job counts of the categories up to their ancestors,
+// so that a category counts the jobs of its whole subtree, and names them. The
+// categories are listed in tree order, the ones without jobs are left out.
+func CategoryFacets(counts []models.JobFacetValue, tree *CategoryTree) []models.JobFacetValue {
+	totals := map[int64]int{}
+	for _, count := range counts {
+		id, err := strconv.ParseInt(count.Value, 10, 64)
+		if err != nil {
+			continue
+		}
+		for _, category := range tree.Path(id) {
+			totals[category.ID] += count.Count
+		}
+	}
+
+	facets := []models.JobFacetValue{}
+	var walk func(categories []*models.JobCategory)
+	walk = func(categories []*models.JobCategory) {
+		for _, category := range categories {
+			if totals[category.ID] == 0 {
+				continue
+			}
+			facet := models.JobFacetValue{
+				Value: strconv.FormatInt(category.ID, 10),
+				Label: category.Name,
+				Count: totals[category.ID],
+			}
+			if category.ParentID != nil {
+				facet.Parent = strconv.FormatInt(*category.ParentID, 10)
+			}
+			facets = append(facets, facet)
+			walk(category.Children)
+		}
+	}
+	walk(tree.Roots)
+	return facets
+}
@@ -0,0 +1,73 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCategories returns IT > Backend > Go, IT > Frontend and Health > Nursing
+func newTestCategories() []*models.JobCategory {
+	id := func(v int64) *int64 { return &v }
+	return []*models.JobCategory{
+		{ID: 1, Name: "IT"},
+		{ID: 2, ParentID: id(1), Name: "Backend"},
+		{ID: 3, ParentID: id(1), Name: "Frontend"},
+		{ID: 4, Name: "Health"},
+		{ID: 5, ParentID: id(4), Name: "Nursing"},
+		{ID: 6, ParentID: id(2), Name: "Go"},
+	}
+}
+
+func TestCategoryTree(t *testing.T) {
+	tree := NewCategoryTree(newTestCategories())
+
+	assert.Len(t, tree.Roots, 2)
+	assert.Equal(t, "Backend", tree.Get(1).Children[0].Name)
+	assert.Equal(t, "Go", tree.Get(2).Children[0].Name)
+	assert.Nil(t, tree.Get(9))
+
+	var names []string
+	for _, category := range tree.Path(6) {
+		names = append(names, category.Name)
+	}
+	assert.Equal(t, []string{"IT", "Backend", "Go"}, names)
+	assert.Empty(t, tree.Path(9))
+
+	assert.True(t, tree.IsWithin(6, 1))
+	assert.True(t, tree.IsWithin(2, 2))
+	assert.False(t, tree.IsWithin(1, 2))
+	assert.False(t, tree.IsWithin(5, 1))
+}
+
+func TestCategoryTreeCycle(t *testing.T) {
+	parentOf := func(v int64) *int64 { return &v }
+	tree := NewCategoryTree([]*models.JobCategory{
+		{ID: 1, ParentID: parentOf(2), Name: "A"},
+		{ID: 2, ParentID: parentOf(1), Name: "B"},
+	})
+
+	assert.Empty(t, tree.Roots)
+	assert.LessOrEqual(t, len(tree.Path(1)), 3)
+}
+
+func TestCategorySlug(t *testing.T) {
+	assert.Equal(t, "sales-marketing", CategorySlug("Sales & Marketing"))
+	assert.Equal(t, "sante-soins-infirmiers", CategorySlug("  Santé / Soins infirmiers "))
+	assert.Empty(t, CategorySlug("&"))
+}
+
+func TestCategoryFacets(t *testing.T) {
+	tree := NewCategoryTree(newTestCategories())
+	counts := []models.JobFacetValue{{Value: "6", Count: 3}, {Value: "3", Count: 2}, {Value: "5", Count: 1}, {Value: "x", Count: 4}}
+
+	assert.Equal(t, []models.JobFacetValue{
+		{Value: "1", Label: "IT", Count: 5},
+		{Value: "2", Label: "Backend", Parent: "1", Count: 3},
+		{Value: "6", Label: "Go", Parent: "2", Count: 3},
+		{Value: "3", Label: "Frontend", Parent: "1", Count: 2},
+		{Value: "4", Label: "Health", Count: 1},
+		{Value: "5", Label: "Nursing", Parent: "4", Count: 1},
+	}, CategoryFacets(counts, tree))
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// salaryBucketBounds are the lower bounds of the salary buckets counted by the
+// search facets, a job falls in a bucket by its minimum salary, or its maximum
+// when it has no minimum
+var salaryBucketBounds = []int{0, 30000, 50000, 80000, 120000, 200000}
+
+// SalaryBucketSQL returns the salary bucket of the jobs table aliased by prefix,
+// such as "30000-50000" or "200000+", NULL for the jobs without a salary. The
+// bounds of a bucket can be sent back as the min_salary and max_salary filters.
+func SalaryBucketSQL(prefix string) string {
+	salary := fmt.Sprintf("COALESCE(%ssalary_min, %ssalary_max)", prefix, prefix)
+	var b strings.Builder
+	b.WriteString("CASE")
+	for i := len(salaryBucketBounds) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, " WHEN %s >= %d THEN '%s'", salary, salaryBucketBounds[i], salaryBucket(i))
+	}
+	b.WriteString(" END")
+	return b.String()
+}
+
+func salaryBucket(i int) string {
+	if i == len(salaryBucketBounds)-1 {
+		return fmt.Sprintf("%d+", salaryBucketBounds[i])
+	}
+	return fmt.Sprintf("%d-%d", salaryBucketBounds[i], salaryBucketBounds[i+1])
+}
+
+// LabelJobFacets names the values of the facets counted by a search and orders
+// them: categories in tree order, salary buckets from the lowest, and the other
+// facets from the most frequent value
+func LabelJobFacets(facets *models.JobFacets, categories *CategoryTree) {
+	facets.Categories = CategoryFacets(facets.Categories, categories)
+
+	for i := range facets.Wilayas {
+		if code, err := strconv.Atoi(facets.Wilayas[i].Value); err == nil {
+			if wilaya := GetWilaya(code); wilaya != nil {
+				facets.Wilayas[i].Label = wilaya.Name.FR
+			}
+		}
+	}
+
+	order := make(map[string]int, len(salaryBucketBounds))
+	for i := range salaryBucketBounds {
+		order[salaryBucket(i)] = i
+	}
+	sort.SliceStable(facets.SalaryBuckets, func(i, j int) bool {
+		return order[facets.SalaryBuckets[i].Value] < order[facets.SalaryBuckets[j].Value]
+	})
+
+	for _, values := range [][]models.JobFacetValue{facets.JobTypes, facets.Wilayas, facets.Statuses} {
+		sort.SliceStable(values, func(i, j int) bool {
+			if values[i].Count != values[j].Count {
+				return values[i].Count > values[j].Count
+			}
+			return values[i].Value < values[j].Value
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSalaryBucketSQL(t *testing.T) {
+	sql := SalaryBucketSQL("j.")
+
+	assert.True(t, strings.HasPrefix(sql, "CASE WHEN COALESCE(j.salary_min, j.salary_max) >= 200000 THEN '200000+'"))
+	assert.Contains(t, sql, "WHEN COALESCE(j.salary_min, j.salary_max) >= 30000 THEN '30000-50000'")
+	assert.True(t, strings.HasSuffix(sql, "THEN '0-30000' END"))
+}
+
+func TestLabelJobFacets(t *testing.T) {
+	facets := &models.JobFacets{
+		Categories:    []models.JobFacetValue{{Value: "5", Count: 2}},
+		JobTypes:      []models.JobFacetValue{{Value: "part-time", Count: 1}, {Value: "full-time", Count: 4}, {Value: "freelance", Count: 1}},
+		Wilayas:       []models.JobFacetValue{{Value: "31", Count: 1}, {Value: "16", Count: 3}},
+		SalaryBuckets: []models.JobFacetValue{{Value: "200000+", Count: 1}, {Value: "0-30000", Count: 2}, {Value: "50000-80000", Count: 5}},
+		Statuses:      []models.JobFacetValue{{Value: "closed", Count: 1}, {Value: "open", Count: 6}},
+	}
+
+	LabelJobFacets(facets, NewCategoryTree(newTestCategories()))
+
+	assert.Equal(t, []models.JobFacetValue{
+		{Value: "4", Label: "Health", Count: 2},
+		{Value: "5", Label: "Nursing", Parent: "4", Count: 2},
+	}, facets.Categories)
+	assert.Equal(t, []models.JobFacetValue{{Value: "full-time", Count: 4}, {Value: "freelance", Count: 1}, {Value: "part-time", Count: 1}}, facets.JobTypes)
+	assert.Equal(t, []models.JobFacetValue{{Value: "16", Label: "Alger", Count: 3}, {Value: "31", Label: "Oran", Count: 1}}, facets.Wilayas)
+	assert.Equal(t, []string{"0-30000", "50000-80000", "200000+"}, []string{facets.SalaryBuckets[0].Value, facets.SalaryBuckets[1].Value, facets.SalaryBuckets[2].Value})
+	assert.Equal(t, "open", facets.Statuses[0].Value)
+}
@@ -0,0 +1,318 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// JobFeedItem is a job listed in a feed along with its public page and the name
+// of the company posting it
+type JobFeedItem struct {
+	Job     *models.Job
+	Company string
+	URL     string
+}
+
+// wilayaName is the French name of the wilaya of a job, if it has one
+func (i JobFeedItem) wilayaName() string {
+	if i.Job.WilayaCode == nil {
+		return ""
+	}
+	if wilaya := GetWilaya(*i.Job.WilayaCode); wilaya != nil {
+		return wilaya.Name.FR
+	}
+	return ""
+}
+
+// JobFeed is the content shared by the RSS, Atom and Indeed feeds
+type JobFeed struct {
+	Title    string
+	Link     string // Public job board page
+	SelfLink string // URL the feed is served from
+	Updated  time.Time
+	Items    []JobFeedItem
+}
+
+// SitemapURL is a page listed in a sitemap
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	Description cdata   `xml:"description"`
+	Category    string  `xml:"category,omitempty"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Links   []atomLink  `xml:"link"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Category  *atomTerm   `xml:"category,omitempty"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomTerm struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type indeedFeed struct {
+	XMLName       xml.Name    `xml:"source"`
+	Publisher     string      `xml:"publisher"`
+	PublisherURL  string      `xml:"publisherurl"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Jobs          []indeedJob `xml:"job"`
+}
+
+type indeedJob struct {
+	Title           cdata  `xml:"title"`
+	Date            cdata  `xml:"date"`
+	ReferenceNumber cdata  `xml:"referencenumber"`
+	URL             cdata  `xml:"url"`
+	Company         cdata  `xml:"company"`
+	City            cdata  `xml:"city"`
+	State           cdata  `xml:"state"`
+	Country         cdata  `xml:"country"`
+	RemoteType      *cdata `xml:"remotetype,omitempty"`
+	Description     cdata  `xml:"description"`
+	Salary          cdata  `xml:"salary"`
+	JobType         cdata  `xml:"jobtype"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// indeedJobTypes maps the job types to the ones Indeed understands
+var indeedJobTypes = map[string]string{
+	"full-time": "fulltime",
+	"part-time": "parttime",
+	"freelance": "contract",
+}
+
+// indeedRemoteTypes maps the work modes to Indeed remote types, on site jobs have none
+var indeedRemoteTypes = map[string]string{
+	"remote": "Fully remote",
+	"hybrid": "Hybrid remote",
+}
+
+var salaryPeriods = map[string]string{
+	"monthly": "per month",
+	"yearly":  "per year",
+	"hourly":  "per hour",
+}
+
+// RenderJobFeed renders feed as an RSS 2.0 ("rss"), Atom ("atom") or
+// Indeed-style ("indeed") XML document
+func RenderJobFeed(format string, feed JobFeed) ([]byte, error) {
+	var document interface{}
+	switch format {
+	case "rss":
+		document = toRSSFeed(feed)
+	case "atom":
+		document = toAtomFeed(feed)
+	case "indeed":
+		document = toIndeedFeed(feed)
+	default:
+		return nil, fmt.Errorf("unsupported feed format %q", format)
+	}
+	return marshalXML(document)
+}
+
+// RenderSitemap renders urls as a sitemaps.org urlset
+func RenderSitemap(urls []SitemapURL) ([]byte, error) {
+	urlSet := sitemapURLSet{URLs: make([]sitemapURL, 0, len(urls))}
+	for _, url := range urls {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{Loc: url.Loc, LastMod: url.LastMod.UTC().Format(time.RFC3339)})
+	}
+	return marshalXML(urlSet)
+}
+
+// JobPublishedAt is when a job was last opened, jobs published before the
+// scheduling columns existed fall back to their creation time
+func JobPublishedAt(job *models.Job) time.Time {
+	if job.PublishedAt != nil {
+		return *job.PublishedAt
+	}
+	return job.CreatedAt
+}
+
+// FormatJobSalary describes the salary range of a job, such as
+// "50000 - 80000 DZD per month", or returns an empty string if it has none
+func FormatJobSalary(job *models.Job) string {
+	var salary string
+	switch {
+	case job.SalaryMin != nil && job.SalaryMax != nil:
+		salary = fmt.Sprintf("%.0f - %.0f", *job.SalaryMin, *job.SalaryMax)
+	case job.SalaryMin != nil:
+		salary = fmt.Sprintf("from %.0f", *job.SalaryMin)
+	case job.SalaryMax != nil:
+		salary = fmt.Sprintf("up to %.0f", *job.SalaryMax)
+	default:
+		return ""
+	}
+	if job.Currency != "" {
+		salary += " " + job.Currency
+	}
+	if period, ok := salaryPeriods[job.Period]; ok {
+		salary += " " + period
+	}
+	return salary
+}
+
+func toRSSFeed(feed JobFeed) rssFeed {
+	channel := rssChannel{
+		Title:       feed.Title,
+		Link:        feed.Link,
+		Description: "The latest open jobs on " + feed.Title,
+		Items:       make([]rssItem, 0, len(feed.Items)),
+	}
+	if !feed.Updated.IsZero() {
+		channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, item := range feed.Items {
+		channel.Items = append(channel.Items, rssItem{
+			Title:       item.Job.Title,
+			Link:        item.URL,
+			GUID:        rssGUID{Value: item.URL, IsPermaLink: true},
+			Description: cdata{item.Job.Description},
+			Category:    item.Job.JobType,
+			PubDate:     JobPublishedAt(item.Job).UTC().Format(time.RFC1123Z),
+		})
+	}
+	return rssFeed{Version: "2.0", Channel: channel}
+}
+
+func toAtomFeed(feed JobFeed) atomFeed {
+	updated := feed.Updated
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	atom := atomFeed{
+		Title:   feed.Title,
+		ID:      feed.SelfLink,
+		Links:   []atomLink{{Href: feed.SelfLink, Rel: "self"}, {Href: feed.Link, Rel: "alternate"}},
+		Updated: updated.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: feed.Title},
+		Entries: make([]atomEntry, 0, len(feed.Items)),
+	}
+	for _, item := range feed.Items {
+		entry := atomEntry{
+			Title:     item.Job.Title,
+			ID:        item.URL,
+			Link:      atomLink{Href: item.URL, Rel: "alternate"},
+			Published: JobPublishedAt(item.Job).UTC().Format(time.RFC3339),
+			Updated:   item.Job.UpdatedAt.UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "text", Value: item.Job.Description},
+		}
+		if item.Company != "" {
+			entry.Author = &atomAuthor{Name: item.Company}
+		}
+		if item.Job.JobType != "" {
+			entry.Category = &atomTerm{Term: item.Job.JobType}
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+	return atom
+}
+
+func toIndeedFeed(feed JobFeed) indeedFeed {
+	indeed := indeedFeed{
+		Publisher:    feed.Title,
+		PublisherURL: feed.Link,
+		Jobs:         make([]indeedJob, 0, len(feed.Items)),
+	}
+	if !feed.Updated.IsZero() {
+		indeed.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, item := range feed.Items {
+		job := indeedJob{
+			Title:           cdata{item.Job.Title},
+			Date:            cdata{JobPublishedAt(item.Job).UTC().Format(time.RFC1123Z)},
+			ReferenceNumber: cdata{fmt.Sprintf("%d", item.Job.ID)},
+			URL:             cdata{item.URL},
+			Company:         cdata{item.Company},
+			City:            cdata{item.Job.Location},
+			State:           cdata{item.wilayaName()},
+			Country:         cdata{"DZ"},
+			Description:     cdata{item.Job.Description},
+			Salary:          cdata{FormatJobSalary(item.Job)},
+			JobType:         cdata{indeedJobTypes[item.Job.JobType]},
+		}
+		if remoteType, ok := indeedRemoteTypes[item.Job.WorkMode]; ok {
+			job.RemoteType = &cdata{remoteType}
+		}
+		indeed.Jobs = append(indeed.Jobs, job)
+	}
+	return indeed
+}
+
+func marshalXML(document interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
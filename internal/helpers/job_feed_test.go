@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJobFeed() JobFeed {
+	publishedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	salaryMin, salaryMax := 80000.0, 120000.0
+	wilayaCode := 16
+	return JobFeed{
+		Title:    "Dz Jobs",
+		Link:     "https://dzjobs.example/jobs",
+		SelfLink: "https://api.dzjobs.example/v1/jobs/feed.atom",
+		Updated:  publishedAt.Add(time.Hour),
+		Items: []JobFeedItem{{
+			Job: &models.Job{
+				ID:          7,
+				Title:       "Go developer",
+				Description: "Build <fast> APIs & services",
+				Location:    "Alger Centre",
+				SalaryMin:   &salaryMin,
+				SalaryMax:   &salaryMax,
+				Currency:    "DZD",
+				Period:      "monthly",
+				JobType:     "freelance",
+				WorkMode:    "hybrid",
+				WilayaCode:  &wilayaCode,
+				CreatedAt:   publishedAt.Add(-24 * time.Hour),
+				UpdatedAt:   publishedAt,
+				PublishedAt: &publishedAt,
+			},
+			Company: "Yassir",
+			URL:     "https://dzjobs.example/jobs/7",
+		}},
+	}
+}
+
+func TestRenderJobFeed(t *testing.T) {
+	feed := newTestJobFeed()
+
+	t.Run("RSS", func(t *testing.T) {
+		body, err := RenderJobFeed("rss", feed)
+		require.NoError(t, err)
+
+		var rss rssFeed
+		require.NoError(t, xml.Unmarshal(body, &rss))
+		assert.Equal(t, "2.0", rss.Version)
+		assert.Equal(t, "Fri, 01 Mar 2024 10:00:00 +0000", rss.Channel.LastBuildDate)
+		require.Len(t, rss.Channel.Items, 1)
+		assert.Equal(t, "https://dzjobs.example/jobs/7", rss.Channel.Items[0].GUID.Value)
+		assert.Equal(t, "Fri, 01 Mar 2024 09:00:00 +0000", rss.Channel.Items[0].PubDate)
+		assert.Contains(t, string(body), "<![CDATA[Build <fast> APIs & services]]>")
+	})
+
+	t.Run("Atom", func(t *testing.T) {
+		body, err := RenderJobFeed("atom", feed)
+		require.NoError(t, err)
+
+		var atom atomFeed
+		require.NoError(t, xml.Unmarshal(body, &atom))
+		assert.Equal(t, feed.SelfLink, atom.ID)
+		assert.Equal(t, "2024-03-01T10:00:00Z", atom.Updated)
+		require.Len(t, atom.Entries, 1)
+		assert.Equal(t, "Yassir", atom.Entries[0].Author.Name)
+		assert.Equal(t, "2024-03-01T09:00:00Z", atom.Entries[0].Published)
+		assert.Equal(t, "freelance", atom.Entries[0].Category.Term)
+	})
+
+	t.Run("Indeed", func(t *testing.T) {
+		body, err := RenderJobFeed("indeed", feed)
+		require.NoError(t, err)
+
+		var indeed indeedFeed
+		require.NoError(t, xml.Unmarshal(body, &indeed))
+		require.Len(t, indeed.Jobs, 1)
+		job := indeed.Jobs[0]
+		assert.Equal(t, "7", job.ReferenceNumber.Value)
+		assert.Equal(t, "Alger", job.State.Value)
+		assert.Equal(t, "contract", job.JobType.Value)
+		assert.Equal(t, "Hybrid remote", job.RemoteType.Value)
+		assert.Equal(t, "80000 - 120000 DZD per month", job.Salary.Value)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		_, err := RenderJobFeed("json", feed)
+		assert.Error(t, err)
+	})
+}
+
+func TestRenderSitemap(t *testing.T) {
+	body, err := RenderSitemap([]SitemapURL{{Loc: "https://dzjobs.example/jobs/7", LastMod: time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))}})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(body), xml.Header))
+	assert.Contains(t, string(body), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, string(body), "<lastmod>2024-03-01T09:00:00Z</lastmod>")
+}
+
+func TestFormatJobSalary(t *testing.T) {
+	salaryMin, salaryMax := 50000.0, 80000.0
+
+	assert.Equal(t, "50000 - 80000 DZD per month", FormatJobSalary(&models.Job{SalaryMin: &salaryMin, SalaryMax: &salaryMax, Currency: "DZD", Period: "monthly"}))
+	assert.Equal(t, "from 50000 EUR per year", FormatJobSalary(&models.Job{SalaryMin: &salaryMin, Currency: "EUR", Period: "yearly"}))
+	assert.Equal(t, "up to 80000", FormatJobSalary(&models.Job{SalaryMax: &salaryMax}))
+	assert.Empty(t, FormatJobSalary(&models.Job{Currency: "DZD"}))
+}
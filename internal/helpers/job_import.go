@@ -0,0 +1,251 @@
+package helpers
+
+import (
+	"bufio"
+	"dz-jobs-api/internal/dto/request"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxJobImportRows is the number of jobs a single import file can hold
+const MaxJobImportRows = 500
+
+// maxJobImportLine bounds the size of one JSON Lines record
+const maxJobImportLine = 1 << 20
+
+// JobImportRow is a job read from an import file, along with the errors that
+// keep it from being posted. CSV imports read the columns matching a
+// PostNewJobRequest field and ignore the others, so that an export can be
+// imported back.
+type JobImportRow struct {
+	Line    int
+	Request request.PostNewJobRequest
+	Errors  []string
+}
+
+// jobImportSetters parse a CSV value into the matching PostNewJobRequest field
+var jobImportSetters = map[string]func(req *request.PostNewJobRequest, value string) error{
+	"title":           func(req *request.PostNewJobRequest, value string) error { req.Title = value; return nil },
+	"description":     func(req *request.PostNewJobRequest, value string) error { req.Description = value; return nil },
+	"location":        func(req *request.PostNewJobRequest, value string) error { req.Location = value; return nil },
+	"currency":        func(req *request.PostNewJobRequest, value string) error { req.Currency = value; return nil },
+	"period":          func(req *request.PostNewJobRequest, value string) error { req.Period = value; return nil },
+	"required_skills": func(req *request.PostNewJobRequest, value string) error { req.RequiredSkills = value; return nil },
+	"status":          func(req *request.PostNewJobRequest, value string) error { req.Status = value; return nil },
+	"job_type":        func(req *request.PostNewJobRequest, value string) error { req.JobType = value; return nil },
+	"work_mode":       func(req *request.PostNewJobRequest, value string) error { req.WorkMode = value; return nil },
+	"salary_min": func(req *request.PostNewJobRequest, value string) (err error) {
+		req.SalaryMin, err = parseOptionalFloat(value)
+		return
+	},
+	"salary_max": func(req *request.PostNewJobRequest, value string) (err error) {
+		req.SalaryMax, err = parseOptionalFloat(value)
+		return
+	},
+	"wilaya_code": func(req *request.PostNewJobRequest, value string) (err error) {
+		req.WilayaCode, err = parseOptionalInt(value)
+		return
+	},
+	"commune_code": func(req *request.PostNewJobRequest, value string) (err error) {
+		req.CommuneCode, err = parseOptionalInt(value)
+		return
+	},
+	"category_id": func(req *request.PostNewJobRequest, value string) (err error) {
+		req.CategoryID, err = parseOptionalInt64(value)
+		return
+	},
+	"publish_at": func(req *request.PostNewJobRequest, value string) (err error) {
+		req.PublishAt, err = parseOptionalTime(value)
+		return
+	},
+	"expires_at": func(req *request.PostNewJobRequest, value string) (err error) {
+		req.ExpiresAt, err = parseOptionalTime(value)
+		return
+	},
+}
+
+// JobImportFormat returns the format of an import file, given explicitly or
+// guessed from the file name
+func JobImportFormat(format, filename string) (string, error) {
+	if format != "" {
+		return format, nil
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv", nil
+	case ".jsonl", ".ndjson":
+		return "jsonl", nil
+	}
+	return "", errors.New("cannot tell the format of the file, use a .csv or .jsonl file or set the format parameter")
+}
+
+// ParseJobImport reads the jobs of a CSV or JSON Lines file and validates each of
+// them against the PostNewJobRequest binding rules. An error is only returned when
+// the file as a whole cannot be read, row errors are reported on the rows.
+func ParseJobImport(file io.Reader, format string) ([]JobImportRow, error) {
+	var rows []JobImportRow
+	var err error
+	switch format {
+	case "csv":
+		rows, err = parseJobImportCSV(file)
+	case "jsonl":
+		rows, err = parseJobImportJSONLines(file)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("the file does not contain any job")
+	}
+	return rows, nil
+}
+
+// validateJobImportRow checks the request of a row against its binding rules
+func validateJobImportRow(row *JobImportRow) {
+	err := binding.Validator.ValidateStruct(&row.Request)
+	if err == nil {
+		return
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		row.Errors = append(row.Errors, err.Error())
+		return
+	}
+	for _, e := range validationErrors {
+		row.Errors = append(row.Errors, fmt.Sprintf("Field: %s, Error: %s", e.Field(), e.Tag()))
+	}
+}
+
+func parseJobImportCSV(file io.Reader) ([]JobImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the file does not contain any job")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+
+	var rows []JobImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		if len(rows) == MaxJobImportRows {
+			return nil, fmt.Errorf("an import cannot hold more than %d jobs", MaxJobImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := JobImportRow{Line: line}
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("expected %d columns, got %d", len(header), len(record)))
+			rows = append(rows, row)
+			continue
+		}
+		for i, value := range record {
+			setter, ok := jobImportSetters[header[i]]
+			if !ok {
+				continue
+			}
+			if err := setter(&row.Request, strings.TrimSpace(value)); err != nil {
+				row.Errors = append(row.Errors, fmt.Sprintf("Field: %s, Error: %s", header[i], err.Error()))
+			}
+		}
+		validateJobImportRow(&row)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseJobImportJSONLines(file io.Reader) ([]JobImportRow, error) {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxJobImportLine)
+
+	var rows []JobImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if len(rows) == MaxJobImportRows {
+			return nil, fmt.Errorf("an import cannot hold more than %d jobs", MaxJobImportRows)
+		}
+
+		row := JobImportRow{Line: line}
+		if err := json.Unmarshal([]byte(text), &row.Request); err != nil {
+			row.Errors = append(row.Errors, "invalid JSON: "+err.Error())
+		} else {
+			validateJobImportRow(&row)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid JSON Lines file: %w", err)
+	}
+	return rows, nil
+}
+
+func parseOptionalFloat(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, errors.New("not a number")
+	}
+	return &number, nil
+}
+
+func parseOptionalInt(value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, errors.New("not a whole number")
+	}
+	return &number, nil
+}
+
+func parseOptionalInt64(value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, errors.New("not a whole number")
+	}
+	return &number, nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.New("not an RFC 3339 date")
+	}
+	return &parsed, nil
+}
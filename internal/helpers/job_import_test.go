@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobImportFormat(t *testing.T) {
+	format, err := JobImportFormat("", "jobs.CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, "csv", format)
+
+	format, err = JobImportFormat("", "jobs.ndjson")
+	assert.NoError(t, err)
+	assert.Equal(t, "jsonl", format)
+
+	format, err = JobImportFormat("jsonl", "jobs.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "jsonl", format)
+
+	_, err = JobImportFormat("", "jobs.xlsx")
+	assert.Error(t, err)
+}
+
+func TestParseJobImport(t *testing.T) {
+	t.Run("CSV rows checked one by one", func(t *testing.T) {
+		file := "\ufefftitle,description,status,job_type,salary_min,job_id\n" +
+			"Go developer,Build APIs,open,full-time,80000,12\n" +
+			"Designer,Design screens,archived,full-time,,13\n" +
+			"Tester,Test the API,open,full-time,a lot,14\n" +
+			"Too short,open\n"
+
+		rows, err := ParseJobImport(strings.NewReader(file), "csv")
+		assert.NoError(t, err)
+		if assert.Len(t, rows, 4) {
+			assert.Equal(t, 2, rows[0].Line)
+			assert.Empty(t, rows[0].Errors)
+			assert.Equal(t, "Go developer", rows[0].Request.Title)
+			assert.Equal(t, 80000.0, *rows[0].Request.SalaryMin)
+			assert.Equal(t, []string{"Field: Status, Error: oneof"}, rows[1].Errors)
+			assert.Equal(t, []string{"Field: salary_min, Error: not a number"}, rows[2].Errors)
+			assert.Equal(t, []string{"expected 6 columns, got 2"}, rows[3].Errors)
+		}
+	})
+
+	t.Run("JSON Lines rows keep their line numbers", func(t *testing.T) {
+		file := `{"title":"Go developer","description":"Build APIs","status":"draft","job_type":"freelance"}` + "\n\n" +
+			`{"title":"Designer"` + "\n"
+
+		rows, err := ParseJobImport(strings.NewReader(file), "jsonl")
+		assert.NoError(t, err)
+		if assert.Len(t, rows, 2) {
+			assert.Equal(t, 1, rows[0].Line)
+			assert.Empty(t, rows[0].Errors)
+			assert.Equal(t, 3, rows[1].Line)
+			assert.Len(t, rows[1].Errors, 1)
+			assert.Contains(t, rows[1].Errors[0], "invalid JSON")
+		}
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		_, err := ParseJobImport(strings.NewReader("title,description\n"), "csv")
+		assert.EqualError(t, err, "the file does not contain any job")
+	})
+
+	t.Run("Too many rows", func(t *testing.T) {
+		file := "title\n" + strings.Repeat("Go developer\n", MaxJobImportRows+1)
+
+		_, err := ParseJobImport(strings.NewReader(file), "csv")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,165 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Weights of the matching criteria, criteria that do not apply to a job or a
+// candidate are left out and the score is scaled back to 100
+const (
+	skillsMatchWeight     = 50
+	experienceMatchWeight = 25
+	locationMatchWeight   = 15
+	jobTypeMatchWeight    = 10
+	// nearbyDistanceKm is the distance between two wilayas past which a candidate
+	// living in the other one scores nothing for the location
+	nearbyDistanceKm = 150
+)
+
+var accentFolder = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u",
+)
+
+// titleStopWords are left out when comparing job titles
+var titleStopWords = map[string]bool{
+	"and": true, "the": true, "for": true, "with": true,
+	"des": true, "les": true, "pour": true, "avec": true, "une": true,
+}
+
+// ScoreJobMatch scores a candidate profile against a job requiring jobSkills.
+// preferredJobType is optional, the job type criterion only applies when it is set.
+func ScoreJobMatch(profile *models.CandidateProfile, job *models.Job, jobSkills []models.Skill, preferredJobType string) models.MatchScore {
+	match := models.MatchScore{MatchedSkills: []string{}, MissingSkills: []string{}}
+
+	if len(jobSkills) > 0 {
+		candidateSkills := map[int64]bool{}
+		for _, skill := range profile.Skills {
+			candidateSkills[skill.ID] = true
+		}
+		for _, skill := range jobSkills {
+			if candidateSkills[skill.ID] {
+				match.MatchedSkills = append(match.MatchedSkills, skill.Name)
+			} else {
+				match.MissingSkills = append(match.MissingSkills, skill.Name)
+			}
+		}
+		match.Breakdown = append(match.Breakdown, models.MatchCriterion{
+			Criterion: "skills",
+			Score:     skillsMatchWeight * float64(len(match.MatchedSkills)) / float64(len(jobSkills)),
+			MaxScore:  skillsMatchWeight,
+			Detail:    fmt.Sprintf("matched %d/%d required skills", len(match.MatchedSkills), len(jobSkills)),
+		})
+	}
+
+	if titleWords := matchWords(job.Title); len(titleWords) > 0 {
+		criterion := models.MatchCriterion{Criterion: "experience", MaxScore: experienceMatchWeight, Detail: "no related experience"}
+		best := 0
+		for _, title := range profile.ExperienceTitles {
+			common := 0
+			for _, word := range matchWords(title) {
+				if slices.Contains(titleWords, word) {
+					common++
+				}
+			}
+			if common > best {
+				best = common
+				criterion.Detail = fmt.Sprintf("experience as %q matches %d/%d job title keywords", title, common, len(titleWords))
+			}
+		}
+		criterion.Score = experienceMatchWeight * math.Min(1, float64(best)/float64(len(titleWords)))
+		match.Breakdown = append(match.Breakdown, criterion)
+	}
+
+	if job.WorkMode == "remote" {
+		match.Breakdown = append(match.Breakdown, models.MatchCriterion{
+			Criterion: "location", Score: locationMatchWeight, MaxScore: locationMatchWeight, Detail: "remote job",
+		})
+	} else if job.WilayaCode != nil && profile.WilayaCode != nil {
+		match.Breakdown = append(match.Breakdown, scoreWilayaMatch(profile, job))
+	} else if locationWords := matchWords(job.Location); len(locationWords) > 0 {
+		criterion := models.MatchCriterion{Criterion: "location", MaxScore: locationMatchWeight}
+		addressWords := matchWords(profile.Address)
+		switch {
+		case len(addressWords) == 0:
+			criterion.Detail = "candidate address unknown"
+		case overlaps(locationWords, addressWords):
+			criterion.Score = locationMatchWeight
+			criterion.Detail = fmt.Sprintf("candidate lives in %s", job.Location)
+		default:
+			criterion.Detail = fmt.Sprintf("job is located in %s", job.Location)
+		}
+		match.Breakdown = append(match.Breakdown, criterion)
+	}
+
+	if preferredJobType != "" && job.JobType != "" {
+		criterion := models.MatchCriterion{Criterion: "job_type", MaxScore: jobTypeMatchWeight}
+		if job.JobType == preferredJobType {
+			criterion.Score = jobTypeMatchWeight
+			criterion.Detail = fmt.Sprintf("%s job as preferred", job.JobType)
+		} else {
+			criterion.Detail = fmt.Sprintf("%s job, %s preferred", job.JobType, preferredJobType)
+		}
+		match.Breakdown = append(match.Breakdown, criterion)
+	}
+
+	var score, maxScore float64
+	for _, criterion := range match.Breakdown {
+		score += criterion.Score
+		maxScore += criterion.MaxScore
+	}
+	if maxScore > 0 {
+		match.Score = int(math.Round(score / maxScore * 100))
+	}
+	return match
+}
+
+// scoreWilayaMatch scores the location of a candidate living in the wilaya of the
+// job, or in a wilaya close enough for the distance between their centroids to be
+// under nearbyDistanceKm
+func scoreWilayaMatch(profile *models.CandidateProfile, job *models.Job) models.MatchCriterion {
+	criterion := models.MatchCriterion{Criterion: "location", MaxScore: locationMatchWeight}
+	jobWilaya, candidateWilaya := GetWilaya(*job.WilayaCode), GetWilaya(*profile.WilayaCode)
+	if jobWilaya == nil || candidateWilaya == nil {
+		criterion.Detail = "candidate location unknown"
+		return criterion
+	}
+	if jobWilaya.Code == candidateWilaya.Code {
+		criterion.Score = locationMatchWeight
+		criterion.Detail = fmt.Sprintf("candidate lives in the wilaya of %s", jobWilaya.Name.FR)
+		return criterion
+	}
+	distance := DistanceKm(candidateWilaya.Latitude, candidateWilaya.Longitude, jobWilaya.Latitude, jobWilaya.Longitude)
+	criterion.Score = locationMatchWeight * math.Max(0, 1-distance/nearbyDistanceKm)
+	criterion.Detail = fmt.Sprintf("candidate lives in %s, %.0f km from %s", candidateWilaya.Name.FR, distance, jobWilaya.Name.FR)
+	return criterion
+}
+
+// matchWords splits text into lower case, accent free words of at least three
+// letters, leaving out stop words
+func matchWords(text string) []string {
+	fields := strings.FieldsFunc(accentFolder.Replace(strings.ToLower(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var words []string
+	for _, word := range fields {
+		if len(word) >= 3 && !titleStopWords[word] && !slices.Contains(words, word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func overlaps(a, b []string) bool {
+	for _, word := range a {
+		if slices.Contains(b, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreJobMatch(t *testing.T) {
+	goSkill, sqlSkill := models.Skill{ID: 1, Name: "Go"}, models.Skill{ID: 2, Name: "PostgreSQL"}
+
+	t.Run("Skills and experience", func(t *testing.T) {
+		profile := &models.CandidateProfile{Skills: []models.Skill{goSkill}, ExperienceTitles: []string{"Développeur Backend"}}
+		job := &models.Job{Title: "Developpeur backend Go", WorkMode: "remote"}
+
+		match := ScoreJobMatch(profile, job, []models.Skill{goSkill, sqlSkill}, "")
+		assert.Equal(t, []string{"Go"}, match.MatchedSkills)
+		assert.Equal(t, []string{"PostgreSQL"}, match.MissingSkills)
+		// 25/50 for the skills, 25/25 for the accent free title keywords ("Go" is too
+		// short to count) and 15/15 for a remote job
+		assert.Equal(t, 72, match.Score)
+		assert.Len(t, match.Breakdown, 3)
+	})
+
+	t.Run("Criteria that do not apply are left out", func(t *testing.T) {
+		profile := &models.CandidateProfile{Skills: []models.Skill{goSkill}}
+		job := &models.Job{Title: "Go", JobType: "full-time"}
+
+		match := ScoreJobMatch(profile, job, []models.Skill{goSkill}, "full-time")
+		assert.Equal(t, 100, match.Score)
+	})
+
+	t.Run("Same wilaya", func(t *testing.T) {
+		algiers := 16
+		profile := &models.CandidateProfile{WilayaCode: &algiers}
+		job := &models.Job{WilayaCode: &algiers, WorkMode: "onsite"}
+
+		match := ScoreJobMatch(profile, job, nil, "")
+		assert.Equal(t, 100, match.Score)
+	})
+
+	t.Run("Distant wilaya", func(t *testing.T) {
+		algiers, tamanrasset := 16, 11
+		profile := &models.CandidateProfile{WilayaCode: &tamanrasset}
+		job := &models.Job{WilayaCode: &algiers, WorkMode: "onsite"}
+
+		match := ScoreJobMatch(profile, job, nil, "")
+		assert.Equal(t, 0, match.Score)
+	})
+}
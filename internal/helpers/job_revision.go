@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"reflect"
+	"strings"
+)
+
+// JobSnapshotOf returns the content of job kept by its revisions
+func JobSnapshotOf(job *models.Job) models.JobSnapshot {
+	return models.JobSnapshot{
+		Title:          job.Title,
+		Description:    job.Description,
+		Location:       job.Location,
+		SalaryMin:      job.SalaryMin,
+		SalaryMax:      job.SalaryMax,
+		Currency:       job.Currency,
+		Period:         job.Period,
+		RequiredSkills: job.RequiredSkills,
+		JobType:        job.JobType,
+		WilayaCode:     job.WilayaCode,
+		CommuneCode:    job.CommuneCode,
+		WorkMode:       job.WorkMode,
+		CategoryID:     job.CategoryID,
+	}
+}
+
+// ApplyJobSnapshot overwrites the content of job with snapshot, the coordinates
+// of the job follow its restored wilaya and commune. Snapshots taken when remote
+// was still a job type restore as full-time remote jobs.
+func ApplyJobSnapshot(job *models.Job, snapshot models.JobSnapshot) error {
+	if snapshot.JobType == "remote" {
+		snapshot.JobType, snapshot.WorkMode = "full-time", "remote"
+	}
+	job.Title = snapshot.Title
+	job.Description = snapshot.Description
+	job.Location = snapshot.Location
+	job.SalaryMin = snapshot.SalaryMin
+	job.SalaryMax = snapshot.SalaryMax
+	job.Currency = snapshot.Currency
+	job.Period = snapshot.Period
+	job.RequiredSkills = snapshot.RequiredSkills
+	job.JobType = snapshot.JobType
+	job.WilayaCode = snapshot.WilayaCode
+	job.CommuneCode = snapshot.CommuneCode
+	if snapshot.WorkMode != "" {
+		job.WorkMode = snapshot.WorkMode
+	}
+	job.CategoryID = snapshot.CategoryID
+	return LocateJob(job)
+}
+
+// DiffJobSnapshots lists the fields changed from one snapshot to the other, named
+// after their JSON keys
+func DiffJobSnapshots(from, to models.JobSnapshot) []models.JobFieldChange {
+	changes := []models.JobFieldChange{}
+	fromValue, toValue := reflect.ValueOf(from), reflect.ValueOf(to)
+	for i := 0; i < fromValue.NumField(); i++ {
+		a, b := fromValue.Field(i).Interface(), toValue.Field(i).Interface()
+		if reflect.DeepEqual(a, b) {
+			continue
+		}
+		field := strings.Split(fromValue.Type().Field(i).Tag.Get("json"), ",")[0]
+		changes = append(changes, models.JobFieldChange{Field: field, From: a, To: b})
+	}
+	return changes
+}
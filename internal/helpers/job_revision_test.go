@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyJobSnapshot(t *testing.T) {
+	t.Run("Remote job type restored as a work mode", func(t *testing.T) {
+		job := &models.Job{JobType: "part-time", WorkMode: "onsite"}
+
+		err := ApplyJobSnapshot(job, models.JobSnapshot{Title: "Go developer", JobType: "remote"})
+		assert.NoError(t, err)
+		assert.Equal(t, "full-time", job.JobType)
+		assert.Equal(t, "remote", job.WorkMode)
+	})
+
+	t.Run("Work mode kept when the snapshot has none", func(t *testing.T) {
+		job := &models.Job{JobType: "part-time", WorkMode: "hybrid"}
+
+		err := ApplyJobSnapshot(job, models.JobSnapshot{Title: "Go developer", JobType: "freelance"})
+		assert.NoError(t, err)
+		assert.Equal(t, "freelance", job.JobType)
+		assert.Equal(t, "hybrid", job.WorkMode)
+	})
+}
+
+func TestDiffJobSnapshots(t *testing.T) {
+	salaryMin, otherMin := 80000.0, 90000.0
+	wilayaCode := 16
+	from := models.JobSnapshot{Title: "Go developer", SalaryMin: &salaryMin, JobType: "full-time"}
+	to := models.JobSnapshot{Title: "Senior Go developer", SalaryMin: &otherMin, JobType: "full-time", WilayaCode: &wilayaCode}
+
+	assert.Equal(t, []models.JobFieldChange{
+		{Field: "title", From: "Go developer", To: "Senior Go developer"},
+		{Field: "salary_min", From: &salaryMin, To: &otherMin},
+		{Field: "wilaya_code", From: (*int)(nil), To: &wilayaCode},
+	}, DiffJobSnapshots(from, to))
+
+	sameMin := salaryMin
+	from.SalaryMin = &sameMin
+	assert.Empty(t, DiffJobSnapshots(from, models.JobSnapshot{Title: "Go developer", SalaryMin: &salaryMin, JobType: "full-time"}))
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import "fmt"
+
+// searchConfigs are the text search configurations jobs are indexed with, see the
+// search_vector column of the jobs table
+var searchConfigs = []string{"french", "english", "simple"}
+
+// JobSearchQuery returns the tsquery matching the websearch_to_tsquery syntax
+// keyword passed as parameter $paramCount in every configuration jobs are indexed with
+func JobSearchQuery(paramCount int) string {
+	query := ""
+	for i, config := range searchConfigs {
+		if i > 0 {
+			query += " || "
+		}
+		query += fmt.Sprintf("websearch_to_tsquery('%s', $%d)", config, paramCount)
+	}
+	return "(" + query + ")"
+}
+
+// JobSearchRank returns the relevance of the job matched by tsquery
+func JobSearchRank(prefix, tsquery string) string {
+	return fmt.Sprintf("ts_rank(%ssearch_vector, %s)", prefix, tsquery)
+}
+
+// JobSearchSnippet returns the description fragments matched by tsquery, with
+// the matching words wrapped in <mark> tags
+func JobSearchSnippet(prefix, tsquery string) string {
+	return fmt.Sprintf(
+		`ts_headline('french', coalesce(%sdescription, ''), %s, 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" ... "')`,
+		prefix, tsquery,
+	)
+}
@@ -0,0 +1,260 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// earthRadiusKm is the mean radius of the Earth used by the distance computations
+const earthRadiusKm = 6371.0
+
+// algeriaLocations is the reference list of the wilayas and their communes, with
+// their official codes, names in French, Arabic and English and their centroids
+//
+//go:embed data/algeria_locations.json
+var algeriaLocations []byte
+
+var (
+	wilayas        []models.Wilaya
+	wilayasByCode  = map[int]*models.Wilaya{}
+	communesByCode = map[int]*models.Commune{}
+	wilayaNames    = map[string]*models.Wilaya{}
+	communeNames   = map[string][]*models.Commune{}
+)
+
+// arabicFolder folds the spelling variants of Arabic letters commonly mixed up
+var arabicFolder = strings.NewReplacer("أ", "ا", "إ", "ا", "آ", "ا", "ة", "ه", "ى", "ي")
+
+func init() {
+	if err := json.Unmarshal(algeriaLocations, &wilayas); err != nil {
+		panic(fmt.Sprintf("helpers: invalid location dataset: %v", err))
+	}
+	for i := range wilayas {
+		wilaya := &wilayas[i]
+		wilayasByCode[wilaya.Code] = wilaya
+		for _, name := range append([]string{wilaya.Name.FR, wilaya.Name.AR, wilaya.Name.EN}, wilaya.Aliases...) {
+			wilayaNames[normalizeLocationName(name)] = wilaya
+		}
+		for j := range wilaya.Communes {
+			commune := &wilaya.Communes[j]
+			commune.WilayaCode = wilaya.Code
+			communesByCode[commune.Code] = commune
+			for _, name := range []string{commune.Name.FR, commune.Name.AR, commune.Name.EN} {
+				key := normalizeLocationName(name)
+				if len(communeNames[key]) == 0 || communeNames[key][len(communeNames[key])-1] != commune {
+					communeNames[key] = append(communeNames[key], commune)
+				}
+			}
+		}
+	}
+}
+
+// LocationError is returned for a wilaya, commune or radius a search or a profile
+// cannot use
+type LocationError struct {
+	Reason string
+}
+
+func (e *LocationError) Error() string {
+	return "invalid location: " + e.Reason
+}
+
+// LocationFilter is the area a job search is restricted to, either a wilaya, a
+// commune, or the jobs within RadiusKm of the centroid of one of them
+type LocationFilter struct {
+	Wilaya   *models.Wilaya
+	Commune  *models.Commune
+	RadiusKm float64
+}
+
+// Center is the centroid the radius of the filter is measured from
+func (f *LocationFilter) Center() (float64, float64) {
+	if f.Commune != nil {
+		return f.Commune.Latitude, f.Commune.Longitude
+	}
+	return f.Wilaya.Latitude, f.Wilaya.Longitude
+}
+
+// Wilayas returns all the wilayas, ordered by code
+func Wilayas() []models.Wilaya {
+	return wilayas
+}
+
+// GetWilaya returns the wilaya with the given code, or nil if there is none
+func GetWilaya(code int) *models.Wilaya {
+	return wilayasByCode[code]
+}
+
+// GetCommune returns the commune with the given code, or nil if there is none
+func GetCommune(code int) *models.Commune {
+	return communesByCode[code]
+}
+
+// FindWilaya returns the wilaya given by its code or by one of its names, in
+// French, Arabic or English, ignoring case, accents and punctuation
+func FindWilaya(query string) *models.Wilaya {
+	query = strings.TrimSpace(query)
+	if code, err := strconv.Atoi(query); err == nil {
+		return GetWilaya(code)
+	}
+	return wilayaNames[normalizeLocationName(query)]
+}
+
+// FindCommune returns the commune given by its code or by one of its names. When
+// wilayaCode is set only the communes of that wilaya are searched.
+func FindCommune(query string, wilayaCode int) *models.Commune {
+	query = strings.TrimSpace(query)
+	if code, err := strconv.Atoi(query); err == nil {
+		commune := GetCommune(code)
+		if commune == nil || (wilayaCode != 0 && commune.WilayaCode != wilayaCode) {
+			return nil
+		}
+		return commune
+	}
+	for _, commune := range communeNames[normalizeLocationName(query)] {
+		if wilayaCode == 0 || commune.WilayaCode == wilayaCode {
+			return commune
+		}
+	}
+	return nil
+}
+
+// ResolveLocation finds the wilaya and commune named by a free text location such
+// as "Alger" or "Bab Ezzouar, Algiers". The whole text is tried first, then each
+// of its comma separated parts. Both are nil when no wilaya is recognised.
+func ResolveLocation(text string) (*models.Wilaya, *models.Commune) {
+	parts := append([]string{text}, strings.Split(text, ",")...)
+	for _, part := range parts {
+		if commune := FindCommune(part, 0); commune != nil {
+			return GetWilaya(commune.WilayaCode), commune
+		}
+	}
+	for _, part := range parts {
+		if wilaya := FindWilaya(part); wilaya != nil {
+			return wilaya, nil
+		}
+	}
+	return nil, nil
+}
+
+// NewLocationFilter resolves the wilaya and commune of a job search, each given by
+// code or name. It returns nil when the search is not restricted to a location.
+func NewLocationFilter(wilayaQuery, communeQuery string, radiusKm float64) (*LocationFilter, error) {
+	filter := &LocationFilter{RadiusKm: radiusKm}
+	if wilayaQuery != "" {
+		if filter.Wilaya = FindWilaya(wilayaQuery); filter.Wilaya == nil {
+			return nil, &LocationError{Reason: fmt.Sprintf("unknown wilaya %q", wilayaQuery)}
+		}
+	}
+	if communeQuery != "" {
+		wilayaCode := 0
+		if filter.Wilaya != nil {
+			wilayaCode = filter.Wilaya.Code
+		}
+		if filter.Commune = FindCommune(communeQuery, wilayaCode); filter.Commune == nil {
+			return nil, &LocationError{Reason: fmt.Sprintf("unknown commune %q", communeQuery)}
+		}
+		filter.Wilaya = GetWilaya(filter.Commune.WilayaCode)
+	}
+	if filter.Wilaya == nil {
+		if radiusKm > 0 {
+			return nil, &LocationError{Reason: "radius_km needs a wilaya or a commune to measure from"}
+		}
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// CheckLocation makes sure a commune belongs to the wilaya it is given with, and
+// returns the wilaya code, taken from the commune when only the commune is set
+func CheckLocation(wilayaCode, communeCode *int) (*int, error) {
+	if wilayaCode != nil && GetWilaya(*wilayaCode) == nil {
+		return nil, &LocationError{Reason: fmt.Sprintf("unknown wilaya %d", *wilayaCode)}
+	}
+	if communeCode == nil {
+		return wilayaCode, nil
+	}
+	commune := GetCommune(*communeCode)
+	if commune == nil {
+		return nil, &LocationError{Reason: fmt.Sprintf("unknown commune %d", *communeCode)}
+	}
+	if wilayaCode != nil && *wilayaCode != commune.WilayaCode {
+		return nil, &LocationError{Reason: fmt.Sprintf("commune %d is not in wilaya %d", *communeCode, *wilayaCode)}
+	}
+	code := commune.WilayaCode
+	return &code, nil
+}
+
+// LocateJob checks the wilaya and commune of a job and sets its coordinates to
+// their centroid. A job given without a wilaya is located from its free text
+// location when it names one.
+func LocateJob(job *models.Job) error {
+	if job.WilayaCode == nil && job.CommuneCode == nil {
+		if wilaya, commune := ResolveLocation(job.Location); wilaya != nil {
+			wilayaCode := wilaya.Code
+			job.WilayaCode = &wilayaCode
+			if commune != nil {
+				communeCode := commune.Code
+				job.CommuneCode = &communeCode
+			}
+		}
+	}
+	wilayaCode, err := CheckLocation(job.WilayaCode, job.CommuneCode)
+	if err != nil {
+		return err
+	}
+	job.WilayaCode = wilayaCode
+	job.Latitude, job.Longitude = nil, nil
+
+	var latitude, longitude float64
+	switch {
+	case job.CommuneCode != nil:
+		commune := GetCommune(*job.CommuneCode)
+		latitude, longitude = commune.Latitude, commune.Longitude
+	case job.WilayaCode != nil:
+		wilaya := GetWilaya(*job.WilayaCode)
+		latitude, longitude = wilaya.Latitude, wilaya.Longitude
+	default:
+		return nil
+	}
+	job.Latitude, job.Longitude = &latitude, &longitude
+	return nil
+}
+
+// DistanceKm is the great-circle distance between two points, using the haversine formula
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// DistanceSQL returns the haversine distance in kilometers between the latitude and
+// longitude columns of a table, prefix being its alias, and the point given by the
+// latParam and lonParam placeholders
+func DistanceSQL(prefix string, latParam, lonParam int) string {
+	return fmt.Sprintf(
+		`(2 * %[4]g * ASIN(SQRT(POWER(SIN(RADIANS(%[1]slatitude - $%[2]d) / 2), 2)
+            + COS(RADIANS($%[2]d)) * COS(RADIANS(%[1]slatitude)) * POWER(SIN(RADIANS(%[1]slongitude - $%[3]d) / 2), 2))))`,
+		prefix, latParam, lonParam, earthRadiusKm,
+	)
+}
+
+// normalizeLocationName lower cases a place name and strips its accents, spaces
+// and punctuation, so that "Bordj Bou Arréridj" matches "bordj-bou-arreridj"
+func normalizeLocationName(name string) string {
+	name = arabicFolder.Replace(accentFolder.Replace(strings.ToLower(name)))
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+}
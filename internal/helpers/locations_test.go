@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWilaya(t *testing.T) {
+	for _, query := range []string{"16", "Alger", "algiers", "الجزائر", "El Djazair", " ALGER "} {
+		if wilaya := FindWilaya(query); assert.NotNil(t, wilaya, query) {
+			assert.Equal(t, 16, wilaya.Code, query)
+		}
+	}
+	if wilaya := FindWilaya("bordj-bou-arreridj"); assert.NotNil(t, wilaya) {
+		assert.Equal(t, 34, wilaya.Code)
+	}
+	assert.Nil(t, FindWilaya("Paris"))
+	assert.Nil(t, FindWilaya("59"))
+	assert.Len(t, Wilayas(), 58)
+}
+
+func TestFindCommune(t *testing.T) {
+	if commune := FindCommune("alger centre", 0); assert.NotNil(t, commune) {
+		assert.Equal(t, 1601, commune.Code)
+		assert.Equal(t, 16, commune.WilayaCode)
+	}
+	assert.NotNil(t, FindCommune("3101", 31))
+	assert.Nil(t, FindCommune("3101", 16))
+	assert.Nil(t, FindCommune("Oran", 16))
+}
+
+func TestResolveLocation(t *testing.T) {
+	wilaya, commune := ResolveLocation("Alger Centre, Algiers")
+	if assert.NotNil(t, wilaya) && assert.NotNil(t, commune) {
+		assert.Equal(t, 16, wilaya.Code)
+		assert.Equal(t, 1601, commune.Code)
+	}
+
+	wilaya, commune = ResolveLocation("Hydra, Alger")
+	if assert.NotNil(t, wilaya) {
+		assert.Equal(t, 16, wilaya.Code)
+	}
+	assert.Nil(t, commune)
+
+	wilaya, commune = ResolveLocation("Remote")
+	assert.Nil(t, wilaya)
+	assert.Nil(t, commune)
+}
+
+func TestNewLocationFilter(t *testing.T) {
+	t.Run("No location", func(t *testing.T) {
+		filter, err := NewLocationFilter("", "", 0)
+		assert.NoError(t, err)
+		assert.Nil(t, filter)
+	})
+
+	t.Run("Commune sets its wilaya", func(t *testing.T) {
+		filter, err := NewLocationFilter("", "Oran", 20)
+		assert.NoError(t, err)
+		assert.Equal(t, 31, filter.Wilaya.Code)
+		assert.Equal(t, 3101, filter.Commune.Code)
+		latitude, longitude := filter.Center()
+		assert.Equal(t, filter.Commune.Latitude, latitude)
+		assert.Equal(t, filter.Commune.Longitude, longitude)
+	})
+
+	t.Run("Commune outside the wilaya", func(t *testing.T) {
+		_, err := NewLocationFilter("Alger", "Oran", 0)
+		var locationErr *LocationError
+		assert.ErrorAs(t, err, &locationErr)
+	})
+
+	t.Run("Radius without a place", func(t *testing.T) {
+		_, err := NewLocationFilter("", "", 50)
+		assert.EqualError(t, err, "invalid location: radius_km needs a wilaya or a commune to measure from")
+	})
+
+	t.Run("Unknown wilaya", func(t *testing.T) {
+		_, err := NewLocationFilter("Atlantis", "", 0)
+		assert.EqualError(t, err, `invalid location: unknown wilaya "Atlantis"`)
+	})
+}
+
+func TestLocateJob(t *testing.T) {
+	t.Run("Located from its free text location", func(t *testing.T) {
+		job := &models.Job{Location: "Oran"}
+
+		assert.NoError(t, LocateJob(job))
+		assert.Equal(t, 31, *job.WilayaCode)
+		assert.Equal(t, 3101, *job.CommuneCode)
+		assert.Equal(t, GetCommune(3101).Latitude, *job.Latitude)
+	})
+
+	t.Run("Wilaya taken from the commune", func(t *testing.T) {
+		communeCode := 1601
+		job := &models.Job{CommuneCode: &communeCode}
+
+		assert.NoError(t, LocateJob(job))
+		assert.Equal(t, 16, *job.WilayaCode)
+	})
+
+	t.Run("Commune outside the wilaya", func(t *testing.T) {
+		wilayaCode, communeCode := 31, 1601
+		job := &models.Job{WilayaCode: &wilayaCode, CommuneCode: &communeCode}
+
+		assert.EqualError(t, LocateJob(job), "invalid location: commune 1601 is not in wilaya 31")
+	})
+
+	t.Run("Unknown place left unlocated", func(t *testing.T) {
+		job := &models.Job{Location: "Remote"}
+
+		assert.NoError(t, LocateJob(job))
+		assert.Nil(t, job.WilayaCode)
+		assert.Nil(t, job.Latitude)
+	})
+}
+
+func TestDistanceKm(t *testing.T) {
+	alger, oran := GetWilaya(16), GetWilaya(31)
+
+	assert.InDelta(t, 351.4, DistanceKm(alger.Latitude, alger.Longitude, oran.Latitude, oran.Longitude), 0.1)
+	assert.Zero(t, DistanceKm(alger.Latitude, alger.Longitude, alger.Latitude, alger.Longitude))
+}
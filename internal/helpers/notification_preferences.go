@@ -0,0 +1,63 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"fmt"
+	"time"
+)
+
+// DigestHour is the hour of the day, in Algiers time, digests are sent at
+const DigestHour = 8
+
+// ParseClock parses a "15:04" time of day into minutes after midnight
+func ParseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock formats minutes after midnight as a "15:04" time of day
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// QuietHoursEnd returns when the quiet hours of a user running at t end, and
+// false when t is outside of them. Quiet hours ending before they start span
+// midnight.
+func QuietHoursEnd(preferences *models.NotificationPreferences, t time.Time) (time.Time, bool) {
+	if preferences.QuietHoursStart == nil || preferences.QuietHoursEnd == nil {
+		return t, false
+	}
+	start, end := *preferences.QuietHoursStart, *preferences.QuietHoursEnd
+
+	local := t.In(AlgiersTime)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, AlgiersTime)
+	minute := local.Hour()*60 + local.Minute()
+	endsAt := midnight.Add(time.Duration(end) * time.Minute)
+
+	switch {
+	case start < end && minute >= start && minute < end:
+		return endsAt, true
+	case start > end && minute >= start:
+		return endsAt.AddDate(0, 0, 1), true
+	case start > end && minute < end:
+		return endsAt, true
+	}
+	return t, false
+}
+
+// NextDigestTime returns when the digest of a user goes out after t, at DigestHour
+// unless the quiet hours of the user hold it back
+func NextDigestTime(preferences *models.NotificationPreferences, t time.Time) time.Time {
+	local := t.In(AlgiersTime)
+	next := time.Date(local.Year(), local.Month(), local.Day(), DigestHour, 0, 0, 0, AlgiersTime)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	if end, quiet := QuietHoursEnd(preferences, next); quiet {
+		return end
+	}
+	return next
+}
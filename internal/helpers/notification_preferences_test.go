@@ -0,0 +1,65 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClock(t *testing.T) {
+	minutes, err := ParseClock("22:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 22*60+30, minutes)
+	assert.Equal(t, "07:05", FormatClock(7*60+5))
+
+	_, err = ParseClock("25:00")
+	assert.Error(t, err)
+}
+
+func TestQuietHoursEnd(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, AlgiersTime)
+	}
+	quietHours := func(start, end string) *models.NotificationPreferences {
+		startMinutes, _ := ParseClock(start)
+		endMinutes, _ := ParseClock(end)
+		return &models.NotificationPreferences{QuietHoursStart: &startMinutes, QuietHoursEnd: &endMinutes}
+	}
+	tests := []struct {
+		name        string
+		preferences *models.NotificationPreferences
+		t           time.Time
+		wantEnd     time.Time
+		wantQuiet   bool
+	}{
+		{"no quiet hours", &models.NotificationPreferences{}, at(1, 23, 0), at(1, 23, 0), false},
+		{"within daytime quiet hours", quietHours("12:00", "14:00"), at(1, 13, 0), at(1, 14, 0), true},
+		{"at the end of the quiet hours", quietHours("12:00", "14:00"), at(1, 14, 0), at(1, 14, 0), false},
+		{"before midnight", quietHours("22:00", "07:00"), at(1, 23, 30), at(2, 7, 0), true},
+		{"after midnight", quietHours("22:00", "07:00"), at(2, 6, 59), at(2, 7, 0), true},
+		{"outside overnight quiet hours", quietHours("22:00", "07:00"), at(2, 12, 0), at(2, 12, 0), false},
+		{"given in UTC", quietHours("22:00", "07:00"), time.Date(2024, 3, 1, 21, 30, 0, 0, time.UTC), at(2, 7, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, quiet := QuietHoursEnd(tt.preferences, tt.t)
+			assert.Equal(t, tt.wantQuiet, quiet)
+			assert.True(t, tt.wantEnd.Equal(end), "got %s", end)
+		})
+	}
+}
+
+func TestNextDigestTime(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 3, day, hour, 0, 0, 0, AlgiersTime)
+	}
+
+	assert.True(t, at(1, 8).Equal(NextDigestTime(&models.NotificationPreferences{}, at(1, 6))))
+	assert.True(t, at(2, 8).Equal(NextDigestTime(&models.NotificationPreferences{}, at(1, 8))))
+
+	start, end := 22*60, 9*60
+	quiet := &models.NotificationPreferences{QuietHoursStart: &start, QuietHoursEnd: &end}
+	assert.True(t, at(2, 9).Equal(NextDigestTime(quiet, at(1, 12))))
+}
@@ -0,0 +1,145 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/pkg/utils"
+	"fmt"
+	"slices"
+)
+
+const DefaultPageLimit = 20
+
+// PageError is returned for paging parameters a list cannot honour
+type PageError struct {
+	Reason string
+}
+
+func (e *PageError) Error() string {
+	return "invalid pagination parameters: " + e.Reason
+}
+
+// PageKey is the sort key of a row, scanned from the columns added by KeyColumns
+type PageKey struct {
+	Value string
+	ID    string
+}
+
+// PageQuery turns a PageRequest into the keyset condition, ordering and limit of
+// a list query
+type PageQuery struct {
+	sort     string
+	sortExpr string
+	idExpr   string
+	order    string
+	limit    int
+	offset   int
+	cursor   *utils.Cursor
+}
+
+// NewPageQuery validates page against the sorts a list supports. sortColumns maps
+// a sort name to its SQL expression and idExpr is the unique column used to break
+// ties between rows with the same sort value.
+func NewPageQuery(page request.PageRequest, sortColumns map[string]string, idExpr string) (*PageQuery, error) {
+	query := &PageQuery{
+		sort:   page.Sort,
+		idExpr: idExpr,
+		order:  page.Order,
+		limit:  page.Limit,
+		offset: page.Offset,
+	}
+	if query.sort == "" {
+		query.sort = "created_at"
+	}
+	if query.order == "" {
+		query.order = "desc"
+	}
+	if query.limit <= 0 {
+		query.limit = DefaultPageLimit
+	}
+
+	if page.Cursor != "" {
+		cursor, err := utils.DecodeCursor(page.Cursor)
+		if err != nil {
+			return nil, &PageError{Reason: err.Error()}
+		}
+		if (page.Sort != "" && page.Sort != cursor.Sort) || (page.Order != "" && page.Order != cursor.Order) {
+			return nil, &PageError{Reason: "cursor does not match the requested sort order"}
+		}
+		query.sort, query.order, query.offset, query.cursor = cursor.Sort, cursor.Order, 0, &cursor
+	}
+
+	sortExpr, ok := sortColumns[query.sort]
+	if !ok {
+		return nil, &PageError{Reason: fmt.Sprintf("this list cannot be sorted by %q", query.sort)}
+	}
+	query.sortExpr = sortExpr
+	return query, nil
+}
+
+// KeyColumns is appended to the select list so that every row carries its sort key
+func (q *PageQuery) KeyColumns() string {
+	return fmt.Sprintf(", (%s)::text, (%s)::text", q.sortExpr, q.idExpr)
+}
+
+// Apply appends the keyset condition, ORDER BY and LIMIT/OFFSET clauses to a
+// query ending in a WHERE clause that uses all of args. One extra row is fetched
+// to tell whether there is another page.
+func (q *PageQuery) Apply(query string, args []interface{}) (string, []interface{}) {
+	paramCount := len(args) + 1
+	order := q.order
+	if q.cursor != nil && q.cursor.Backward {
+		order = map[string]string{"asc": "desc", "desc": "asc"}[order]
+	}
+
+	if q.cursor != nil {
+		operator := ">"
+		if order == "desc" {
+			operator = "<"
+		}
+		query += fmt.Sprintf(" AND (%s, %s) %s ($%d, $%d)", q.sortExpr, q.idExpr, operator, paramCount, paramCount+1)
+		args = append(args, q.cursor.Value, q.cursor.ID)
+		paramCount += 2
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT $%d", q.sortExpr, order, q.idExpr, order, paramCount)
+	args = append(args, q.limit+1)
+	if q.offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", paramCount+1)
+		args = append(args, q.offset)
+	}
+	return query, args
+}
+
+// Paginate trims the extra row fetched by Apply, restores the order of a backward
+// page and builds the cursors pointing to the neighbouring pages
+func Paginate[T any](q *PageQuery, rows []T, keys []PageKey, total int) ([]T, *models.PageInfo) {
+	hasMore := len(rows) > q.limit
+	if hasMore {
+		rows, keys = rows[:q.limit], keys[:q.limit]
+	}
+
+	hasNext, hasPrev := hasMore, q.offset > 0
+	if q.cursor != nil {
+		hasPrev = true
+		if q.cursor.Backward {
+			slices.Reverse(rows)
+			slices.Reverse(keys)
+			hasNext, hasPrev = true, hasMore
+		}
+	}
+
+	page := &models.PageInfo{Total: total, Limit: q.limit, Offset: q.offset}
+	if len(keys) == 0 {
+		return rows, page
+	}
+	if hasNext {
+		last := keys[len(keys)-1]
+		page.NextCursor = utils.EncodeCursor(utils.Cursor{Sort: q.sort, Order: q.order, Value: last.Value, ID: last.ID})
+	}
+	if hasPrev {
+		first := keys[0]
+		page.PrevCursor = utils.EncodeCursor(utils.Cursor{Sort: q.sort, Order: q.order, Value: first.Value, ID: first.ID, Backward: true})
+	}
+	return rows, page
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSortColumns = map[string]string{"created_at": "created_at", "title": "title"}
+
+func TestNewPageQuery(t *testing.T) {
+	t.Run("Unsupported sort", func(t *testing.T) {
+		_, err := NewPageQuery(request.PageRequest{Sort: "salary"}, testSortColumns, "job_id")
+		var pageErr *PageError
+		assert.ErrorAs(t, err, &pageErr)
+	})
+
+	t.Run("Cursor of another sort", func(t *testing.T) {
+		cursor := utils.EncodeCursor(utils.Cursor{Sort: "title", Order: "asc", Value: "Go developer", ID: "3"})
+		_, err := NewPageQuery(request.PageRequest{Cursor: cursor, Sort: "created_at"}, testSortColumns, "job_id")
+		var pageErr *PageError
+		assert.ErrorAs(t, err, &pageErr)
+	})
+
+	t.Run("Keyset condition from the cursor", func(t *testing.T) {
+		cursor := utils.EncodeCursor(utils.Cursor{Sort: "title", Order: "asc", Value: "Go developer", ID: "3"})
+		query, err := NewPageQuery(request.PageRequest{Cursor: cursor, Limit: 2, Offset: 40}, testSortColumns, "job_id")
+		require.NoError(t, err)
+
+		sql, args := query.Apply("SELECT job_id FROM jobs WHERE status = $1", []interface{}{"open"})
+		assert.Equal(t, "SELECT job_id FROM jobs WHERE status = $1 AND (title, job_id) > ($2, $3) ORDER BY title asc, job_id asc LIMIT $4", sql)
+		assert.Equal(t, []interface{}{"open", "Go developer", "3", 3}, args, "the offset is ignored with a cursor")
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	keys := []PageKey{{Value: "a", ID: "1"}, {Value: "b", ID: "2"}, {Value: "c", ID: "3"}}
+
+	t.Run("First page", func(t *testing.T) {
+		query, err := NewPageQuery(request.PageRequest{Sort: "title", Order: "asc", Limit: 2}, testSortColumns, "job_id")
+		require.NoError(t, err)
+
+		rows, page := Paginate(query, []string{"a", "b", "c"}, keys, 10)
+		assert.Equal(t, []string{"a", "b"}, rows)
+		assert.Equal(t, 10, page.Total)
+		assert.Empty(t, page.PrevCursor)
+
+		next, err := utils.DecodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, utils.Cursor{Sort: "title", Order: "asc", Value: "b", ID: "2"}, next)
+	})
+
+	t.Run("Backward page", func(t *testing.T) {
+		cursor := utils.EncodeCursor(utils.Cursor{Sort: "title", Order: "asc", Value: "d", ID: "4", Backward: true})
+		query, err := NewPageQuery(request.PageRequest{Cursor: cursor, Limit: 2}, testSortColumns, "job_id")
+		require.NoError(t, err)
+
+		// Rows of a backward page are fetched in reverse order
+		rows, page := Paginate(query, []string{"c", "b"}, []PageKey{keys[2], keys[1]}, 10)
+		assert.Equal(t, []string{"b", "c"}, rows)
+		assert.NotEmpty(t, page.NextCursor)
+		assert.Empty(t, page.PrevCursor, "no rows before the first one")
+	})
+}
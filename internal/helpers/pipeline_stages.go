@@ -0,0 +1,113 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultPipelineStages is used for recruiters that have not defined their own stage set
+var DefaultPipelineStages = []models.PipelineStage{
+	{Name: "received", Category: "applied", Position: 0},
+	{Name: "screening", Category: "review", Position: 1},
+	{Name: "interview", Category: "interview", Position: 2},
+	{Name: "offer", Category: "offer", Position: 3},
+	{Name: "hired", Category: "hired", Position: 4},
+	{Name: "rejected", Category: "rejected", Position: 5},
+}
+
+// candidateStatuses maps a stage category to the status shown to candidates, so
+// recruiter-defined stage names never leak to applicants
+var candidateStatuses = map[string]string{
+	"applied":   "submitted",
+	"review":    "in_review",
+	"interview": "interviewing",
+	"offer":     "offer",
+	"hired":     "hired",
+	"rejected":  "not_selected",
+}
+
+func IsTerminalStageCategory(category string) bool {
+	return category == "hired" || category == "rejected"
+}
+
+// IsFinalCandidateStatus tells whether a candidate status is the outcome of a
+// hired or rejected stage
+func IsFinalCandidateStatus(status string) bool {
+	return status == candidateStatuses["hired"] || status == candidateStatuses["rejected"]
+}
+
+func CandidateStatusForCategory(category string) string {
+	if status, ok := candidateStatuses[category]; ok {
+		return status
+	}
+	return "in_review"
+}
+
+// ValidateStageSet checks a recruiter-defined stage set before it is stored
+func ValidateStageSet(stages []models.PipelineStage) error {
+	if len(stages) == 0 {
+		return errors.New("at least one stage is required")
+	}
+	if IsTerminalStageCategory(stages[0].Category) {
+		return errors.New("the first stage cannot be a hired or rejected stage")
+	}
+
+	seen := map[string]bool{}
+	hasHired, hasRejected := false, false
+	for _, stage := range stages {
+		name := strings.ToLower(strings.TrimSpace(stage.Name))
+		if name == "" {
+			return errors.New("stage names cannot be empty")
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate stage name %q", stage.Name)
+		}
+		seen[name] = true
+		if _, ok := candidateStatuses[stage.Category]; !ok {
+			return fmt.Errorf("invalid category %q for stage %q", stage.Category, stage.Name)
+		}
+		hasHired = hasHired || stage.Category == "hired"
+		hasRejected = hasRejected || stage.Category == "rejected"
+	}
+	if !hasHired || !hasRejected {
+		return errors.New("the stage set must contain a hired and a rejected stage")
+	}
+	return nil
+}
+
+// ValidateStageTransition returns the target stage if an application may move from
+// one stage to the other. Applications only move forward through the pipeline,
+// may be sent to a hired or rejected stage at any time, and never leave a hired
+// or rejected stage. A stage holding applications cannot be removed from the set,
+// a current stage missing from it only comes from data predating that rule and
+// may move anywhere.
+func ValidateStageTransition(stages []models.PipelineStage, from, to string) (*models.PipelineStage, error) {
+	var current, target *models.PipelineStage
+	for i := range stages {
+		if stages[i].Name == from {
+			current = &stages[i]
+		}
+		if stages[i].Name == to {
+			target = &stages[i]
+		}
+	}
+
+	if target == nil {
+		return nil, fmt.Errorf("unknown stage %q", to)
+	}
+	if from == to {
+		return nil, fmt.Errorf("application is already in stage %q", to)
+	}
+	if current == nil {
+		return target, nil
+	}
+	if IsTerminalStageCategory(current.Category) {
+		return nil, fmt.Errorf("cannot move an application out of the final stage %q", from)
+	}
+	if target.Position < current.Position && !IsTerminalStageCategory(target.Category) {
+		return nil, fmt.Errorf("cannot move an application back from %q to %q", from, to)
+	}
+	return target, nil
+}
@@ -0,0 +1,20 @@
+package helpers
+
+import "time"
+
+// InstantAlertInterval is how often the scheduler runs the saved searches with
+// instant alerts
+const InstantAlertInterval = 5 * time.Minute
+
+// NextAlertRun returns when a saved search with the given alert frequency runs
+// again after a run at ranAt
+func NextAlertRun(frequency string, ranAt time.Time) time.Time {
+	switch frequency {
+	case "daily":
+		return ranAt.Add(24 * time.Hour)
+	case "weekly":
+		return ranAt.Add(7 * 24 * time.Hour)
+	default:
+		return ranAt.Add(InstantAlertInterval)
+	}
+}
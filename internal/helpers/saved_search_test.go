@@ -0,0 +1,16 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextAlertRun(t *testing.T) {
+	ranAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, ranAt.Add(24*time.Hour), NextAlertRun("daily", ranAt))
+	assert.Equal(t, ranAt.AddDate(0, 0, 7), NextAlertRun("weekly", ranAt))
+	assert.Equal(t, ranAt.Add(InstantAlertInterval), NextAlertRun("instant", ranAt))
+}
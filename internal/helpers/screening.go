@@ -0,0 +1,281 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxTextAnswerLength bounds the free text answers
+const maxTextAnswerLength = 5000
+
+// ScreeningError is returned for screening questions a recruiter cannot set, and
+// for answers or answer filters that do not fit the questions of a job
+type ScreeningError struct {
+	Reason string
+}
+
+func (e *ScreeningError) Error() string {
+	return "invalid screening: " + e.Reason
+}
+
+func screeningError(format string, args ...interface{}) error {
+	return &ScreeningError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// IsChoiceQuestion tells whether a question type is answered with its options
+func IsChoiceQuestion(questionType string) bool {
+	return questionType == "single_choice" || questionType == "multiple_choice"
+}
+
+// ValidateScreeningQuestions checks that each question only has the options and
+// auto-reject rule its type can use. Choice questions need at least two distinct
+// options and reject answers must be among them, yes/no questions reject "yes"
+// or "no".
+func ValidateScreeningQuestions(questions []models.ScreeningQuestion) error {
+	for i, question := range questions {
+		position := i + 1
+		if IsChoiceQuestion(question.Type) {
+			if len(question.Options) < 2 {
+				return screeningError("question %d needs at least two options", position)
+			}
+			seen := map[string]bool{}
+			for _, option := range question.Options {
+				if seen[option] {
+					return screeningError("question %d has the option %q twice", position, option)
+				}
+				seen[option] = true
+			}
+		} else if len(question.Options) > 0 {
+			return screeningError("question %d is not a choice question and cannot have options", position)
+		}
+
+		if question.Type != "number" && (question.RejectBelow != nil || question.RejectAbove != nil) {
+			return screeningError("question %d is not a number question and cannot reject a range", position)
+		}
+		if question.RejectBelow != nil && question.RejectAbove != nil && *question.RejectBelow > *question.RejectAbove {
+			return screeningError("question %d rejects every number", position)
+		}
+
+		for _, answer := range question.RejectAnswers {
+			switch {
+			case question.Type == "yes_no":
+				if answer != "yes" && answer != "no" {
+					return screeningError("question %d can only reject \"yes\" or \"no\"", position)
+				}
+			case IsChoiceQuestion(question.Type):
+				if findOption(question.Options, answer) == "" {
+					return screeningError("question %d rejects %q which is not one of its options", position, answer)
+				}
+			default:
+				return screeningError("question %d cannot reject answers, only yes/no and choice questions can", position)
+			}
+		}
+	}
+	return nil
+}
+
+// EvaluateScreeningAnswers checks the answers of a candidate against the
+// questions of a job and returns them ready to be stored, ordered like the
+// questions. Every required question must be answered. The first question whose
+// auto-reject rule an answer matches is returned as the knockout question.
+func EvaluateScreeningAnswers(questions []models.ScreeningQuestion, answers []request.ScreeningAnswerRequest) ([]models.ScreeningAnswer, *models.ScreeningQuestion, error) {
+	byQuestion := make(map[int64]interface{}, len(answers))
+	for _, answer := range answers {
+		if _, ok := byQuestion[answer.QuestionID]; ok {
+			return nil, nil, screeningError("question %d is answered twice", answer.QuestionID)
+		}
+		byQuestion[answer.QuestionID] = answer.Answer
+	}
+
+	var evaluated []models.ScreeningAnswer
+	var knockout *models.ScreeningQuestion
+	for i := range questions {
+		question := &questions[i]
+		value, answered := byQuestion[question.ID]
+		delete(byQuestion, question.ID)
+
+		var answer *models.ScreeningAnswer
+		if answered && value != nil {
+			var err error
+			if answer, err = evaluateScreeningAnswer(question, value); err != nil {
+				return nil, nil, err
+			}
+		}
+		if answer == nil {
+			if question.Required {
+				return nil, nil, screeningError("question %d is required", question.ID)
+			}
+			continue
+		}
+		if answer.Knockout && knockout == nil {
+			knockout = question
+		}
+		evaluated = append(evaluated, *answer)
+	}
+
+	for questionID := range byQuestion {
+		return nil, nil, screeningError("question %d is not a question of this job", questionID)
+	}
+	return evaluated, knockout, nil
+}
+
+// evaluateScreeningAnswer reads a JSON answer to a question. It returns nil for
+// an empty text or an empty list of options, which leave the question unanswered.
+func evaluateScreeningAnswer(question *models.ScreeningQuestion, value interface{}) (*models.ScreeningAnswer, error) {
+	questionID := question.ID
+	answer := &models.ScreeningAnswer{
+		QuestionID: &questionID,
+		Position:   question.Position,
+		Type:       question.Type,
+		Prompt:     question.Prompt,
+	}
+
+	switch question.Type {
+	case "yes_no":
+		yes, ok := value.(bool)
+		if !ok {
+			return nil, screeningError("question %d must be answered with true or false", question.ID)
+		}
+		answer.Value = "no"
+		if yes {
+			answer.Value = "yes"
+		}
+		answer.Knockout = containsString(question.RejectAnswers, answer.Value)
+
+	case "number":
+		number, ok := value.(float64)
+		if !ok {
+			return nil, screeningError("question %d must be answered with a number", question.ID)
+		}
+		answer.Number = &number
+		answer.Knockout = (question.RejectBelow != nil && number < *question.RejectBelow) ||
+			(question.RejectAbove != nil && number > *question.RejectAbove)
+
+	case "single_choice":
+		text, ok := value.(string)
+		if !ok {
+			return nil, screeningError("question %d must be answered with one of its options", question.ID)
+		}
+		if strings.TrimSpace(text) == "" {
+			return nil, nil
+		}
+		if answer.Value = findOption(question.Options, text); answer.Value == "" {
+			return nil, screeningError("%q is not an option of question %d", text, question.ID)
+		}
+		answer.Knockout = containsString(question.RejectAnswers, answer.Value)
+
+	case "multiple_choice":
+		values, ok := value.([]interface{})
+		if !ok {
+			return nil, screeningError("question %d must be answered with a list of its options", question.ID)
+		}
+		for _, value := range values {
+			text, ok := value.(string)
+			if !ok {
+				return nil, screeningError("question %d must be answered with a list of its options", question.ID)
+			}
+			option := findOption(question.Options, text)
+			if option == "" {
+				return nil, screeningError("%q is not an option of question %d", text, question.ID)
+			}
+			if !containsString(answer.Choices, option) {
+				answer.Choices = append(answer.Choices, option)
+				answer.Knockout = answer.Knockout || containsString(question.RejectAnswers, option)
+			}
+		}
+		if len(answer.Choices) == 0 {
+			return nil, nil
+		}
+
+	case "text":
+		text, ok := value.(string)
+		if !ok {
+			return nil, screeningError("question %d must be answered with a text", question.ID)
+		}
+		if answer.Value = strings.TrimSpace(text); answer.Value == "" {
+			return nil, nil
+		}
+		if len(answer.Value) > maxTextAnswerLength {
+			return nil, screeningError("the answer to question %d is longer than %d characters", question.ID, maxTextAnswerLength)
+		}
+
+	default:
+		return nil, screeningError("question %d has an unknown type %q", question.ID, question.Type)
+	}
+	return answer, nil
+}
+
+// ParseAnswerFilter reads an applicant filter given as "question_id:value" for
+// one of the questions of a job. Yes/no answers are given as "yes" or "no" and
+// number answers as an exact number or a "min..max" range, either bound being
+// optional.
+func ParseAnswerFilter(raw string, questions []models.ScreeningQuestion) (models.AnswerFilter, error) {
+	id, value, found := strings.Cut(raw, ":")
+	questionID, err := strconv.ParseInt(strings.TrimSpace(id), 10, 64)
+	if !found || err != nil {
+		return models.AnswerFilter{}, screeningError("answer filter %q must look like question_id:value", raw)
+	}
+	var question *models.ScreeningQuestion
+	for i := range questions {
+		if questions[i].ID == questionID {
+			question = &questions[i]
+			break
+		}
+	}
+	if question == nil {
+		return models.AnswerFilter{}, screeningError("question %d is not a question of this job", questionID)
+	}
+
+	filter := models.AnswerFilter{QuestionID: questionID, Type: question.Type}
+	value = strings.TrimSpace(value)
+	switch question.Type {
+	case "yes_no":
+		if filter.Value = strings.ToLower(value); filter.Value != "yes" && filter.Value != "no" {
+			return filter, screeningError("question %d is filtered by \"yes\" or \"no\"", questionID)
+		}
+	case "number":
+		lower, upper, isRange := strings.Cut(value, "..")
+		if !isRange {
+			upper = lower
+		}
+		if filter.Min, err = parseOptionalFloat(strings.TrimSpace(lower)); err == nil {
+			filter.Max, err = parseOptionalFloat(strings.TrimSpace(upper))
+		}
+		if err != nil || (filter.Min == nil && filter.Max == nil) {
+			return filter, screeningError("question %d is filtered by a number or a min..max range", questionID)
+		}
+	case "single_choice", "multiple_choice":
+		if filter.Value = findOption(question.Options, value); filter.Value == "" {
+			return filter, screeningError("%q is not an option of question %d", value, questionID)
+		}
+	default:
+		if filter.Value = value; filter.Value == "" {
+			return filter, screeningError("question %d is filtered by a non empty text", questionID)
+		}
+	}
+	return filter, nil
+}
+
+// findOption returns the option matching text regardless of case and surrounding
+// spaces, or an empty string if there is none
+func findOption(options []string, text string) string {
+	text = strings.TrimSpace(text)
+	for _, option := range options {
+		if strings.EqualFold(option, text) {
+			return option
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package helpers
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestScreeningQuestions returns a required driving licence question rejecting
+// "no", a years of Go question rejecting less than 2, an optional contract choice
+// rejecting "Internship", a languages question and a free text question
+func newTestScreeningQuestions() []models.ScreeningQuestion {
+	two := 2.0
+	return []models.ScreeningQuestion{
+		{ID: 1, Position: 1, Type: "yes_no", Prompt: "Do you have a driving licence?", Required: true, RejectAnswers: []string{"no"}},
+		{ID: 2, Position: 2, Type: "number", Prompt: "Years of Go experience?", Required: true, RejectBelow: &two},
+		{ID: 3, Position: 3, Type: "single_choice", Prompt: "Contract?", Options: []string{"CDI", "CDD", "Internship"}, RejectAnswers: []string{"Internship"}},
+		{ID: 4, Position: 4, Type: "multiple_choice", Prompt: "Languages?", Options: []string{"Arabic", "French", "English"}},
+		{ID: 5, Position: 5, Type: "text", Prompt: "Expected salary?"},
+	}
+}
+
+func TestValidateScreeningQuestions(t *testing.T) {
+	one, two := 1.0, 2.0
+	tests := []struct {
+		name     string
+		question models.ScreeningQuestion
+		wantErr  string
+	}{
+		{"choice with one option", models.ScreeningQuestion{Type: "single_choice", Options: []string{"CDI"}}, "invalid screening: question 1 needs at least two options"},
+		{"option twice", models.ScreeningQuestion{Type: "multiple_choice", Options: []string{"CDI", "CDI"}}, `invalid screening: question 1 has the option "CDI" twice`},
+		{"options on a text", models.ScreeningQuestion{Type: "text", Options: []string{"a", "b"}}, "invalid screening: question 1 is not a choice question and cannot have options"},
+		{"range on a yes/no", models.ScreeningQuestion{Type: "yes_no", RejectBelow: &one}, "invalid screening: question 1 is not a number question and cannot reject a range"},
+		{"range rejecting everything", models.ScreeningQuestion{Type: "number", RejectBelow: &two, RejectAbove: &one}, "invalid screening: question 1 rejects every number"},
+		{"yes/no rejecting maybe", models.ScreeningQuestion{Type: "yes_no", RejectAnswers: []string{"maybe"}}, `invalid screening: question 1 can only reject "yes" or "no"`},
+		{"rejected answer not an option", models.ScreeningQuestion{Type: "single_choice", Options: []string{"CDI", "CDD"}, RejectAnswers: []string{"Freelance"}}, `invalid screening: question 1 rejects "Freelance" which is not one of its options`},
+		{"text rejecting answers", models.ScreeningQuestion{Type: "text", RejectAnswers: []string{"no"}}, "invalid screening: question 1 cannot reject answers, only yes/no and choice questions can"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, ValidateScreeningQuestions([]models.ScreeningQuestion{tt.question}), tt.wantErr)
+		})
+	}
+
+	assert.NoError(t, ValidateScreeningQuestions(newTestScreeningQuestions()))
+}
+
+func TestEvaluateScreeningAnswers(t *testing.T) {
+	questions := newTestScreeningQuestions()
+
+	t.Run("Answers stored in question order", func(t *testing.T) {
+		answers, knockout, err := EvaluateScreeningAnswers(questions, []request.ScreeningAnswerRequest{
+			{QuestionID: 5, Answer: "  80000 DZD  "},
+			{QuestionID: 4, Answer: []interface{}{"french", "Arabic", "French"}},
+			{QuestionID: 2, Answer: 3.0},
+			{QuestionID: 1, Answer: true},
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, knockout)
+		if assert.Len(t, answers, 4) {
+			assert.Equal(t, "yes", answers[0].Value)
+			assert.Equal(t, 3.0, *answers[1].Number)
+			assert.Equal(t, []string{"French", "Arabic"}, answers[2].Choices)
+			assert.Equal(t, "80000 DZD", answers[3].Value)
+			assert.Equal(t, "Expected salary?", answers[3].Prompt)
+		}
+	})
+
+	t.Run("First knockout question returned", func(t *testing.T) {
+		answers, knockout, err := EvaluateScreeningAnswers(questions, []request.ScreeningAnswerRequest{
+			{QuestionID: 1, Answer: true},
+			{QuestionID: 2, Answer: 1.0},
+			{QuestionID: 3, Answer: "internship"},
+		})
+		assert.NoError(t, err)
+		if assert.NotNil(t, knockout) {
+			assert.Equal(t, int64(2), knockout.ID)
+		}
+		assert.False(t, answers[0].Knockout)
+		assert.True(t, answers[1].Knockout)
+		assert.True(t, answers[2].Knockout)
+		assert.Equal(t, "Internship", answers[2].Value)
+	})
+
+	t.Run("Empty answer to an optional question", func(t *testing.T) {
+		answers, _, err := EvaluateScreeningAnswers(questions, []request.ScreeningAnswerRequest{
+			{QuestionID: 1, Answer: false},
+			{QuestionID: 2, Answer: 5.0},
+			{QuestionID: 3, Answer: " "},
+			{QuestionID: 4, Answer: []interface{}{}},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, answers, 2)
+	})
+
+	errorTests := []struct {
+		name    string
+		answers []request.ScreeningAnswerRequest
+		wantErr string
+	}{
+		{"required question unanswered", []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: true}}, "invalid screening: question 2 is required"},
+		{"required question answered with null", []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: nil}, {QuestionID: 2, Answer: 3.0}}, "invalid screening: question 1 is required"},
+		{"question answered twice", []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: true}, {QuestionID: 1, Answer: false}}, "invalid screening: question 1 is answered twice"},
+		{"question of another job", []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: true}, {QuestionID: 2, Answer: 3.0}, {QuestionID: 9, Answer: "x"}}, "invalid screening: question 9 is not a question of this job"},
+		{"yes/no answered with text", []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: "yes"}}, "invalid screening: question 1 must be answered with true or false"},
+		{"number answered with text", []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: true}, {QuestionID: 2, Answer: "three"}}, "invalid screening: question 2 must be answered with a number"},
+		{"unknown option", []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: true}, {QuestionID: 2, Answer: 3.0}, {QuestionID: 3, Answer: "Freelance"}}, `invalid screening: "Freelance" is not an option of question 3`},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := EvaluateScreeningAnswers(questions, tt.answers)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseAnswerFilter(t *testing.T) {
+	questions := newTestScreeningQuestions()
+
+	filter, err := ParseAnswerFilter("1:Yes", questions)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AnswerFilter{QuestionID: 1, Type: "yes_no", Value: "yes"}, filter)
+
+	filter, err = ParseAnswerFilter("2:3..", questions)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, *filter.Min)
+	assert.Nil(t, filter.Max)
+
+	filter, err = ParseAnswerFilter("2:4", questions)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, *filter.Min)
+	assert.Equal(t, 4.0, *filter.Max)
+
+	filter, err = ParseAnswerFilter("4: english", questions)
+	assert.NoError(t, err)
+	assert.Equal(t, "English", filter.Value)
+
+	for _, raw := range []string{"1", "x:yes", "9:yes", "1:maybe", "2:..", "2:a..b", "3:Freelance", "5: "} {
+		_, err := ParseAnswerFilter(raw, questions)
+		var screeningErr *ScreeningError
+		assert.ErrorAs(t, err, &screeningErr, raw)
+	}
+}
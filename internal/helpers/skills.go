@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+)
+
+var skillSeparators = regexp.MustCompile(`[,;|]`)
+
+// NormalizeSkillName returns the slug a skill name is matched on in the skills
+// catalog: lower case, trimmed and single spaced
+func NormalizeSkillName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// SplitSkills splits the required skills text of a job into skill names
+func SplitSkills(text string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range skillSeparators.Split(text, -1) {
+		name = strings.TrimSpace(name)
+		slug := NormalizeSkillName(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSkillName(t *testing.T) {
+	assert.Equal(t, "node js", NormalizeSkillName("  Node   JS "))
+}
+
+func TestSplitSkills(t *testing.T) {
+	t.Run("Separators", func(t *testing.T) {
+		assert.Equal(t, []string{"Go", "PostgreSQL", "Docker", "CI/CD"}, SplitSkills("Go, PostgreSQL; Docker | CI/CD"))
+	})
+
+	t.Run("Duplicates and blanks dropped", func(t *testing.T) {
+		assert.Equal(t, []string{"Go", "React"}, SplitSkills("Go,, go ,React, "))
+	})
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+)
+
+// UnsubscribePage is the page opened by the unsubscribe link of an email. The
+// link only shows it, the emails are turned off by the form it posts, so that
+// mail scanners following links do not unsubscribe anyone.
+type UnsubscribePage struct {
+	Token        string
+	Description  string // The emails the link turns off
+	Unsubscribed bool
+}
+
+// RenderUnsubscribePage executes the unsubscribe page template of internal/templates
+func RenderUnsubscribePage(page UnsubscribePage) ([]byte, error) {
+	templatePath := filepath.Join("internal", "templates", "unsubscribe_page_template.html")
+
+	pageTemplate, err := template.ParseFiles(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read unsubscribe page template at %s: %w", templatePath, err)
+	}
+
+	var html bytes.Buffer
+	if err := pageTemplate.Execute(&html, page); err != nil {
+		return nil, err
+	}
+	return html.Bytes(), nil
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderUnsubscribePage(t *testing.T) {
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir("../.."))
+	t.Cleanup(func() { _ = os.Chdir(dir) })
+
+	t.Run("Confirmation form", func(t *testing.T) {
+		html, err := RenderUnsubscribePage(UnsubscribePage{Token: "a.b&c", Description: "all Dz Jobs notification emails"})
+		require.NoError(t, err)
+		assert.Contains(t, string(html), `<form method="post" action="?token=a.b%26c">`)
+	})
+
+	t.Run("Unsubscribed", func(t *testing.T) {
+		html, err := RenderUnsubscribePage(UnsubscribePage{Unsubscribed: true})
+		require.NoError(t, err)
+		assert.NotContains(t, string(html), "<form")
+	})
+}
@@ -1,8 +1,12 @@
 package integrations
 
 import (
+	"bytes"
 	"dz-jobs-api/config"
+	"dz-jobs-api/internal/models"
+	"encoding/base64"
 	"fmt"
+	"html/template"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +15,7 @@ import (
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
 )
 
+// SendOTPEmail sends the one-time password of a password reset
 func SendOTPEmail(email, otp, sendGridAPIKey string) error {
 	appConfig, err := config.LoadConfig()
 	if err != nil {
@@ -49,3 +54,303 @@ func SendOTPEmail(email, otp, sendGridAPIKey string) error {
 
 	return nil
 }
+
+// VerificationEmail is the content of the email confirming the address of a new
+// account
+type VerificationEmail struct {
+	Name          string
+	OTP           string
+	ExpiryMinutes int
+}
+
+// RenderVerificationEmail renders the email with the code confirming the address
+// of a new account
+func RenderVerificationEmail(email string, content VerificationEmail) (*models.Email, error) {
+	emailBodyHTML, err := renderEmailTemplate("verification_email_template.html", content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render verification email: %w", err)
+	}
+
+	return &models.Email{
+		Type:      "email_verification",
+		To:        email,
+		Subject:   "Dz Jobs: confirm your email address",
+		PlainText: fmt.Sprintf("Your Dz Jobs verification code is: %s\nIt expires in %d minutes.\n", content.OTP, content.ExpiryMinutes),
+		HTML:      emailBodyHTML,
+	}, nil
+}
+
+// AccountLockedEmail is the content of the email warning a user that their
+// account was locked after too many failed login attempts
+type AccountLockedEmail struct {
+	Name        string
+	Attempts    int
+	IP          string
+	LockMinutes int
+}
+
+// RenderAccountLockedEmail renders the email warning a user that their account
+// was temporarily locked
+func RenderAccountLockedEmail(email string, content AccountLockedEmail) (*models.Email, error) {
+	emailBodyHTML, err := renderEmailTemplate("account_locked_email_template.html", content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render account locked email: %w", err)
+	}
+
+	return &models.Email{
+		Type:      "account_locked",
+		To:        email,
+		Subject:   "Dz Jobs: your account was temporarily locked",
+		PlainText: fmt.Sprintf("Your Dz Jobs account was locked for %d minutes after %d failed login attempts, the last one from %s.\nIf these attempts were not yours, reset your password and enable two-factor authentication.\n", content.LockMinutes, content.Attempts, content.IP),
+		HTML:      emailBodyHTML,
+	}, nil
+}
+
+// SendEmail sends a rendered email. An email about an event links to its
+// unsubscribe URL, which mail clients can also call with one click. Notification
+// preferences are up to the caller, security mail such as codes and account lock
+// warnings is sent whatever they are.
+func SendEmail(email *models.Email, serviceEmail, sendGridAPIKey string) error {
+	if sendGridAPIKey == "" {
+		return fmt.Errorf("SendGrid API key is missing")
+	}
+
+	client := sendgrid.NewSendClient(sendGridAPIKey)
+
+	from := mail.NewEmail("Dz Jobs", serviceEmail)
+	to := mail.NewEmail(email.To, email.To)
+
+	emailBodyPlainText := email.PlainText
+	if email.UnsubscribeURL != "" {
+		emailBodyPlainText += fmt.Sprintf("\nUnsubscribe from these emails: %s\n", email.UnsubscribeURL)
+	}
+
+	message := mail.NewSingleEmail(from, email.Subject, to, emailBodyPlainText, email.HTML)
+	if email.UnsubscribeURL != "" {
+		message.SetHeader("List-Unsubscribe", "<"+email.UnsubscribeURL+">")
+		message.SetHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	for _, attachment := range email.Attachments {
+		file := mail.NewAttachment()
+		file.SetContent(base64.StdEncoding.EncodeToString(attachment.Content))
+		file.SetType(attachment.ContentType)
+		file.SetFilename(attachment.FileName)
+		file.SetDisposition("attachment")
+		message.AddAttachment(file)
+	}
+
+	response, err := client.Send(message)
+	if err != nil {
+		return fmt.Errorf("failed to send %s email: %w", email.Type, err)
+	}
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("failed to send %s email: received status code %d", email.Type, response.StatusCode)
+	}
+
+	return nil
+}
+
+// JobAlertDigest is the content of a saved search alert email
+type JobAlertDigest struct {
+	SearchName     string
+	Jobs           []JobAlertItem
+	MoreCount      int
+	StopAlertsURL  string // Stops the alerts of this saved search only
+	UnsubscribeURL string
+}
+
+type JobAlertItem struct {
+	Title    string
+	Location string
+	JobType  string
+	URL      string
+}
+
+func RenderJobAlertEmail(email string, digest JobAlertDigest) (*models.Email, error) {
+	emailBodyHTML, err := renderEmailTemplate("job_alert_email_template.html", digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render job alert email: %w", err)
+	}
+
+	var emailBodyPlainText strings.Builder
+	fmt.Fprintf(&emailBodyPlainText, "New jobs for %q:\n\n", digest.SearchName)
+	for _, job := range digest.Jobs {
+		fmt.Fprintf(&emailBodyPlainText, "- %s: %s\n", job.Title, job.URL)
+	}
+	if digest.MoreCount > 0 {
+		fmt.Fprintf(&emailBodyPlainText, "\nAnd %d more on Dz Jobs.\n", digest.MoreCount)
+	}
+	fmt.Fprintf(&emailBodyPlainText, "\nStop the alerts of this search: %s\n", digest.StopAlertsURL)
+
+	return &models.Email{
+		To:             email,
+		Subject:        fmt.Sprintf("Dz Jobs: new jobs for %q", digest.SearchName),
+		PlainText:      emailBodyPlainText.String(),
+		HTML:           emailBodyHTML,
+		UnsubscribeURL: digest.UnsubscribeURL,
+	}, nil
+}
+
+// JobExpiryReminder is the content of the email warning a recruiter that a job is about to expire
+type JobExpiryReminder struct {
+	JobTitle       string
+	ExpiresAt      string
+	JobURL         string
+	UnsubscribeURL string
+}
+
+func RenderJobExpiryReminderEmail(email string, reminder JobExpiryReminder) (*models.Email, error) {
+	emailBodyHTML, err := renderEmailTemplate("job_expiry_email_template.html", reminder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render job expiry email: %w", err)
+	}
+
+	emailBodyPlainText := fmt.Sprintf(
+		"Your job %q expires on %s and will then be closed automatically.\nRepost it to keep receiving applications: %s\n",
+		reminder.JobTitle, reminder.ExpiresAt, reminder.JobURL,
+	)
+
+	return &models.Email{
+		To:             email,
+		Subject:        fmt.Sprintf("Dz Jobs: your job %q expires soon", reminder.JobTitle),
+		PlainText:      emailBodyPlainText,
+		HTML:           emailBodyHTML,
+		UnsubscribeURL: reminder.UnsubscribeURL,
+	}, nil
+}
+
+// InterviewNotice is the content of an email about an interview. Calendar is the
+// iCalendar invite attached to it, sent with the iTIP CalendarMethod.
+type InterviewNotice struct {
+	Subject         string
+	Title           string
+	Message         string
+	JobTitle        string
+	CompanyName     string
+	When            string
+	Slots           []string
+	DurationMinutes int
+	Location        string
+	VideoLink       string
+	Notes           string
+	CancelReason    string
+	Calendar        []byte
+	CalendarMethod  string
+	UnsubscribeURL  string
+}
+
+func RenderInterviewEmail(email string, notice InterviewNotice) (*models.Email, error) {
+	emailBodyHTML, err := renderEmailTemplate("interview_email_template.html", notice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render interview email: %w", err)
+	}
+
+	var emailBodyPlainText strings.Builder
+	fmt.Fprintf(&emailBodyPlainText, "%s\n\n%s - %s\n", notice.Message, notice.JobTitle, notice.CompanyName)
+	if notice.When != "" {
+		fmt.Fprintf(&emailBodyPlainText, "%s (%d minutes, Algiers time)\n", notice.When, notice.DurationMinutes)
+	}
+	for _, slot := range notice.Slots {
+		fmt.Fprintf(&emailBodyPlainText, "- %s\n", slot)
+	}
+	if notice.Location != "" {
+		fmt.Fprintf(&emailBodyPlainText, "Location: %s\n", notice.Location)
+	}
+	if notice.VideoLink != "" {
+		fmt.Fprintf(&emailBodyPlainText, "Video link: %s\n", notice.VideoLink)
+	}
+	if notice.CancelReason != "" {
+		fmt.Fprintf(&emailBodyPlainText, "Reason: %s\n", notice.CancelReason)
+	}
+
+	rendered := &models.Email{
+		To:             email,
+		Subject:        notice.Subject,
+		PlainText:      emailBodyPlainText.String(),
+		HTML:           emailBodyHTML,
+		UnsubscribeURL: notice.UnsubscribeURL,
+	}
+	if len(notice.Calendar) > 0 {
+		rendered.Attachments = append(rendered.Attachments, models.EmailAttachment{
+			Content:     notice.Calendar,
+			ContentType: "text/calendar; charset=UTF-8; method=" + notice.CalendarMethod,
+			FileName:    "invite.ics",
+		})
+	}
+	return rendered, nil
+}
+
+// NotificationEmail is the content of the email about an event that has no email
+// of its own, it repeats the in-app notification
+type NotificationEmail struct {
+	Title          string
+	Body           string
+	URL            string
+	UnsubscribeURL string
+}
+
+func RenderNotificationEmail(email string, content NotificationEmail) (*models.Email, error) {
+	emailBodyHTML, err := renderEmailTemplate("notification_email_template.html", content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render notification email: %w", err)
+	}
+
+	return &models.Email{
+		To:             email,
+		Subject:        "Dz Jobs: " + content.Title,
+		PlainText:      fmt.Sprintf("%s\n\n%s\n%s\n", content.Title, content.Body, content.URL),
+		HTML:           emailBodyHTML,
+		UnsubscribeURL: content.UnsubscribeURL,
+	}, nil
+}
+
+// NotificationDigest is the content of the daily email batching the emails of the
+// low priority events of a user
+type NotificationDigest struct {
+	Items          []NotificationDigestItem
+	UnsubscribeURL string
+}
+
+type NotificationDigestItem struct {
+	Subject        string
+	Text           string
+	UnsubscribeURL string
+}
+
+func RenderNotificationDigestEmail(email string, digest NotificationDigest) (*models.Email, error) {
+	emailBodyHTML, err := renderEmailTemplate("notification_digest_email_template.html", digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render notification digest email: %w", err)
+	}
+
+	var emailBodyPlainText strings.Builder
+	for _, item := range digest.Items {
+		fmt.Fprintf(&emailBodyPlainText, "%s\n%s\n", item.Subject, item.Text)
+		fmt.Fprintf(&emailBodyPlainText, "Unsubscribe from emails like this one: %s\n\n", item.UnsubscribeURL)
+	}
+
+	return &models.Email{
+		To:             email,
+		Subject:        fmt.Sprintf("Dz Jobs: your digest of %d update(s)", len(digest.Items)),
+		PlainText:      emailBodyPlainText.String(),
+		HTML:           emailBodyHTML,
+		UnsubscribeURL: digest.UnsubscribeURL,
+	}, nil
+}
+
+// renderEmailTemplate executes an HTML email template of internal/templates
+func renderEmailTemplate(name string, data interface{}) (string, error) {
+	templatePath := filepath.Join("internal", "templates", name)
+
+	emailTemplate, err := template.ParseFiles(templatePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read email template at %s: %w", templatePath, err)
+	}
+
+	var emailBodyHTML bytes.Buffer
+	if err := emailTemplate.Execute(&emailBodyHTML, data); err != nil {
+		return "", err
+	}
+	return emailBodyHTML.String(), nil
+}
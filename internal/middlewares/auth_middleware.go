@@ -2,14 +2,19 @@ package middlewares
 
 import (
 	"dz-jobs-api/config"
+	"dz-jobs-api/internal/repositories/interfaces"
 	"dz-jobs-api/pkg/utils"
 
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
-func AuthMiddleware(config *config.AppConfig) gin.HandlerFunc {
+// AuthMiddleware authenticates requests by their access token. The login session
+// the token belongs to must still exist, so that logging out or revoking a
+// session takes effect before its access tokens expire.
+func AuthMiddleware(config *config.AppConfig, redisRepo interfaces.RedisRepository) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		accessToken, err := ctx.Cookie("access_token")
@@ -26,9 +31,29 @@ func AuthMiddleware(config *config.AppConfig) gin.HandlerFunc {
 			return
 		}
 
+		if claims.SessionID == "" {
+			_ = ctx.Error(utils.NewCustomError(http.StatusUnauthorized, "Session expired or revoked"))
+			ctx.Abort()
+			return
+		}
+		live, err := redisRepo.SessionExists(ctx, claims.SessionID)
+		if err != nil {
+			log.WithFields(log.Fields{"session_id": claims.SessionID, "error": err}).Error("Failed to check session")
+			_ = ctx.Error(utils.NewCustomError(http.StatusInternalServerError, "Failed to check session"))
+			ctx.Abort()
+			return
+		}
+		if !live {
+			_ = ctx.Error(utils.NewCustomError(http.StatusUnauthorized, "Session expired or revoked"))
+			ctx.Abort()
+			return
+		}
+
 		ctx.Set("user_id", claims.ID)
 		ctx.Set("role", claims.Role)
 		ctx.Set("purpose", claims.Purpose)
+		ctx.Set("session_id", claims.SessionID)
+		ctx.Set("two_factor", claims.TwoFactor)
 		if claims.Role == "candidate" {
 			ctx.Set("candidate_id", claims.ID)
 		} else if claims.Role == "recruiter" {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"dz-jobs-api/internal/dto/response"
@@ -39,6 +40,9 @@ func handleErrors(ctx *gin.Context) {
 		switch err := e.Err.(type) {
 		case *utils.CustomError:
 
+			if err.RetryAfter > 0 {
+				ctx.Header("Retry-After", strconv.Itoa(utils.RetryAfterSeconds(err.RetryAfter)))
+			}
 			ctx.JSON(err.StatusCode, response.Response{
 				Code:    err.StatusCode,
 				Status:  http.StatusText(err.StatusCode),
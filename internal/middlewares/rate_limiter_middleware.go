@@ -2,24 +2,58 @@ package middlewares
 
 import (
 	"dz-jobs-api/internal/dto/response"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/time/rate"
+	log "github.com/sirupsen/logrus"
 )
 
-func RateLimiter(limit int, burst int) gin.HandlerFunc {
-
-	limiter := rate.NewLimiter(rate.Limit(limit), burst)
+// RateLimiter limits the requests each client makes to a group of routes under
+// the policy name, counting them by authenticated user, or by IP before the
+// authentication middleware. Every response carries the RateLimit-Limit,
+// RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers. Requests are
+// let through when the limiter is unavailable, unless the limit fails closed.
+func RateLimiter(rateLimiter interfaces.RateLimiter, name string, limit utils.RateLimit) gin.HandlerFunc {
+	policy := fmt.Sprintf("%d;w=%d", limit.Limit, int(limit.Period.Seconds()))
 
 	return func(ctx *gin.Context) {
+		client := "ip:" + ctx.ClientIP()
+		if userID, exists := ctx.Get("user_id"); exists {
+			client = fmt.Sprintf("user:%v", userID)
+		}
+
+		result, err := rateLimiter.Take(ctx, name+":"+client, limit)
+		if err != nil {
+			utils.IncrementRateLimitFailure()
+			log.WithFields(log.Fields{"policy": name, "client": client, "fail_closed": limit.FailClosed, "error": err}).Error("Failed to check rate limit")
+			if limit.FailClosed {
+				ctx.JSON(http.StatusServiceUnavailable, response.Response{
+					Code:    http.StatusServiceUnavailable,
+					Status:  "Service Unavailable",
+					Message: "Rate limit unavailable. Please try again later.",
+				})
+				ctx.Abort()
+				return
+			}
+			ctx.Next()
+			return
+		}
 
-		if !limiter.Allow() {
+		ctx.Header("RateLimit-Limit", strconv.Itoa(limit.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Header("RateLimit-Reset", strconv.Itoa(utils.RetryAfterSeconds(result.ResetAfter)))
+		ctx.Header("RateLimit-Policy", policy)
 
+		if !result.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(utils.RetryAfterSeconds(result.RetryAfter)))
 			ctx.JSON(http.StatusTooManyRequests, response.Response{
 				Code:    http.StatusTooManyRequests,
 				Status:  "Too Many Requests",
-				Message: "Rate limit exceeded. Please try again later.a",
+				Message: "Rate limit exceeded. Please try again later.",
 			})
 			ctx.Abort()
 			return
@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"context"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// unavailableRateLimiter fails every check, as when Redis is down
+type unavailableRateLimiter struct {
+	interfaces.RateLimiter
+}
+
+func (unavailableRateLimiter) Take(ctx context.Context, key string, limit utils.RateLimit) (*utils.RateLimitResult, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRateLimiterUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		failClosed bool
+		status     int
+	}{
+		{"Fails open", false, http.StatusOK},
+		{"Fails closed", true, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			limit := utils.RateLimit{Limit: 10, Period: time.Minute, FailClosed: tt.failClosed}
+			router.Use(RateLimiter(unavailableRateLimiter{}, "test", limit))
+			router.GET("/auth/login", func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			failures := utils.GetRateLimitFailures()
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, failures+1, utils.GetRateLimitFailures())
+		})
+	}
+}
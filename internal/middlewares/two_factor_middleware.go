@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"dz-jobs-api/config"
+	"dz-jobs-api/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorMiddleware refuses the admin sessions not logged in with a second
+// factor when admins are required to use two-factor authentication
+func TwoFactorMiddleware(config *config.AppConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if config.RequireAdminTwoFactor && ctx.GetString("role") == "admin" && !ctx.GetBool("two_factor") {
+			_ = ctx.Error(utils.NewCustomError(http.StatusForbidden, "Two-factor authentication is required for admin accounts, set it up and log in again"))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"dz-jobs-api/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		required  bool
+		role      string
+		twoFactor bool
+		admitted  bool
+	}{
+		{"Admin without second factor", true, "admin", false, false},
+		{"Admin with second factor", true, "admin", true, true},
+		{"Admin when not required", false, "admin", false, true},
+		{"Recruiter without second factor", true, "recruiter", false, true},
+		{"Candidate without second factor", true, "candidate", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				ctx.Set("role", tt.role)
+				ctx.Set("two_factor", tt.twoFactor)
+			}, TwoFactorMiddleware(&config.AppConfig{RequireAdminTwoFactor: tt.required}))
+			admitted := false
+			router.GET("/candidates/profile", func(ctx *gin.Context) {
+				admitted = true
+				ctx.Status(http.StatusOK)
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/candidates/profile", nil))
+			assert.Equal(t, tt.admitted, admitted)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Application struct {
+	ID          int64             `db:"application_id"`
+	JobID       int64             `db:"job_id"`
+	JobRevision int               `db:"job_revision"` // The revision of the job the candidate applied to
+	CandidateID uuid.UUID         `db:"candidate_id"`
+	Resume      string            `db:"resume"`
+	CoverLetter string            `db:"cover_letter"`
+	Stage       string            `db:"stage"`
+	Status      string            `db:"status"`
+	CreatedAt   time.Time         `db:"created_at" default:"CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time         `db:"updated_at" default:"CURRENT_TIMESTAMP"`
+	Answers     []ScreeningAnswer `db:"-"` // Only loaded for single applications
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email is an email rendered for a user about an event. It is queued in the
+// outbox until it is due, right away unless the quiet hours of the user hold it
+// back or a digest batches it.
+type Email struct {
+	ID             int64             `db:"email_id"`
+	UserID         uuid.UUID         `db:"user_id"`
+	Type           string            `db:"type"`
+	To             string            `db:"recipient"`
+	Subject        string            `db:"subject"`
+	PlainText      string            `db:"plain_text"`
+	HTML           string            `db:"html"`
+	UnsubscribeURL string            `db:"unsubscribe_url"`
+	Attachments    []EmailAttachment `db:"attachments"`
+	Digest         bool              `db:"digest"`
+	SendAfter      time.Time         `db:"send_after"`
+	CreatedAt      time.Time         `db:"created_at"`
+}
+
+type EmailAttachment struct {
+	Content     []byte `json:"content"`
+	ContentType string `json:"content_type"`
+	FileName    string `json:"file_name"`
+}
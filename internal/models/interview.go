@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Interview is an interview of the candidate of an application. The recruiter
+// proposes slots, the interview is scheduled once the candidate selects one.
+type Interview struct {
+	ID              int64      `db:"interview_id"`
+	ApplicationID   int64      `db:"application_id"`
+	Status          string     `db:"status"` // proposed, scheduled or cancelled
+	DurationMinutes int        `db:"duration_minutes"`
+	Location        string     `db:"location"`
+	VideoLink       string     `db:"video_link"`
+	Notes           string     `db:"notes"`
+	ScheduledAt     *time.Time `db:"scheduled_at"` // Start of the selected slot
+	Sequence        int        `db:"sequence"`     // iCalendar SEQUENCE of the invites
+	CancelReason    string     `db:"cancel_reason"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+
+	Slots []InterviewSlot `db:"-"`
+
+	// Loaded from the application, its job and the two participants
+	JobID          int64     `db:"-"`
+	JobTitle       string    `db:"-"`
+	CandidateID    uuid.UUID `db:"-"`
+	CandidateName  string    `db:"-"`
+	CandidateEmail string    `db:"-"`
+	RecruiterID    uuid.UUID `db:"-"`
+	RecruiterEmail string    `db:"-"`
+	CompanyName    string    `db:"-"`
+}
+
+// EndsAt is the end of the scheduled interview, nil while it is not scheduled
+func (i *Interview) EndsAt() *time.Time {
+	if i.ScheduledAt == nil {
+		return nil
+	}
+	endsAt := i.ScheduledAt.Add(time.Duration(i.DurationMinutes) * time.Minute)
+	return &endsAt
+}
+
+type InterviewSlot struct {
+	ID          int64     `db:"slot_id"`
+	InterviewID int64     `db:"interview_id"`
+	StartsAt    time.Time `db:"starts_at"`
+}
+
+// CalendarFeed is the secret token giving access to the iCalendar feed of the
+// upcoming interviews of a user. Only the digest of the token is stored, the
+// token itself is shown once when the feed is created.
+type CalendarFeed struct {
+	UserID    uuid.UUID `db:"user_id"`
+	TokenHash string    `db:"token_hash"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -7,15 +7,31 @@ import (
 )
 
 type Job struct {
-	ID             int64     `db:"job_id"`
-	Title          string    `db:"title"`
-	Description    string    `db:"description"`
-	Location       string    `db:"location,omitempty"`
-	SalaryRange    string    `db:"salary_range,omitempty"`
-	RequiredSkills string    `db:"required_skills,omitempty"`
-	RecruiterID    uuid.UUID `db:"recruiter_id"`
-	CreatedAt      time.Time `db:"created_at" default:"CURRENT_TIMESTAMP"`
-	UpdatedAt      time.Time `db:"updated_at" default:"CURRENT_TIMESTAMP"`
-	Status         string    `db:"status"`
-	JobType        string    `db:"job_type"`
+	ID                   int64      `db:"job_id"`
+	Title                string     `db:"title"`
+	Description          string     `db:"description"`
+	Location             string     `db:"location,omitempty"`
+	SalaryMin            *float64   `db:"salary_min"`
+	SalaryMax            *float64   `db:"salary_max"`
+	Currency             string     `db:"currency"`
+	Period               string     `db:"period"`
+	SalaryNeedsReview    bool       `db:"salary_needs_review"`
+	RequiredSkills       string     `db:"required_skills,omitempty"`
+	RecruiterID          uuid.UUID  `db:"recruiter_id"`
+	CreatedAt            time.Time  `db:"created_at" default:"CURRENT_TIMESTAMP"`
+	UpdatedAt            time.Time  `db:"updated_at" default:"CURRENT_TIMESTAMP"`
+	Status               string     `db:"status"`
+	JobType              string     `db:"job_type"`
+	PublishAt            *time.Time `db:"publish_at"`   // When a scheduled job opens
+	PublishedAt          *time.Time `db:"published_at"` // When the job was last opened or reposted
+	ExpiresAt            *time.Time `db:"expires_at"`
+	ExpiryReminderSentAt *time.Time `db:"expiry_reminder_sent_at"`
+	WilayaCode           *int       `db:"wilaya_code"`
+	CommuneCode          *int       `db:"commune_code"`
+	Latitude             *float64   `db:"latitude"` // Centroid of the commune, or of the wilaya
+	Longitude            *float64   `db:"longitude"`
+	WorkMode             string     `db:"work_mode"` // onsite, remote or hybrid
+	CategoryID           *int64     `db:"category_id"`
+	Snippet              string     `db:"-"`
+	RecruiterEmail       string     `db:"-"` // Only loaded for the expiry reminders
 }
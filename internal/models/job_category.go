@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// JobCategory is a node of the job categories tree, top level categories have
+// no parent
+type JobCategory struct {
+	ID        int64          `db:"category_id"`
+	ParentID  *int64         `db:"parent_id"`
+	Name      string         `db:"name"`
+	Slug      string         `db:"slug"`
+	CreatedAt time.Time      `db:"created_at"`
+	Children  []*JobCategory `db:"-"`
+}
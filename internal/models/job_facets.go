@@ -0,0 +1,20 @@
+package models
+
+// JobFacetValue is the number of jobs matching a search that share one value of
+// a facet. Category values also carry the value of their parent category.
+type JobFacetValue struct {
+	Value  string
+	Label  string
+	Parent string
+	Count  int
+}
+
+// JobFacets are the counts of the jobs matching a search by category, job type,
+// wilaya, salary bucket and status
+type JobFacets struct {
+	Categories    []JobFacetValue
+	JobTypes      []JobFacetValue
+	Wilayas       []JobFacetValue
+	SalaryBuckets []JobFacetValue
+	Statuses      []JobFacetValue
+}
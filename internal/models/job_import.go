@@ -0,0 +1,11 @@
+package models
+
+// JobImportResult is the outcome of one row of a job import: "valid" in a dry
+// run, "created", "invalid" when the row breaks the job rules or "failed" when
+// the job could not be saved
+type JobImportResult struct {
+	Line   int
+	Status string
+	JobID  int64
+	Errors []string
+}
@@ -0,0 +1,13 @@
+package models
+
+// JobPosting is a public job along with the recruiter who posted it, its page on
+// the front end, its wilaya and commune and its category path, as published to
+// structured data consumers
+type JobPosting struct {
+	Job        *Job
+	Recruiter  *Recruiter
+	URL        string
+	Wilaya     *Wilaya
+	Commune    *Commune
+	Categories []*JobCategory // From the top level category down to the job's
+}
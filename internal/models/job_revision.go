@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobSnapshot is the content of a job as kept by each of its revisions, stored as
+// JSON. The status and schedule of a job are not part of it.
+type JobSnapshot struct {
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	Location       string   `json:"location"`
+	SalaryMin      *float64 `json:"salary_min"`
+	SalaryMax      *float64 `json:"salary_max"`
+	Currency       string   `json:"currency"`
+	Period         string   `json:"period"`
+	RequiredSkills string   `json:"required_skills"`
+	JobType        string   `json:"job_type"`
+	WilayaCode     *int     `json:"wilaya_code,omitempty"`
+	CommuneCode    *int     `json:"commune_code,omitempty"`
+	WorkMode       string   `json:"work_mode,omitempty"` // Not set by the revisions recorded before work modes
+	CategoryID     *int64   `json:"category_id,omitempty"`
+}
+
+type JobRevision struct {
+	JobID        int64       `db:"job_id"`
+	Revision     int         `db:"revision"`
+	Snapshot     JobSnapshot `db:"snapshot"`
+	EditedBy     uuid.UUID   `db:"edited_by"`
+	RestoredFrom *int        `db:"restored_from"`
+	CreatedAt    time.Time   `db:"created_at" default:"CURRENT_TIMESTAMP"`
+}
+
+// JobFieldChange is a field that differs between two revisions of a job
+type JobFieldChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
@@ -0,0 +1,29 @@
+package models
+
+// LocationName is the name of a wilaya or commune in French, Arabic and English
+type LocationName struct {
+	FR string `json:"fr"`
+	AR string `json:"ar"`
+	EN string `json:"en"`
+}
+
+// Wilaya is one of the 58 provinces of Algeria, located by the centroid of its
+// seat. Its code is its official number, from 1 (Adrar) to 58 (El Meniaa).
+type Wilaya struct {
+	Code      int          `json:"code"`
+	Name      LocationName `json:"name"`
+	Aliases   []string     `json:"aliases"`
+	Latitude  float64      `json:"latitude"`
+	Longitude float64      `json:"longitude"`
+	Communes  []Commune    `json:"communes"`
+}
+
+// Commune is a municipality of a wilaya, its code is the wilaya code followed by
+// its two digit number within the wilaya
+type Commune struct {
+	Code       int          `json:"code"`
+	WilayaCode int          `json:"-"`
+	Name       LocationName `json:"name"`
+	Latitude   float64      `json:"latitude"`
+	Longitude  float64      `json:"longitude"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is a message of the conversation thread of an application, between the
+// recruiter who owns the job and the candidate who applied
+type Message struct {
+	ID            int64      `db:"message_id"`
+	ApplicationID int64      `db:"application_id"`
+	SenderID      uuid.UUID  `db:"sender_id"`
+	SenderRole    string     `db:"-"` // candidate or recruiter, depending on the side of the thread of the sender
+	Body          string     `db:"body"`
+	ReadAt        *time.Time `db:"read_at"`    // Set when the other participant reads the message
+	RemovedAt     *time.Time `db:"removed_at"` // Set when an admin removes the message after a report
+	CreatedAt     time.Time  `db:"created_at"`
+
+	Attachments []MessageAttachment `db:"-"`
+}
+
+type MessageAttachment struct {
+	ID          int64  `db:"attachment_id"`
+	MessageID   int64  `db:"message_id"`
+	URL         string `db:"url"`
+	FileName    string `db:"file_name"`
+	ContentType string `db:"content_type"`
+	Size        int64  `db:"size"`
+}
+
+// Conversation is the thread of an application as seen by one of its
+// participants, with the number of messages they have not read yet
+type Conversation struct {
+	ApplicationID int64
+	JobID         int64
+	JobTitle      string
+	CompanyName   string
+	CandidateID   uuid.UUID
+	CandidateName string
+	RecruiterID   uuid.UUID
+	LastMessageAt time.Time
+	UnreadCount   int
+}
+
+// MessageReport is a message reported by the participant who received it, for an
+// admin to dismiss or to remove the message
+type MessageReport struct {
+	ID         int64      `db:"report_id"`
+	MessageID  int64      `db:"message_id"`
+	ReportedBy uuid.UUID  `db:"reported_by"`
+	Reason     string     `db:"reason"`
+	Status     string     `db:"status"` // open, dismissed or removed
+	ReviewedBy *uuid.UUID `db:"reviewed_by"`
+	ReviewNote string     `db:"review_note"`
+	ReviewedAt *time.Time `db:"reviewed_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+
+	Message *Message `db:"-"` // The reported message, loaded for admins
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types, one per event users are told about
+const (
+	NotificationApplicationReceived      = "application_received"
+	NotificationApplicationStatusChanged = "application_status_changed"
+	NotificationInterviewProposed        = "interview_proposed"
+	NotificationInterviewScheduled       = "interview_scheduled"
+	NotificationInterviewCancelled       = "interview_cancelled"
+	NotificationMessageReceived          = "message_received"
+	NotificationBookmarkedJobClosing     = "bookmarked_job_closing"
+	NotificationJobExpiring              = "job_expiring"
+	NotificationJobAlert                 = "job_alert"
+)
+
+// Notification tells a user about an event relevant to them, it is stored for the
+// notification center and pushed to the user's open notification streams
+type Notification struct {
+	ID        int64            `db:"notification_id"`
+	UserID    uuid.UUID        `db:"user_id"`
+	Type      string           `db:"type"`
+	Title     string           `db:"title"`
+	Body      string           `db:"body"`
+	Data      NotificationData `db:"data"`
+	ReadAt    *time.Time       `db:"read_at"`
+	CreatedAt time.Time        `db:"created_at"`
+}
+
+// NotificationData identifies what a notification is about, only the fields
+// relevant to its type are set
+type NotificationData struct {
+	JobID         int64 `json:"job_id,omitempty"`
+	ApplicationID int64 `json:"application_id,omitempty"`
+	InterviewID   int64 `json:"interview_id,omitempty"`
+	MessageID     int64 `json:"message_id,omitempty"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification channels, how users are told about an event
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelInApp = "in_app"
+)
+
+// NotificationEvent describes an event users can be told about, the channels it
+// is delivered on and whether digests batch its emails
+type NotificationEvent struct {
+	Type        string
+	Channels    []string
+	LowPriority bool
+}
+
+// NotificationEvents lists every event users can set preferences for
+var NotificationEvents = []NotificationEvent{
+	{Type: NotificationApplicationReceived, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}, LowPriority: true},
+	{Type: NotificationApplicationStatusChanged, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}},
+	{Type: NotificationInterviewProposed, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}},
+	{Type: NotificationInterviewScheduled, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}},
+	{Type: NotificationInterviewCancelled, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}},
+	{Type: NotificationMessageReceived, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}, LowPriority: true},
+	{Type: NotificationBookmarkedJobClosing, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}, LowPriority: true},
+	{Type: NotificationJobExpiring, Channels: []string{NotificationChannelEmail, NotificationChannelInApp}},
+	{Type: NotificationJobAlert, Channels: []string{NotificationChannelEmail}},
+}
+
+// NotificationPreferences are the choices of a user on how they are told about
+// events. An event is delivered on every channel the user did not turn off.
+type NotificationPreferences struct {
+	UserID   uuid.UUID                       `db:"user_id"`
+	Channels []NotificationChannelPreference `db:"-"`
+	// QuietHoursStart and QuietHoursEnd are minutes after midnight in Algiers
+	// time, emails are held back between them
+	QuietHoursStart *int       `db:"quiet_hours_start"`
+	QuietHoursEnd   *int       `db:"quiet_hours_end"`
+	Digest          bool       `db:"digest"` // Batch the emails of low priority events in a daily digest
+	UpdatedAt       *time.Time `db:"updated_at"`
+}
+
+type NotificationChannelPreference struct {
+	Type    string `db:"type"`
+	Channel string `db:"channel"`
+	Enabled bool   `db:"enabled"`
+}
+
+// Allows tells whether the user wants to be told about an event on a channel
+func (p *NotificationPreferences) Allows(notificationType, channel string) bool {
+	for _, preference := range p.Channels {
+		if preference.Type == notificationType && preference.Channel == channel {
+			return preference.Enabled
+		}
+	}
+	return true
+}
+
+// IsLowPriorityNotification tells whether digests batch the emails of an event
+func IsLowPriorityNotification(notificationType string) bool {
+	for _, event := range NotificationEvents {
+		if event.Type == notificationType {
+			return event.LowPriority
+		}
+	}
+	return false
+}
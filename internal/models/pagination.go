@@ -0,0 +1,10 @@
+package models
+
+// PageInfo describes the page of a list returned by a repository
+type PageInfo struct {
+	Total      int
+	Limit      int
+	Offset     int
+	NextCursor string
+	PrevCursor string
+}
@@ -13,4 +13,6 @@ type CandidatePersonalInfo struct {
 	DateOfBirth string    `db:"date_of_birth"`
 	Gender      string    `db:"gender"`
 	Bio         string    `db:"bio"`
+	WilayaCode  *int      `db:"wilaya_code"`
+	CommuneCode *int      `db:"commune_code"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PipelineStage struct {
+	ID          int64     `db:"stage_id"`
+	RecruiterID uuid.UUID `db:"recruiter_id"`
+	Name        string    `db:"name"`
+	Category    string    `db:"category"`
+	Position    int       `db:"position"`
+}
+
+type ApplicationTransition struct {
+	ID            int64     `db:"transition_id"`
+	ApplicationID int64     `db:"application_id"`
+	FromStage     string    `db:"from_stage"`
+	ToStage       string    `db:"to_stage"`
+	MovedBy       uuid.UUID `db:"moved_by"`
+	Reason        string    `db:"reason"`
+	CreatedAt     time.Time `db:"created_at" default:"CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "github.com/google/uuid"
+
+// CandidateProfile gathers the parts of a candidate profile jobs are matched on
+type CandidateProfile struct {
+	CandidateID      uuid.UUID
+	Name             string
+	Address          string
+	WilayaCode       *int
+	CommuneCode      *int
+	Skills           []Skill
+	ExperienceTitles []string
+}
+
+// MatchCriterion is the part of a match score given by one criterion
+type MatchCriterion struct {
+	Criterion string
+	Score     float64
+	MaxScore  float64
+	Detail    string
+}
+
+// MatchScore is the explainable score, out of 100, of a candidate against a job
+type MatchScore struct {
+	Score         int
+	MatchedSkills []string
+	MissingSkills []string
+	Breakdown     []MatchCriterion
+}
+
+type JobRecommendation struct {
+	Job   *Job
+	Match MatchScore
+}
+
+type CandidateMatch struct {
+	Candidate *CandidateProfile
+	Match     MatchScore
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavedSearch struct {
+	ID               int64      `db:"saved_search_id"`
+	CandidateID      uuid.UUID  `db:"candidate_id"`
+	Name             string     `db:"name"`
+	Filters          []byte     `db:"filters"` // JSON encoded request.SavedSearchFilters
+	Frequency        string     `db:"frequency"`
+	UnsubscribeToken string     `db:"unsubscribe_token"`
+	AlertsEnabled    bool       `db:"alerts_enabled"`
+	LastRunAt        *time.Time `db:"last_run_at"`
+	NextRunAt        time.Time  `db:"next_run_at"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+	CandidateEmail   string     `db:"-"` // Only loaded for the searches due to run
+}
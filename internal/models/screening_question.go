@@ -0,0 +1,42 @@
+package models
+
+// ScreeningQuestion is a question a candidate answers when applying to a job.
+// Options are the choices of the single and multiple choice questions.
+type ScreeningQuestion struct {
+	ID       int64    `db:"question_id"`
+	JobID    int64    `db:"job_id"`
+	Position int      `db:"position"`
+	Type     string   `db:"type"` // yes_no, number, single_choice, multiple_choice or text
+	Prompt   string   `db:"prompt"`
+	Required bool     `db:"required"`
+	Options  []string `db:"options"`
+	// Auto-reject rule: a yes/no answer or a chosen option listed in RejectAnswers,
+	// or a number outside of RejectBelow and RejectAbove, knocks the candidate out
+	RejectAnswers []string `db:"reject_answers"`
+	RejectBelow   *float64 `db:"reject_below"`
+	RejectAbove   *float64 `db:"reject_above"`
+}
+
+// ScreeningAnswer is the answer of an application to a screening question, Value
+// holds the yes/no, single choice and text answers
+type ScreeningAnswer struct {
+	ApplicationID int64    `db:"application_id"`
+	QuestionID    *int64   `db:"question_id"` // Nil once the question is removed from the job
+	Position      int      `db:"position"`
+	Type          string   `db:"type"`
+	Prompt        string   `db:"prompt"`
+	Value         string   `db:"value"`
+	Number        *float64 `db:"number"`
+	Choices       []string `db:"choices"`
+	Knockout      bool     `db:"knockout"` // The answer matched the auto-reject rule of the question
+}
+
+// AnswerFilter selects the applications whose answer to a question matches Value,
+// or lies between Min and Max for number questions
+type AnswerFilter struct {
+	QuestionID int64
+	Type       string
+	Value      string
+	Min        *float64
+	Max        *float64
+}
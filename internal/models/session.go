@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a device a user is logged in on. Its refresh token is rotated on
+// every refresh, the tokens issued along the way form the token family of the
+// session and only the latest one, whose hash is kept, is accepted. TwoFactor
+// is set for the sessions logged in with a second factor.
+type Session struct {
+	ID         uuid.UUID `json:"session_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Role       string    `json:"role"`
+	TokenHash  string    `json:"token_hash"`
+	TwoFactor  bool      `json:"two_factor"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type Skill struct {
+	ID        int64     `db:"skill_id"`
+	Name      string    `db:"name"`
+	Slug      string    `db:"slug"`
+	Category  string    `db:"category"`
+	Aliases   []string  `db:"-"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -5,6 +5,8 @@ import (
 )
 
 type CandidateSkills struct {
-	ID    uuid.UUID `db:"candidate_id"`
-	Skill string    `db:"skill"`
+	ID       uuid.UUID `db:"candidate_id"`
+	SkillID  int64     `db:"skill_id"`
+	Skill    string    `db:"skill"`
+	Category string    `db:"category"`
 }
@@ -7,11 +7,12 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `db:"user_id"`
-	Name      string    `db:"name"`
-	Email     string    `db:"email"`
-	Password  string    `db:"password"`
-	Role      string    `db:"role"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID              uuid.UUID  `db:"user_id"`
+	Name            string     `db:"name"`
+	Email           string     `db:"email"`
+	Password        string     `db:"password"`
+	Role            string     `db:"role"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
 }
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrAlreadyApplied is returned when a candidate already has an application to the job
+var ErrAlreadyApplied = errors.New("repository: candidate already applied to job")
+
+type ApplicationRepository interface {
+	CreateApplication(ctx context.Context, application *models.Application) error
+	GetApplication(ctx context.Context, applicationID int64) (*models.Application, error)
+	GetApplicationByJobAndCandidate(ctx context.Context, jobID int64, candidateID uuid.UUID) (*models.Application, error)
+	GetApplicationsByCandidate(ctx context.Context, candidateID uuid.UUID, page request.PageRequest) ([]*models.Application, *models.PageInfo, error)
+	GetApplicationsByJob(ctx context.Context, jobID int64, answers []models.AnswerFilter, page request.PageRequest) ([]*models.Application, *models.PageInfo, error)
+	GetApplicationAnswers(ctx context.Context, applicationID int64) ([]models.ScreeningAnswer, error)
+	DeleteApplication(ctx context.Context, jobID int64, candidateID uuid.UUID, stage string) error
+	TransitionApplication(ctx context.Context, transition *models.ApplicationTransition, status string) error
+	GetApplicationTransitions(ctx context.Context, applicationID int64) ([]*models.ApplicationTransition, error)
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"dz-jobs-api/internal/dto/request"
 	"dz-jobs-api/internal/models"
 
 	"github.com/google/uuid"
@@ -10,5 +11,6 @@ import (
 type BookmarksRepository interface {
 	AddBookmark(ctx context.Context, candidateID uuid.UUID, jobID int64) error
 	RemoveBookmark(ctx context.Context, candidateID uuid.UUID, jobID int64) error
-	GetBookmarks(ctx context.Context, candidateID uuid.UUID) ([]*models.Job, error)
+	GetBookmarks(ctx context.Context, candidateID uuid.UUID, page request.PageRequest) ([]*models.Job, *models.PageInfo, error)
+	GetJobBookmarkers(ctx context.Context, jobID int64) ([]uuid.UUID, error)
 }
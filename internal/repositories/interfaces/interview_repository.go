@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InterviewRepository interface {
+	CreateInterview(ctx context.Context, interview *models.Interview) error
+	GetInterview(ctx context.Context, interviewID int64) (*models.Interview, error)
+	GetApplicationInterviews(ctx context.Context, applicationID int64) ([]*models.Interview, error)
+	GetUpcomingInterviews(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.Interview, error)
+	UpdateInterview(ctx context.Context, interview *models.Interview, fromStatus string, replaceSlots bool) error
+	GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error)
+	SaveCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	"errors"
+)
+
+// ErrCategoryExists is returned when a category slug is already used by another category
+var ErrCategoryExists = errors.New("repository: category already exists")
+
+// ErrCategoryHasChildren is returned when deleting a category that still has subcategories
+var ErrCategoryHasChildren = errors.New("repository: category has subcategories")
+
+type JobCategoryRepository interface {
+	GetCategories(ctx context.Context) ([]*models.JobCategory, error)
+	GetCategory(ctx context.Context, categoryID int64) (*models.JobCategory, error)
+	CreateCategory(ctx context.Context, category *models.JobCategory) error
+	UpdateCategory(ctx context.Context, category *models.JobCategory) error
+	DeleteCategory(ctx context.Context, categoryID int64) error
+}
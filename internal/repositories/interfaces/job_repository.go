@@ -4,20 +4,31 @@ import (
 	"context"
 	"dz-jobs-api/internal/dto/request"
 	"dz-jobs-api/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type JobRepository interface {
-	CreateJob(ctx context.Context, job *models.Job) error
+	CreateJob(ctx context.Context, job *models.Job, skillIDs []int64) error
 	GetJobDetails(ctx context.Context, jobID int64, recruiterID uuid.UUID) (*models.Job, error)
-	GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID) ([]*models.Job, error)
-	UpdateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job) error
+	GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID, page request.PageRequest) ([]*models.Job, *models.PageInfo, error)
+	ExportJobs(ctx context.Context, recruiterID uuid.UUID, status string, fn func(job *models.Job) error) error
+	UpdateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job, skillIDs []int64) error
+	RestoreJobRevision(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job, revision int, skillIDs []int64) error
+	GetJobRevisions(ctx context.Context, jobID int64) ([]*models.JobRevision, error)
+	GetJobRevision(ctx context.Context, jobID int64, revision int) (*models.JobRevision, error)
 	DeactivateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) error
-	RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) error
+	RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, repostedAt, expiresAt time.Time) error
 	DeleteJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) error
 	ValidateJobOwnership(ctx context.Context, jobID int64, recruiterID uuid.UUID) error
-	GetAllJobs(ctx context.Context, ) ([]*models.Job, error)
-	GetJobListings(ctx context.Context, filters request.JobFilters) ([]*models.Job, error)
+	GetAllJobs(ctx context.Context, page request.PageRequest) ([]*models.Job, *models.PageInfo, error)
+	GetJobListings(ctx context.Context, filters request.JobFilters) ([]*models.Job, *models.PageInfo, error)
+	SearchJobs(ctx context.Context, filters request.JobFilters) ([]*models.Job, *models.PageInfo, *models.JobFacets, error)
 	GetJobDetailsPublic(ctx context.Context, jobID int64) (*models.Job, error)
+	GetJob(ctx context.Context, jobID int64) (*models.Job, error)
+	GetSitemapJobs(ctx context.Context, limit int) ([]*models.Job, error)
+	PublishScheduledJobs(ctx context.Context, now time.Time) (int64, error)
+	ExpireJobs(ctx context.Context, now time.Time) (int64, error)
+	ClaimExpiringJobs(ctx context.Context, now, before time.Time) ([]*models.Job, error)
 }
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrMessageReported is returned when a user reports a message they already reported
+var ErrMessageReported = errors.New("repository: message already reported")
+
+type MessageRepository interface {
+	CreateMessage(ctx context.Context, message *models.Message) error
+	GetMessage(ctx context.Context, messageID int64) (*models.Message, error)
+	GetMessages(ctx context.Context, applicationID int64, page request.PageRequest) ([]*models.Message, *models.PageInfo, error)
+	MarkMessagesRead(ctx context.Context, applicationID int64, readerID uuid.UUID, readAt time.Time) (int64, error)
+	GetConversations(ctx context.Context, userID uuid.UUID, page request.PageRequest) ([]*models.Conversation, *models.PageInfo, error)
+	CountUnreadMessages(ctx context.Context, userID uuid.UUID) (int, error)
+	CreateReport(ctx context.Context, report *models.MessageReport) error
+	GetReport(ctx context.Context, reportID int64) (*models.MessageReport, error)
+	GetReports(ctx context.Context, status string, page request.PageRequest) ([]*models.MessageReport, *models.PageInfo, error)
+	ReviewReport(ctx context.Context, report *models.MessageReport) error
+}
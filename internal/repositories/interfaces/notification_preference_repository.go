@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationPreferenceRepository interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+}
+
+// EmailRepository is the outbox of the emails waiting to be sent, right away or
+// once quiet hours end or their digest is due
+type EmailRepository interface {
+	QueueEmail(ctx context.Context, email *models.Email) error
+	ClaimDueEmails(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Email, error)
+	DeleteEmails(ctx context.Context, emailIDs []int64) error
+}
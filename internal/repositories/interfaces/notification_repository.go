@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *models.Notification) error
+	GetNotifications(ctx context.Context, userID uuid.UUID, filters request.NotificationFilters) ([]*models.Notification, *models.PageInfo, error)
+	GetNotificationsAfter(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]*models.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkNotificationRead(ctx context.Context, userID uuid.UUID, notificationID int64, readAt time.Time) (*models.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+}
+
+// NotificationBroker fans notifications out to every instance of the API, so that
+// a notification reaches the streams a user opened on any of them
+type NotificationBroker interface {
+	Publish(ctx context.Context, notification *models.Notification) error
+	Subscribe(ctx context.Context) (<-chan *models.Notification, error)
+}
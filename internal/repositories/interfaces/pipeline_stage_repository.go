@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrStageInUse is returned when replacing a stage set would remove a stage that still holds applications
+var ErrStageInUse = errors.New("repository: pipeline stage still holds applications")
+
+type PipelineStageRepository interface {
+	GetStages(ctx context.Context, recruiterID uuid.UUID) ([]models.PipelineStage, error)
+	ReplaceStages(ctx context.Context, recruiterID uuid.UUID, stages []models.PipelineStage) error
+}
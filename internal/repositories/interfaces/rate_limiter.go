@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/pkg/utils"
+)
+
+type RateLimiter interface {
+	Take(ctx context.Context, key string, limit utils.RateLimit) (*utils.RateLimitResult, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type RecommendationRepository interface {
+	GetCandidateProfile(ctx context.Context, candidateID uuid.UUID) (*models.CandidateProfile, error)
+	GetCandidateProfilesForJob(ctx context.Context, jobID int64, limit int) ([]*models.CandidateProfile, error)
+	GetOpenJobsForCandidate(ctx context.Context, candidateID uuid.UUID, limit int) ([]*models.Job, error)
+	GetJobSkills(ctx context.Context, jobIDs []int64) (map[int64][]models.Skill, error)
+}
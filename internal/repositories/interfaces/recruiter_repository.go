@@ -10,6 +10,7 @@ import (
 type RecruiterRepository interface {
 	CreateRecruiter(ctx context.Context, recruiter *models.Recruiter) error
 	GetRecruiter(ctx context.Context, recruiterID uuid.UUID) (*models.Recruiter, error)
+	GetRecruiters(ctx context.Context, recruiterIDs []uuid.UUID) (map[uuid.UUID]*models.Recruiter, error)
 	UpdateRecruiter(ctx context.Context, recruiterID uuid.UUID, recruiter *models.Recruiter) error
 	DeleteRecruiter(ctx context.Context, recruiterID uuid.UUID) error
 }
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"dz-jobs-api/internal/models"
 	"dz-jobs-api/pkg/utils"
 	"time"
 )
@@ -10,13 +11,25 @@ type RedisRepository interface {
 	StoreOTP(ctx context.Context, email, otp string, expiry time.Duration) error
 	GetOTP(ctx context.Context, email string) (string, error)
 	InvalidateOTP(ctx context.Context, email string) error
+	StoreVerificationOTP(ctx context.Context, email, otp string, expiry time.Duration) error
+	GetVerificationOTP(ctx context.Context, email string) (string, error)
+	InvalidateVerificationOTP(ctx context.Context, email string) error
+	AcquireVerificationCooldown(ctx context.Context, email string, cooldown time.Duration) (bool, error)
 	StoreResetToken(ctx context.Context, email, token string, expiry time.Duration) error
 	GetResetToken(ctx context.Context, email string) (string, error)
 	InvalidateResetToken(ctx context.Context, email string) error
-	StoreRefreshToken(ctx context.Context, UserID, refreshToken string, expiry time.Duration) error
-	GetRefreshToken(ctx context.Context, UserID string) (string, error)
-	InvalidateRefreshToken(ctx context.Context, UserID string) error
+	RecordAttempt(ctx context.Context, key string, at time.Time, window time.Duration) (string, []time.Time, error)
+	RemoveAttempt(ctx context.Context, key, attemptID string) error
+	ClearAttempts(ctx context.Context, key string) error
+	StoreSession(ctx context.Context, session *models.Session, expiry time.Duration) error
+	GetSession(ctx context.Context, sessionID string) (*models.Session, error)
+	SessionExists(ctx context.Context, sessionID string) (bool, error)
+	GetUserSessions(ctx context.Context, userID string) ([]*models.Session, error)
+	RotateSession(ctx context.Context, session *models.Session, previousTokenHash string, expiry time.Duration) (bool, error)
+	InvalidateSession(ctx context.Context, userID, sessionID string) error
 	StoreAssetCache(ctx context.Context, assetID string, assetType string, data *utils.AssetCache, expiry time.Duration) error
 	GetAssetCache(ctx context.Context, assetID string, assetType string) (*utils.AssetCache, error)
 	InvalidateAssetCache(ctx context.Context, assetID string, assetType string) error
+	StoreFeedCache(ctx context.Context, feedKey string, data *utils.FeedCache, expiry time.Duration) error
+	GetFeedCache(ctx context.Context, feedKey string) (*utils.FeedCache, error)
 }
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavedSearchRepository interface {
+	CreateSavedSearch(ctx context.Context, search *models.SavedSearch) error
+	GetSavedSearch(ctx context.Context, searchID int64, candidateID uuid.UUID) (*models.SavedSearch, error)
+	GetSavedSearches(ctx context.Context, candidateID uuid.UUID) ([]*models.SavedSearch, error)
+	CountSavedSearches(ctx context.Context, candidateID uuid.UUID) (int, error)
+	UpdateSavedSearch(ctx context.Context, search *models.SavedSearch) error
+	DeleteSavedSearch(ctx context.Context, searchID int64, candidateID uuid.UUID) error
+	DisableAlerts(ctx context.Context, unsubscribeToken string) error
+	ClaimDueSavedSearches(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.SavedSearch, error)
+	MarkSavedSearchRun(ctx context.Context, searchID int64, ranAt, nextRunAt time.Time) error
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+)
+
+type ScreeningQuestionRepository interface {
+	GetQuestions(ctx context.Context, jobID int64) ([]models.ScreeningQuestion, error)
+	ReplaceQuestions(ctx context.Context, jobID int64, questions []models.ScreeningQuestion) error
+}
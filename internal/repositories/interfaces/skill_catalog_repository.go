@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	"errors"
+)
+
+// ErrSkillExists is returned when a skill name or alias is already used by another skill
+var ErrSkillExists = errors.New("repository: skill name or alias already exists")
+
+type SkillCatalogRepository interface {
+	SearchSkills(ctx context.Context, prefix string, limit int) ([]*models.Skill, error)
+	GetSkill(ctx context.Context, skillID int64) (*models.Skill, error)
+	FindSkill(ctx context.Context, name string) (*models.Skill, error)
+	FindOrCreateSkill(ctx context.Context, name string) (*models.Skill, error)
+	CreateSkill(ctx context.Context, skill *models.Skill) error
+	UpdateSkill(ctx context.Context, skill *models.Skill) error
+	MergeSkills(ctx context.Context, targetID int64, sourceIDs []int64) error
+}
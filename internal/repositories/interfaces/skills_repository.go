@@ -10,5 +10,5 @@ import (
 type CandidateSkillsRepository interface {
 	CreateSkill(ctx context.Context, skill *models.CandidateSkills) error
 	GetSkills(ctx context.Context, candidateID uuid.UUID) ([]models.CandidateSkills, error)
-	DeleteSkill(ctx context.Context, candidateID uuid.UUID, skill string) error
+	DeleteSkill(ctx context.Context, candidateID uuid.UUID, skillID int64) error
 }
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type TwoFactorRepository interface {
+	GetTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactor, error)
+	GetTwoFactors(ctx context.Context) ([]*models.TwoFactor, error)
+	ReplaceSecret(ctx context.Context, userID uuid.UUID, oldSecret, newSecret string) error
+	SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTwoFactor(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+	DisableTwoFactor(ctx context.Context, userID uuid.UUID) error
+}
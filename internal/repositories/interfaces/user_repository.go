@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"dz-jobs-api/internal/dto/request"
 	"dz-jobs-api/internal/models"
 
 	"github.com/google/uuid"
@@ -11,8 +12,9 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
-	GetAllUsers(ctx context.Context, ) ([]*models.User, error)
+	GetAllUsers(ctx context.Context, page request.PageRequest) ([]*models.User, *models.PageInfo, error)
 	UpdateUser(ctx context.Context, userID uuid.UUID, user *models.User) error
 	UpdateUserPassword(ctx context.Context, email, hashedPassword string) error
+	MarkEmailVerified(ctx context.Context, userID uuid.UUID) error
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
 }
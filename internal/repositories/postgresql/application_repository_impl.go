@@ -0,0 +1,339 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const applicationColumns = `application_id, job_id, job_revision, candidate_id, resume, cover_letter, stage, status, created_at, updated_at`
+
+type SQLApplicationRepository struct {
+	db *sql.DB
+}
+
+func NewApplicationRepository(db *sql.DB) repositoryInterfaces.ApplicationRepository {
+	return &SQLApplicationRepository{
+		db: db,
+	}
+}
+
+// CreateApplication inserts the application, attached to the latest revision of its
+// job, along with its answers to the screening questions in a single transaction
+func (r *SQLApplicationRepository) CreateApplication(ctx context.Context, application *models.Application) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        INSERT INTO applications (
+            job_id, job_revision, candidate_id, resume, cover_letter, stage, status, created_at, updated_at
+        ) VALUES (
+            $1, (SELECT MAX(revision) FROM job_revisions WHERE job_id = $1), $2, $3, $4, $5, $6, $7, $8
+        ) RETURNING application_id, job_revision
+    `
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		application.JobID, application.CandidateID, application.Resume, application.CoverLetter,
+		application.Stage, application.Status, application.CreatedAt, application.UpdatedAt,
+	).Scan(&application.ID, &application.JobRevision)
+
+	if err != nil {
+		if isConstraintViolation(err, "applications_job_candidate_unique") {
+			return repositoryInterfaces.ErrAlreadyApplied
+		}
+		return fmt.Errorf("repository: failed to create application: %w", err)
+	}
+
+	answerQuery := `INSERT INTO application_answers (application_id, question_id, position, type, prompt, value, number, choices, knockout)
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	for i := range application.Answers {
+		answer := &application.Answers[i]
+		answer.ApplicationID = application.ID
+		_, err := tx.ExecContext(ctx, answerQuery,
+			answer.ApplicationID, answer.QuestionID, answer.Position, answer.Type, answer.Prompt,
+			answer.Value, answer.Number, pq.Array(nonNilStrings(answer.Choices)), answer.Knockout,
+		)
+		if err != nil {
+			return fmt.Errorf("repository: failed to save application answer: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetApplicationAnswers returns the answers of an application to the screening
+// questions, in the order the questions were asked
+func (r *SQLApplicationRepository) GetApplicationAnswers(ctx context.Context, applicationID int64) ([]models.ScreeningAnswer, error) {
+	query := `SELECT application_id, question_id, position, type, prompt, value, number, choices, knockout
+              FROM application_answers WHERE application_id = $1 ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch application answers: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []models.ScreeningAnswer
+	for rows.Next() {
+		var answer models.ScreeningAnswer
+		err := rows.Scan(
+			&answer.ApplicationID, &answer.QuestionID, &answer.Position, &answer.Type, &answer.Prompt,
+			&answer.Value, &answer.Number, pq.Array(&answer.Choices), &answer.Knockout,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan application answer: %w", err)
+		}
+		answers = append(answers, answer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	return answers, nil
+}
+
+func (r *SQLApplicationRepository) GetApplication(ctx context.Context, applicationID int64) (*models.Application, error) {
+	query := `SELECT ` + applicationColumns + ` FROM applications WHERE application_id = $1`
+
+	application, err := scanApplication(r.db.QueryRowContext(ctx, query, applicationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch application by ID: %w", err)
+	}
+	return application, nil
+}
+
+func (r *SQLApplicationRepository) GetApplicationByJobAndCandidate(ctx context.Context, jobID int64, candidateID uuid.UUID) (*models.Application, error) {
+	query := `SELECT ` + applicationColumns + ` FROM applications WHERE job_id = $1 AND candidate_id = $2`
+
+	application, err := scanApplication(r.db.QueryRowContext(ctx, query, jobID, candidateID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch application: %w", err)
+	}
+	return application, nil
+}
+
+func (r *SQLApplicationRepository) GetApplicationsByCandidate(ctx context.Context, candidateID uuid.UUID, page request.PageRequest) ([]*models.Application, *models.PageInfo, error) {
+	return r.queryApplicationPage(ctx, ` FROM applications WHERE candidate_id = $1`, []interface{}{candidateID}, page)
+}
+
+// GetApplicationsByJob returns the applications to a job whose answers match all
+// of the answer filters
+func (r *SQLApplicationRepository) GetApplicationsByJob(ctx context.Context, jobID int64, answers []models.AnswerFilter, page request.PageRequest) ([]*models.Application, *models.PageInfo, error) {
+	from := ` FROM applications WHERE job_id = $1`
+	args := []interface{}{jobID}
+	for _, filter := range answers {
+		args = append(args, filter.QuestionID)
+		condition := fmt.Sprintf(`a.question_id = $%d`, len(args))
+		switch filter.Type {
+		case "number":
+			if filter.Min != nil {
+				args = append(args, *filter.Min)
+				condition += fmt.Sprintf(` AND a.number >= $%d`, len(args))
+			}
+			if filter.Max != nil {
+				args = append(args, *filter.Max)
+				condition += fmt.Sprintf(` AND a.number <= $%d`, len(args))
+			}
+		case "multiple_choice":
+			args = append(args, filter.Value)
+			condition += fmt.Sprintf(` AND $%d = ANY(a.choices)`, len(args))
+		case "text":
+			args = append(args, "%"+escapeLike(filter.Value)+"%")
+			condition += fmt.Sprintf(` AND a.value ILIKE $%d`, len(args))
+		default:
+			args = append(args, filter.Value)
+			condition += fmt.Sprintf(` AND a.value = $%d`, len(args))
+		}
+		from += ` AND EXISTS (SELECT 1 FROM application_answers a WHERE a.application_id = applications.application_id AND ` + condition + `)`
+	}
+	return r.queryApplicationPage(ctx, from, args, page)
+}
+
+// DeleteApplication withdraws the application of a candidate if it is still on
+// stage, so that a concurrent move is not withdrawn unseen. The withdrawal is
+// appended to the transition log, which outlives the application.
+func (r *SQLApplicationRepository) DeleteApplication(ctx context.Context, jobID int64, candidateID uuid.UUID, stage string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var applicationID int64
+	err = tx.QueryRowContext(ctx,
+		`DELETE FROM applications WHERE job_id = $1 AND candidate_id = $2 AND stage = $3 RETURNING application_id`,
+		jobID, candidateID, stage,
+	).Scan(&applicationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("repository: failed to delete application: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO application_transitions (application_id, from_stage, to_stage, moved_by, reason)
+         VALUES ($1, $2, 'withdrawn', $3, 'Withdrawn by the candidate')`,
+		applicationID, stage, candidateID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository: failed to record application withdrawal: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// TransitionApplication moves the application to transition.ToStage and appends
+// the transition to the log in a single transaction. The update only applies if
+// the application is still on transition.FromStage, so concurrent moves cannot
+// both succeed.
+func (r *SQLApplicationRepository) TransitionApplication(ctx context.Context, transition *models.ApplicationTransition, status string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE applications SET stage = $1, status = $2, updated_at = $3 WHERE application_id = $4 AND stage = $5`,
+		transition.ToStage, status, transition.CreatedAt, transition.ApplicationID, transition.FromStage,
+	)
+	if err != nil {
+		return fmt.Errorf("repository: failed to update application stage: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO application_transitions (application_id, from_stage, to_stage, moved_by, reason, created_at)
+         VALUES ($1, $2, $3, $4, $5, $6) RETURNING transition_id`,
+		transition.ApplicationID, transition.FromStage, transition.ToStage, transition.MovedBy, transition.Reason, transition.CreatedAt,
+	).Scan(&transition.ID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to record application transition: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLApplicationRepository) GetApplicationTransitions(ctx context.Context, applicationID int64) ([]*models.ApplicationTransition, error) {
+	query := `SELECT transition_id, application_id, from_stage, to_stage, moved_by, reason, created_at
+              FROM application_transitions WHERE application_id = $1 ORDER BY created_at, transition_id`
+
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch application transitions: %w", err)
+	}
+	defer rows.Close()
+
+	var transitions []*models.ApplicationTransition
+	for rows.Next() {
+		transition := &models.ApplicationTransition{}
+		err := rows.Scan(
+			&transition.ID, &transition.ApplicationID, &transition.FromStage, &transition.ToStage,
+			&transition.MovedBy, &transition.Reason, &transition.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan application transition: %w", err)
+		}
+		transitions = append(transitions, transition)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// queryApplicationPage fetches one page of the applications selected by from, a
+// FROM and WHERE clause using args, along with the total number of matches
+func (r *SQLApplicationRepository) queryApplicationPage(ctx context.Context, from string, args []interface{}, page request.PageRequest) ([]*models.Application, *models.PageInfo, error) {
+	pageQuery, err := helpers.NewPageQuery(page, map[string]string{"created_at": "created_at"}, "application_id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to count applications: %w", err)
+	}
+
+	query, queryArgs := pageQuery.Apply(`SELECT `+applicationColumns+pageQuery.KeyColumns()+from, args)
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch applications: %w", err)
+	}
+	defer rows.Close()
+
+	var applications []*models.Application
+	var keys []helpers.PageKey
+	for rows.Next() {
+		var key helpers.PageKey
+		application, err := scanApplication(rows, &key.Value, &key.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository: failed to scan application: %w", err)
+		}
+		applications = append(applications, application)
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	applications, pageInfo := helpers.Paginate(pageQuery, applications, keys, total)
+	return applications, pageInfo, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanApplication scans the applicationColumns of a row, followed by any extra columns
+func scanApplication(row rowScanner, extra ...interface{}) (*models.Application, error) {
+	application := &models.Application{}
+	dest := []interface{}{
+		&application.ID, &application.JobID, &application.JobRevision, &application.CandidateID, &application.Resume,
+		&application.CoverLetter, &application.Stage, &application.Status, &application.CreatedAt, &application.UpdatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	return application, nil
+}
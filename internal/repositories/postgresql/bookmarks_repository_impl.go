@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"dz-jobs-api/internal/dto/request"
 	"dz-jobs-api/internal/models"
 	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
 	"fmt"
@@ -38,35 +39,43 @@ func (r *SQLBookmarksRepository) RemoveBookmark(ctx context.Context, candidateID
 	return nil
 }
 
-func (r *SQLBookmarksRepository) GetBookmarks(ctx context.Context, candidateID uuid.UUID) ([]*models.Job, error) {
-	query := `
-        SELECT j.job_id, j.title, j.description, j.location, j.salary_range, j.required_skills, j.recruiter_id, j.created_at, j.updated_at, j.status
+func (r *SQLBookmarksRepository) GetBookmarks(ctx context.Context, candidateID uuid.UUID, page request.PageRequest) ([]*models.Job, *models.PageInfo, error) {
+	from := `
         FROM bookmarks b
         JOIN jobs j ON b.job_id = j.job_id
-        WHERE b.candidate_id = $1
-    `
-	rows, err := r.db.Query(query, candidateID)
+        WHERE b.candidate_id = $1`
+	jobs, pageInfo, err := queryJobPage(ctx, r.db, from, "j.", []interface{}{candidateID}, page, jobSortColumns("j."), "")
 	if err != nil {
-		return nil, fmt.Errorf("repository: failed to fetch bookmarks: %w", err)
+		return nil, nil, fmt.Errorf("repository: failed to fetch bookmarks: %w", err)
+	}
+	return jobs, pageInfo, nil
+}
+
+// GetJobBookmarkers returns the candidates who bookmarked a job and did not apply to it yet
+func (r *SQLBookmarksRepository) GetJobBookmarkers(ctx context.Context, jobID int64) ([]uuid.UUID, error) {
+	query := `
+        SELECT b.candidate_id
+        FROM bookmarks b
+        WHERE b.job_id = $1
+          AND NOT EXISTS (
+            SELECT 1 FROM applications a WHERE a.job_id = b.job_id AND a.candidate_id = b.candidate_id
+          )`
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch job bookmarkers: %w", err)
 	}
 	defer rows.Close()
 
-	var jobs []*models.Job
+	var candidateIDs []uuid.UUID
 	for rows.Next() {
-		job := &models.Job{}
-		err := rows.Scan(
-			&job.ID, &job.Title, &job.Description, &job.Location, &job.SalaryRange, &job.RequiredSkills,
-			&job.RecruiterID, &job.CreatedAt, &job.UpdatedAt, &job.Status,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("repository: failed to scan job: %w", err)
+		var candidateID uuid.UUID
+		if err := rows.Scan(&candidateID); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan job bookmarker: %w", err)
 		}
-		jobs = append(jobs, job)
+		candidateIDs = append(candidateIDs, candidateID)
 	}
-
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("repository: rows error: %w", err)
 	}
-
-	return jobs, nil
+	return candidateIDs, nil
 }
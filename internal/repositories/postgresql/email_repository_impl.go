@@ -0,0 +1,104 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const emailColumns = `email_id, user_id, type, recipient, subject, plain_text, html, unsubscribe_url, attachments, digest, send_after, created_at`
+
+type SQLEmailRepository struct {
+	db *sql.DB
+}
+
+func NewEmailRepository(db *sql.DB) repositoryInterfaces.EmailRepository {
+	return &SQLEmailRepository{
+		db: db,
+	}
+}
+
+func (r *SQLEmailRepository) QueueEmail(ctx context.Context, email *models.Email) error {
+	attachments, err := json.Marshal(nonNilAttachments(email.Attachments))
+	if err != nil {
+		return fmt.Errorf("repository: failed to encode email attachments: %w", err)
+	}
+
+	query := `
+        INSERT INTO email_outbox (user_id, type, recipient, subject, plain_text, html, unsubscribe_url, attachments, digest, send_after, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        RETURNING email_id
+    `
+	err = r.db.QueryRowContext(ctx, query,
+		email.UserID, email.Type, email.To, email.Subject, email.PlainText, email.HTML, email.UnsubscribeURL,
+		attachments, email.Digest, email.SendAfter, email.CreatedAt,
+	).Scan(&email.ID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to queue email: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueEmails returns the queued emails due at now, oldest first, and holds
+// them back for the lease so that they are retried if they are not deleted once
+// sent
+func (r *SQLEmailRepository) ClaimDueEmails(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Email, error) {
+	query := `
+        UPDATE email_outbox SET send_after = $2
+        WHERE email_id IN (
+            SELECT email_id FROM email_outbox
+            WHERE send_after <= $1
+            ORDER BY send_after, email_id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + emailColumns
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to claim due emails: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []*models.Email
+	for rows.Next() {
+		email := &models.Email{}
+		var attachments []byte
+		err := rows.Scan(
+			&email.ID, &email.UserID, &email.Type, &email.To, &email.Subject, &email.PlainText, &email.HTML,
+			&email.UnsubscribeURL, &attachments, &email.Digest, &email.SendAfter, &email.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan email: %w", err)
+		}
+		if err := json.Unmarshal(attachments, &email.Attachments); err != nil {
+			return nil, fmt.Errorf("repository: failed to decode email attachments: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return emails, nil
+}
+
+func (r *SQLEmailRepository) DeleteEmails(ctx context.Context, emailIDs []int64) error {
+	query := `DELETE FROM email_outbox WHERE email_id = ANY($1)`
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(emailIDs)); err != nil {
+		return fmt.Errorf("repository: failed to delete emails: %w", err)
+	}
+	return nil
+}
+
+func nonNilAttachments(attachments []models.EmailAttachment) []models.EmailAttachment {
+	if attachments == nil {
+		return []models.EmailAttachment{}
+	}
+	return attachments
+}
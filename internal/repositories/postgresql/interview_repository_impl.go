@@ -0,0 +1,245 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// interviewQuery selects the interviews along with their job and participants
+const interviewQuery = `
+    SELECT i.interview_id, i.application_id, i.status, i.duration_minutes, i.location, i.video_link, i.notes,
+           i.scheduled_at, i.sequence, i.cancel_reason, i.created_at, i.updated_at,
+           j.job_id, j.title, a.candidate_id, cu.name, cu.email, j.recruiter_id, ru.email, COALESCE(r.company_name, '')
+    FROM interviews i
+    JOIN applications a ON a.application_id = i.application_id
+    JOIN jobs j ON j.job_id = a.job_id
+    JOIN users cu ON cu.user_id = a.candidate_id
+    JOIN users ru ON ru.user_id = j.recruiter_id
+    LEFT JOIN recruiters r ON r.recruiter_id = j.recruiter_id`
+
+type SQLInterviewRepository struct {
+	db *sql.DB
+}
+
+func NewInterviewRepository(db *sql.DB) repositoryInterfaces.InterviewRepository {
+	return &SQLInterviewRepository{
+		db: db,
+	}
+}
+
+// CreateInterview inserts the interview and its proposed slots in a single transaction
+func (r *SQLInterviewRepository) CreateInterview(ctx context.Context, interview *models.Interview) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        INSERT INTO interviews (
+            application_id, status, duration_minutes, location, video_link, notes, scheduled_at, sequence, created_at, updated_at
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING interview_id
+    `
+	err = tx.QueryRowContext(ctx, query,
+		interview.ApplicationID, interview.Status, interview.DurationMinutes, interview.Location, interview.VideoLink,
+		interview.Notes, interview.ScheduledAt, interview.Sequence, interview.CreatedAt, interview.UpdatedAt,
+	).Scan(&interview.ID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to create interview: %w", err)
+	}
+
+	if err := insertInterviewSlots(ctx, tx, interview); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLInterviewRepository) GetInterview(ctx context.Context, interviewID int64) (*models.Interview, error) {
+	interviews, err := r.queryInterviews(ctx, interviewQuery+` WHERE i.interview_id = $1`, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if len(interviews) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return interviews[0], nil
+}
+
+func (r *SQLInterviewRepository) GetApplicationInterviews(ctx context.Context, applicationID int64) ([]*models.Interview, error) {
+	return r.queryInterviews(ctx, interviewQuery+` WHERE i.application_id = $1 ORDER BY i.created_at, i.interview_id`, applicationID)
+}
+
+// GetUpcomingInterviews returns the interviews of a user, as the candidate or the
+// recruiter, that are scheduled to end after since or still have a slot to pick
+// after since
+func (r *SQLInterviewRepository) GetUpcomingInterviews(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.Interview, error) {
+	query := interviewQuery + `
+        WHERE (a.candidate_id = $1 OR j.recruiter_id = $1)
+          AND (
+              (i.status = 'scheduled' AND i.scheduled_at + make_interval(mins => i.duration_minutes) > $2)
+              OR (i.status = 'proposed' AND EXISTS (
+                  SELECT 1 FROM interview_slots s WHERE s.interview_id = i.interview_id AND s.starts_at > $2
+              ))
+          )
+        ORDER BY i.scheduled_at NULLS LAST, i.interview_id`
+	return r.queryInterviews(ctx, query, userID, since)
+}
+
+// UpdateInterview saves the status, details and invite sequence of an interview,
+// replacing its slots when replaceSlots is set. The update only applies if the
+// interview is still in fromStatus, so that a candidate cannot pick a slot of an
+// interview being rescheduled or cancelled at the same time.
+func (r *SQLInterviewRepository) UpdateInterview(ctx context.Context, interview *models.Interview, fromStatus string, replaceSlots bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        UPDATE interviews
+        SET status = $1, duration_minutes = $2, location = $3, video_link = $4, notes = $5, scheduled_at = $6,
+            sequence = $7, cancel_reason = $8, updated_at = $9
+        WHERE interview_id = $10 AND status = $11
+    `
+	result, err := tx.ExecContext(ctx, query,
+		interview.Status, interview.DurationMinutes, interview.Location, interview.VideoLink, interview.Notes,
+		interview.ScheduledAt, interview.Sequence, interview.CancelReason, interview.UpdatedAt, interview.ID, fromStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("repository: failed to update interview: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if replaceSlots {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM interview_slots WHERE interview_id = $1`, interview.ID); err != nil {
+			return fmt.Errorf("repository: failed to clear interview slots: %w", err)
+		}
+		if err := insertInterviewSlots(ctx, tx, interview); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLInterviewRepository) GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	feed := &models.CalendarFeed{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT user_id, token_hash, created_at FROM calendar_feeds WHERE token_hash = $1`, tokenHash,
+	).Scan(&feed.UserID, &feed.TokenHash, &feed.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// SaveCalendarFeed creates the calendar feed of a user or replaces its token
+func (r *SQLInterviewRepository) SaveCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error {
+	query := `
+        INSERT INTO calendar_feeds (user_id, token_hash, created_at) VALUES ($1, $2, $3)
+        ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+    `
+	if _, err := r.db.ExecContext(ctx, query, feed.UserID, feed.TokenHash, feed.CreatedAt); err != nil {
+		return fmt.Errorf("repository: failed to save calendar feed: %w", err)
+	}
+	return nil
+}
+
+// queryInterviews runs a query selecting the interviewQuery columns and loads the
+// slots of the interviews it returns
+func (r *SQLInterviewRepository) queryInterviews(ctx context.Context, query string, args ...interface{}) ([]*models.Interview, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch interviews: %w", err)
+	}
+	defer rows.Close()
+
+	var interviews []*models.Interview
+	byID := map[int64]*models.Interview{}
+	for rows.Next() {
+		interview := &models.Interview{}
+		err := rows.Scan(
+			&interview.ID, &interview.ApplicationID, &interview.Status, &interview.DurationMinutes, &interview.Location,
+			&interview.VideoLink, &interview.Notes, &interview.ScheduledAt, &interview.Sequence, &interview.CancelReason,
+			&interview.CreatedAt, &interview.UpdatedAt, &interview.JobID, &interview.JobTitle, &interview.CandidateID,
+			&interview.CandidateName, &interview.CandidateEmail, &interview.RecruiterID, &interview.RecruiterEmail,
+			&interview.CompanyName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan interview: %w", err)
+		}
+		interviews = append(interviews, interview)
+		byID[interview.ID] = interview
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	if len(interviews) == 0 {
+		return interviews, nil
+	}
+
+	ids := make([]int64, 0, len(interviews))
+	for _, interview := range interviews {
+		ids = append(ids, interview.ID)
+	}
+	slotRows, err := r.db.QueryContext(ctx,
+		`SELECT slot_id, interview_id, starts_at FROM interview_slots WHERE interview_id = ANY($1) ORDER BY starts_at`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch interview slots: %w", err)
+	}
+	defer slotRows.Close()
+
+	for slotRows.Next() {
+		var slot models.InterviewSlot
+		if err := slotRows.Scan(&slot.ID, &slot.InterviewID, &slot.StartsAt); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan interview slot: %w", err)
+		}
+		interview := byID[slot.InterviewID]
+		interview.Slots = append(interview.Slots, slot)
+	}
+	if err = slotRows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	return interviews, nil
+}
+
+func insertInterviewSlots(ctx context.Context, tx *sql.Tx, interview *models.Interview) error {
+	query := `INSERT INTO interview_slots (interview_id, starts_at) VALUES ($1, $2) RETURNING slot_id`
+	for i := range interview.Slots {
+		slot := &interview.Slots[i]
+		slot.InterviewID = interview.ID
+		if err := tx.QueryRowContext(ctx, query, slot.InterviewID, slot.StartsAt).Scan(&slot.ID); err != nil {
+			return fmt.Errorf("repository: failed to create interview slot: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"errors"
+	"fmt"
+)
+
+type SQLJobCategoryRepository struct {
+	db *sql.DB
+}
+
+func NewJobCategoryRepository(db *sql.DB) repositoryInterfaces.JobCategoryRepository {
+	return &SQLJobCategoryRepository{
+		db: db,
+	}
+}
+
+// GetCategories returns all the categories ordered by name, the tree is built
+// from their parent IDs
+func (r *SQLJobCategoryRepository) GetCategories(ctx context.Context) ([]*models.JobCategory, error) {
+	query := `SELECT category_id, parent_id, name, slug, created_at FROM job_categories ORDER BY name, category_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*models.JobCategory
+	for rows.Next() {
+		category := &models.JobCategory{}
+		if err := rows.Scan(&category.ID, &category.ParentID, &category.Name, &category.Slug, &category.CreatedAt); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return categories, nil
+}
+
+func (r *SQLJobCategoryRepository) GetCategory(ctx context.Context, categoryID int64) (*models.JobCategory, error) {
+	query := `SELECT category_id, parent_id, name, slug, created_at FROM job_categories WHERE category_id = $1`
+
+	category := &models.JobCategory{}
+	err := r.db.QueryRowContext(ctx, query, categoryID).Scan(
+		&category.ID, &category.ParentID, &category.Name, &category.Slug, &category.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch category: %w", err)
+	}
+	return category, nil
+}
+
+func (r *SQLJobCategoryRepository) CreateCategory(ctx context.Context, category *models.JobCategory) error {
+	query := `INSERT INTO job_categories (parent_id, name, slug, created_at) VALUES ($1, $2, $3, $4) RETURNING category_id`
+
+	err := r.db.QueryRowContext(ctx, query, category.ParentID, category.Name, category.Slug, category.CreatedAt).Scan(&category.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repositoryInterfaces.ErrCategoryExists
+		}
+		return fmt.Errorf("repository: failed to create category: %w", err)
+	}
+	return nil
+}
+
+// UpdateCategory renames the category and moves it under its parent
+func (r *SQLJobCategoryRepository) UpdateCategory(ctx context.Context, category *models.JobCategory) error {
+	query := `UPDATE job_categories SET parent_id = $1, name = $2, slug = $3 WHERE category_id = $4`
+
+	result, err := r.db.ExecContext(ctx, query, category.ParentID, category.Name, category.Slug, category.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repositoryInterfaces.ErrCategoryExists
+		}
+		return fmt.Errorf("repository: failed to update category: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteCategory deletes a category without subcategories, its jobs are left
+// without a category
+func (r *SQLJobCategoryRepository) DeleteCategory(ctx context.Context, categoryID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM job_categories WHERE category_id = $1`, categoryID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return repositoryInterfaces.ErrCategoryHasChildren
+		}
+		return fmt.Errorf("repository: failed to delete category: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -7,12 +7,14 @@ import (
 	"dz-jobs-api/internal/helpers"
 	"dz-jobs-api/internal/models"
 	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type SQLJobRepository struct {
@@ -25,24 +27,46 @@ func NewJobRepository(db *sql.DB) repositoryInterfaces.JobRepository {
 	}
 }
 
-func (r *SQLJobRepository) CreateJob(ctx context.Context, job *models.Job) error {
+// CreateJob inserts the job along with its first revision
+// CreateJob creates a job with its first revision and required skills in a single
+// transaction
+func (r *SQLJobRepository) CreateJob(ctx context.Context, job *models.Job, skillIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	query := `
         INSERT INTO jobs (
-            title, description, location, salary_range, required_skills, recruiter_id, created_at, updated_at, status, job_type
+            title, description, location, salary_min, salary_max, currency, period, required_skills, recruiter_id, created_at, updated_at, status, job_type,
+            publish_at, published_at, expires_at, wilaya_code, commune_code, latitude, longitude, work_mode, category_id
         ) VALUES (
-            $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+            $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
         ) RETURNING job_id
     `
 
-	err := r.db.QueryRow(
+	err = tx.QueryRowContext(
+		ctx,
 		query,
-		job.Title, job.Description, job.Location, job.SalaryRange, job.RequiredSkills, job.RecruiterID,
-		job.CreatedAt, job.UpdatedAt, job.Status, job.JobType,
+		job.Title, job.Description, job.Location, job.SalaryMin, job.SalaryMax, job.Currency, job.Period, job.RequiredSkills, job.RecruiterID,
+		job.CreatedAt, job.UpdatedAt, job.Status, job.JobType, job.PublishAt, job.PublishedAt, job.ExpiresAt,
+		job.WilayaCode, job.CommuneCode, job.Latitude, job.Longitude, job.WorkMode, job.CategoryID,
 	).Scan(&job.ID)
 
 	if err != nil {
 		return fmt.Errorf("repository: failed to create job: %w", err)
 	}
+	if err := insertJobRevision(ctx, tx, job.ID, job, job.RecruiterID, nil); err != nil {
+		return err
+	}
+	if err := insertJobSkills(ctx, tx, job.ID, skillIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -51,14 +75,9 @@ func (r *SQLJobRepository) GetJobDetails(ctx context.Context, jobID int64, recru
 		return nil, err
 	}
 
-	query := `SELECT job_id, title, description, location, salary_range, required_skills, recruiter_id, created_at, updated_at, status, job_type
-              FROM jobs WHERE job_id = $1`
+	query := `SELECT ` + jobColumns("") + ` FROM jobs WHERE job_id = $1`
 
-	job := &models.Job{}
-	err := r.db.QueryRow(query, jobID).Scan(
-		&job.ID, &job.Title, &job.Description, &job.Location, &job.SalaryRange, &job.RequiredSkills,
-		&job.RecruiterID, &job.CreatedAt, &job.UpdatedAt, &job.Status, &job.JobType,
-	)
+	job, err := scanJob(r.db.QueryRow(query, jobID))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -69,50 +88,73 @@ func (r *SQLJobRepository) GetJobDetails(ctx context.Context, jobID int64, recru
 	return job, nil
 }
 
-func (r *SQLJobRepository) GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID) ([]*models.Job, error) {
+func (r *SQLJobRepository) GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID, page request.PageRequest) ([]*models.Job, *models.PageInfo, error) {
+	jobs, pageInfo, err := queryJobPage(ctx, r.db, " FROM jobs WHERE status = $1 AND recruiter_id = $2", "", []interface{}{status, recruiterID}, page, jobSortColumns(""), "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch jobs by status: %w", err)
+	}
+	return jobs, pageInfo, nil
+}
 
-	query := `SELECT job_id, title, description, location, salary_range, required_skills, recruiter_id, created_at, updated_at, status
-              FROM jobs WHERE status = $1 AND recruiter_id = $2`
+// ExportJobs calls fn with each of the recruiter's jobs having the given status, or
+// all of them when status is empty, as they are read from the database
+func (r *SQLJobRepository) ExportJobs(ctx context.Context, recruiterID uuid.UUID, status string, fn func(job *models.Job) error) error {
+	query := `SELECT ` + jobColumns("") + ` FROM jobs WHERE recruiter_id = $1 AND ($2 = '' OR status = $2) ORDER BY created_at, job_id`
 
-	rows, err := r.db.Query(query, status, recruiterID)
+	rows, err := r.db.QueryContext(ctx, query, recruiterID, status)
 	if err != nil {
-		return nil, fmt.Errorf("repository: failed to fetch jobs by status: %w", err)
+		return fmt.Errorf("repository: failed to export jobs: %w", err)
 	}
 	defer rows.Close()
 
-	var jobs []*models.Job
 	for rows.Next() {
-		job := &models.Job{}
-		err := rows.Scan(
-			&job.ID, &job.Title, &job.Description, &job.Location, &job.SalaryRange, &job.RequiredSkills,
-			&job.RecruiterID, &job.CreatedAt, &job.UpdatedAt, &job.Status,
-		)
+		job, err := scanJob(rows)
 		if err != nil {
-			return nil, fmt.Errorf("repository: failed to scan job: %w", err)
+			return fmt.Errorf("repository: failed to scan job: %w", err)
 		}
-		if job.RecruiterID != recruiterID {
-			return nil, fmt.Errorf("repository: unauthorized access, recruiter does not own job with ID %d", job.ID)
+		if err := fn(job); err != nil {
+			return err
 		}
-		jobs = append(jobs, job)
 	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("repository: rows error: %w", err)
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("repository: rows error: %w", err)
 	}
+	return nil
+}
 
-	return jobs, nil
+// UpdateJob overwrites the job and its required skills, and records its content as
+// a new revision edited by recruiterID, in a single transaction
+func (r *SQLJobRepository) UpdateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job, skillIDs []int64) error {
+	return r.updateJob(ctx, jobID, recruiterID, job, nil, skillIDs)
 }
 
-func (r *SQLJobRepository) UpdateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job) error {
-	query := `UPDATE jobs SET 
-        title = $1, description = $2, location = $3, salary_range = $4, required_skills = $5, 
-        recruiter_id = $6, updated_at = $7, status = $8, job_type = $9
-        WHERE job_id = $10`
+// RestoreJobRevision overwrites the job and its required skills with the content of
+// one of its revisions, recording it as a new revision restored from the old one
+func (r *SQLJobRepository) RestoreJobRevision(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job, revision int, skillIDs []int64) error {
+	return r.updateJob(ctx, jobID, recruiterID, job, &revision, skillIDs)
+}
 
-	result, err := r.db.Exec(
+func (r *SQLJobRepository) updateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job, restoredFrom *int, skillIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `UPDATE jobs SET 
+        title = $1, description = $2, location = $3, salary_min = $4, salary_max = $5, currency = $6, period = $7,
+        salary_needs_review = $8, required_skills = $9, recruiter_id = $10, updated_at = $11, status = $12, job_type = $13,
+        publish_at = $14, published_at = $15, expires_at = $16, expiry_reminder_sent_at = $17,
+        wilaya_code = $18, commune_code = $19, latitude = $20, longitude = $21, work_mode = $22, category_id = $23
+        WHERE job_id = $24`
+
+	result, err := tx.ExecContext(
+		ctx,
 		query,
-		job.Title, job.Description, job.Location, job.SalaryRange, job.RequiredSkills,
-		job.RecruiterID, job.UpdatedAt, job.Status, job.JobType, jobID,
+		job.Title, job.Description, job.Location, job.SalaryMin, job.SalaryMax, job.Currency, job.Period,
+		job.SalaryNeedsReview, job.RequiredSkills, job.RecruiterID, job.UpdatedAt, job.Status, job.JobType,
+		job.PublishAt, job.PublishedAt, job.ExpiresAt, job.ExpiryReminderSentAt,
+		job.WilayaCode, job.CommuneCode, job.Latitude, job.Longitude, job.WorkMode, job.CategoryID, jobID,
 	)
 
 	if err != nil {
@@ -125,6 +167,69 @@ func (r *SQLJobRepository) UpdateJob(ctx context.Context, jobID int64, recruiter
 		return errors.New("repository: job not found")
 	}
 
+	if err := insertJobRevision(ctx, tx, jobID, job, recruiterID, restoredFrom); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_skills WHERE job_id = $1`, jobID); err != nil {
+		return fmt.Errorf("repository: failed to delete job skills: %w", err)
+	}
+	if err := insertJobSkills(ctx, tx, jobID, skillIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetJobRevisions returns the revisions of a job, latest first
+func (r *SQLJobRepository) GetJobRevisions(ctx context.Context, jobID int64) ([]*models.JobRevision, error) {
+	query := `SELECT ` + jobRevisionColumns + ` FROM job_revisions WHERE job_id = $1 ORDER BY revision DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch job revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.JobRevision
+	for rows.Next() {
+		revision, err := scanJobRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan job revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return revisions, nil
+}
+
+func (r *SQLJobRepository) GetJobRevision(ctx context.Context, jobID int64, revision int) (*models.JobRevision, error) {
+	query := `SELECT ` + jobRevisionColumns + ` FROM job_revisions WHERE job_id = $1 AND revision = $2`
+
+	jobRevision, err := scanJobRevision(r.db.QueryRowContext(ctx, query, jobID, revision))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch job revision: %w", err)
+	}
+	return jobRevision, nil
+}
+
+func insertJobSkills(ctx context.Context, tx *sql.Tx, jobID int64, skillIDs []int64) error {
+	if len(skillIDs) == 0 {
+		return nil
+	}
+	query := `INSERT INTO job_skills (job_id, skill_id)
+              SELECT $1, UNNEST($2::BIGINT[])
+              ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, jobID, pq.Array(skillIDs)); err != nil {
+		return fmt.Errorf("repository: failed to create job skills: %w", err)
+	}
 	return nil
 }
 
@@ -151,14 +256,19 @@ func (r *SQLJobRepository) DeactivateJob(ctx context.Context, jobID int64, recru
 	return nil
 }
 
-func (r *SQLJobRepository) RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) error {
+// RepostJob reopens the job as if it was published at repostedAt, until expiresAt
+func (r *SQLJobRepository) RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, repostedAt, expiresAt time.Time) error {
 
 	query := `UPDATE jobs SET 
         status = $1, 
-        updated_at = $2 
-        WHERE job_id = $3`
-
-	result, err := r.db.Exec(query, "open", time.Now(), jobID)
+        updated_at = $2,
+        publish_at = NULL,
+        published_at = $2,
+        expires_at = $3,
+        expiry_reminder_sent_at = NULL
+        WHERE job_id = $4`
+
+	result, err := r.db.ExecContext(ctx, query, "open", repostedAt, expiresAt, jobID)
 	if err != nil {
 		return fmt.Errorf("repository: failed to update job status to open: %w", err)
 	}
@@ -212,39 +322,113 @@ func (r *SQLJobRepository) ValidateJobOwnership(ctx context.Context, jobID int64
 	return nil
 }
 
-func (r *SQLJobRepository) GetAllJobs(ctx context.Context) ([]*models.Job, error) {
-	query := `SELECT job_id, title, description, location, salary_range, required_skills, recruiter_id, created_at, updated_at, status, job_type
-              FROM jobs`
+func (r *SQLJobRepository) GetAllJobs(ctx context.Context, page request.PageRequest) ([]*models.Job, *models.PageInfo, error) {
+	jobs, pageInfo, err := queryJobPage(ctx, r.db, " FROM jobs WHERE "+publicJobCondition, "", nil, page, jobSortColumns(""), "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch all jobs: %w", err)
+	}
+	return jobs, pageInfo, nil
+}
+
+func (r *SQLJobRepository) GetJobListings(ctx context.Context, filters request.JobFilters) ([]*models.Job, *models.PageInfo, error) {
+	listing, err := newJobListingQuery(filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	jobs, pageInfo, err := queryJobPage(ctx, r.db, listing.from, "", listing.args, listing.page, listing.sortColumns, listing.snippet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch jobs with filters: %w", err)
+	}
+	return jobs, pageInfo, nil
+}
+
+// SearchJobs fetches a page of the jobs matching filters like GetJobListings,
+// along with the facet counts of all of them. The total and the facets are
+// counted by a single grouping sets query over the same filters as the page,
+// both queries run in one repeatable read transaction so that they see the
+// same snapshot of the jobs.
+func (r *SQLJobRepository) SearchJobs(ctx context.Context, filters request.JobFilters) ([]*models.Job, *models.PageInfo, *models.JobFacets, error) {
+	listing, err := newJobListingQuery(filters)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pageQuery, err := helpers.NewPageQuery(listing.page, listing.sortColumns, "job_id")
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	rows, err := r.db.Query(query)
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
 	if err != nil {
-		return nil, fmt.Errorf("repository: failed to fetch all jobs: %w", err)
+		return nil, nil, nil, fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+        SELECT GROUPING(category_id, job_type, wilaya_code, salary_bucket, status),
+               COALESCE(category_id::TEXT, job_type::TEXT, wilaya_code::TEXT, salary_bucket, status::TEXT),
+               COUNT(*)
+        FROM (SELECT category_id, job_type, wilaya_code, ` + helpers.SalaryBucketSQL("") + ` AS salary_bucket, status` + listing.from + `) AS matched
+        GROUP BY GROUPING SETS ((category_id), (job_type), (wilaya_code), (salary_bucket), (status), ())`
+
+	rows, err := tx.QueryContext(ctx, query, listing.args...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("repository: failed to count job facets: %w", err)
 	}
 	defer rows.Close()
 
-	var jobs []*models.Job
+	// GROUPING sets a bit, from category_id down to status, for each column a
+	// row is not grouped by
+	facets := &models.JobFacets{}
+	facetsByGrouping := map[int]*[]models.JobFacetValue{
+		0b01111: &facets.Categories,
+		0b10111: &facets.JobTypes,
+		0b11011: &facets.Wilayas,
+		0b11101: &facets.SalaryBuckets,
+		0b11110: &facets.Statuses,
+	}
+	var total int
 	for rows.Next() {
-		job := &models.Job{}
-		err := rows.Scan(
-			&job.ID, &job.Title, &job.Description, &job.Location, &job.SalaryRange, &job.RequiredSkills,
-			&job.RecruiterID, &job.CreatedAt, &job.UpdatedAt, &job.Status, &job.JobType,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("repository: failed to scan job: %w", err)
+		var grouping, count int
+		var value sql.NullString
+		if err := rows.Scan(&grouping, &value, &count); err != nil {
+			return nil, nil, nil, fmt.Errorf("repository: failed to scan job facet: %w", err)
 		}
-		jobs = append(jobs, job)
+		if values, ok := facetsByGrouping[grouping]; ok && value.Valid {
+			*values = append(*values, models.JobFacetValue{Value: value.String, Count: count})
+		} else if grouping == 0b11111 {
+			total = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, nil, fmt.Errorf("repository: rows error: %w", err)
 	}
+	rows.Close()
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("repository: rows error: %w", err)
+	jobs, pageInfo, err := fetchJobPage(ctx, tx, listing.from, "", listing.args, pageQuery, listing.snippet, total)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("repository: failed to search jobs: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, nil, fmt.Errorf("repository: failed to commit transaction: %w", err)
 	}
+	return jobs, pageInfo, facets, nil
+}
 
-	return jobs, nil
+// jobListingQuery is the FROM and WHERE clause selecting the jobs matching a
+// search, with the arguments and the ordering of its pages
+type jobListingQuery struct {
+	from        string
+	args        []interface{}
+	page        request.PageRequest
+	sortColumns map[string]string
+	snippet     string
 }
 
-func (r *SQLJobRepository) GetJobListings(ctx context.Context, filters request.JobFilters) ([]*models.Job, error) {
-	query := `SELECT job_id, title, description, location, salary_range, required_skills, recruiter_id, created_at, updated_at, status, job_type
-              FROM jobs WHERE 1=1`
+func newJobListingQuery(filters request.JobFilters) (*jobListingQuery, error) {
+	if err := helpers.ValidateSalaryFilter(filters.SalaryRangeMin, filters.SalaryRangeMax, filters.Sort, filters.Currency, filters.Period); err != nil {
+		return nil, err
+	}
+	query := ` FROM jobs WHERE ` + publicJobCondition
 
 	args := []interface{}{}
 	paramCount := 1
@@ -261,78 +445,409 @@ func (r *SQLJobRepository) GetJobListings(ctx context.Context, filters request.J
 		paramCount++
 	}
 
+	// A free text location also matches the jobs located in the wilaya or commune
+	// it names, in whichever language the job spells it. A name shared by a wilaya
+	// and its seat commune is searched in the whole wilaya.
 	if filters.Location != "" {
-		query += fmt.Sprintf(" AND location ILIKE $%d", paramCount)
-		args = append(args, "%"+filters.Location+"%")
+		wilaya, commune := helpers.FindWilaya(filters.Location), (*models.Commune)(nil)
+		if wilaya == nil {
+			wilaya, commune = helpers.ResolveLocation(filters.Location)
+		}
+		switch {
+		case commune != nil:
+			query += fmt.Sprintf(" AND (location ILIKE $%d OR commune_code = $%d)", paramCount, paramCount+1)
+			args = append(args, "%"+filters.Location+"%", commune.Code)
+			paramCount += 2
+		case wilaya != nil:
+			query += fmt.Sprintf(" AND (location ILIKE $%d OR wilaya_code = $%d)", paramCount, paramCount+1)
+			args = append(args, "%"+filters.Location+"%", wilaya.Code)
+			paramCount += 2
+		default:
+			query += fmt.Sprintf(" AND location ILIKE $%d", paramCount)
+			args = append(args, "%"+filters.Location+"%")
+			paramCount++
+		}
+	}
+
+	locationFilter, err := helpers.NewLocationFilter(filters.Wilaya, filters.Commune, filters.RadiusKm)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case locationFilter == nil:
+	case locationFilter.RadiusKm > 0:
+		latitude, longitude := locationFilter.Center()
+		query += fmt.Sprintf(" AND latitude IS NOT NULL AND %s <= $%d", helpers.DistanceSQL("", paramCount, paramCount+1), paramCount+2)
+		args = append(args, latitude, longitude, locationFilter.RadiusKm)
+		paramCount += 3
+	case locationFilter.Commune != nil:
+		query += fmt.Sprintf(" AND commune_code = $%d", paramCount)
+		args = append(args, locationFilter.Commune.Code)
+		paramCount++
+	default:
+		query += fmt.Sprintf(" AND wilaya_code = $%d", paramCount)
+		args = append(args, locationFilter.Wilaya.Code)
+		paramCount++
+	}
+
+	if filters.WorkMode != "" {
+		query += fmt.Sprintf(" AND work_mode = $%d", paramCount)
+		args = append(args, filters.WorkMode)
+		paramCount++
+	}
+
+	// A category, given by ID or slug, also matches the jobs of its subcategories
+	if filters.Category != "" {
+		query += fmt.Sprintf(` AND category_id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT category_id FROM job_categories WHERE category_id::TEXT = $%d OR slug = $%d
+                UNION
+                SELECT c.category_id FROM job_categories c JOIN subtree ON c.parent_id = subtree.category_id
+            )
+            SELECT category_id FROM subtree)`, paramCount, paramCount)
+		args = append(args, strings.ToLower(strings.TrimSpace(filters.Category)))
+		paramCount++
+	}
+
+	if filters.Currency != "" {
+		query += fmt.Sprintf(" AND currency = $%d", paramCount)
+		args = append(args, filters.Currency)
+		paramCount++
+	}
+
+	if filters.Period != "" {
+		query += fmt.Sprintf(" AND period = $%d", paramCount)
+		args = append(args, filters.Period)
 		paramCount++
 	}
 
 	if filters.SalaryRangeMin > 0 || filters.SalaryRangeMax > 0 {
-		salaryRangeQuery, salaryRangeArgs := helpers.ConvertSalaryRange(filters.SalaryRangeMin, filters.SalaryRangeMax)
+		salaryRangeQuery, salaryRangeArgs := helpers.ConvertSalaryRange(filters.SalaryRangeMin, filters.SalaryRangeMax, paramCount)
 		query += fmt.Sprintf(" AND (%s)", salaryRangeQuery)
 		args = append(args, salaryRangeArgs...)
 		paramCount += len(salaryRangeArgs)
 	}
 
+	if filters.PublishedAfter != nil {
+		query += fmt.Sprintf(" AND COALESCE(published_at, created_at) > $%d", paramCount)
+		args = append(args, *filters.PublishedAfter)
+		paramCount++
+	}
+
+	if filters.PublishedBefore != nil {
+		query += fmt.Sprintf(" AND COALESCE(published_at, created_at) <= $%d", paramCount)
+		args = append(args, *filters.PublishedBefore)
+		paramCount++
+	}
+
 	if len(filters.RequiredSkills) > 0 {
-		skillConditions := []string{}
+		slugs := make([]string, 0, len(filters.RequiredSkills))
 		for _, skill := range filters.RequiredSkills {
-			skillConditions = append(skillConditions, fmt.Sprintf("required_skills ILIKE $%d", paramCount))
-			args = append(args, "%"+skill+"%")
-			paramCount++
+			slugs = append(slugs, helpers.NormalizeSkillName(skill))
 		}
-		query += " AND (" + strings.Join(skillConditions, " OR ") + ")"
+		query += fmt.Sprintf(` AND EXISTS (
+            SELECT 1 FROM job_skills js
+            JOIN skills s ON s.skill_id = js.skill_id
+            WHERE js.job_id = jobs.job_id
+              AND (s.slug = ANY($%d) OR s.skill_id IN (SELECT skill_id FROM skill_aliases WHERE slug = ANY($%d))))`, paramCount, paramCount)
+		args = append(args, pq.Array(slugs))
+		paramCount++
 	}
 
+	sortColumns := jobSortColumns("")
+	if filters.Currency != "" && filters.Period != "" {
+		sortColumns["salary"] = helpers.SalarySortExpr("")
+	}
+	snippet := ""
+	page := filters.PageRequest
 	if filters.Keyword != "" {
-		query += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)",
-			paramCount, paramCount+1)
-		searchTerm := "%" + filters.Keyword + "%"
-		args = append(args, searchTerm, searchTerm)
+		tsquery := helpers.JobSearchQuery(paramCount)
+		query += " AND search_vector @@ " + tsquery
+		args = append(args, filters.Keyword)
+		sortColumns["relevance"] = helpers.JobSearchRank("", tsquery)
+		snippet = helpers.JobSearchSnippet("", tsquery)
+		if page.Sort == "" && page.Cursor == "" {
+			page.Sort = "relevance"
+		}
 	}
 
-	rows, err := r.db.Query(query, args...)
+	return &jobListingQuery{from: query, args: args, page: page, sortColumns: sortColumns, snippet: snippet}, nil
+}
+
+func (r *SQLJobRepository) GetJobDetailsPublic(ctx context.Context, jobID int64) (*models.Job, error) {
+	query := `SELECT ` + jobColumns("") + ` FROM jobs WHERE job_id = $1 AND ` + publicJobCondition
+
+	job, err := scanJob(r.db.QueryRow(query, jobID))
+
 	if err != nil {
-		return nil, fmt.Errorf("repository: failed to fetch jobs with filters: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch job by ID: %w", err)
+	}
+	return job, nil
+}
+
+// GetJob returns a job whatever its status, for internal lookups that are not
+// exposed to the public
+func (r *SQLJobRepository) GetJob(ctx context.Context, jobID int64) (*models.Job, error) {
+	query := `SELECT ` + jobColumns("") + ` FROM jobs WHERE job_id = $1`
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, jobID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch job by ID: %w", err)
+	}
+	return job, nil
+}
+
+// GetSitemapJobs returns the ID and update time of the most recently updated open jobs
+func (r *SQLJobRepository) GetSitemapJobs(ctx context.Context, limit int) ([]*models.Job, error) {
+	query := `SELECT job_id, updated_at FROM jobs WHERE status = 'open' ORDER BY updated_at DESC LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch sitemap jobs: %w", err)
 	}
 	defer rows.Close()
 
 	var jobs []*models.Job
 	for rows.Next() {
 		job := &models.Job{}
-		err := rows.Scan(
-			&job.ID, &job.Title, &job.Description, &job.Location, &job.SalaryRange, &job.RequiredSkills,
-			&job.RecruiterID, &job.CreatedAt, &job.UpdatedAt, &job.Status, &job.JobType,
-		)
-		if err != nil {
+		if err := rows.Scan(&job.ID, &job.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("repository: failed to scan job: %w", err)
 		}
 		jobs = append(jobs, job)
 	}
-
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("repository: rows error: %w", err)
 	}
+	return jobs, nil
+}
+
+// PublishScheduledJobs opens the scheduled jobs whose publish time has come
+func (r *SQLJobRepository) PublishScheduledJobs(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+        UPDATE jobs SET status = 'open', published_at = publish_at, updated_at = $1
+        WHERE status = 'scheduled' AND publish_at <= $1`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to publish scheduled jobs: %w", err)
+	}
+	published, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	return published, nil
+}
 
+// ExpireJobs closes the open jobs whose expiry time has passed
+func (r *SQLJobRepository) ExpireJobs(ctx context.Context, now time.Time) (int64, error) {
+	query := `UPDATE jobs SET status = 'closed', updated_at = $1 WHERE status = 'open' AND expires_at <= $1`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to expire jobs: %w", err)
+	}
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	return expired, nil
+}
+
+// ClaimExpiringJobs marks the reminder of the open jobs expiring before the given
+// time as sent and returns them along with their recruiter email, so that two
+// workers never remind the same job
+func (r *SQLJobRepository) ClaimExpiringJobs(ctx context.Context, now, before time.Time) ([]*models.Job, error) {
+	query := `
+        UPDATE jobs j SET expiry_reminder_sent_at = $1
+        FROM users u
+        WHERE u.user_id = j.recruiter_id
+          AND j.status = 'open'
+          AND j.expires_at > $1 AND j.expires_at <= $2
+          AND j.expiry_reminder_sent_at IS NULL
+        RETURNING ` + jobColumns("j.") + `, u.email`
+
+	rows, err := r.db.QueryContext(ctx, query, now, before)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to claim expiring jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.Job
+	for rows.Next() {
+		var email string
+		job, err := scanJob(rows, &email)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan job: %w", err)
+		}
+		job.RecruiterEmail = email
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
 	return jobs, nil
 }
 
-func (r *SQLJobRepository) GetJobDetailsPublic(ctx context.Context, jobID int64) (*models.Job, error) {
-	query := `SELECT job_id, title, description, location, salary_range, required_skills, recruiter_id, created_at, updated_at, status, job_type
-              FROM jobs WHERE job_id = $1`
+// publicJobCondition hides draft and scheduled jobs from the public listings
+const publicJobCondition = "status IN ('open', 'closed')"
 
-	row := r.db.QueryRow(query, jobID)
-	job := &models.Job{}
-	err := row.Scan(
-		&job.ID, &job.Title, &job.Description, &job.Location, &job.SalaryRange, &job.RequiredSkills,
-		&job.RecruiterID, &job.CreatedAt, &job.UpdatedAt, &job.Status, &job.JobType,
-	)
+// jobSortColumns returns the sorts supported by job lists, prefix is the alias of
+// the jobs table in the query. Salaries are not comparable across currencies and
+// periods, only searches selecting both add the salary sort.
+func jobSortColumns(prefix string) map[string]string {
+	return map[string]string{
+		"created_at": fmt.Sprintf("COALESCE(%spublished_at, %screated_at)", prefix, prefix),
+		"title":      prefix + "title",
+	}
+}
 
+// queryJobPage fetches one page of the jobs selected by from, a FROM and WHERE
+// clause using args, along with the total number of matching jobs. Both are read
+// in one repeatable read transaction, so that the total matches the page. When
+// snippet is set, the SQL expression is selected into the Snippet of every job.
+func queryJobPage(ctx context.Context, db *sql.DB, from, prefix string, args []interface{}, page request.PageRequest, sortColumns map[string]string, snippet string) ([]*models.Job, *models.PageInfo, error) {
+	pageQuery, err := helpers.NewPageQuery(page, sortColumns, prefix+"job_id")
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, sql.ErrNoRows
+		return nil, nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	jobs, pageInfo, err := fetchJobPage(ctx, tx, from, prefix, args, pageQuery, snippet, total)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return jobs, pageInfo, nil
+}
+
+// fetchJobPage fetches the page of pageQuery among the jobs selected by from like
+// queryJobPage, in the transaction of the caller that counted them into total
+func fetchJobPage(ctx context.Context, tx *sql.Tx, from, prefix string, args []interface{}, pageQuery *helpers.PageQuery, snippet string, total int) ([]*models.Job, *models.PageInfo, error) {
+	columns := jobColumns(prefix)
+	if snippet != "" {
+		columns += ", " + snippet
+	}
+	query, queryArgs := pageQuery.Apply("SELECT "+columns+pageQuery.KeyColumns()+from, args)
+
+	rows, err := tx.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*models.Job
+	var keys []helpers.PageKey
+	for rows.Next() {
+		var snippetText string
+		var key helpers.PageKey
+		extra := []interface{}{&key.Value, &key.ID}
+		if snippet != "" {
+			extra = append([]interface{}{&snippetText}, extra...)
 		}
-		return nil, fmt.Errorf("repository: failed to fetch job by ID: %w", err)
+		job, err := scanJob(rows, extra...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		job.Snippet = snippetText
+		jobs = append(jobs, job)
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	jobs, pageInfo := helpers.Paginate(pageQuery, jobs, keys, total)
+	return jobs, pageInfo, nil
+}
+
+// jobColumns returns the columns scanned by scanJob, prefix is the alias of the
+// jobs table in the query
+func jobColumns(prefix string) string {
+	columns := []string{
+		"job_id", "title", "description", "location", "salary_min", "salary_max", "currency", "period",
+		"salary_needs_review", "required_skills", "recruiter_id", "created_at", "updated_at", "status", "job_type",
+		"publish_at", "published_at", "expires_at", "expiry_reminder_sent_at",
+		"wilaya_code", "commune_code", "latitude", "longitude", "work_mode", "category_id",
+	}
+	for i := range columns {
+		columns[i] = prefix + columns[i]
+	}
+	return strings.Join(columns, ", ")
+}
+
+// scanJob scans the jobColumns of a row, followed by any extra columns
+func scanJob(row rowScanner, extra ...interface{}) (*models.Job, error) {
+	job := &models.Job{}
+	dest := []interface{}{
+		&job.ID, &job.Title, &job.Description, &job.Location, &job.SalaryMin, &job.SalaryMax, &job.Currency, &job.Period,
+		&job.SalaryNeedsReview, &job.RequiredSkills, &job.RecruiterID, &job.CreatedAt, &job.UpdatedAt, &job.Status, &job.JobType,
+		&job.PublishAt, &job.PublishedAt, &job.ExpiresAt, &job.ExpiryReminderSentAt,
+		&job.WilayaCode, &job.CommuneCode, &job.Latitude, &job.Longitude, &job.WorkMode, &job.CategoryID,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
 	}
 	return job, nil
 }
+
+const jobRevisionColumns = `job_id, revision, snapshot, edited_by, restored_from, created_at`
+
+func scanJobRevision(row rowScanner) (*models.JobRevision, error) {
+	revision := &models.JobRevision{}
+	var snapshot []byte
+	err := row.Scan(&revision.JobID, &revision.Revision, &snapshot, &revision.EditedBy, &revision.RestoredFrom, &revision.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return nil, fmt.Errorf("invalid job revision snapshot: %w", err)
+	}
+	return revision, nil
+}
+
+// insertJobRevision records the content of job as its next revision, unless it
+// did not change since the latest one. The caller must hold the lock on the job
+// row so that revisions are numbered in order.
+func insertJobRevision(ctx context.Context, tx *sql.Tx, jobID int64, job *models.Job, editedBy uuid.UUID, restoredFrom *int) error {
+	snapshot, err := json.Marshal(helpers.JobSnapshotOf(job))
+	if err != nil {
+		return fmt.Errorf("repository: failed to encode job revision: %w", err)
+	}
+
+	var latest int
+	var unchanged bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT revision, snapshot = $2::jsonb FROM job_revisions WHERE job_id = $1 ORDER BY revision DESC LIMIT 1`,
+		jobID, snapshot,
+	).Scan(&latest, &unchanged)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("repository: failed to fetch latest job revision: %w", err)
+	}
+	if unchanged {
+		return nil
+	}
+
+	query := `INSERT INTO job_revisions (job_id, revision, snapshot, edited_by, restored_from, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.ExecContext(ctx, query, jobID, latest+1, snapshot, editedBy, restoredFrom, job.UpdatedAt); err != nil {
+		return fmt.Errorf("repository: failed to create job revision: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobListingQueryKeyword(t *testing.T) {
+	t.Run("Ranked by relevance with snippets", func(t *testing.T) {
+		listing, err := newJobListingQuery(request.JobFilters{Keyword: `"go developer" -intern`})
+		require.NoError(t, err)
+
+		assert.Contains(t, listing.from, "search_vector @@ (websearch_to_tsquery('french', $1)")
+		assert.Equal(t, []interface{}{`"go developer" -intern`}, listing.args)
+		assert.Equal(t, "relevance", listing.page.Sort)
+		assert.Contains(t, listing.sortColumns, "relevance")
+		assert.True(t, strings.HasPrefix(listing.snippet, "ts_headline("))
+	})
+
+	t.Run("Requested sort kept", func(t *testing.T) {
+		listing, err := newJobListingQuery(request.JobFilters{Keyword: "go", PageRequest: request.PageRequest{Sort: "title"}})
+		require.NoError(t, err)
+		assert.Equal(t, "title", listing.page.Sort)
+	})
+
+	t.Run("No keyword", func(t *testing.T) {
+		listing, err := newJobListingQuery(request.JobFilters{})
+		require.NoError(t, err)
+		assert.NotContains(t, listing.sortColumns, "relevance")
+		assert.Empty(t, listing.snippet)
+	})
+}
+
+func TestJobListingQuerySalary(t *testing.T) {
+	t.Run("Salary sort within a currency and period", func(t *testing.T) {
+		listing, err := newJobListingQuery(request.JobFilters{Currency: "DZD", Period: "monthly", PageRequest: request.PageRequest{Sort: "salary"}})
+		require.NoError(t, err)
+		assert.Contains(t, listing.sortColumns, "salary")
+	})
+
+	t.Run("Salary filter without a period", func(t *testing.T) {
+		_, err := newJobListingQuery(request.JobFilters{SalaryRangeMin: 50000, Currency: "DZD"})
+		var salaryErr *helpers.SalaryFilterError
+		assert.ErrorAs(t, err, &salaryErr)
+	})
+}
+
+func TestJobListingQueryLocation(t *testing.T) {
+	t.Run("Free text wilaya name", func(t *testing.T) {
+		listing, err := newJobListingQuery(request.JobFilters{Location: "Algiers"})
+		require.NoError(t, err)
+		assert.Contains(t, listing.from, "(location ILIKE $1 OR wilaya_code = $2)")
+		assert.Equal(t, []interface{}{"%Algiers%", 16}, listing.args)
+	})
+
+	t.Run("Commune", func(t *testing.T) {
+		listing, err := newJobListingQuery(request.JobFilters{Commune: "3101"})
+		require.NoError(t, err)
+		assert.Contains(t, listing.from, "commune_code = $1")
+		assert.Equal(t, []interface{}{3101}, listing.args)
+	})
+
+	t.Run("Radius around a wilaya", func(t *testing.T) {
+		listing, err := newJobListingQuery(request.JobFilters{Wilaya: "Oran", RadiusKm: 50, WorkMode: "hybrid"})
+		require.NoError(t, err)
+		oran := helpers.GetWilaya(31)
+		assert.Contains(t, listing.from, "latitude IS NOT NULL AND "+helpers.DistanceSQL("", 1, 2)+" <= $3")
+		assert.Contains(t, listing.from, "work_mode = $4")
+		assert.Equal(t, []interface{}{oran.Latitude, oran.Longitude, 50.0, "hybrid"}, listing.args)
+	})
+
+	t.Run("Unknown commune", func(t *testing.T) {
+		_, err := newJobListingQuery(request.JobFilters{Commune: "Atlantis"})
+		var locationErr *helpers.LocationError
+		assert.ErrorAs(t, err, &locationErr)
+	})
+}
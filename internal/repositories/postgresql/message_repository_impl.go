@@ -0,0 +1,455 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// messageColumns selects a message along with the side of the thread of its
+// sender, from messages m joined with their application a
+const messageColumns = `
+    m.message_id, m.application_id, m.sender_id,
+    CASE WHEN m.sender_id = a.candidate_id THEN 'candidate' ELSE 'recruiter' END,
+    m.body, m.read_at, m.removed_at, m.created_at`
+
+// reportColumns selects a message report along with the reported message, from
+// reportTables
+const reportColumns = `
+    r.report_id, r.message_id, r.reported_by, r.reason, r.status, r.reviewed_by, r.review_note, r.reviewed_at,
+    r.created_at,` + messageColumns
+
+const reportTables = `
+    FROM message_reports r
+    JOIN messages m ON m.message_id = r.message_id
+    JOIN applications a ON a.application_id = m.application_id`
+
+type SQLMessageRepository struct {
+	db *sql.DB
+}
+
+func NewMessageRepository(db *sql.DB) repositoryInterfaces.MessageRepository {
+	return &SQLMessageRepository{
+		db: db,
+	}
+}
+
+// CreateMessage inserts the message and its attachments in a single transaction
+func (r *SQLMessageRepository) CreateMessage(ctx context.Context, message *models.Message) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        INSERT INTO messages (application_id, sender_id, body, created_at)
+        VALUES ($1, $2, $3, $4)
+        RETURNING message_id
+    `
+	err = tx.QueryRowContext(ctx, query, message.ApplicationID, message.SenderID, message.Body, message.CreatedAt).Scan(&message.ID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to create message: %w", err)
+	}
+
+	attachmentQuery := `
+        INSERT INTO message_attachments (message_id, url, file_name, content_type, size)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING attachment_id
+    `
+	for i := range message.Attachments {
+		attachment := &message.Attachments[i]
+		attachment.MessageID = message.ID
+		err := tx.QueryRowContext(ctx, attachmentQuery,
+			attachment.MessageID, attachment.URL, attachment.FileName, attachment.ContentType, attachment.Size,
+		).Scan(&attachment.ID)
+		if err != nil {
+			return fmt.Errorf("repository: failed to create message attachment: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLMessageRepository) GetMessage(ctx context.Context, messageID int64) (*models.Message, error) {
+	query := `SELECT ` + messageColumns + `
+        FROM messages m
+        JOIN applications a ON a.application_id = m.application_id
+        WHERE m.message_id = $1`
+	message, err := scanMessage(r.db.QueryRowContext(ctx, query, messageID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch message: %w", err)
+	}
+	if err := r.loadAttachments(ctx, []*models.Message{message}); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// GetMessages returns a page of the messages of the thread of an application,
+// newest first unless the page asks otherwise
+func (r *SQLMessageRepository) GetMessages(ctx context.Context, applicationID int64, page request.PageRequest) ([]*models.Message, *models.PageInfo, error) {
+	pageQuery, err := helpers.NewPageQuery(page, map[string]string{"created_at": "m.created_at"}, "m.message_id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from := `
+        FROM messages m
+        JOIN applications a ON a.application_id = m.application_id
+        WHERE m.application_id = $1`
+	args := []interface{}{applicationID}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to count messages: %w", err)
+	}
+
+	query, queryArgs := pageQuery.Apply(`SELECT `+messageColumns+pageQuery.KeyColumns()+from, args)
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*models.Message
+	var keys []helpers.PageKey
+	for rows.Next() {
+		var key helpers.PageKey
+		message, err := scanMessage(rows, &key.Value, &key.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository: failed to scan message: %w", err)
+		}
+		messages = append(messages, message)
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	messages, pageInfo := helpers.Paginate(pageQuery, messages, keys, total)
+	if err := r.loadAttachments(ctx, messages); err != nil {
+		return nil, nil, err
+	}
+	return messages, pageInfo, nil
+}
+
+// MarkMessagesRead sets the read receipt of the messages of a thread that were
+// sent to readerID up to readAt, and returns how many were not read yet
+func (r *SQLMessageRepository) MarkMessagesRead(ctx context.Context, applicationID int64, readerID uuid.UUID, readAt time.Time) (int64, error) {
+	query := `
+        UPDATE messages
+        SET read_at = $3
+        WHERE application_id = $1 AND sender_id <> $2 AND read_at IS NULL AND created_at <= $3
+    `
+	result, err := r.db.ExecContext(ctx, query, applicationID, readerID, readAt)
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to mark messages as read: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
+// GetConversations returns the threads with at least one message of the
+// applications a user is the candidate or the recruiter of, the most recently
+// active first
+func (r *SQLMessageRepository) GetConversations(ctx context.Context, userID uuid.UUID, page request.PageRequest) ([]*models.Conversation, *models.PageInfo, error) {
+	pageQuery, err := helpers.NewPageQuery(page, map[string]string{"created_at": "c.last_message_at"}, "c.application_id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from := `
+        FROM (
+            SELECT a.application_id, j.job_id, j.title, COALESCE(r.company_name, '') AS company_name,
+                   a.candidate_id, cu.name AS candidate_name, j.recruiter_id,
+                   MAX(m.created_at) AS last_message_at,
+                   COUNT(*) FILTER (WHERE m.sender_id <> $1 AND m.read_at IS NULL AND m.removed_at IS NULL) AS unread_count
+            FROM messages m
+            JOIN applications a ON a.application_id = m.application_id
+            JOIN jobs j ON j.job_id = a.job_id
+            JOIN users cu ON cu.user_id = a.candidate_id
+            LEFT JOIN recruiters r ON r.recruiter_id = j.recruiter_id
+            WHERE a.candidate_id = $1 OR j.recruiter_id = $1
+            GROUP BY a.application_id, j.job_id, r.company_name, cu.name
+        ) c
+        WHERE TRUE`
+	args := []interface{}{userID}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to count conversations: %w", err)
+	}
+
+	columns := `SELECT c.application_id, c.job_id, c.title, c.company_name, c.candidate_id, c.candidate_name, c.recruiter_id,
+        c.last_message_at, c.unread_count`
+	query, queryArgs := pageQuery.Apply(columns+pageQuery.KeyColumns()+from, args)
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var conversations []*models.Conversation
+	var keys []helpers.PageKey
+	for rows.Next() {
+		var key helpers.PageKey
+		conversation := &models.Conversation{}
+		err := rows.Scan(
+			&conversation.ApplicationID, &conversation.JobID, &conversation.JobTitle, &conversation.CompanyName,
+			&conversation.CandidateID, &conversation.CandidateName, &conversation.RecruiterID,
+			&conversation.LastMessageAt, &conversation.UnreadCount, &key.Value, &key.ID,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository: failed to scan conversation: %w", err)
+		}
+		conversations = append(conversations, conversation)
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	conversations, pageInfo := helpers.Paginate(pageQuery, conversations, keys, total)
+	return conversations, pageInfo, nil
+}
+
+// CountUnreadMessages counts the messages sent to a user, across all of their
+// threads, that they have not read yet
+func (r *SQLMessageRepository) CountUnreadMessages(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM messages m
+        JOIN applications a ON a.application_id = m.application_id
+        JOIN jobs j ON j.job_id = a.job_id
+        WHERE (a.candidate_id = $1 OR j.recruiter_id = $1)
+          AND m.sender_id <> $1 AND m.read_at IS NULL AND m.removed_at IS NULL
+    `
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("repository: failed to count unread messages: %w", err)
+	}
+	return count, nil
+}
+
+func (r *SQLMessageRepository) CreateReport(ctx context.Context, report *models.MessageReport) error {
+	query := `
+        INSERT INTO message_reports (message_id, reported_by, reason, status, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING report_id
+    `
+	err := r.db.QueryRowContext(ctx, query, report.MessageID, report.ReportedBy, report.Reason, report.Status, report.CreatedAt).Scan(&report.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repositoryInterfaces.ErrMessageReported
+		}
+		return fmt.Errorf("repository: failed to create message report: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLMessageRepository) GetReport(ctx context.Context, reportID int64) (*models.MessageReport, error) {
+	report, err := scanReport(r.db.QueryRowContext(ctx, `SELECT `+reportColumns+reportTables+` WHERE r.report_id = $1`, reportID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch message report: %w", err)
+	}
+	if err := r.loadAttachments(ctx, []*models.Message{report.Message}); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetReports returns a page of the message reports in a status, or of all of
+// them when status is empty
+func (r *SQLMessageRepository) GetReports(ctx context.Context, status string, page request.PageRequest) ([]*models.MessageReport, *models.PageInfo, error) {
+	pageQuery, err := helpers.NewPageQuery(page, map[string]string{"created_at": "r.created_at"}, "r.report_id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	where := ` WHERE ($1 = '' OR r.status = $1)`
+	args := []interface{}{status}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM message_reports r` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to count message reports: %w", err)
+	}
+
+	query, queryArgs := pageQuery.Apply(`SELECT `+reportColumns+pageQuery.KeyColumns()+reportTables+where, args)
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch message reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*models.MessageReport
+	var keys []helpers.PageKey
+	for rows.Next() {
+		var key helpers.PageKey
+		report, err := scanReport(rows, &key.Value, &key.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository: failed to scan message report: %w", err)
+		}
+		reports = append(reports, report)
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	reports, pageInfo := helpers.Paginate(pageQuery, reports, keys, total)
+	messages := make([]*models.Message, 0, len(reports))
+	for _, report := range reports {
+		messages = append(messages, report.Message)
+	}
+	if err := r.loadAttachments(ctx, messages); err != nil {
+		return nil, nil, err
+	}
+	return reports, pageInfo, nil
+}
+
+// ReviewReport closes an open report with its review. When the review removes the
+// message, the message is marked as removed and the other open reports of the
+// message are closed along with it.
+func (r *SQLMessageRepository) ReviewReport(ctx context.Context, report *models.MessageReport) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        UPDATE message_reports
+        SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = $4
+        WHERE report_id = $5 AND status = 'open'
+    `
+	result, err := tx.ExecContext(ctx, query, report.Status, report.ReviewedBy, report.ReviewNote, report.ReviewedAt, report.ID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to review message report: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if report.Status == "removed" {
+		_, err := tx.ExecContext(ctx,
+			`UPDATE messages SET removed_at = $1 WHERE message_id = $2 AND removed_at IS NULL`,
+			report.ReviewedAt, report.MessageID,
+		)
+		if err != nil {
+			return fmt.Errorf("repository: failed to remove message: %w", err)
+		}
+		closeQuery := `
+            UPDATE message_reports
+            SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = $4
+            WHERE message_id = $5 AND status = 'open'
+        `
+		_, err = tx.ExecContext(ctx, closeQuery, report.Status, report.ReviewedBy, report.ReviewNote, report.ReviewedAt, report.MessageID)
+		if err != nil {
+			return fmt.Errorf("repository: failed to close message reports: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// loadAttachments loads the attachments of messages in a single query
+func (r *SQLMessageRepository) loadAttachments(ctx context.Context, messages []*models.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(messages))
+	byID := make(map[int64][]*models.Message, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+		byID[message.ID] = append(byID[message.ID], message)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT attachment_id, message_id, url, file_name, content_type, size
+        FROM message_attachments
+        WHERE message_id = ANY($1)
+        ORDER BY attachment_id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("repository: failed to fetch message attachments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attachment models.MessageAttachment
+		err := rows.Scan(
+			&attachment.ID, &attachment.MessageID, &attachment.URL, &attachment.FileName, &attachment.ContentType, &attachment.Size,
+		)
+		if err != nil {
+			return fmt.Errorf("repository: failed to scan message attachment: %w", err)
+		}
+		for _, message := range byID[attachment.MessageID] {
+			message.Attachments = append(message.Attachments, attachment)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("repository: rows error: %w", err)
+	}
+	return nil
+}
+
+// scanMessage scans the messageColumns of a row, followed by any extra columns
+func scanMessage(row rowScanner, extra ...interface{}) (*models.Message, error) {
+	message := &models.Message{}
+	dest := []interface{}{
+		&message.ID, &message.ApplicationID, &message.SenderID, &message.SenderRole, &message.Body, &message.ReadAt,
+		&message.RemovedAt, &message.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// scanReport scans the reportColumns of a row, followed by any extra columns
+func scanReport(row rowScanner, extra ...interface{}) (*models.MessageReport, error) {
+	report := &models.MessageReport{}
+	message := &models.Message{}
+	dest := []interface{}{
+		&report.ID, &report.MessageID, &report.ReportedBy, &report.Reason, &report.Status, &report.ReviewedBy,
+		&report.ReviewNote, &report.ReviewedAt, &report.CreatedAt,
+		&message.ID, &message.ApplicationID, &message.SenderID, &message.SenderRole, &message.Body, &message.ReadAt,
+		&message.RemovedAt, &message.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	report.Message = message
+	return report, nil
+}
@@ -0,0 +1,97 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type SQLNotificationPreferenceRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationPreferenceRepository(db *sql.DB) repositoryInterfaces.NotificationPreferenceRepository {
+	return &SQLNotificationPreferenceRepository{
+		db: db,
+	}
+}
+
+// GetPreferences returns the preferences of a user, the defaults when the user
+// never set any
+func (r *SQLNotificationPreferenceRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	preferences := &models.NotificationPreferences{UserID: userID}
+
+	query := `SELECT quiet_hours_start, quiet_hours_end, digest, updated_at FROM notification_preferences WHERE user_id = $1`
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&preferences.QuietHoursStart, &preferences.QuietHoursEnd, &preferences.Digest, &preferences.UpdatedAt,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("repository: failed to fetch notification preferences: %w", err)
+	}
+
+	query = `SELECT type, channel, enabled FROM notification_channel_preferences WHERE user_id = $1 ORDER BY type, channel`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch notification channel preferences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var channel models.NotificationChannelPreference
+		if err := rows.Scan(&channel.Type, &channel.Channel, &channel.Enabled); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan notification channel preference: %w", err)
+		}
+		preferences.Channels = append(preferences.Channels, channel)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences saves the quiet hours and digest mode of a user along with
+// the channels of the preferences, the channels left out keep their setting
+func (r *SQLNotificationPreferenceRepository) UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        INSERT INTO notification_preferences (user_id, quiet_hours_start, quiet_hours_end, digest, updated_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (user_id) DO UPDATE SET
+            quiet_hours_start = EXCLUDED.quiet_hours_start,
+            quiet_hours_end = EXCLUDED.quiet_hours_end,
+            digest = EXCLUDED.digest,
+            updated_at = EXCLUDED.updated_at
+    `
+	_, err = tx.ExecContext(ctx, query,
+		preferences.UserID, preferences.QuietHoursStart, preferences.QuietHoursEnd, preferences.Digest, preferences.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("repository: failed to save notification preferences: %w", err)
+	}
+
+	query = `
+        INSERT INTO notification_channel_preferences (user_id, type, channel, enabled)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (user_id, type, channel) DO UPDATE SET enabled = EXCLUDED.enabled
+    `
+	for _, channel := range preferences.Channels {
+		if _, err := tx.ExecContext(ctx, query, preferences.UserID, channel.Type, channel.Channel, channel.Enabled); err != nil {
+			return fmt.Errorf("repository: failed to save notification channel preference: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const notificationColumns = `notification_id, user_id, type, title, body, data, read_at, created_at`
+
+type SQLNotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) repositoryInterfaces.NotificationRepository {
+	return &SQLNotificationRepository{
+		db: db,
+	}
+}
+
+func (r *SQLNotificationRepository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return fmt.Errorf("repository: failed to encode notification data: %w", err)
+	}
+
+	query := `
+        INSERT INTO notifications (user_id, type, title, body, data, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING notification_id
+    `
+	err = r.db.QueryRowContext(ctx, query,
+		notification.UserID, notification.Type, notification.Title, notification.Body, data, notification.CreatedAt,
+	).Scan(&notification.ID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// GetNotifications returns a page of the notifications of a user matching the
+// filters, newest first unless the page asks otherwise
+func (r *SQLNotificationRepository) GetNotifications(ctx context.Context, userID uuid.UUID, filters request.NotificationFilters) ([]*models.Notification, *models.PageInfo, error) {
+	pageQuery, err := helpers.NewPageQuery(filters.PageRequest, map[string]string{"created_at": "created_at"}, "notification_id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from := ` FROM notifications WHERE user_id = $1`
+	args := []interface{}{userID}
+	if filters.Unread {
+		from += ` AND read_at IS NULL`
+	}
+	if filters.Type != "" {
+		args = append(args, filters.Type)
+		from += fmt.Sprintf(` AND type = $%d`, len(args))
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to count notifications: %w", err)
+	}
+
+	query, queryArgs := pageQuery.Apply(`SELECT `+notificationColumns+pageQuery.KeyColumns()+from, args)
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository: failed to fetch notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	var keys []helpers.PageKey
+	for rows.Next() {
+		var key helpers.PageKey
+		notification, err := scanNotification(rows, &key.Value, &key.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository: failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	notifications, pageInfo := helpers.Paginate(pageQuery, notifications, keys, total)
+	return notifications, pageInfo, nil
+}
+
+// GetNotificationsAfter returns the oldest notifications of a user created after
+// the notification afterID, for a stream to catch up on what it missed
+func (r *SQLNotificationRepository) GetNotificationsAfter(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]*models.Notification, error) {
+	query := `SELECT ` + notificationColumns + `
+        FROM notifications
+        WHERE user_id = $1 AND notification_id > $2
+        ORDER BY notification_id
+        LIMIT $3`
+	rows, err := r.db.QueryContext(ctx, query, userID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return notifications, nil
+}
+
+func (r *SQLNotificationRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("repository: failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkNotificationRead marks a notification of the user as read, keeping the time
+// it was first read at
+func (r *SQLNotificationRepository) MarkNotificationRead(ctx context.Context, userID uuid.UUID, notificationID int64, readAt time.Time) (*models.Notification, error) {
+	query := `
+        UPDATE notifications SET read_at = COALESCE(read_at, $3)
+        WHERE notification_id = $1 AND user_id = $2
+        RETURNING ` + notificationColumns
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, notificationID, userID, readAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to mark notification as read: %w", err)
+	}
+	return notification, nil
+}
+
+func (r *SQLNotificationRepository) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	query := `UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL AND created_at <= $2`
+	result, err := r.db.ExecContext(ctx, query, userID, readAt)
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to mark notifications as read: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
+// scanNotification scans the notificationColumns of a row, followed by any extra columns
+func scanNotification(row rowScanner, extra ...interface{}) (*models.Notification, error) {
+	notification := &models.Notification{}
+	var data []byte
+	dest := []interface{}{
+		&notification.ID, &notification.UserID, &notification.Type, &notification.Title, &notification.Body, &data,
+		&notification.ReadAt, &notification.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &notification.Data); err != nil {
+		return nil, fmt.Errorf("repository: failed to decode notification data: %w", err)
+	}
+	return notification, nil
+}
@@ -21,9 +21,9 @@ func NewCandidatePersonalInfoRepository(db *sql.DB) repositoryInterfaces.Candida
 }
 func (r *SQLCandidatePersonalInfoRepository) CreatePersonalInfo(ctx context.Context, info *models.CandidatePersonalInfo) error {
 	query := `
-		INSERT INTO candidate_personal_info (candidate_id, name, email, phone, address, date_of_birth, gender, bio)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
-	_, err := r.db.Exec(query, info.ID, info.Name, info.Email, info.Phone, info.Address, info.DateOfBirth, info.Gender, info.Bio)
+		INSERT INTO candidate_personal_info (candidate_id, name, email, phone, address, date_of_birth, gender, bio, wilaya_code, commune_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := r.db.Exec(query, info.ID, info.Name, info.Email, info.Phone, info.Address, info.DateOfBirth, info.Gender, info.Bio, info.WilayaCode, info.CommuneCode)
 	if err != nil {
 		return fmt.Errorf("unable to create personal info: %w", err)
 	}
@@ -32,10 +32,11 @@ func (r *SQLCandidatePersonalInfoRepository) CreatePersonalInfo(ctx context.Cont
 func (r *SQLCandidatePersonalInfoRepository) GetPersonalInfo(ctx context.Context, candidateID uuid.UUID) (*models.CandidatePersonalInfo, error) {
 	var info models.CandidatePersonalInfo
 	query := `
-		SELECT candidate_id, name, email, phone, address, date_of_birth, gender, bio
+		SELECT candidate_id, name, email, phone, address, date_of_birth, gender, bio, wilaya_code, commune_code
 		FROM candidate_personal_info
 		WHERE candidate_id = $1`
-	err := r.db.QueryRow(query, candidateID).Scan(&info.ID, &info.Name, &info.Email, &info.Phone, &info.Address, &info.DateOfBirth, &info.Gender, &info.Bio)
+	err := r.db.QueryRow(query, candidateID).Scan(&info.ID, &info.Name, &info.Email, &info.Phone, &info.Address, &info.DateOfBirth, &info.Gender, &info.Bio,
+		&info.WilayaCode, &info.CommuneCode)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return &models.CandidatePersonalInfo{}, fmt.Errorf("personal info not found: %w", err)
@@ -84,6 +85,12 @@ func (r *SQLCandidatePersonalInfoRepository) UpdatePersonalInfo(ctx context.Cont
 		args = append(args, info.Bio)
 		argIndex++
 	}
+	// The wilaya and commune are replaced together, a new wilaya clears the commune
+	if info.WilayaCode != nil {
+		query += fmt.Sprintf(" wilaya_code = $%d, commune_code = $%d,", argIndex, argIndex+1)
+		args = append(args, info.WilayaCode, info.CommuneCode)
+		argIndex += 2
+	}
 
 	if len(args) == 0 {
 		return fmt.Errorf("no fields to update")
@@ -0,0 +1,94 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SQLPipelineStageRepository struct {
+	db *sql.DB
+}
+
+func NewPipelineStageRepository(db *sql.DB) repositoryInterfaces.PipelineStageRepository {
+	return &SQLPipelineStageRepository{
+		db: db,
+	}
+}
+
+func (r *SQLPipelineStageRepository) GetStages(ctx context.Context, recruiterID uuid.UUID) ([]models.PipelineStage, error) {
+	query := `SELECT stage_id, recruiter_id, name, category, position
+              FROM pipeline_stages WHERE recruiter_id = $1 ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, recruiterID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch pipeline stages: %w", err)
+	}
+	defer rows.Close()
+
+	var stages []models.PipelineStage
+	for rows.Next() {
+		var stage models.PipelineStage
+		if err := rows.Scan(&stage.ID, &stage.RecruiterID, &stage.Name, &stage.Category, &stage.Position); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan pipeline stage: %w", err)
+		}
+		stages = append(stages, stage)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	return stages, nil
+}
+
+func (r *SQLPipelineStageRepository) ReplaceStages(ctx context.Context, recruiterID uuid.UUID, stages []models.PipelineStage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = stage.Name
+	}
+	var inUse bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM applications a
+            JOIN jobs j ON j.job_id = a.job_id
+            WHERE j.recruiter_id = $1 AND NOT (a.stage = ANY($2))
+        )`, recruiterID, pq.Array(names),
+	).Scan(&inUse)
+	if err != nil {
+		return fmt.Errorf("repository: failed to check pipeline stages in use: %w", err)
+	}
+	if inUse {
+		return repositoryInterfaces.ErrStageInUse
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pipeline_stages WHERE recruiter_id = $1`, recruiterID); err != nil {
+		return fmt.Errorf("repository: failed to clear pipeline stages: %w", err)
+	}
+
+	query := `INSERT INTO pipeline_stages (recruiter_id, name, category, position) VALUES ($1, $2, $3, $4) RETURNING stage_id`
+	for i := range stages {
+		stages[i].RecruiterID = recruiterID
+		stages[i].Position = i
+		err := tx.QueryRowContext(ctx, query, recruiterID, stages[i].Name, stages[i].Category, stages[i].Position).Scan(&stages[i].ID)
+		if err != nil {
+			return fmt.Errorf("repository: failed to create pipeline stage: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SQLRecommendationRepository struct {
+	db *sql.DB
+}
+
+func NewRecommendationRepository(db *sql.DB) repositoryInterfaces.RecommendationRepository {
+	return &SQLRecommendationRepository{
+		db: db,
+	}
+}
+
+func (r *SQLRecommendationRepository) GetCandidateProfile(ctx context.Context, candidateID uuid.UUID) (*models.CandidateProfile, error) {
+	profiles, err := r.loadCandidateProfiles(ctx, []uuid.UUID{candidateID})
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return profiles[0], nil
+}
+
+// GetCandidateProfilesForJob returns the profiles of the candidates sharing the
+// most skills with the job, the candidates who applied to it are left out
+func (r *SQLRecommendationRepository) GetCandidateProfilesForJob(ctx context.Context, jobID int64, limit int) ([]*models.CandidateProfile, error) {
+	query := `
+        SELECT c.candidate_id
+        FROM candidates c
+        LEFT JOIN candidate_skills cs
+            ON cs.candidate_id = c.candidate_id
+           AND cs.skill_id IN (SELECT skill_id FROM job_skills WHERE job_id = $1)
+        WHERE NOT EXISTS (SELECT 1 FROM applications a WHERE a.job_id = $1 AND a.candidate_id = c.candidate_id)
+        GROUP BY c.candidate_id
+        ORDER BY COUNT(cs.skill_id) DESC, c.candidate_id
+        LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidateIDs []uuid.UUID
+	for rows.Next() {
+		var candidateID uuid.UUID
+		if err := rows.Scan(&candidateID); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan candidate: %w", err)
+		}
+		candidateIDs = append(candidateIDs, candidateID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return r.loadCandidateProfiles(ctx, candidateIDs)
+}
+
+// GetOpenJobsForCandidate returns the open jobs sharing the most skills with the
+// candidate, newest first, the jobs the candidate applied to are left out
+func (r *SQLRecommendationRepository) GetOpenJobsForCandidate(ctx context.Context, candidateID uuid.UUID, limit int) ([]*models.Job, error) {
+	query := `
+        SELECT ` + jobColumns("j.") + `
+        FROM jobs j
+        LEFT JOIN job_skills js
+            ON js.job_id = j.job_id
+           AND js.skill_id IN (SELECT skill_id FROM candidate_skills WHERE candidate_id = $1)
+        WHERE j.status = 'open'
+          AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.job_id = j.job_id AND a.candidate_id = $1)
+        GROUP BY j.job_id
+        ORDER BY COUNT(js.skill_id) DESC, j.created_at DESC
+        LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, candidateID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return jobs, nil
+}
+
+func (r *SQLRecommendationRepository) GetJobSkills(ctx context.Context, jobIDs []int64) (map[int64][]models.Skill, error) {
+	query := `
+        SELECT js.job_id, s.skill_id, s.name, s.slug, s.category, s.created_at
+        FROM job_skills js
+        JOIN skills s ON s.skill_id = js.skill_id
+        WHERE js.job_id = ANY($1)
+        ORDER BY js.job_id, s.name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(jobIDs))
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch job skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := map[int64][]models.Skill{}
+	for rows.Next() {
+		var jobID int64
+		var skill models.Skill
+		if err := rows.Scan(&jobID, &skill.ID, &skill.Name, &skill.Slug, &skill.Category, &skill.CreatedAt); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan job skill: %w", err)
+		}
+		skills[jobID] = append(skills[jobID], skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return skills, nil
+}
+
+// loadCandidateProfiles returns the profiles of the given candidates in the same order
+func (r *SQLRecommendationRepository) loadCandidateProfiles(ctx context.Context, candidateIDs []uuid.UUID) ([]*models.CandidateProfile, error) {
+	if len(candidateIDs) == 0 {
+		return []*models.CandidateProfile{}, nil
+	}
+	ids := make([]string, len(candidateIDs))
+	for i, candidateID := range candidateIDs {
+		ids[i] = candidateID.String()
+	}
+
+	query := `
+        SELECT c.candidate_id, COALESCE(pi.name, ''), COALESCE(pi.address, ''), pi.wilaya_code, pi.commune_code,
+            ARRAY(SELECT e.job_title FROM candidate_experience e WHERE e.candidate_id = c.candidate_id)
+        FROM candidates c
+        LEFT JOIN candidate_personal_info pi ON pi.candidate_id = c.candidate_id
+        WHERE c.candidate_id = ANY($1::UUID[])`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch candidate profiles: %w", err)
+	}
+	defer rows.Close()
+
+	byID := map[uuid.UUID]*models.CandidateProfile{}
+	for rows.Next() {
+		profile := &models.CandidateProfile{Skills: []models.Skill{}}
+		if err := rows.Scan(&profile.CandidateID, &profile.Name, &profile.Address, &profile.WilayaCode, &profile.CommuneCode, pq.Array(&profile.ExperienceTitles)); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan candidate profile: %w", err)
+		}
+		byID[profile.CandidateID] = profile
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	skillRows, err := r.db.QueryContext(ctx, `
+        SELECT cs.candidate_id, s.skill_id, s.name, s.slug, s.category, s.created_at
+        FROM candidate_skills cs
+        JOIN skills s ON s.skill_id = cs.skill_id
+        WHERE cs.candidate_id = ANY($1::UUID[])`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch candidate skills: %w", err)
+	}
+	defer skillRows.Close()
+
+	for skillRows.Next() {
+		var candidateID uuid.UUID
+		var skill models.Skill
+		if err := skillRows.Scan(&candidateID, &skill.ID, &skill.Name, &skill.Slug, &skill.Category, &skill.CreatedAt); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan candidate skill: %w", err)
+		}
+		if profile, ok := byID[candidateID]; ok {
+			profile.Skills = append(profile.Skills, skill)
+		}
+	}
+	if err := skillRows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	profiles := make([]*models.CandidateProfile, 0, len(byID))
+	for _, candidateID := range candidateIDs {
+		if profile, ok := byID[candidateID]; ok {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles, nil
+}
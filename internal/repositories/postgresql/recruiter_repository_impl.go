@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type SQLRecruiterRepository struct {
@@ -55,6 +56,40 @@ func (r *SQLRecruiterRepository) GetRecruiter(ctx context.Context, recruiterID u
 	return recruiter, nil
 }
 
+// GetRecruiters returns the recruiters with the given IDs, by ID
+func (r *SQLRecruiterRepository) GetRecruiters(ctx context.Context, recruiterIDs []uuid.UUID) (map[uuid.UUID]*models.Recruiter, error) {
+	ids := make([]string, len(recruiterIDs))
+	for i, id := range recruiterIDs {
+		ids[i] = id.String()
+	}
+
+	query := `SELECT recruiter_id, company_name, company_logo, company_description, company_website,
+			  company_location, company_contact, social_links, verified_status
+			  FROM recruiters WHERE recruiter_id = ANY($1::uuid[])`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch recruiters: %w", err)
+	}
+	defer rows.Close()
+
+	recruiters := map[uuid.UUID]*models.Recruiter{}
+	for rows.Next() {
+		recruiter := &models.Recruiter{}
+		err := rows.Scan(&recruiter.ID, &recruiter.CompanyName, &recruiter.CompanyLogo,
+			&recruiter.CompanyDescription, &recruiter.CompanyWebsite, &recruiter.CompanyLocation,
+			&recruiter.CompanyContact, &recruiter.SocialLinks, &recruiter.VerifiedStatus)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan recruiter: %w", err)
+		}
+		recruiters[recruiter.ID] = recruiter
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return recruiters, nil
+}
+
 func (r *SQLRecruiterRepository) UpdateRecruiter(ctx context.Context, recruiterID uuid.UUID, recruiter *models.Recruiter) error {
 
 	query := `UPDATE recruiters SET company_name = $1, company_logo = $2, company_description = $3, 
@@ -0,0 +1,203 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SQLSavedSearchRepository struct {
+	db *sql.DB
+}
+
+func NewSavedSearchRepository(db *sql.DB) repositoryInterfaces.SavedSearchRepository {
+	return &SQLSavedSearchRepository{
+		db: db,
+	}
+}
+
+func savedSearchColumns(prefix string) string {
+	columns := []string{
+		"saved_search_id", "candidate_id", "name", "filters", "frequency", "unsubscribe_token",
+		"alerts_enabled", "last_run_at", "next_run_at", "created_at", "updated_at",
+	}
+	for i := range columns {
+		columns[i] = prefix + columns[i]
+	}
+	return strings.Join(columns, ", ")
+}
+
+func scanSavedSearch(row rowScanner, extra ...interface{}) (*models.SavedSearch, error) {
+	search := &models.SavedSearch{}
+	dest := []interface{}{
+		&search.ID, &search.CandidateID, &search.Name, &search.Filters, &search.Frequency, &search.UnsubscribeToken,
+		&search.AlertsEnabled, &search.LastRunAt, &search.NextRunAt, &search.CreatedAt, &search.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (r *SQLSavedSearchRepository) CreateSavedSearch(ctx context.Context, search *models.SavedSearch) error {
+	query := `
+        INSERT INTO saved_searches (
+            candidate_id, name, filters, frequency, unsubscribe_token, alerts_enabled, next_run_at, created_at, updated_at
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING saved_search_id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		search.CandidateID, search.Name, search.Filters, search.Frequency, search.UnsubscribeToken,
+		search.AlertsEnabled, search.NextRunAt, search.CreatedAt, search.UpdatedAt,
+	).Scan(&search.ID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to create saved search: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLSavedSearchRepository) GetSavedSearch(ctx context.Context, searchID int64, candidateID uuid.UUID) (*models.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns("") + ` FROM saved_searches WHERE saved_search_id = $1 AND candidate_id = $2`
+
+	search, err := scanSavedSearch(r.db.QueryRowContext(ctx, query, searchID, candidateID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch saved search: %w", err)
+	}
+	return search, nil
+}
+
+func (r *SQLSavedSearchRepository) GetSavedSearches(ctx context.Context, candidateID uuid.UUID) ([]*models.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns("") + ` FROM saved_searches WHERE candidate_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []*models.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan saved search: %w", err)
+		}
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return searches, nil
+}
+
+func (r *SQLSavedSearchRepository) CountSavedSearches(ctx context.Context, candidateID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM saved_searches WHERE candidate_id = $1`, candidateID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository: failed to count saved searches: %w", err)
+	}
+	return count, nil
+}
+
+func (r *SQLSavedSearchRepository) UpdateSavedSearch(ctx context.Context, search *models.SavedSearch) error {
+	query := `
+        UPDATE saved_searches SET
+            name = $1, filters = $2, frequency = $3, alerts_enabled = $4, next_run_at = $5, updated_at = $6
+        WHERE saved_search_id = $7 AND candidate_id = $8`
+
+	result, err := r.db.ExecContext(ctx, query,
+		search.Name, search.Filters, search.Frequency, search.AlertsEnabled, search.NextRunAt, search.UpdatedAt,
+		search.ID, search.CandidateID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository: failed to update saved search: %w", err)
+	}
+	return checkRowsAffected(result)
+}
+
+func (r *SQLSavedSearchRepository) DeleteSavedSearch(ctx context.Context, searchID int64, candidateID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM saved_searches WHERE saved_search_id = $1 AND candidate_id = $2`, searchID, candidateID)
+	if err != nil {
+		return fmt.Errorf("repository: failed to delete saved search: %w", err)
+	}
+	return checkRowsAffected(result)
+}
+
+// DisableAlerts stops the alerts of the saved search the unsubscribe token was issued for
+func (r *SQLSavedSearchRepository) DisableAlerts(ctx context.Context, unsubscribeToken string) error {
+	query := `UPDATE saved_searches SET alerts_enabled = FALSE, updated_at = $1 WHERE unsubscribe_token = $2`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), unsubscribeToken)
+	if err != nil {
+		return fmt.Errorf("repository: failed to disable saved search alerts: %w", err)
+	}
+	return checkRowsAffected(result)
+}
+
+// ClaimDueSavedSearches returns the saved searches due to run, along with the
+// candidate email. Their next run is pushed back by lease so that they are neither
+// picked up by another scheduler instance nor lost if this run does not complete.
+func (r *SQLSavedSearchRepository) ClaimDueSavedSearches(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.SavedSearch, error) {
+	query := `
+        UPDATE saved_searches s SET next_run_at = $2
+        FROM users u
+        WHERE u.user_id = s.candidate_id
+          AND s.saved_search_id IN (
+              SELECT saved_search_id FROM saved_searches
+              WHERE alerts_enabled AND next_run_at <= $1
+              ORDER BY next_run_at
+              LIMIT $3
+              FOR UPDATE SKIP LOCKED
+          )
+        RETURNING ` + savedSearchColumns("s.") + `, u.email`
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to claim due saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches []*models.SavedSearch
+	for rows.Next() {
+		var email string
+		search, err := scanSavedSearch(rows, &email)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan saved search: %w", err)
+		}
+		search.CandidateEmail = email
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return searches, nil
+}
+
+func (r *SQLSavedSearchRepository) MarkSavedSearchRun(ctx context.Context, searchID int64, ranAt, nextRunAt time.Time) error {
+	query := `UPDATE saved_searches SET last_run_at = $1, next_run_at = $2 WHERE saved_search_id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, ranAt, nextRunAt, searchID); err != nil {
+		return fmt.Errorf("repository: failed to update saved search run: %w", err)
+	}
+	return nil
+}
+
+func checkRowsAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+type SQLScreeningQuestionRepository struct {
+	db *sql.DB
+}
+
+func NewScreeningQuestionRepository(db *sql.DB) repositoryInterfaces.ScreeningQuestionRepository {
+	return &SQLScreeningQuestionRepository{
+		db: db,
+	}
+}
+
+func (r *SQLScreeningQuestionRepository) GetQuestions(ctx context.Context, jobID int64) ([]models.ScreeningQuestion, error) {
+	query := `SELECT question_id, job_id, position, type, prompt, required, options, reject_answers, reject_below, reject_above
+              FROM job_screening_questions WHERE job_id = $1 ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch screening questions: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []models.ScreeningQuestion
+	for rows.Next() {
+		var question models.ScreeningQuestion
+		err := rows.Scan(
+			&question.ID, &question.JobID, &question.Position, &question.Type, &question.Prompt, &question.Required,
+			pq.Array(&question.Options), pq.Array(&question.RejectAnswers), &question.RejectBelow, &question.RejectAbove,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("repository: failed to scan screening question: %w", err)
+		}
+		questions = append(questions, question)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+
+	return questions, nil
+}
+
+// ReplaceQuestions sets the questions of a job in a single transaction. Questions
+// with the ID of a current question of the job are updated in place so that the
+// answers given to them stay attached, the others are created and the current
+// questions left out are removed.
+func (r *SQLScreeningQuestionRepository) ReplaceQuestions(ctx context.Context, jobID int64, questions []models.ScreeningQuestion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var kept []int64
+	for _, question := range questions {
+		if question.ID != 0 {
+			kept = append(kept, question.ID)
+		}
+	}
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM job_screening_questions WHERE job_id = $1 AND NOT (question_id = ANY($2))`,
+		jobID, pq.Array(kept),
+	)
+	if err != nil {
+		return fmt.Errorf("repository: failed to remove screening questions: %w", err)
+	}
+
+	update := `UPDATE job_screening_questions
+               SET position = $1, type = $2, prompt = $3, required = $4, options = $5, reject_answers = $6, reject_below = $7, reject_above = $8
+               WHERE question_id = $9 AND job_id = $10`
+	insert := `INSERT INTO job_screening_questions (job_id, position, type, prompt, required, options, reject_answers, reject_below, reject_above)
+               VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING question_id`
+	for i := range questions {
+		question := &questions[i]
+		question.JobID = jobID
+		question.Position = i
+		options, rejectAnswers := pq.Array(nonNilStrings(question.Options)), pq.Array(nonNilStrings(question.RejectAnswers))
+
+		if question.ID == 0 {
+			err := tx.QueryRowContext(ctx, insert,
+				jobID, question.Position, question.Type, question.Prompt, question.Required,
+				options, rejectAnswers, question.RejectBelow, question.RejectAbove,
+			).Scan(&question.ID)
+			if err != nil {
+				return fmt.Errorf("repository: failed to create screening question: %w", err)
+			}
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, update,
+			question.Position, question.Type, question.Prompt, question.Required,
+			options, rejectAnswers, question.RejectBelow, question.RejectAbove, question.ID, jobID,
+		)
+		if err != nil {
+			return fmt.Errorf("repository: failed to update screening question: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("repository: failed to check rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// nonNilStrings returns an empty slice for nil, pq.Array stores nil as NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,297 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type SQLSkillCatalogRepository struct {
+	db *sql.DB
+}
+
+func NewSkillCatalogRepository(db *sql.DB) repositoryInterfaces.SkillCatalogRepository {
+	return &SQLSkillCatalogRepository{
+		db: db,
+	}
+}
+
+// SearchSkills returns the skills whose name or one of its aliases starts with prefix,
+// exact matches first
+func (r *SQLSkillCatalogRepository) SearchSkills(ctx context.Context, prefix string, limit int) ([]*models.Skill, error) {
+	slug := helpers.NormalizeSkillName(prefix)
+	query := `
+        SELECT s.skill_id, s.name, s.slug, s.category, s.created_at
+        FROM skills s
+        WHERE s.slug LIKE $1 || '%'
+           OR EXISTS (SELECT 1 FROM skill_aliases a WHERE a.skill_id = s.skill_id AND a.slug LIKE $1 || '%')
+        ORDER BY (s.slug = $1 OR EXISTS (SELECT 1 FROM skill_aliases a WHERE a.skill_id = s.skill_id AND a.slug = $1)) DESC, s.name
+        LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, escapeLike(slug), limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to search skills: %w", err)
+	}
+	defer rows.Close()
+
+	var skills []*models.Skill
+	for rows.Next() {
+		skill := &models.Skill{}
+		if err := rows.Scan(&skill.ID, &skill.Name, &skill.Slug, &skill.Category, &skill.CreatedAt); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan skill: %w", err)
+		}
+		skills = append(skills, skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return skills, nil
+}
+
+func (r *SQLSkillCatalogRepository) GetSkill(ctx context.Context, skillID int64) (*models.Skill, error) {
+	query := `SELECT skill_id, name, slug, category, created_at FROM skills WHERE skill_id = $1`
+
+	skill := &models.Skill{}
+	err := r.db.QueryRowContext(ctx, query, skillID).Scan(&skill.ID, &skill.Name, &skill.Slug, &skill.Category, &skill.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch skill: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT slug FROM skill_aliases WHERE skill_id = $1 ORDER BY slug`, skillID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch skill aliases: %w", err)
+	}
+	defer rows.Close()
+
+	skill.Aliases = []string{}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan skill alias: %w", err)
+		}
+		skill.Aliases = append(skill.Aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: rows error: %w", err)
+	}
+	return skill, nil
+}
+
+// FindSkill returns the skill whose name or one of its aliases matches name
+func (r *SQLSkillCatalogRepository) FindSkill(ctx context.Context, name string) (*models.Skill, error) {
+	query := `
+        SELECT s.skill_id, s.name, s.slug, s.category, s.created_at
+        FROM skills s
+        WHERE s.slug = $1
+           OR s.skill_id = (SELECT skill_id FROM skill_aliases WHERE slug = $1)
+        LIMIT 1`
+
+	skill := &models.Skill{}
+	err := r.db.QueryRowContext(ctx, query, helpers.NormalizeSkillName(name)).Scan(
+		&skill.ID, &skill.Name, &skill.Slug, &skill.Category, &skill.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to find skill: %w", err)
+	}
+	return skill, nil
+}
+
+// FindOrCreateSkill returns the catalog skill matching name, adding it to the
+// catalog under the 'other' category if it is unknown
+func (r *SQLSkillCatalogRepository) FindOrCreateSkill(ctx context.Context, name string) (*models.Skill, error) {
+	skill, err := r.FindSkill(ctx, name)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return skill, err
+	}
+
+	query := `INSERT INTO skills (name, slug) VALUES ($1, $2) ON CONFLICT (slug) DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, name, helpers.NormalizeSkillName(name)); err != nil {
+		return nil, fmt.Errorf("repository: failed to create skill: %w", err)
+	}
+	return r.FindSkill(ctx, name)
+}
+
+func (r *SQLSkillCatalogRepository) CreateSkill(ctx context.Context, skill *models.Skill) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := checkSkillSlugsFree(ctx, tx, 0, append([]string{skill.Slug}, skill.Aliases...)); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO skills (name, slug, category, created_at) VALUES ($1, $2, $3, $4) RETURNING skill_id`,
+		skill.Name, skill.Slug, skill.Category, skill.CreatedAt,
+	).Scan(&skill.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repositoryInterfaces.ErrSkillExists
+		}
+		return fmt.Errorf("repository: failed to create skill: %w", err)
+	}
+
+	if err := insertSkillAliases(ctx, tx, skill.ID, skill.Aliases); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateSkill renames the skill and replaces its aliases
+func (r *SQLSkillCatalogRepository) UpdateSkill(ctx context.Context, skill *models.Skill) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := checkSkillSlugsFree(ctx, tx, skill.ID, append([]string{skill.Slug}, skill.Aliases...)); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE skills SET name = $1, slug = $2, category = $3 WHERE skill_id = $4`,
+		skill.Name, skill.Slug, skill.Category, skill.ID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repositoryInterfaces.ErrSkillExists
+		}
+		return fmt.Errorf("repository: failed to update skill: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM skill_aliases WHERE skill_id = $1`, skill.ID); err != nil {
+		return fmt.Errorf("repository: failed to delete skill aliases: %w", err)
+	}
+	if err := insertSkillAliases(ctx, tx, skill.ID, skill.Aliases); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// MergeSkills folds the source skills into the target skill: their names become
+// aliases of the target, and the candidates and jobs referencing them are
+// re-pointed to the target before the sources are deleted
+func (r *SQLSkillCatalogRepository) MergeSkills(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var found int
+	err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM skills WHERE skill_id = $1 OR skill_id = ANY($2)`,
+		targetID, pq.Array(sourceIDs),
+	).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("repository: failed to fetch skills: %w", err)
+	}
+	if found != len(sourceIDs)+1 {
+		return sql.ErrNoRows
+	}
+
+	statements := []string{
+		`UPDATE skill_aliases SET skill_id = $1 WHERE skill_id = ANY($2)`,
+		`INSERT INTO skill_aliases (slug, skill_id)
+         SELECT slug, $1 FROM skills WHERE skill_id = ANY($2)
+         ON CONFLICT (slug) DO UPDATE SET skill_id = EXCLUDED.skill_id`,
+		`INSERT INTO candidate_skills (candidate_id, skill_id, skill)
+         SELECT DISTINCT cs.candidate_id, $1::BIGINT, (SELECT name FROM skills WHERE skill_id = $1)
+         FROM candidate_skills cs WHERE cs.skill_id = ANY($2)
+         ON CONFLICT (candidate_id, skill_id) DO NOTHING`,
+		`INSERT INTO job_skills (job_id, skill_id)
+         SELECT DISTINCT job_id, $1::BIGINT FROM job_skills WHERE skill_id = ANY($2)
+         ON CONFLICT DO NOTHING`,
+		`DELETE FROM skills WHERE skill_id = ANY($2)`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, targetID, pq.Array(sourceIDs)); err != nil {
+			return fmt.Errorf("repository: failed to merge skills: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// checkSkillSlugsFree makes sure none of slugs is the name or alias of a skill
+// other than skillID
+func checkSkillSlugsFree(ctx context.Context, tx *sql.Tx, skillID int64, slugs []string) error {
+	var taken bool
+	err := tx.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM skills WHERE slug = ANY($1) AND skill_id <> $2)
+            OR EXISTS (SELECT 1 FROM skill_aliases WHERE slug = ANY($1) AND skill_id <> $2)`,
+		pq.Array(slugs), skillID,
+	).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("repository: failed to check skill names: %w", err)
+	}
+	if taken {
+		return repositoryInterfaces.ErrSkillExists
+	}
+	return nil
+}
+
+func insertSkillAliases(ctx context.Context, tx *sql.Tx, skillID int64, aliases []string) error {
+	for _, alias := range aliases {
+		_, err := tx.ExecContext(ctx, `INSERT INTO skill_aliases (slug, skill_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, alias, skillID)
+		if err != nil {
+			return fmt.Errorf("repository: failed to create skill alias: %w", err)
+		}
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// isConstraintViolation tells whether err was raised by the named constraint
+func isConstraintViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Constraint == constraint
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
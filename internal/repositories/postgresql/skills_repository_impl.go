@@ -21,8 +21,9 @@ func NewCandidateSkillsRepository(db *sql.DB) repositoryInterfaces.CandidateSkil
 }
 
 func (r *SQLCandidateSkillsRepository) CreateSkill(ctx context.Context, skill *models.CandidateSkills) error {
-	query := `INSERT INTO candidate_skills (candidate_id, skill) VALUES ($1, $2)`
-	_, err := r.db.Exec(query, skill.ID, skill.Skill)
+	query := `INSERT INTO candidate_skills (candidate_id, skill_id, skill) VALUES ($1, $2, $3)
+              ON CONFLICT (candidate_id, skill_id) DO NOTHING`
+	_, err := r.db.Exec(query, skill.ID, skill.SkillID, skill.Skill)
 	if err != nil {
 		return fmt.Errorf("unable to create skill: %w", err)
 	}
@@ -30,7 +31,12 @@ func (r *SQLCandidateSkillsRepository) CreateSkill(ctx context.Context, skill *m
 }
 
 func (r *SQLCandidateSkillsRepository) GetSkills(ctx context.Context, candidateID uuid.UUID) ([]models.CandidateSkills, error) {
-	rows, err := r.db.Query(`SELECT candidate_id, skill FROM candidate_skills WHERE candidate_id = $1`, candidateID)
+	query := `SELECT cs.candidate_id, cs.skill_id, s.name, s.category
+              FROM candidate_skills cs
+              JOIN skills s ON s.skill_id = cs.skill_id
+              WHERE cs.candidate_id = $1
+              ORDER BY s.name`
+	rows, err := r.db.Query(query, candidateID)
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch skills: %w", err)
 	}
@@ -39,7 +45,7 @@ func (r *SQLCandidateSkillsRepository) GetSkills(ctx context.Context, candidateI
 	var skills []models.CandidateSkills
 	for rows.Next() {
 		var skill models.CandidateSkills
-		if err := rows.Scan(&skill.ID, &skill.Skill); err != nil {
+		if err := rows.Scan(&skill.ID, &skill.SkillID, &skill.Skill, &skill.Category); err != nil {
 			return nil, fmt.Errorf("unable to scan skill data: %w", err)
 		}
 		skills = append(skills, skill)
@@ -50,9 +56,9 @@ func (r *SQLCandidateSkillsRepository) GetSkills(ctx context.Context, candidateI
 	return skills, nil
 }
 
-func (r *SQLCandidateSkillsRepository) DeleteSkill(ctx context.Context, candidateID uuid.UUID, skillName string) error {
-	query := `DELETE FROM candidate_skills WHERE candidate_id = $1 AND skill = $2`
-	_, err := r.db.Exec(query, candidateID, skillName)
+func (r *SQLCandidateSkillsRepository) DeleteSkill(ctx context.Context, candidateID uuid.UUID, skillID int64) error {
+	query := `DELETE FROM candidate_skills WHERE candidate_id = $1 AND skill_id = $2`
+	_, err := r.db.Exec(query, candidateID, skillID)
 	if err != nil {
 		return fmt.Errorf("unable to delete skill: %w", err)
 	}
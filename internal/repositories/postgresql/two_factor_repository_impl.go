@@ -0,0 +1,197 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type SQLTwoFactorRepository struct {
+	db *sql.DB
+}
+
+func NewTwoFactorRepository(db *sql.DB) repositoryInterfaces.TwoFactorRepository {
+	return &SQLTwoFactorRepository{
+		db: db,
+	}
+}
+
+func (r *SQLTwoFactorRepository) GetTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactor, error) {
+	twoFactor := &models.TwoFactor{}
+	query := `SELECT user_id, secret, enabled_at, last_used_step, created_at FROM user_two_factor WHERE user_id = $1`
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&twoFactor.UserID, &twoFactor.Secret, &twoFactor.EnabledAt, &twoFactor.LastUsedStep, &twoFactor.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("repository: failed to fetch two-factor settings: %w", err)
+	}
+	return twoFactor, nil
+}
+
+func (r *SQLTwoFactorRepository) GetTwoFactors(ctx context.Context) ([]*models.TwoFactor, error) {
+	query := `SELECT user_id, secret, enabled_at, last_used_step, created_at FROM user_two_factor`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("repository: failed to fetch two-factor settings: %w", err)
+	}
+	defer rows.Close()
+
+	var twoFactors []*models.TwoFactor
+	for rows.Next() {
+		twoFactor := &models.TwoFactor{}
+		if err := rows.Scan(&twoFactor.UserID, &twoFactor.Secret, &twoFactor.EnabledAt, &twoFactor.LastUsedStep, &twoFactor.CreatedAt); err != nil {
+			return nil, fmt.Errorf("repository: failed to scan two-factor settings: %w", err)
+		}
+		twoFactors = append(twoFactors, twoFactor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: failed to iterate two-factor settings: %w", err)
+	}
+	return twoFactors, nil
+}
+
+// ReplaceSecret swaps the stored secret of a user for another form of it, unless
+// it changed in the meantime
+func (r *SQLTwoFactorRepository) ReplaceSecret(ctx context.Context, userID uuid.UUID, oldSecret, newSecret string) error {
+	query := `UPDATE user_two_factor SET secret = $3 WHERE user_id = $1 AND secret = $2`
+	if _, err := r.db.ExecContext(ctx, query, userID, oldSecret, newSecret); err != nil {
+		return fmt.Errorf("repository: failed to replace two-factor secret: %w", err)
+	}
+	return nil
+}
+
+// SavePendingSecret replaces the secret of a user whose two-factor authentication
+// is not enabled yet, the secret of an enabled one is left untouched
+func (r *SQLTwoFactorRepository) SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	query := `
+        INSERT INTO user_two_factor (user_id, secret, created_at)
+        VALUES ($1, $2, CURRENT_TIMESTAMP)
+        ON CONFLICT (user_id) DO UPDATE SET
+            secret = EXCLUDED.secret,
+            last_used_step = 0,
+            created_at = EXCLUDED.created_at
+        WHERE user_two_factor.enabled_at IS NULL
+    `
+	result, err := r.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return fmt.Errorf("repository: failed to save two-factor secret: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// EnableTwoFactor enables the pending secret of a user, recording the step of the
+// code it was confirmed with, and replaces the recovery codes of the user
+func (r *SQLTwoFactorRepository) EnableTwoFactor(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        UPDATE user_two_factor SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2
+        WHERE user_id = $1 AND enabled_at IS NULL
+    `
+	result, err := tx.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("repository: failed to enable two-factor authentication: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("repository: failed to delete recovery codes: %w", err)
+	}
+	query = `INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)`
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx, query, userID, codeHash); err != nil {
+			return fmt.Errorf("repository: failed to save recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UseStep records the time step of an accepted code, reporting false when a code
+// of that step or a later one was already used
+func (r *SQLTwoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `UPDATE user_two_factor SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+	result, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("repository: failed to record two-factor code use: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("repository: failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// UseRecoveryCode marks a recovery code of a user used, reporting false when it
+// does not exist or was already used
+func (r *SQLTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `
+        UPDATE user_recovery_codes SET used_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+    `
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("repository: failed to use recovery code: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("repository: failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *SQLTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("repository: failed to count recovery codes: %w", err)
+	}
+	return count, nil
+}
+
+func (r *SQLTwoFactorRepository) DisableTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("repository: failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_two_factor WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("repository: failed to disable two-factor authentication: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package postgresql
 import (
     "context" 
     "database/sql"
+    "dz-jobs-api/internal/dto/request"
+    "dz-jobs-api/internal/helpers"
     "dz-jobs-api/internal/models"
     repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
     "errors"
@@ -22,9 +24,9 @@ func NewUserRepository(db *sql.DB) repositoryInterfaces.UserRepository {
 }
 
 func (r *SQLUserRepository) CreateUser(ctx context.Context, user *models.User) error { 
-    query := "INSERT INTO users (name, email, password, role, created_at, updated_at) VALUES ($1, $2, $3, $4, NOW(), NOW()) RETURNING user_id"
+    query := "INSERT INTO users (name, email, password, role, email_verified_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING user_id"
     var user_id uuid.UUID
-    err := r.db.QueryRowContext(ctx, query, user.Name, user.Email, user.Password, user.Role).Scan(&user_id) 
+    err := r.db.QueryRowContext(ctx, query, user.Name, user.Email, user.Password, user.Role, user.EmailVerifiedAt).Scan(&user_id) 
     if err != nil {
         return fmt.Errorf("repository: failed to create user: %w", err)
     }
@@ -33,10 +35,10 @@ func (r *SQLUserRepository) CreateUser(ctx context.Context, user *models.User) e
 }
 
 func (r *SQLUserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) { 
-    query := "SELECT user_id, name, email, password, role, created_at, updated_at FROM users WHERE email = $1"
+    query := "SELECT user_id, name, email, password, role, email_verified_at, created_at, updated_at FROM users WHERE email = $1"
     row := r.db.QueryRowContext(ctx, query, email) 
     user := &models.User{}
-    err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+    err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
     if err != nil {
         if errors.Is(err, sql.ErrNoRows) {
             return nil, sql.ErrNoRows
@@ -47,10 +49,10 @@ func (r *SQLUserRepository) GetUserByEmail(ctx context.Context, email string) (*
 }
 
 func (r *SQLUserRepository) GetUserByID(ctx context.Context, user_id uuid.UUID) (*models.User, error) { 
-    query := "SELECT user_id, name, email, role, created_at, updated_at FROM users WHERE user_id = $1"
+    query := "SELECT user_id, name, email, role, email_verified_at, created_at, updated_at FROM users WHERE user_id = $1"
     row := r.db.QueryRowContext(ctx, query, user_id) 
     user := &models.User{}
-    err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+    err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
     if err != nil {
         if errors.Is(err, sql.ErrNoRows) {
             return nil, sql.ErrNoRows
@@ -60,29 +62,47 @@ func (r *SQLUserRepository) GetUserByID(ctx context.Context, user_id uuid.UUID)
     return user, nil
 }
 
-func (r *SQLUserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error) { 
-    query := "SELECT user_id, name, email, role, created_at, updated_at FROM users"
-    rows, err := r.db.QueryContext(ctx, query) 
+func (r *SQLUserRepository) GetAllUsers(ctx context.Context, page request.PageRequest) ([]*models.User, *models.PageInfo, error) { 
+    pageQuery, err := helpers.NewPageQuery(page, map[string]string{"created_at": "created_at"}, "user_id")
     if err != nil {
-        return nil, fmt.Errorf("repository: failed to fetch users: %w", err)
+        return nil, nil, err
+    }
+    var total int
+    if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&total); err != nil {
+        return nil, nil, fmt.Errorf("repository: failed to count users: %w", err)
+    }
+    query, args := pageQuery.Apply("SELECT user_id, name, email, role, email_verified_at, created_at, updated_at"+pageQuery.KeyColumns()+" FROM users WHERE 1=1", nil)
+    rows, err := r.db.QueryContext(ctx, query, args...) 
+    if err != nil {
+        return nil, nil, fmt.Errorf("repository: failed to fetch users: %w", err)
     }
     defer rows.Close()
     var users []*models.User
+    var keys []helpers.PageKey
     for rows.Next() {
         user := &models.User{}
-        if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt); err != nil {
-            return nil, fmt.Errorf("repository: failed to scan user data: %w", err)
+        var key helpers.PageKey
+        if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt, &key.Value, &key.ID); err != nil {
+            return nil, nil, fmt.Errorf("repository: failed to scan user data: %w", err)
         }
         users = append(users, user)
+        keys = append(keys, key)
     }
     if err := rows.Err(); err != nil {
-        return nil, fmt.Errorf("repository: error occurred while iterating users: %w", err)
+        return nil, nil, fmt.Errorf("repository: error occurred while iterating users: %w", err)
     }
-    return users, nil
+    users, pageInfo := helpers.Paginate(pageQuery, users, keys, total)
+    return users, pageInfo, nil
 }
 
 func (r *SQLUserRepository) UpdateUser(ctx context.Context, user_id uuid.UUID, user *models.User) error { 
-    query := "UPDATE users SET name = $1, email = $2, password = $3, role = $4, updated_at = NOW() WHERE user_id = $5"
+    // A new email address has to be verified again
+    query := `
+        UPDATE users SET name = $1, email = $2, password = $3, role = $4,
+            email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
+            updated_at = NOW()
+        WHERE user_id = $5
+    `
     result, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.Password, user.Role, user_id) 
     if err != nil {
         return fmt.Errorf("repository: failed to update user: %w", err)
@@ -113,6 +133,16 @@ func (r *SQLUserRepository) UpdateUserPassword(ctx context.Context, email, hashe
     return nil
 }
 
+// MarkEmailVerified records that a user confirmed their email address, the first
+// confirmation is kept
+func (r *SQLUserRepository) MarkEmailVerified(ctx context.Context, user_id uuid.UUID) error {
+    query := "UPDATE users SET email_verified_at = NOW(), updated_at = NOW() WHERE user_id = $1 AND email_verified_at IS NULL"
+    if _, err := r.db.ExecContext(ctx, query, user_id); err != nil {
+        return fmt.Errorf("repository: failed to mark email verified: %w", err)
+    }
+    return nil
+}
+
 func (r *SQLUserRepository) DeleteUser(ctx context.Context, user_id uuid.UUID) error { 
     query := "DELETE FROM users WHERE user_id = $1"
     result, err := r.db.ExecContext(ctx, query, user_id) 
@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+)
+
+// notificationChannel is the pub/sub channel every instance of the API publishes
+// its notifications to and listens on
+const notificationChannel = "notifications"
+
+type NotificationBroker struct {
+	redisClient *redis.Client
+}
+
+func NewNotificationBroker(redisClient *redis.Client) repositoryInterfaces.NotificationBroker {
+	return &NotificationBroker{
+		redisClient: redisClient,
+	}
+}
+
+func (b *NotificationBroker) Publish(ctx context.Context, notification *models.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("redis: failed to marshal notification: %w", err)
+	}
+	if err := b.redisClient.Publish(ctx, notificationChannel, payload).Err(); err != nil {
+		return fmt.Errorf("redis: failed to publish notification %d: %w", notification.ID, err)
+	}
+	return nil
+}
+
+// Subscribe listens to the notifications published by every instance. The
+// returned channel is closed once ctx is cancelled or the subscription is lost.
+func (b *NotificationBroker) Subscribe(ctx context.Context) (<-chan *models.Notification, error) {
+	pubsub := b.redisClient.Subscribe(ctx, notificationChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("redis: failed to subscribe to notifications: %w", err)
+	}
+
+	notifications := make(chan *models.Notification)
+	go func() {
+		defer close(notifications)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				notification := &models.Notification{}
+				if err := json.Unmarshal([]byte(message.Payload), notification); err != nil {
+					log.WithError(err).Error("Failed to decode published notification")
+					continue
+				}
+				select {
+				case notifications <- notification:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return notifications, nil
+}
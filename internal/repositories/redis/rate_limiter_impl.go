@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// rateLimitRetries is how many times a request retries taking from a limit raced
+// by another request of the same client
+const rateLimitRetries = 3
+
+// RateLimiter keeps the state of the rate limits in Redis so that they hold
+// across every instance of the API
+type RateLimiter struct {
+	redisClient *redis.Client
+}
+
+func NewRateLimiter(redisClient *redis.Client) repositoryInterfaces.RateLimiter {
+	return &RateLimiter{
+		redisClient: redisClient,
+	}
+}
+
+// Take takes a request out of the limit under key, storing the theoretical
+// arrival time of the client until its limit is replenished
+func (l *RateLimiter) Take(ctx context.Context, key string, limit utils.RateLimit) (*utils.RateLimitResult, error) {
+	limitKey := fmt.Sprintf("rate_limit:%s", key)
+	var result utils.RateLimitResult
+	take := func(tx *redis.Tx) error {
+		var tat time.Time
+		value, err := tx.Get(ctx, limitKey).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if err == nil {
+			nanos, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			tat = time.Unix(0, nanos)
+		}
+
+		var newTAT time.Time
+		result, newTAT = limit.Take(tat, time.Now())
+		if !result.Allowed {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, limitKey, newTAT.UnixNano(), result.ResetAfter)
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for i := 0; i < rateLimitRetries; i++ {
+		if err = l.redisClient.Watch(ctx, take, limitKey); err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis: failed to take rate limit %s: %w", key, err)
+	}
+	return &result, nil
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"dz-jobs-api/internal/models"
 	repositoryInterfaces "dz-jobs-api/internal/repositories/interfaces"
 	"dz-jobs-api/pkg/utils"
 	"encoding/json"
@@ -49,6 +50,45 @@ func (r *RedisRepository) InvalidateOTP(ctx context.Context, email string) error
 	return nil
 }
 
+func (r *RedisRepository) StoreVerificationOTP(ctx context.Context, email, otp string, expiry time.Duration) error {
+	key := fmt.Sprintf("verification_otp:%s", email)
+	if err := r.redisClient.Set(ctx, key, otp, expiry).Err(); err != nil {
+		return fmt.Errorf("redis: failed to store verification OTP for email %s: %w", email, err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) GetVerificationOTP(ctx context.Context, email string) (string, error) {
+	key := fmt.Sprintf("verification_otp:%s", email)
+	result, err := r.redisClient.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", redis.Nil
+		}
+		return "", fmt.Errorf("redis: failed to get verification OTP for email %s: %w", email, err)
+	}
+	return result, nil
+}
+
+func (r *RedisRepository) InvalidateVerificationOTP(ctx context.Context, email string) error {
+	key := fmt.Sprintf("verification_otp:%s", email)
+	if err := r.redisClient.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("redis: failed to delete verification OTP for email %s: %w", email, err)
+	}
+	return nil
+}
+
+// AcquireVerificationCooldown reports whether a verification email can be sent
+// to an address, holding further ones back for the cooldown when it can
+func (r *RedisRepository) AcquireVerificationCooldown(ctx context.Context, email string, cooldown time.Duration) (bool, error) {
+	key := fmt.Sprintf("verification_cooldown:%s", email)
+	acquired, err := r.redisClient.SetNX(ctx, key, 1, cooldown).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis: failed to set verification cooldown for email %s: %w", email, err)
+	}
+	return acquired, nil
+}
+
 func (r *RedisRepository) StoreResetToken(ctx context.Context, email, token string, expiry time.Duration) error {
 	key := fmt.Sprintf("reset_token:%s", email)
 	if err := r.redisClient.Set(ctx, key, token, expiry).Err(); err != nil {
@@ -77,30 +117,198 @@ func (r *RedisRepository) InvalidateResetToken(ctx context.Context, email string
 	return nil
 }
 
-func (r *RedisRepository) StoreRefreshToken(ctx context.Context, UserID, refreshToken string, expiry time.Duration) error {
-	key := fmt.Sprintf("refresh_token:%s", UserID)
-	if err := r.redisClient.Set(ctx, key, refreshToken, expiry).Err(); err != nil {
-		return fmt.Errorf("redis: failed to store refresh token for UserID %s: %w", UserID, err)
+// RecordAttempt adds an attempt under key to its sliding window, dropping the
+// ones older than the window. It returns the ID of the attempt, to take it back
+// with RemoveAttempt, and the times of the attempts recorded before it within the
+// window, oldest first. Both run in one transaction, so that concurrent attempts
+// always count each other.
+func (r *RedisRepository) RecordAttempt(ctx context.Context, key string, at time.Time, window time.Duration) (string, []time.Time, error) {
+	attemptsKey := fmt.Sprintf("attempts:%s", key)
+	member := fmt.Sprintf("%d:%s", at.UnixNano(), utils.GenerateOpaqueToken(4))
+
+	var previous *redis.ZSliceCmd
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, attemptsKey, "-inf", fmt.Sprintf("(%d", at.Add(-window).UnixMilli()))
+		previous = pipe.ZRangeWithScores(ctx, attemptsKey, 0, -1)
+		pipe.ZAdd(ctx, attemptsKey, &redis.Z{Score: float64(at.UnixMilli()), Member: member})
+		pipe.Expire(ctx, attemptsKey, window)
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("redis: failed to record attempt for %s: %w", key, err)
+	}
+
+	attempts := make([]time.Time, 0, len(previous.Val()))
+	for _, entry := range previous.Val() {
+		attempts = append(attempts, time.UnixMilli(int64(entry.Score)))
+	}
+	return member, attempts, nil
+}
+
+// RemoveAttempt takes back an attempt recorded under key by RecordAttempt
+func (r *RedisRepository) RemoveAttempt(ctx context.Context, key, attemptID string) error {
+	attemptsKey := fmt.Sprintf("attempts:%s", key)
+	if err := r.redisClient.ZRem(ctx, attemptsKey, attemptID).Err(); err != nil {
+		return fmt.Errorf("redis: failed to remove attempt for %s: %w", key, err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) ClearAttempts(ctx context.Context, key string) error {
+	attemptsKey := fmt.Sprintf("attempts:%s", key)
+	if err := r.redisClient.Del(ctx, attemptsKey).Err(); err != nil {
+		return fmt.Errorf("redis: failed to clear attempts for %s: %w", key, err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) StoreSession(ctx context.Context, session *models.Session, expiry time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("redis: failed to marshal session: %w", err)
+	}
+
+	userKey := fmt.Sprintf("user_sessions:%s", session.UserID)
+	_, err = r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, fmt.Sprintf("session:%s", session.ID), data, expiry)
+		pipe.SAdd(ctx, userKey, session.ID.String())
+		pipe.Expire(ctx, userKey, expiry)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis: failed to store session %s: %w", session.ID, err)
 	}
 	return nil
 }
 
-func (r *RedisRepository) GetRefreshToken(ctx context.Context, UserID string) (string, error) {
-	key := fmt.Sprintf("refresh_token:%s", UserID)
+func (r *RedisRepository) GetSession(ctx context.Context, sessionID string) (*models.Session, error) {
+	key := fmt.Sprintf("session:%s", sessionID)
 	result, err := r.redisClient.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return "", redis.Nil
+			return nil, redis.Nil
 		}
-		return "", fmt.Errorf("redis: failed to get refresh token for UserID %s: %w", UserID, err)
+		return nil, fmt.Errorf("redis: failed to get session %s: %w", sessionID, err)
 	}
-	return result, nil
+
+	var session models.Session
+	if err := json.Unmarshal([]byte(result), &session); err != nil {
+		return nil, fmt.Errorf("redis: failed to unmarshal session: %w", err)
+	}
+	return &session, nil
 }
 
-func (r *RedisRepository) InvalidateRefreshToken(ctx context.Context, UserID string) error {
-	key := fmt.Sprintf("refresh_token:%s", UserID)
-	if err := r.redisClient.Del(ctx, key).Err(); err != nil {
-		return fmt.Errorf("redis: failed to delete refresh token for user_id %s: %w", UserID, err)
+// SessionExists reports whether a session is still live, that is neither expired
+// nor revoked
+func (r *RedisRepository) SessionExists(ctx context.Context, sessionID string) (bool, error) {
+	count, err := r.redisClient.Exists(ctx, fmt.Sprintf("session:%s", sessionID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis: failed to check session %s: %w", sessionID, err)
+	}
+	return count > 0, nil
+}
+
+// GetUserSessions returns the sessions of a user that did not expire, and drops
+// the expired ones from the sessions of the user
+func (r *RedisRepository) GetUserSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	userKey := fmt.Sprintf("user_sessions:%s", userID)
+	sessionIDs, err := r.redisClient.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis: failed to get sessions of user_id %s: %w", userID, err)
+	}
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(sessionIDs))
+	for i, sessionID := range sessionIDs {
+		keys[i] = fmt.Sprintf("session:%s", sessionID)
+	}
+	results, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis: failed to get sessions of user_id %s: %w", userID, err)
+	}
+
+	var sessions []*models.Session
+	var expired []interface{}
+	for i, result := range results {
+		data, ok := result.(string)
+		if !ok {
+			expired = append(expired, sessionIDs[i])
+			continue
+		}
+		var session models.Session
+		if err := json.Unmarshal([]byte(data), &session); err != nil {
+			return nil, fmt.Errorf("redis: failed to unmarshal session: %w", err)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if len(expired) > 0 {
+		if err := r.redisClient.SRem(ctx, userKey, expired...).Err(); err != nil {
+			return nil, fmt.Errorf("redis: failed to drop expired sessions of user_id %s: %w", userID, err)
+		}
+	}
+	return sessions, nil
+}
+
+// RotateSession replaces a session only while its refresh token is still the one
+// hashed to previousTokenHash, so that of two refreshes racing with the same
+// token only one succeeds. It reports false when the token was already rotated or
+// the session is gone.
+func (r *RedisRepository) RotateSession(ctx context.Context, session *models.Session, previousTokenHash string, expiry time.Duration) (bool, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return false, fmt.Errorf("redis: failed to marshal session: %w", err)
+	}
+
+	key := fmt.Sprintf("session:%s", session.ID)
+	userKey := fmt.Sprintf("user_sessions:%s", session.UserID)
+	rotated := false
+	err = r.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		result, err := tx.Get(ctx, key).Result()
+		if err != nil {
+			if err == redis.Nil {
+				return nil
+			}
+			return err
+		}
+		var current models.Session
+		if err := json.Unmarshal([]byte(result), &current); err != nil {
+			return err
+		}
+		if current.TokenHash != previousTokenHash {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, expiry)
+			pipe.Expire(ctx, userKey, expiry)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	}, key)
+	if err == redis.TxFailedErr {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("redis: failed to rotate session %s: %w", session.ID, err)
+	}
+	return rotated, nil
+}
+
+func (r *RedisRepository) InvalidateSession(ctx context.Context, userID, sessionID string) error {
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, fmt.Sprintf("session:%s", sessionID))
+		pipe.SRem(ctx, fmt.Sprintf("user_sessions:%s", userID), sessionID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis: failed to delete session %s of user_id %s: %w", sessionID, userID, err)
 	}
 	return nil
 }
@@ -146,3 +354,36 @@ func (r *RedisRepository) InvalidateAssetCache(ctx context.Context, assetID stri
 	}
 	return nil
 }
+
+func (r *RedisRepository) StoreFeedCache(ctx context.Context, feedKey string, data *utils.FeedCache, expiry time.Duration) error {
+	key := fmt.Sprintf("feed:%s", feedKey)
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("redis: failed to marshal feed data: %w", err)
+	}
+
+	if err := r.redisClient.Set(ctx, key, jsonData, expiry).Err(); err != nil {
+		return fmt.Errorf("redis: failed to store feed cache for %s: %w", feedKey, err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) GetFeedCache(ctx context.Context, feedKey string) (*utils.FeedCache, error) {
+	key := fmt.Sprintf("feed:%s", feedKey)
+
+	result, err := r.redisClient.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("redis: failed to get feed cache for %s: %w", feedKey, err)
+	}
+
+	var feedCache utils.FeedCache
+	if err := json.Unmarshal([]byte(result), &feedCache); err != nil {
+		return nil, fmt.Errorf("redis: failed to unmarshal feed data: %w", err)
+	}
+
+	return &feedCache, nil
+}
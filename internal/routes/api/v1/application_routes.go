@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CandidateApplicationRoutes(rg *gin.RouterGroup, applicationController *controllers.ApplicationController) {
+	applications := rg.Group("/applications")
+	applications.POST("/:jobId", applicationController.Apply)
+	applications.GET("/", applicationController.GetCandidateApplications)
+	applications.GET("/:jobId", applicationController.GetCandidateApplication)
+	applications.DELETE("/:jobId", applicationController.WithdrawApplication)
+}
+
+func RecruiterApplicationRoutes(rg *gin.RouterGroup, applicationController *controllers.ApplicationController) {
+	applications := rg.Group("/jobs/:jobId/applications")
+	applications.GET("/", applicationController.GetJobApplications)
+	applications.GET("/:applicationId", applicationController.GetJobApplication)
+}
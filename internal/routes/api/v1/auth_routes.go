@@ -9,7 +9,10 @@ import (
 func AuthRoutes(rg *gin.RouterGroup, authController *controllers.AuthController) {
 	authRoute := rg.Group("/auth")
 	authRoute.POST("/register", authController.Register)
+	authRoute.POST("/verify-email", authController.VerifyEmail)
+	authRoute.POST("/resend-verification", authController.ResendVerification)
 	authRoute.POST("/login", authController.Login)
+	authRoute.POST("/2fa/verify", authController.VerifyTwoFactor)
 	authRoute.POST("/logout", authController.Logout)
 	authRoute.POST("/refresh-token", authController.RefreshToken)
 	authRoute.POST("/send-reset-otp", authController.SendResetOTP)
@@ -19,3 +22,17 @@ func AuthRoutes(rg *gin.RouterGroup, authController *controllers.AuthController)
 	authRoute.GET("/google/callback", authController.GoogleCallbackConnect)
 
 }
+
+func AuthSessionRoutes(rg *gin.RouterGroup, authController *controllers.AuthController) {
+	sessionRoute := rg.Group("/auth/sessions")
+	sessionRoute.GET("", authController.GetSessions)
+	sessionRoute.DELETE("/:sessionId", authController.RevokeSession)
+}
+
+func TwoFactorRoutes(rg *gin.RouterGroup, twoFactorController *controllers.TwoFactorController) {
+	twoFactorRoute := rg.Group("/auth/2fa")
+	twoFactorRoute.GET("", twoFactorController.GetTwoFactor)
+	twoFactorRoute.POST("/setup", twoFactorController.SetupTwoFactor)
+	twoFactorRoute.POST("/confirm", twoFactorController.ConfirmTwoFactor)
+	twoFactorRoute.POST("/disable", twoFactorController.DisableTwoFactor)
+}
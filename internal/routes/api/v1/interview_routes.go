@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CalendarFeedRoutes(rg *gin.RouterGroup, interviewController *controllers.InterviewController) {
+	rg.GET("/calendar/:token", interviewController.GetCalendarFeed)
+}
+
+func InterviewRoutes(rg *gin.RouterGroup, interviewController *controllers.InterviewController) {
+	interviews := rg.Group("/interviews")
+	interviews.POST("/calendar-feed", interviewController.CreateCalendarFeed)
+}
+
+func CandidateInterviewRoutes(rg *gin.RouterGroup, interviewController *controllers.InterviewController) {
+	interviews := rg.Group("/interviews")
+	interviews.GET("/", interviewController.GetCandidateInterviews)
+	interviews.GET("/:interviewId", interviewController.GetCandidateInterview)
+	interviews.PUT("/:interviewId/slot", interviewController.SelectSlot)
+	interviews.PUT("/:interviewId/cancel", interviewController.CancelCandidateInterview)
+}
+
+func RecruiterInterviewRoutes(rg *gin.RouterGroup, interviewController *controllers.InterviewController) {
+	applicationInterviews := rg.Group("/jobs/:jobId/applications/:applicationId/interviews")
+	applicationInterviews.POST("", interviewController.ProposeInterview)
+	applicationInterviews.GET("", interviewController.GetApplicationInterviews)
+
+	interviews := rg.Group("/interviews")
+	interviews.GET("/", interviewController.GetRecruiterInterviews)
+	interviews.GET("/:interviewId", interviewController.GetRecruiterInterview)
+	interviews.PUT("/:interviewId/reschedule", interviewController.RescheduleInterview)
+	interviews.PUT("/:interviewId/cancel", interviewController.CancelRecruiterInterview)
+}
@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func JobCategoryRoutes(rg *gin.RouterGroup, jobCategoryController *controllers.JobCategoryController) {
+	categoriesRoute := rg.Group("/categories")
+	categoriesRoute.GET("/", jobCategoryController.GetCategories)
+}
+
+func AdminJobCategoryRoutes(rg *gin.RouterGroup, jobCategoryController *controllers.JobCategoryController) {
+	categoriesRoute := rg.Group("/categories")
+	categoriesRoute.POST("/", jobCategoryController.CreateCategory)
+	categoriesRoute.PUT("/:categoryId", jobCategoryController.UpdateCategory)
+	categoriesRoute.DELETE("/:categoryId", jobCategoryController.DeleteCategory)
+}
@@ -0,0 +1,14 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func JobFeedRoutes(rg *gin.RouterGroup, jobFeedController *controllers.JobFeedController) {
+	rg.GET("/jobs/feed.rss", jobFeedController.GetRSSFeed)
+	rg.GET("/jobs/feed.atom", jobFeedController.GetAtomFeed)
+	rg.GET("/jobs/feed.xml", jobFeedController.GetIndeedFeed)
+	rg.GET("/sitemap.xml", jobFeedController.GetSitemap)
+}
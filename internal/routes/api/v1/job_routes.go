@@ -11,9 +11,15 @@ func RecruiterJobRoutes(rg *gin.RouterGroup, jobController *controllers.JobContr
 	jobs.POST("/", jobController.PostNewJob)
 	jobs.GET("/:jobId", jobController.GetJobDetails)
 	jobs.GET("/", jobController.GetJobListingsByStatus)
+	jobs.POST("/import", jobController.ImportJobs)
+	jobs.GET("/export", jobController.ExportJobs)
 	jobs.PUT("/:jobId", jobController.EditJob)
 	jobs.PUT("/:jobId/deactivate", jobController.DeactivateJob)
 	jobs.PUT("/:jobId/repost", jobController.RepostJob)
+	jobs.GET("/:jobId/revisions", jobController.GetJobRevisions)
+	jobs.GET("/:jobId/revisions/diff", jobController.DiffJobRevisions)
+	jobs.GET("/:jobId/revisions/:revision", jobController.GetJobRevision)
+	jobs.POST("/:jobId/revisions/:revision/restore", jobController.RestoreJobRevision)
 	jobs.DELETE("/:jobId", jobController.DeleteJob)
 }
 
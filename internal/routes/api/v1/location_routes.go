@@ -0,0 +1,14 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LocationRoutes(rg *gin.RouterGroup, locationController *controllers.LocationController) {
+	locations := rg.Group("/locations")
+	locations.GET("/wilayas", locationController.GetWilayas)
+	locations.GET("/wilayas/:wilaya", locationController.GetWilaya)
+	locations.GET("/search", locationController.SearchLocations)
+}
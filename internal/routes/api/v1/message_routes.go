@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CandidateMessageRoutes(rg *gin.RouterGroup, messageController *controllers.MessageController) {
+	rg.GET("/conversations", messageController.GetCandidateConversations)
+
+	messages := rg.Group("/applications/:jobId/messages")
+	messages.GET("", messageController.GetCandidateMessages)
+	messages.POST("", messageController.SendCandidateMessage)
+	messages.PUT("/read", messageController.MarkCandidateMessagesRead)
+	messages.POST("/:messageId/report", messageController.ReportCandidateMessage)
+}
+
+func RecruiterMessageRoutes(rg *gin.RouterGroup, messageController *controllers.MessageController) {
+	rg.GET("/conversations", messageController.GetRecruiterConversations)
+
+	messages := rg.Group("/jobs/:jobId/applications/:applicationId/messages")
+	messages.GET("", messageController.GetRecruiterMessages)
+	messages.POST("", messageController.SendRecruiterMessage)
+	messages.PUT("/read", messageController.MarkRecruiterMessagesRead)
+	messages.POST("/:messageId/report", messageController.ReportRecruiterMessage)
+}
+
+func AdminMessageRoutes(rg *gin.RouterGroup, messageController *controllers.MessageController) {
+	reports := rg.Group("/message-reports")
+	reports.GET("", messageController.GetMessageReports)
+	reports.PUT("/:reportId", messageController.ReviewMessageReport)
+}
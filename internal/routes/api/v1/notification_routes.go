@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NotificationRoutes(rg *gin.RouterGroup, notificationController *controllers.NotificationController) {
+	notifications := rg.Group("/notifications")
+	notifications.GET("", notificationController.GetNotifications)
+	notifications.GET("/stream", notificationController.StreamNotifications)
+	notifications.PUT("/read-all", notificationController.MarkAllNotificationsRead)
+	notifications.PUT("/:notificationId/read", notificationController.MarkNotificationRead)
+}
+
+func NotificationUnsubscribeRoutes(rg *gin.RouterGroup, notificationPreferenceController *controllers.NotificationPreferenceController) {
+	preferences := rg.Group("/notification-preferences")
+	preferences.GET("/unsubscribe", notificationPreferenceController.ConfirmUnsubscribe)
+	preferences.POST("/unsubscribe", notificationPreferenceController.Unsubscribe)
+}
+
+func NotificationPreferenceRoutes(rg *gin.RouterGroup, notificationPreferenceController *controllers.NotificationPreferenceController) {
+	preferences := rg.Group("/notification-preferences")
+	preferences.GET("", notificationPreferenceController.GetPreferences)
+	preferences.PUT("", notificationPreferenceController.UpdatePreferences)
+}
@@ -0,0 +1,16 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RecruiterPipelineRoutes(rg *gin.RouterGroup, pipelineController *controllers.PipelineController) {
+	rg.GET("/pipeline-stages", pipelineController.GetStages)
+	rg.PUT("/pipeline-stages", pipelineController.UpdateStages)
+
+	applications := rg.Group("/jobs/:jobId/applications")
+	applications.PUT("/:applicationId/stage", pipelineController.MoveApplication)
+	applications.GET("/:applicationId/transitions", pipelineController.GetApplicationTransitions)
+}
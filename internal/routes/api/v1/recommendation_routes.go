@@ -0,0 +1,15 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CandidateRecommendationRoutes(rg *gin.RouterGroup, recommendationController *controllers.RecommendationController) {
+	rg.GET("/recommendations", recommendationController.GetJobRecommendations)
+}
+
+func RecruiterRecommendationRoutes(rg *gin.RouterGroup, recommendationController *controllers.RecommendationController) {
+	rg.GET("/jobs/:jobId/matches", recommendationController.GetJobCandidateMatches)
+}
@@ -4,15 +4,31 @@ import (
 	"dz-jobs-api/config"
 	"dz-jobs-api/docs"
 	"dz-jobs-api/internal/controllers"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
 
 	"dz-jobs-api/internal/middlewares"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// Rate limits of each client per route group. Admin routes are only limited by
+// clientRateLimit and health checks are not limited. The auth routes fail closed
+// when the limits cannot be checked.
+var (
+	authRateLimit      = utils.RateLimit{Limit: 20, Period: time.Minute, FailClosed: true}
+	publicRateLimit    = utils.RateLimit{Limit: 300, Period: time.Minute}
+	protectedRateLimit = utils.RateLimit{Limit: 120, Period: time.Minute}
+	// clientRateLimit limits the requests of each IP to the protected routes before
+	// they are authenticated, it is looser than protectedRateLimit for the users
+	// sharing an IP
+	clientRateLimit = utils.RateLimit{Limit: 600, Period: time.Minute}
+)
+
 func RegisterRoutes(
 	router *gin.Engine,
 	authController *controllers.AuthController,
@@ -27,18 +43,35 @@ func RegisterRoutes(
 	portfolioController *controllers.CandidatePortfolioController,
 	jobController *controllers.JobController,
 	bookmarksController *controllers.BookmarksController,
+	applicationController *controllers.ApplicationController,
+	pipelineController *controllers.PipelineController,
+	skillCatalogController *controllers.SkillCatalogController,
+	recommendationController *controllers.RecommendationController,
+	savedSearchController *controllers.SavedSearchController,
 	systemController *controllers.SystemController,
+	jobFeedController *controllers.JobFeedController,
+	locationController *controllers.LocationController,
+	jobCategoryController *controllers.JobCategoryController,
+	screeningQuestionController *controllers.ScreeningQuestionController,
+	interviewController *controllers.InterviewController,
+	messageController *controllers.MessageController,
+	notificationController *controllers.NotificationController,
+	notificationPreferenceController *controllers.NotificationPreferenceController,
+	twoFactorController *controllers.TwoFactorController,
+	redisRepository interfaces.RedisRepository,
+	rateLimiter interfaces.RateLimiter,
 	appConfig *config.AppConfig,
 ) {
 
 	basePath := router.Group("/v1")
 
-	RegisterPublicRoutes(basePath, authController, jobController, systemController)
+	RegisterPublicRoutes(basePath, authController, jobController, skillCatalogController, savedSearchController, systemController, jobFeedController, locationController, jobCategoryController, screeningQuestionController, interviewController, notificationPreferenceController, rateLimiter)
 
 	protected := basePath.Group("/")
-	protected.Use(middlewares.AuthMiddleware(appConfig))
+	protected.Use(middlewares.RateLimiter(rateLimiter, "client", clientRateLimit), middlewares.AuthMiddleware(appConfig, redisRepository))
 	RegisterProtectedRoutes(
 		protected,
+		authController,
 		userController,
 		recruiterController,
 		candidateController,
@@ -50,6 +83,20 @@ func RegisterRoutes(
 		portfolioController,
 		jobController,
 		bookmarksController,
+		applicationController,
+		pipelineController,
+		skillCatalogController,
+		recommendationController,
+		savedSearchController,
+		jobCategoryController,
+		screeningQuestionController,
+		interviewController,
+		messageController,
+		notificationController,
+		notificationPreferenceController,
+		twoFactorController,
+		rateLimiter,
+		appConfig,
 	)
 }
 
@@ -57,15 +104,39 @@ func RegisterPublicRoutes(
 	router *gin.RouterGroup,
 	authController *controllers.AuthController,
 	jobController *controllers.JobController,
+	skillCatalogController *controllers.SkillCatalogController,
+	savedSearchController *controllers.SavedSearchController,
 	systemController *controllers.SystemController,
+	jobFeedController *controllers.JobFeedController,
+	locationController *controllers.LocationController,
+	jobCategoryController *controllers.JobCategoryController,
+	screeningQuestionController *controllers.ScreeningQuestionController,
+	interviewController *controllers.InterviewController,
+	notificationPreferenceController *controllers.NotificationPreferenceController,
+	rateLimiter interfaces.RateLimiter,
 ) {
-	AuthRoutes(router, authController)
-	JobRoutes(router, jobController)
 	SystemRoutes(router, systemController)
+
+	authGroup := router.Group("/")
+	authGroup.Use(middlewares.RateLimiter(rateLimiter, "auth", authRateLimit))
+	AuthRoutes(authGroup, authController)
+
+	publicGroup := router.Group("/")
+	publicGroup.Use(middlewares.RateLimiter(rateLimiter, "public", publicRateLimit))
+	JobRoutes(publicGroup, jobController)
+	ScreeningQuestionRoutes(publicGroup, screeningQuestionController)
+	JobFeedRoutes(publicGroup, jobFeedController)
+	LocationRoutes(publicGroup, locationController)
+	JobCategoryRoutes(publicGroup, jobCategoryController)
+	SkillCatalogRoutes(publicGroup, skillCatalogController)
+	SavedSearchRoutes(publicGroup, savedSearchController)
+	CalendarFeedRoutes(publicGroup, interviewController)
+	NotificationUnsubscribeRoutes(publicGroup, notificationPreferenceController)
 }
 
 func RegisterProtectedRoutes(
 	router *gin.RouterGroup,
+	authController *controllers.AuthController,
 	userController *controllers.UserController,
 	recruiterController *controllers.RecruiterController,
 	candidateController *controllers.CandidateController,
@@ -77,13 +148,40 @@ func RegisterProtectedRoutes(
 	portfolioController *controllers.CandidatePortfolioController,
 	jobController *controllers.JobController,
 	bookmarksController *controllers.BookmarksController,
+	applicationController *controllers.ApplicationController,
+	pipelineController *controllers.PipelineController,
+	skillCatalogController *controllers.SkillCatalogController,
+	recommendationController *controllers.RecommendationController,
+	savedSearchController *controllers.SavedSearchController,
+	jobCategoryController *controllers.JobCategoryController,
+	screeningQuestionController *controllers.ScreeningQuestionController,
+	interviewController *controllers.InterviewController,
+	messageController *controllers.MessageController,
+	notificationController *controllers.NotificationController,
+	notificationPreferenceController *controllers.NotificationPreferenceController,
+	twoFactorController *controllers.TwoFactorController,
+	rateLimiter interfaces.RateLimiter,
+	appConfig *config.AppConfig,
 ) {
 
+	authGroup := router.Group("/")
+	authGroup.Use(middlewares.RateLimiter(rateLimiter, "auth", authRateLimit))
+	AuthSessionRoutes(authGroup, authController)
+	TwoFactorRoutes(authGroup, twoFactorController)
+
 	adminGroup := router.Group("/admin")
-	adminGroup.Use(middlewares.RoleMiddleware("admin"))
-	RegisterAdminRoutes(adminGroup, userController)
+	adminGroup.Use(middlewares.RoleMiddleware("admin"), middlewares.TwoFactorMiddleware(appConfig))
+	RegisterAdminRoutes(adminGroup, userController, skillCatalogController, jobCategoryController, messageController)
+
+	// Admins are admitted on the candidate and recruiter routes as well, so the
+	// second factor is required on every group but the 2FA setup and sessions
+	limited := router.Group("/")
+	limited.Use(middlewares.RateLimiter(rateLimiter, "protected", protectedRateLimit), middlewares.TwoFactorMiddleware(appConfig))
+	InterviewRoutes(limited, interviewController)
+	NotificationRoutes(limited, notificationController)
+	NotificationPreferenceRoutes(limited, notificationPreferenceController)
 
-	candidateGroup := router.Group("/candidates")
+	candidateGroup := limited.Group("/candidates")
 	candidateGroup.Use(middlewares.RoleMiddleware("candidate", "admin"))
 	RegisterCandidateRoutes(
 		candidateGroup,
@@ -95,18 +193,29 @@ func RegisterProtectedRoutes(
 		certificationsController,
 		portfolioController,
 		bookmarksController,
+		applicationController,
+		recommendationController,
+		savedSearchController,
+		interviewController,
+		messageController,
 	)
 
-	recruiterGroup := router.Group("/recruiters")
+	recruiterGroup := limited.Group("/recruiters")
 	recruiterGroup.Use(middlewares.RoleMiddleware("recruiter", "admin"))
-	RegisterRecruiterRoutes(recruiterGroup, recruiterController, jobController)
+	RegisterRecruiterRoutes(recruiterGroup, recruiterController, jobController, applicationController, pipelineController, recommendationController, screeningQuestionController, interviewController, messageController)
 }
 
 func RegisterAdminRoutes(
 	router *gin.RouterGroup,
 	userController *controllers.UserController,
+	skillCatalogController *controllers.SkillCatalogController,
+	jobCategoryController *controllers.JobCategoryController,
+	messageController *controllers.MessageController,
 ) {
 	UserRoutes(router, userController)
+	AdminSkillCatalogRoutes(router, skillCatalogController)
+	AdminJobCategoryRoutes(router, jobCategoryController)
+	AdminMessageRoutes(router, messageController)
 }
 
 func RegisterCandidateRoutes(
@@ -119,6 +228,11 @@ func RegisterCandidateRoutes(
 	certificationsController *controllers.CandidateCertificationsController,
 	portfolioController *controllers.CandidatePortfolioController,
 	bookmarksController *controllers.BookmarksController,
+	applicationController *controllers.ApplicationController,
+	recommendationController *controllers.RecommendationController,
+	savedSearchController *controllers.SavedSearchController,
+	interviewController *controllers.InterviewController,
+	messageController *controllers.MessageController,
 ) {
 
 	CandidateRoutes(router, candidateController)
@@ -129,15 +243,32 @@ func RegisterCandidateRoutes(
 	CertificationsRoutes(router, certificationsController)
 	PortfolioRoutes(router, portfolioController)
 	BookmarksRoute(router, bookmarksController)
+	CandidateApplicationRoutes(router, applicationController)
+	CandidateRecommendationRoutes(router, recommendationController)
+	CandidateSavedSearchRoutes(router, savedSearchController)
+	CandidateInterviewRoutes(router, interviewController)
+	CandidateMessageRoutes(router, messageController)
 }
 
 func RegisterRecruiterRoutes(
 	router *gin.RouterGroup,
 	recruiterController *controllers.RecruiterController,
 	jobController *controllers.JobController,
+	applicationController *controllers.ApplicationController,
+	pipelineController *controllers.PipelineController,
+	recommendationController *controllers.RecommendationController,
+	screeningQuestionController *controllers.ScreeningQuestionController,
+	interviewController *controllers.InterviewController,
+	messageController *controllers.MessageController,
 ) {
 	RecruiterRoutes(router, recruiterController)
 	RecruiterJobRoutes(router, jobController)
+	RecruiterScreeningQuestionRoutes(router, screeningQuestionController)
+	RecruiterApplicationRoutes(router, applicationController)
+	RecruiterPipelineRoutes(router, pipelineController)
+	RecruiterRecommendationRoutes(router, recommendationController)
+	RecruiterInterviewRoutes(router, interviewController)
+	RecruiterMessageRoutes(router, messageController)
 }
 
 func RegisterSwaggerRoutes(server *gin.Engine) {
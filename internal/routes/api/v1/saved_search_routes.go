@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SavedSearchRoutes(rg *gin.RouterGroup, savedSearchController *controllers.SavedSearchController) {
+	savedSearches := rg.Group("/saved-searches")
+	savedSearches.GET("/unsubscribe", savedSearchController.Unsubscribe)
+	savedSearches.POST("/unsubscribe", savedSearchController.Unsubscribe)
+}
+
+func CandidateSavedSearchRoutes(rg *gin.RouterGroup, savedSearchController *controllers.SavedSearchController) {
+	savedSearches := rg.Group("/saved-searches")
+	savedSearches.POST("/", savedSearchController.CreateSavedSearch)
+	savedSearches.GET("/", savedSearchController.GetSavedSearches)
+	savedSearches.PUT("/:searchId", savedSearchController.UpdateSavedSearch)
+	savedSearches.DELETE("/:searchId", savedSearchController.DeleteSavedSearch)
+}
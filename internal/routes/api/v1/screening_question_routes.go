@@ -0,0 +1,17 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ScreeningQuestionRoutes(rg *gin.RouterGroup, screeningQuestionController *controllers.ScreeningQuestionController) {
+	rg.GET("/jobs/:jobId/questions", screeningQuestionController.GetJobQuestions)
+}
+
+func RecruiterScreeningQuestionRoutes(rg *gin.RouterGroup, screeningQuestionController *controllers.ScreeningQuestionController) {
+	questions := rg.Group("/jobs/:jobId/questions")
+	questions.GET("", screeningQuestionController.GetRecruiterJobQuestions)
+	questions.PUT("", screeningQuestionController.UpdateJobQuestions)
+}
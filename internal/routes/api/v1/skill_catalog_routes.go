@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"dz-jobs-api/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SkillCatalogRoutes(rg *gin.RouterGroup, skillCatalogController *controllers.SkillCatalogController) {
+	skillsRoute := rg.Group("/skills")
+	skillsRoute.GET("/", skillCatalogController.SearchSkills)
+}
+
+func AdminSkillCatalogRoutes(rg *gin.RouterGroup, skillCatalogController *controllers.SkillCatalogController) {
+	skillsRoute := rg.Group("/skills")
+	skillsRoute.POST("/", skillCatalogController.CreateSkill)
+	skillsRoute.PUT("/:skillId", skillCatalogController.UpdateSkill)
+	skillsRoute.POST("/:skillId/merge", skillCatalogController.MergeSkills)
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// emailOutboxInterval bounds how late an email is sent once the quiet hours
+// holding it back end or its digest is due
+const emailOutboxInterval = time.Minute
+
+// EmailOutboxScheduler periodically sends the queued emails that are due, along
+// with the digests
+type EmailOutboxScheduler struct {
+	service  serviceInterfaces.EmailService
+	interval time.Duration
+}
+
+// NewEmailOutboxScheduler creates a scheduler ticking every minute
+func NewEmailOutboxScheduler(service serviceInterfaces.EmailService) *EmailOutboxScheduler {
+	return &EmailOutboxScheduler{service: service, interval: emailOutboxInterval}
+}
+
+// Start sends the due emails on every tick, and as soon as emails due right away
+// are queued, until ctx is cancelled
+func (s *EmailOutboxScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.sendQueuedEmails(ctx, now)
+		case <-s.service.Queued():
+			s.sendQueuedEmails(ctx, time.Now())
+		}
+	}
+}
+
+func (s *EmailOutboxScheduler) sendQueuedEmails(ctx context.Context, now time.Time) {
+	if err := s.service.SendQueuedEmails(ctx, now); err != nil {
+		log.WithError(err).Error("Failed to send queued emails")
+	}
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"context"
+	"dz-jobs-api/internal/helpers"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// JobAlertScheduler periodically runs the saved searches that are due and emails
+// their new jobs to the candidates
+type JobAlertScheduler struct {
+	service  serviceInterfaces.SavedSearchService
+	interval time.Duration
+}
+
+// NewJobAlertScheduler creates a scheduler ticking as often as instant alerts are sent
+func NewJobAlertScheduler(service serviceInterfaces.SavedSearchService) *JobAlertScheduler {
+	return &JobAlertScheduler{service: service, interval: helpers.InstantAlertInterval}
+}
+
+// Start runs the due saved searches on every tick until ctx is cancelled
+func (s *JobAlertScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.service.RunDueSearches(ctx, now); err != nil {
+				log.WithError(err).Error("Failed to run saved search alerts")
+			}
+		}
+	}
+}
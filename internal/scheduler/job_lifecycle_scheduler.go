@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// jobLifecycleInterval bounds how late a job is published or closed
+const jobLifecycleInterval = time.Minute
+
+// JobLifecycleScheduler periodically publishes the scheduled jobs, closes the
+// expired ones and reminds recruiters of the jobs about to expire
+type JobLifecycleScheduler struct {
+	service  serviceInterfaces.JobService
+	interval time.Duration
+}
+
+// NewJobLifecycleScheduler creates a scheduler ticking every minute
+func NewJobLifecycleScheduler(service serviceInterfaces.JobService) *JobLifecycleScheduler {
+	return &JobLifecycleScheduler{service: service, interval: jobLifecycleInterval}
+}
+
+// Start runs the job lifecycle on every tick until ctx is cancelled
+func (s *JobLifecycleScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.service.RunJobLifecycle(ctx, now); err != nil {
+				log.WithError(err).Error("Failed to run job lifecycle")
+			}
+		}
+	}
+}
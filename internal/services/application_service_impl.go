@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type ApplicationService struct {
+	applicationRepository   interfaces.ApplicationRepository
+	jobRepository           interfaces.JobRepository
+	candidateRepository     interfaces.CandidateRepository
+	pipelineStageRepository interfaces.PipelineStageRepository
+	questionRepository      interfaces.ScreeningQuestionRepository
+	userRepository          interfaces.UserRepository
+	notificationService     serviceInterfaces.NotificationService
+}
+
+func NewApplicationService(applicationRepo interfaces.ApplicationRepository, jobRepo interfaces.JobRepository, candidateRepo interfaces.CandidateRepository, pipelineStageRepo interfaces.PipelineStageRepository, questionRepo interfaces.ScreeningQuestionRepository, userRepo interfaces.UserRepository, notificationService serviceInterfaces.NotificationService) *ApplicationService {
+	return &ApplicationService{
+		applicationRepository:   applicationRepo,
+		jobRepository:           jobRepo,
+		candidateRepository:     candidateRepo,
+		pipelineStageRepository: pipelineStageRepo,
+		questionRepository:      questionRepo,
+		userRepository:          userRepo,
+		notificationService:     notificationService,
+	}
+}
+
+func (s *ApplicationService) Apply(ctx context.Context, candidateID uuid.UUID, jobID int64, req request.ApplyToJobRequest) (*models.Application, error) {
+	if err := requireVerifiedEmail(ctx, s.userRepository, candidateID); err != nil {
+		return nil, err
+	}
+	job, err := s.jobRepository.GetJobDetailsPublic(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Job not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job details")
+	}
+	if job.Status == "closed" {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "Job is closed and no longer accepts applications")
+	}
+
+	candidate, err := s.candidateRepository.GetCandidate(ctx, candidateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Candidate not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching Candidate")
+	}
+
+	existingApplication, err := s.applicationRepository.GetApplicationByJobAndCandidate(ctx, jobID, candidateID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching application")
+	}
+	if existingApplication != nil {
+		return nil, utils.NewCustomError(http.StatusConflict, "You have already applied to this job")
+	}
+
+	questions, err := s.questionRepository.GetQuestions(ctx, jobID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch screening questions")
+	}
+	answers, knockout, err := helpers.EvaluateScreeningAnswers(questions, req.Answers)
+	if err != nil {
+		return nil, screeningError(err, "Invalid screening answers")
+	}
+
+	stages, err := loadPipelineStages(ctx, s.pipelineStageRepository, job.RecruiterID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch pipeline stages")
+	}
+
+	application := &models.Application{
+		JobID:       jobID,
+		CandidateID: candidateID,
+		Resume:      candidate.Resume,
+		CoverLetter: req.CoverLetter,
+		Stage:       stages[0].Name,
+		Status:      helpers.CandidateStatusForCategory(stages[0].Category),
+		Answers:     answers,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.applicationRepository.CreateApplication(ctx, application); err != nil {
+		// A concurrent apply can get past the check above, the unique constraint settles it
+		if errors.Is(err, interfaces.ErrAlreadyApplied) {
+			return nil, utils.NewCustomError(http.StatusConflict, "You have already applied to this job")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to submit application")
+	}
+
+	if knockout != nil {
+		if s.rejectApplication(ctx, application, stages, knockout) {
+			s.notificationService.Notify(ctx, applicationStatusNotification(application, job.Title))
+		}
+	} else {
+		s.notificationService.Notify(ctx, applicationReceivedNotification(job, application))
+	}
+
+	return application, nil
+}
+
+// rejectApplication moves an application knocked out by a screening question to
+// the first rejected stage and tells whether it was. The application is already
+// submitted, a failure is logged and leaves it for the recruiter to review.
+func (s *ApplicationService) rejectApplication(ctx context.Context, application *models.Application, stages []models.PipelineStage, knockout *models.ScreeningQuestion) bool {
+	for _, stage := range stages {
+		if stage.Category != "rejected" {
+			continue
+		}
+		transition := &models.ApplicationTransition{
+			ApplicationID: application.ID,
+			FromStage:     application.Stage,
+			ToStage:       stage.Name,
+			MovedBy:       uuid.Nil,
+			Reason:        fmt.Sprintf("Automatically rejected by screening question %q", knockout.Prompt),
+			CreatedAt:     time.Now(),
+		}
+		status := helpers.CandidateStatusForCategory(stage.Category)
+		if err := s.applicationRepository.TransitionApplication(ctx, transition, status); err != nil {
+			log.WithFields(log.Fields{"application_id": application.ID, "error": err}).Error("Failed to reject application")
+			return false
+		}
+		application.Stage = transition.ToStage
+		application.Status = status
+		application.UpdatedAt = transition.CreatedAt
+		return true
+	}
+	return false
+}
+
+func (s *ApplicationService) GetCandidateApplication(ctx context.Context, candidateID uuid.UUID, jobID int64) (*models.Application, error) {
+	application, err := s.applicationRepository.GetApplicationByJobAndCandidate(ctx, jobID, candidateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching application")
+	}
+	if err := s.loadAnswers(ctx, application); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+func (s *ApplicationService) GetCandidateApplications(ctx context.Context, candidateID uuid.UUID, page request.PageRequest) ([]*models.Application, *models.PageInfo, error) {
+	applications, pageInfo, err := s.applicationRepository.GetApplicationsByCandidate(ctx, candidateID, page)
+	if err != nil {
+		return nil, nil, listError(err, "Failed to fetch applications")
+	}
+	return applications, pageInfo, nil
+}
+
+// WithdrawApplication deletes the application of a candidate, along with its
+// interviews and messages. A hired or rejected application is kept, its outcome
+// is part of the hiring record.
+func (s *ApplicationService) WithdrawApplication(ctx context.Context, candidateID uuid.UUID, jobID int64) error {
+	application, err := s.applicationRepository.GetApplicationByJobAndCandidate(ctx, jobID, candidateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusNotFound, "Application not found")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch application")
+	}
+	if helpers.IsFinalCandidateStatus(application.Status) {
+		return utils.NewCustomError(http.StatusConflict, "A hired or rejected application cannot be withdrawn")
+	}
+
+	if err := s.applicationRepository.DeleteApplication(ctx, jobID, candidateID, application.Stage); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusConflict, "The application was just moved to another stage, try again")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to withdraw application")
+	}
+	return nil
+}
+
+// GetJobApplications returns the applicants of a job, restricted to the ones whose
+// answers to the screening questions match all of the answer filters
+func (s *ApplicationService) GetJobApplications(ctx context.Context, recruiterID uuid.UUID, jobID int64, filters request.JobApplicationFilters) ([]*models.Application, *models.PageInfo, error) {
+	if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return nil, nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+
+	var answerFilters []models.AnswerFilter
+	if len(filters.Answers) > 0 {
+		questions, err := s.questionRepository.GetQuestions(ctx, jobID)
+		if err != nil {
+			return nil, nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch screening questions")
+		}
+		for _, raw := range filters.Answers {
+			filter, err := helpers.ParseAnswerFilter(raw, questions)
+			if err != nil {
+				return nil, nil, screeningError(err, "Invalid answer filter")
+			}
+			answerFilters = append(answerFilters, filter)
+		}
+	}
+
+	applications, pageInfo, err := s.applicationRepository.GetApplicationsByJob(ctx, jobID, answerFilters, filters.PageRequest)
+	if err != nil {
+		return nil, nil, listError(err, "Failed to fetch applications")
+	}
+	return applications, pageInfo, nil
+}
+
+func (s *ApplicationService) GetJobApplication(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) (*models.Application, error) {
+	if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+	application, err := s.applicationRepository.GetApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching application")
+	}
+	if application.JobID != jobID {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+	}
+	if err := s.loadAnswers(ctx, application); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+func (s *ApplicationService) loadAnswers(ctx context.Context, application *models.Application) error {
+	answers, err := s.applicationRepository.GetApplicationAnswers(ctx, application.ID)
+	if err != nil {
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch application answers")
+	}
+	application.Answers = answers
+	return nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// applicationFixture is an application service over fakes, with a verified
+// candidate and an open job of a recruiter using the default pipeline stages
+type applicationFixture struct {
+	service       *ApplicationService
+	applications  *fakeApplicationRepository
+	questions     *fakeScreeningQuestionRepository
+	notifications *fakeNotificationService
+	jobs          *fakeJobRepository
+	users         *fakeUserRepository
+	candidateID   uuid.UUID
+	job           *models.Job
+}
+
+func newApplicationFixture() *applicationFixture {
+	verifiedAt := time.Now()
+	candidate := &models.User{ID: uuid.New(), Role: "candidate", EmailVerifiedAt: &verifiedAt}
+	job := newTestJob(uuid.New())
+	job.Status = "open"
+
+	f := &applicationFixture{
+		applications:  &fakeApplicationRepository{},
+		questions:     &fakeScreeningQuestionRepository{questions: map[int64][]models.ScreeningQuestion{}},
+		notifications: &fakeNotificationService{},
+		jobs:          newFakeJobRepository(job),
+		candidateID:   candidate.ID,
+		job:           job,
+	}
+	candidates := &fakeCandidateRepository{candidates: map[uuid.UUID]*models.Candidate{candidate.ID: {Resume: "cv.pdf"}}}
+	f.users = &fakeUserRepository{users: map[uuid.UUID]*models.User{candidate.ID: candidate}}
+	f.service = NewApplicationService(f.applications, f.jobs, candidates, &fakePipelineStageRepository{}, f.questions, f.users, f.notifications)
+	return f
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Application in the first stage", func(t *testing.T) {
+		f := newApplicationFixture()
+
+		application, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{CoverLetter: "Hello"})
+		require.NoError(t, err)
+		assert.Equal(t, helpers.DefaultPipelineStages[0].Name, application.Stage)
+		assert.Equal(t, helpers.CandidateStatusForCategory("applied"), application.Status)
+		assert.Equal(t, "cv.pdf", application.Resume)
+		require.Len(t, f.notifications.notified, 1)
+		assert.Equal(t, f.job.RecruiterID, f.notifications.notified[0].UserID)
+	})
+
+	t.Run("Second application", func(t *testing.T) {
+		f := newApplicationFixture()
+		_, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{})
+		require.NoError(t, err)
+
+		_, err = f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{})
+		assert.Equal(t, http.StatusConflict, statusOf(err))
+	})
+
+	t.Run("Closed job", func(t *testing.T) {
+		f := newApplicationFixture()
+		f.job.Status = "closed"
+
+		_, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{})
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+		assert.Empty(t, f.applications.applications)
+	})
+
+	t.Run("Unverified email", func(t *testing.T) {
+		f := newApplicationFixture()
+		f.users.users[f.candidateID].EmailVerifiedAt = nil
+
+		_, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{})
+		assert.Equal(t, http.StatusForbidden, statusOf(err))
+		assert.Empty(t, f.applications.applications)
+	})
+
+	t.Run("Draft job", func(t *testing.T) {
+		f := newApplicationFixture()
+		f.job.Status = "draft"
+
+		_, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{})
+		assert.Equal(t, http.StatusNotFound, statusOf(err))
+	})
+}
+
+func TestApplyScreening(t *testing.T) {
+	ctx := context.Background()
+	newFixture := func() *applicationFixture {
+		f := newApplicationFixture()
+		f.questions.questions[f.job.ID] = []models.ScreeningQuestion{
+			{ID: 1, JobID: f.job.ID, Position: 1, Type: "yes_no", Prompt: "Do you have a driving licence?", Required: true, RejectAnswers: []string{"no"}},
+		}
+		return f
+	}
+
+	t.Run("Answers stored with the application", func(t *testing.T) {
+		f := newFixture()
+
+		application, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{Answers: []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: true}}})
+		require.NoError(t, err)
+		require.Len(t, application.Answers, 1)
+		assert.Equal(t, "yes", application.Answers[0].Value)
+		assert.Equal(t, helpers.DefaultPipelineStages[0].Name, application.Stage)
+		assert.Empty(t, f.applications.transitions)
+	})
+
+	t.Run("Knockout answer rejects the application", func(t *testing.T) {
+		f := newFixture()
+
+		application, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{Answers: []request.ScreeningAnswerRequest{{QuestionID: 1, Answer: false}}})
+		require.NoError(t, err)
+		assert.Equal(t, "rejected", stageCategory(application.Stage))
+		assert.Equal(t, helpers.CandidateStatusForCategory("rejected"), application.Status)
+		require.Len(t, f.applications.transitions, 1)
+		assert.Equal(t, uuid.Nil, f.applications.transitions[0].MovedBy)
+		assert.Contains(t, f.applications.transitions[0].Reason, "Do you have a driving licence?")
+		require.Len(t, f.notifications.notified, 1)
+		assert.Equal(t, f.candidateID, f.notifications.notified[0].UserID)
+		assert.Equal(t, models.NotificationApplicationStatusChanged, f.notifications.notified[0].Type)
+	})
+
+	t.Run("Required question unanswered", func(t *testing.T) {
+		f := newFixture()
+
+		_, err := f.service.Apply(ctx, f.candidateID, f.job.ID, request.ApplyToJobRequest{})
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+		assert.Empty(t, f.applications.applications)
+	})
+}
+
+func TestWithdrawApplication(t *testing.T) {
+	candidateID := uuid.New()
+	tests := []struct {
+		name       string
+		status     string
+		wantStatus int
+		wantKept   bool
+	}{
+		{"in review", helpers.CandidateStatusForCategory("review"), 0, false},
+		{"hired", helpers.CandidateStatusForCategory("hired"), http.StatusConflict, true},
+		{"rejected", helpers.CandidateStatusForCategory("rejected"), http.StatusConflict, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &fakeApplicationRepository{applications: []*models.Application{
+				{ID: 1, JobID: 7, CandidateID: candidateID, Stage: "stage", Status: tt.status},
+			}}
+			service := &ApplicationService{applicationRepository: repository}
+
+			err := service.WithdrawApplication(context.Background(), candidateID, 7)
+
+			assert.Equal(t, tt.wantStatus, statusOf(err))
+			assert.Equal(t, tt.wantKept, len(repository.applications) == 1)
+		})
+	}
+}
+
+func TestWithdrawApplicationNotFound(t *testing.T) {
+	service := &ApplicationService{applicationRepository: &fakeApplicationRepository{}}
+
+	err := service.WithdrawApplication(context.Background(), uuid.New(), 7)
+
+	assert.Equal(t, http.StatusNotFound, statusOf(err))
+}
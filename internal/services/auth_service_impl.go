@@ -10,22 +10,70 @@ import (
     "dz-jobs-api/internal/repositories/interfaces"
     "dz-jobs-api/pkg/utils"
     "net/http"
+    "sort"
+    "strings"
     "time"
 
     "github.com/go-redis/redis/v8"
+    "github.com/google/uuid"
+    log "github.com/sirupsen/logrus"
 )
 
+const (
+    // verificationOTPMaxAge is how long the code confirming an email address is valid
+    verificationOTPMaxAge = 15 * time.Minute
+    // verificationResendCooldown is the time to wait between two verification emails
+    verificationResendCooldown = time.Minute
+    // maxOTPGuesses is the number of wrong guesses invalidating an emailed code
+    maxOTPGuesses = 5
+)
+
+var (
+    // loginThrottle backs off the failed logins of an account, whether its password
+    // or its second factor was wrong, and locks it for a while once they pile up
+    loginThrottle = utils.Throttle{
+        Window:       15 * time.Minute,
+        FreeAttempts: 3,
+        BaseDelay:    time.Second,
+        MaxDelay:     5 * time.Minute,
+        LockAfter:    10,
+        LockDuration: 15 * time.Minute,
+    }
+    // ipThrottle backs off the failed logins and code guesses of a client across
+    // accounts
+    ipThrottle = utils.Throttle{
+        Window:       15 * time.Minute,
+        FreeAttempts: 20,
+        BaseDelay:    time.Second,
+        MaxDelay:     15 * time.Minute,
+    }
+    // otpSendThrottle caps the password reset codes sent to an address, and
+    // ipOTPSendThrottle the ones a client asks for
+    otpSendThrottle   = utils.Throttle{Window: time.Hour, Limit: 5}
+    ipOTPSendThrottle = utils.Throttle{Window: time.Hour, Limit: 20}
+)
+
+// accountLockedMessage refuses the attempts locked out by a throttle, only
+// loginThrottle locks
+const accountLockedMessage = "Account temporarily locked after too many failed login attempts"
+
+// invalidCredentialsMessage refuses a login without telling whether the email or
+// the password was wrong
+const invalidCredentialsMessage = "Invalid email or password"
+
 type AuthService struct {
-    userRepository  interfaces.UserRepository
-    redisRepository interfaces.RedisRepository
-    config          *config.AppConfig
+    userRepository      interfaces.UserRepository
+    redisRepository     interfaces.RedisRepository
+    twoFactorRepository interfaces.TwoFactorRepository
+    config              *config.AppConfig
 }
 
-func NewAuthService(userRepo interfaces.UserRepository, redisRepo interfaces.RedisRepository, config *config.AppConfig) *AuthService {
+func NewAuthService(userRepo interfaces.UserRepository, redisRepo interfaces.RedisRepository, twoFactorRepo interfaces.TwoFactorRepository, config *config.AppConfig) *AuthService {
     return &AuthService{
-        userRepository:  userRepo,
-        redisRepository: redisRepo,
-        config:          config,
+        userRepository:      userRepo,
+        redisRepository:     redisRepo,
+        twoFactorRepository: twoFactorRepo,
+        config:              config,
     }
 }
 
@@ -52,90 +100,381 @@ func (s *AuthService) Register(ctx context.Context, req request.CreateUsersReque
         if err := s.userRepository.CreateUser(ctx, user); err != nil {
             return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to create user")
         }
+        if _, err := s.redisRepository.AcquireVerificationCooldown(ctx, user.Email, verificationResendCooldown); err != nil {
+            log.WithFields(log.Fields{"user_id": user.ID, "error": err}).Error("Failed to set verification cooldown")
+        }
+        if err := s.sendVerificationEmail(ctx, user); err != nil {
+            log.WithFields(log.Fields{"user_id": user.ID, "error": err}).Error("Failed to send verification email")
+        }
         return user, nil
     }
 }
 
-func (s *AuthService) Login(ctx context.Context, req request.LoginRequest) (*models.User, string, string, error) {
+// VerifyEmail confirms the email address of an account with the code emailed to
+// it, which is invalidated after too many wrong guesses
+func (s *AuthService) VerifyEmail(ctx context.Context, email, otp, ip string) error {
+    ipAttempt, guess, err := s.reserveOTPGuess(ctx, "verification", email, ip, verificationOTPMaxAge)
+    if err != nil {
+        return err
+    }
+    storedOTP, err := s.redisRepository.GetVerificationOTP(ctx, email)
+    if err != nil {
+        releaseAttempts(ctx, s.redisRepository, ipAttempt, guess)
+        if err == redis.Nil {
+            return utils.NewCustomError(http.StatusUnauthorized, "OTP expired or not found")
+        }
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to retrieve OTP")
+    }
+    if storedOTP != otp {
+        return s.wrongOTP(ctx, guess, email, s.redisRepository.InvalidateVerificationOTP)
+    }
+    releaseAttempts(ctx, s.redisRepository, ipAttempt)
+
+    user, err := s.userRepository.GetUserByEmail(ctx, email)
+    if err != nil {
+        // The account was deleted since the code was sent
+        if err == sql.ErrNoRows {
+            return utils.NewCustomError(http.StatusUnauthorized, "OTP expired or not found")
+        }
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
+    }
+    if err := s.userRepository.MarkEmailVerified(ctx, user.ID); err != nil {
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to verify email")
+    }
+
+    _ = s.redisRepository.InvalidateVerificationOTP(ctx, email)
+    _ = s.redisRepository.ClearAttempts(ctx, otpGuessesKey("verification", email))
+    return nil
+}
+
+// ResendVerificationEmail sends a new code to an account whose email address is
+// not confirmed yet, at most once per cooldown. Addresses without such an account
+// get the same answer, cooldown included, so that it does not tell which exist.
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+    acquired, err := s.redisRepository.AcquireVerificationCooldown(ctx, email, verificationResendCooldown)
+    if err != nil {
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to send verification email")
+    }
+    if !acquired {
+        return utils.NewRetryAfterError("A verification email was sent recently, try again later", verificationResendCooldown)
+    }
+
+    user, err := s.userRepository.GetUserByEmail(ctx, email)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return nil
+        }
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
+    }
+    if user.EmailVerifiedAt != nil {
+        return nil
+    }
+
+    if err := s.sendVerificationEmail(ctx, user); err != nil {
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to send verification email")
+    }
+    return nil
+}
+
+// sendVerificationEmail emails a new code confirming the address of an account,
+// replacing the previous one
+func (s *AuthService) sendVerificationEmail(ctx context.Context, user *models.User) error {
+    otp := utils.GenerateSecureOTP(6)
+    if err := s.redisRepository.StoreVerificationOTP(ctx, user.Email, otp, verificationOTPMaxAge); err != nil {
+        return err
+    }
+    _ = s.redisRepository.ClearAttempts(ctx, otpGuessesKey("verification", user.Email))
+
+    email, err := integrations.RenderVerificationEmail(user.Email, integrations.VerificationEmail{
+        Name:          user.Name,
+        OTP:           otp,
+        ExpiryMinutes: int(verificationOTPMaxAge.Minutes()),
+    })
+    if err != nil {
+        return err
+    }
+    email.UserID = user.ID
+    return integrations.SendEmail(email, s.config.ServiceEmail, s.config.SendGridAPIKey)
+}
+
+// Login checks the password of a user and starts a session, or returns a
+// two-factor token to finish logging in with VerifyTwoFactor when the user has
+// two-factor authentication enabled. Failed logins are throttled by email and by
+// client IP, see loginThrottle and ipThrottle.
+func (s *AuthService) Login(ctx context.Context, req request.LoginRequest, userAgent, ip string) (*models.User, string, string, string, error) {
+    emailAttempt, ipAttempt, err := reserveLogin(ctx, s.redisRepository, req.Email, ip)
+    if err != nil {
+        return nil, "", "", "", err
+    }
+
+    // Unknown emails and wrong passwords get the same answer
     user, err := s.userRepository.GetUserByEmail(ctx, req.Email)
-    if err != nil || user == nil {
+    if err != nil {
         if err == sql.ErrNoRows {
-            return nil, "", "", utils.NewCustomError(http.StatusUnauthorized, "Invalid email or password")
+            return nil, "", "", "", utils.NewCustomError(http.StatusUnauthorized, invalidCredentialsMessage)
         }
-        return nil, "", "", utils.NewCustomError(http.StatusUnauthorized, "Invalid email")
+        releaseAttempts(ctx, s.redisRepository, emailAttempt, ipAttempt)
+        return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
     }
 
     verifyErr := utils.VerifyPassword(user.Password, req.Password)
     if verifyErr != nil {
-        return nil, "", "", utils.NewCustomError(http.StatusUnauthorized, "Invalid password")
+        s.notifyLockedAccount(ctx, user, ip, emailAttempt.count)
+        return nil, "", "", "", utils.NewCustomError(http.StatusUnauthorized, invalidCredentialsMessage)
+    }
+    releaseAttempts(ctx, s.redisRepository, emailAttempt, ipAttempt)
+
+    accessToken, refreshToken, twoFactorToken, err := s.startLogin(ctx, user, userAgent, ip)
+    if err != nil {
+        return nil, "", "", "", err
+    }
+    // Failures are only forgiven once logged in, a correct password alone does not
+    // reset the backoff of the second factor
+    if twoFactorToken == "" {
+        _ = s.redisRepository.ClearAttempts(ctx, loginKey(req.Email))
     }
 
-    accessToken, err := utils.GenerateToken(user.ID.String(), s.config.AccessTokenMaxAge, "access", user.Role, s.config.AccessTokenSecret)
+    return user, accessToken, refreshToken, twoFactorToken, nil
+}
+
+// VerifyTwoFactor finishes a login with the two-factor token returned by the
+// first step and a code from the authenticator app of the user or one of their
+// recovery codes
+func (s *AuthService) VerifyTwoFactor(ctx context.Context, twoFactorToken, code, userAgent, ip string) (*models.User, string, string, error) {
+    claims, err := utils.ValidateToken(twoFactorToken, s.config.AccessTokenSecret, "two_factor")
+    if err != nil {
+        return nil, "", "", utils.NewCustomError(http.StatusUnauthorized, "Two-factor token expired or invalid, log in again")
+    }
+    userID, err := uuid.Parse(claims.ID)
     if err != nil {
-        return nil, "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate access token")
+        return nil, "", "", utils.NewCustomError(http.StatusUnauthorized, "Two-factor token expired or invalid, log in again")
     }
-    refreshToken, err := utils.GenerateToken(user.ID.String(), s.config.RefreshTokenMaxAge, "refresh", "", s.config.RefreshTokenSecret)
+    user, err := s.userRepository.GetUserByID(ctx, userID)
     if err != nil {
-        return nil, "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate refresh token")
+        if err == sql.ErrNoRows {
+            return nil, "", "", utils.NewCustomError(http.StatusUnauthorized, "Two-factor token expired or invalid, log in again")
+        }
+        return nil, "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
+    }
+
+    twoFactor, err := s.twoFactorRepository.GetTwoFactor(ctx, userID)
+    if err != nil && err != sql.ErrNoRows {
+        return nil, "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch two-factor settings")
+    }
+    if !twoFactor.Enabled() {
+        return nil, "", "", utils.NewCustomError(http.StatusUnauthorized, "Two-factor authentication is not enabled, log in again")
     }
-    refreshTokenTTL := s.config.RefreshTokenMaxAge
-    err = s.redisRepository.StoreRefreshToken(ctx, user.ID.String(), refreshToken, refreshTokenTTL)
+    emailAttempt, ipAttempt, err := reserveLogin(ctx, s.redisRepository, user.Email, ip)
     if err != nil {
-        return nil, "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to store refresh token")
+        return nil, "", "", err
     }
+    if err := verifyTwoFactorCode(ctx, s.twoFactorRepository, twoFactor, code, s.config.TwoFactorSecretKey); err != nil {
+        if customErr, ok := err.(*utils.CustomError); ok && customErr.StatusCode == http.StatusUnauthorized {
+            s.notifyLockedAccount(ctx, user, ip, emailAttempt.count)
+        } else {
+            releaseAttempts(ctx, s.redisRepository, emailAttempt, ipAttempt)
+        }
+        return nil, "", "", err
+    }
+    releaseAttempts(ctx, s.redisRepository, ipAttempt)
+    _ = s.redisRepository.ClearAttempts(ctx, loginKey(user.Email))
 
+    accessToken, refreshToken, err := s.createSession(ctx, user, true, userAgent, ip)
+    if err != nil {
+        return nil, "", "", err
+    }
     return user, accessToken, refreshToken, nil
 }
 
-func (s *AuthService) RefreshAccessToken(ctx context.Context, userID, userRole, refreshToken string) (string, error) {
-    storedToken, err := s.redisRepository.GetRefreshToken(ctx, userID)
+// RefreshAccessToken issues new access and refresh tokens for the session of a
+// refresh token, which is rotated out. A refresh token presented again once
+// rotated means it leaked, the session is then revoked so that neither the thief
+// nor the user can keep using its token family.
+func (s *AuthService) RefreshAccessToken(ctx context.Context, refreshToken, userAgent, ip string) (string, string, error) {
+    claims, err := utils.ValidateToken(refreshToken, s.config.RefreshTokenSecret, "refresh")
+    if err != nil || claims.SessionID == "" {
+        return "", "", utils.NewCustomError(http.StatusUnauthorized, "Invalid or expired token")
+    }
+    session, err := s.redisRepository.GetSession(ctx, claims.SessionID)
     if err != nil {
         if err == redis.Nil {
-            return "", utils.NewCustomError(http.StatusUnauthorized, "Refresh token expired or not found")
+            return "", "", utils.NewCustomError(http.StatusUnauthorized, "Session expired or revoked")
         }
-        return "", utils.NewCustomError(http.StatusInternalServerError, "Failed to retrieve refresh token")
+        return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to retrieve session")
+    }
+    if session.UserID.String() != claims.ID {
+        return "", "", utils.NewCustomError(http.StatusUnauthorized, "Invalid Token")
+    }
+
+    newRefreshToken, err := utils.GenerateSessionToken(claims.ID, claims.SessionID, session.TwoFactor, s.config.RefreshTokenMaxAge, "refresh", "", s.config.RefreshTokenSecret)
+    if err != nil {
+        return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate refresh token")
+    }
+    rotated := *session
+    rotated.TokenHash = utils.HashToken(newRefreshToken)
+    rotated.UserAgent, rotated.IP = userAgent, ip
+    rotated.LastUsedAt = time.Now()
+
+    ok, err := s.redisRepository.RotateSession(ctx, &rotated, utils.HashToken(refreshToken), s.config.RefreshTokenMaxAge)
+    if err != nil {
+        return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to store refresh token")
     }
-    if storedToken != refreshToken {
-        return "", utils.NewCustomError(http.StatusUnauthorized, "Invalid Token")
+    if !ok {
+        log.WithFields(log.Fields{"user_id": session.UserID, "session_id": session.ID, "ip": ip}).Warn("Rotated refresh token reused, revoking session")
+        if err := s.redisRepository.InvalidateSession(ctx, session.UserID.String(), session.ID.String()); err != nil {
+            return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to revoke session")
+        }
+        return "", "", utils.NewCustomError(http.StatusUnauthorized, "Refresh token reused, the session was revoked")
     }
 
-    accessToken, err := utils.GenerateToken(userID, s.config.AccessTokenMaxAge, "access", userRole, s.config.AccessTokenSecret)
+    accessToken, err := utils.GenerateSessionToken(claims.ID, claims.SessionID, session.TwoFactor, s.config.AccessTokenMaxAge, "access", session.Role, s.config.AccessTokenSecret)
     if err != nil {
-        return "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate access token")
+        return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate access token")
     }
 
-    return accessToken, nil
+    return accessToken, newRefreshToken, nil
 }
 
-func (s *AuthService) Logout(ctx context.Context, userID, refreshToken string) error {
-    err := s.redisRepository.InvalidateRefreshToken(ctx, userID)
+// Logout ends the session of a refresh token, an invalid or expired token has no
+// session left to end
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+    claims, err := utils.ValidateToken(refreshToken, s.config.RefreshTokenSecret, "refresh")
+    if err != nil || claims.SessionID == "" {
+        return nil
+    }
+    err = s.redisRepository.InvalidateSession(ctx, claims.ID, claims.SessionID)
     if err != nil {
         return utils.NewCustomError(http.StatusInternalServerError, "Failed to delete refresh token")
     }
     return nil
 }
 
-func (s *AuthService) SendOTP(ctx context.Context, email string) error {
+// GetSessions returns the devices a user is logged in on, most recently used first
+func (s *AuthService) GetSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+    sessions, err := s.redisRepository.GetUserSessions(ctx, userID.String())
+    if err != nil {
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch sessions")
+    }
+    sort.Slice(sessions, func(i, j int) bool {
+        return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+    })
+    return sessions, nil
+}
+
+// RevokeSession logs a user out of one of their devices
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+    session, err := s.redisRepository.GetSession(ctx, sessionID.String())
+    if err != nil {
+        if err == redis.Nil {
+            return utils.NewCustomError(http.StatusNotFound, "Session not found")
+        }
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to retrieve session")
+    }
+    if session.UserID != userID {
+        return utils.NewCustomError(http.StatusNotFound, "Session not found")
+    }
+
+    if err := s.redisRepository.InvalidateSession(ctx, userID.String(), sessionID.String()); err != nil {
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to revoke session")
+    }
+    return nil
+}
+
+// startLogin starts a session for a user whose password was checked, or returns
+// a short-lived two-factor token instead when the user has two-factor
+// authentication enabled
+func (s *AuthService) startLogin(ctx context.Context, user *models.User, userAgent, ip string) (string, string, string, error) {
+    twoFactor, err := s.twoFactorRepository.GetTwoFactor(ctx, user.ID)
+    if err != nil && err != sql.ErrNoRows {
+        return "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch two-factor settings")
+    }
+    if twoFactor.Enabled() {
+        twoFactorToken, err := utils.GenerateToken(user.ID.String(), s.config.TwoFactorTokenMaxAge, "two_factor", "", s.config.AccessTokenSecret)
+        if err != nil {
+            return "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate two-factor token")
+        }
+        return "", "", twoFactorToken, nil
+    }
+
+    accessToken, refreshToken, err := s.createSession(ctx, user, false, userAgent, ip)
+    if err != nil {
+        return "", "", "", err
+    }
+    return accessToken, refreshToken, "", nil
+}
+
+// createSession logs a user in on a new device and returns the access and
+// refresh tokens of the session
+func (s *AuthService) createSession(ctx context.Context, user *models.User, twoFactor bool, userAgent, ip string) (string, string, error) {
+    sessionID := uuid.New()
+    accessToken, err := utils.GenerateSessionToken(user.ID.String(), sessionID.String(), twoFactor, s.config.AccessTokenMaxAge, "access", user.Role, s.config.AccessTokenSecret)
+    if err != nil {
+        return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate access token")
+    }
+    refreshToken, err := utils.GenerateSessionToken(user.ID.String(), sessionID.String(), twoFactor, s.config.RefreshTokenMaxAge, "refresh", "", s.config.RefreshTokenSecret)
+    if err != nil {
+        return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate refresh token")
+    }
+
+    now := time.Now()
+    session := &models.Session{
+        ID:         sessionID,
+        UserID:     user.ID,
+        Role:       user.Role,
+        TokenHash:  utils.HashToken(refreshToken),
+        TwoFactor:  twoFactor,
+        UserAgent:  userAgent,
+        IP:         ip,
+        CreatedAt:  now,
+        LastUsedAt: now,
+    }
+    if err := s.redisRepository.StoreSession(ctx, session, s.config.RefreshTokenMaxAge); err != nil {
+        return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to store refresh token")
+    }
+    return accessToken, refreshToken, nil
+}
+
+// SendOTP emails a password reset code, a limited number of times per hour to an
+// address and to a client
+func (s *AuthService) SendOTP(ctx context.Context, email, ip string) error {
+    emailRequest, err := reserveAttempt(ctx, s.redisRepository, otpSendThrottle, otpSendKey(email), "Too many codes requested, try again later")
+    if err != nil {
+        return err
+    }
+    if _, err := reserveAttempt(ctx, s.redisRepository, ipOTPSendThrottle, "otp_send:ip:"+ip, "Too many codes requested, try again later"); err != nil {
+        releaseAttempts(ctx, s.redisRepository, emailRequest)
+        return err
+    }
+
     otp := utils.GenerateSecureOTP(6)
-    err := s.redisRepository.StoreOTP(ctx, email, otp, 5*time.Minute)
+    err = s.redisRepository.StoreOTP(ctx, email, otp, 5*time.Minute)
     if err != nil {
         return utils.NewCustomError(http.StatusInternalServerError, "Failed to store OTP")
     }
+    _ = s.redisRepository.ClearAttempts(ctx, otpGuessesKey("reset", email))
 
     return integrations.SendOTPEmail(email, otp, s.config.SendGridAPIKey)
 }
 
-func (s *AuthService) VerifyOTP(ctx context.Context, email, otp string) (string, error) {
+// VerifyOTP exchanges a password reset code for a reset token. The code is
+// invalidated after too many wrong guesses
+func (s *AuthService) VerifyOTP(ctx context.Context, email, otp, ip string) (string, error) {
+    ipAttempt, guess, err := s.reserveOTPGuess(ctx, "reset", email, ip, 5*time.Minute)
+    if err != nil {
+        return "", err
+    }
     storedOTP, err := s.redisRepository.GetOTP(ctx, email)
     if err != nil {
+        releaseAttempts(ctx, s.redisRepository, ipAttempt, guess)
         if err == redis.Nil {
             return "", utils.NewCustomError(http.StatusUnauthorized, "OTP expired or not found")
         }
         return "", utils.NewCustomError(http.StatusInternalServerError, "Failed to retrieve OTP")
     }
     if storedOTP != otp {
-        return "", utils.NewCustomError(http.StatusUnauthorized, "Invalid OTP")
+        return "", s.wrongOTP(ctx, guess, email, s.redisRepository.InvalidateOTP)
     }
+    releaseAttempts(ctx, s.redisRepository, ipAttempt)
     resetToken, err := utils.GenerateToken(email, s.config.AccessTokenMaxAge, "reset_password", "", s.config.ResetPasswordTokenSecret)
     if err != nil {
         return "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate reset password token")
@@ -148,6 +487,7 @@ func (s *AuthService) VerifyOTP(ctx context.Context, email, otp string) (string,
     if err := s.redisRepository.InvalidateOTP(ctx, email); err != nil {
         return "", utils.NewCustomError(http.StatusInternalServerError, "Failed to delete OTP")
     }
+    _ = s.redisRepository.ClearAttempts(ctx, otpGuessesKey("reset", email))
 
     return resetToken, nil
 }
@@ -179,61 +519,200 @@ func (s *AuthService) ResetPassword(ctx context.Context, email, resetToken, newP
     return nil
 }
 
-func (s *AuthService) ValidateToken(ctx context.Context, token string) (string, string, error) {
-    claims, err := utils.ValidateToken(token, s.config.RefreshTokenSecret, "refresh")
-    if err != nil {
-        return "", "", utils.NewCustomError(http.StatusUnauthorized, "Invalid or expired token")
-    }
-    return claims.ID, claims.Role, nil
-}
-
-func (s *AuthService) GoogleConnect(ctx context.Context, code string, role string) (*models.User, string, string, string, error) {
+func (s *AuthService) GoogleConnect(ctx context.Context, code string, role string, userAgent, ip string) (*models.User, string, string, string, string, error) {
     oauthConfig := integrations.InitializeGoogleOAuthConfig(s.config.GoogleClientID, s.config.GoogleClientSecret, s.config.GoogleRedirectURL)
 
     token, err := oauthConfig.Exchange(ctx, code)
     if err != nil {
-        return nil, "", "", "", utils.NewCustomError(http.StatusBadRequest, "Failed to exchange authorization code for token")
+        return nil, "", "", "", "", utils.NewCustomError(http.StatusBadRequest, "Failed to exchange authorization code for token")
     }
 
     userInfo, err := integrations.FetchGoogleUserInfo(oauthConfig, token)
     if err != nil {
-        return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user information from Google")
+        return nil, "", "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user information from Google")
     }
 
+    // Google only hands out verified addresses
+    now := time.Now()
     existingUser, err := s.userRepository.GetUserByEmail(ctx, userInfo.Email)
     if err != nil {
         if err == sql.ErrNoRows {
+            if role != "candidate" && role != "recruiter" {
+                return nil, "", "", "", "", utils.NewCustomError(http.StatusBadRequest, "Role must be candidate or recruiter")
+            }
             hashedPassword, err := utils.HashPassword(utils.GenerateRandomPassword())
             if err != nil {
-                return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to hash password")
+                return nil, "", "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to hash password")
             }
             newUser := &models.User{
-                Name:     userInfo.Name,
-                Email:    userInfo.Email,
-                Role:     role,
-                Password: hashedPassword,
+                Name:            userInfo.Name,
+                Email:           userInfo.Email,
+                Role:            role,
+                Password:        hashedPassword,
+                EmailVerifiedAt: &now,
             }
 
             if err := s.userRepository.CreateUser(ctx, newUser); err != nil {
-                return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to create new user")
+                return nil, "", "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to create new user")
             }
-            return newUser, "", "", "register", nil
+            return newUser, "", "", "", "register", nil
         }
 
-        return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to check user existence")
+        return nil, "", "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to check user existence")
+    }
+    if existingUser.EmailVerifiedAt == nil {
+        if err := s.userRepository.MarkEmailVerified(ctx, existingUser.ID); err != nil {
+            return nil, "", "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to verify email")
+        }
+        existingUser.EmailVerifiedAt = &now
     }
-    accessToken, err := utils.GenerateToken(userInfo.ID, s.config.AccessTokenMaxAge, "access", role, s.config.AccessTokenSecret)
+    accessToken, refreshToken, twoFactorToken, err := s.startLogin(ctx, existingUser, userAgent, ip)
     if err != nil {
-        return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate access token")
+        return nil, "", "", "", "", err
     }
-    refreshToken, err := utils.GenerateToken(userInfo.ID, s.config.RefreshTokenMaxAge, "refresh", "", s.config.RefreshTokenSecret)
+    return existingUser, accessToken, refreshToken, twoFactorToken, "login", nil
+}
+
+// attemptReservation is an attempt recorded under key before it is made
+type attemptReservation struct {
+    key string
+    id  string
+    // count is the number of attempts within the window, this one included
+    count int
+}
+
+// reserveAttempt records an attempt under key before it is made, so that
+// concurrent attempts count against each other, and refuses it while the ones
+// made before it call for waiting. A refused attempt is taken back.
+func reserveAttempt(ctx context.Context, redisRepository interfaces.RedisRepository, throttle utils.Throttle, key, message string) (*attemptReservation, error) {
+    now := time.Now()
+    id, previous, err := redisRepository.RecordAttempt(ctx, key, now, throttle.Window)
     if err != nil {
-        return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to generate refresh token")
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to check attempts")
+    }
+    if retryAfter := throttle.RetryAfter(previous, now); retryAfter > 0 {
+        releaseAttempts(ctx, redisRepository, &attemptReservation{key: key, id: id})
+        if throttle.Locked(len(previous)) {
+            message = accountLockedMessage
+        }
+        return nil, utils.NewRetryAfterError(message, retryAfter)
+    }
+    return &attemptReservation{key: key, id: id, count: len(previous) + 1}, nil
+}
+
+// releaseAttempts takes back the attempts that turned out not to fail, so that
+// they do not count against the account or the client
+func releaseAttempts(ctx context.Context, redisRepository interfaces.RedisRepository, attempts ...*attemptReservation) {
+    for _, attempt := range attempts {
+        if attempt == nil {
+            continue
+        }
+        if err := redisRepository.RemoveAttempt(ctx, attempt.key, attempt.id); err != nil {
+            log.WithFields(log.Fields{"key": attempt.key, "error": err}).Error("Failed to release attempt")
+        }
+    }
+}
+
+// reserveLogin counts a login as failed against the account and the client until
+// it succeeds, refusing it while the failed ones call for waiting. The attempts
+// are released once the password or code is found correct.
+func reserveLogin(ctx context.Context, redisRepository interfaces.RedisRepository, email, ip string) (*attemptReservation, *attemptReservation, error) {
+    emailAttempt, err := reserveAttempt(ctx, redisRepository, loginThrottle, loginKey(email), "Too many failed login attempts, try again later")
+    if err != nil {
+        return nil, nil, err
+    }
+    ipAttempt, err := reserveAttempt(ctx, redisRepository, ipThrottle, "ip:"+ip, "Too many failed login attempts, try again later")
+    if err != nil {
+        releaseAttempts(ctx, redisRepository, emailAttempt)
+        return nil, nil, err
+    }
+    return emailAttempt, ipAttempt, nil
+}
+
+// notifyLockedAccount emails the owner of an account when the failed login that
+// brought its failures to failures locks it. The login failed already, so errors
+// are only logged.
+func (s *AuthService) notifyLockedAccount(ctx context.Context, user *models.User, ip string, failures int) {
+    if failures != loginThrottle.LockAfter {
+        return
+    }
+
+    log.WithFields(log.Fields{"user_id": user.ID, "ip": ip}).Warn("Account locked after too many failed login attempts")
+    lockedEmail, err := integrations.RenderAccountLockedEmail(user.Email, integrations.AccountLockedEmail{
+        Name:        user.Name,
+        Attempts:    failures,
+        IP:          ip,
+        LockMinutes: int(loginThrottle.LockDuration.Minutes()),
+    })
+    if err == nil {
+        lockedEmail.UserID = user.ID
+        err = integrations.SendEmail(lockedEmail, s.config.ServiceEmail, s.config.SendGridAPIKey)
     }
-    refreshTokenTTL := s.config.RefreshTokenMaxAge
-    err = s.redisRepository.StoreRefreshToken(ctx, userInfo.ID, refreshToken, refreshTokenTTL)
     if err != nil {
-        return nil, "", "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to store refresh token")
+        log.WithFields(log.Fields{"user_id": user.ID, "error": err}).Error("Failed to send account locked email")
     }
-    return existingUser, accessToken, refreshToken, "login", nil
-}
\ No newline at end of file
+}
+
+// reserveOTPGuess counts a guess of an emailed code as wrong against the code and
+// the client before the code is compared, so that concurrent guesses cannot get
+// past maxOTPGuesses. The client attempt is released when the guess is right.
+func (s *AuthService) reserveOTPGuess(ctx context.Context, purpose, email, ip string, maxAge time.Duration) (*attemptReservation, *attemptReservation, error) {
+    ipAttempt, err := reserveAttempt(ctx, s.redisRepository, ipThrottle, "ip:"+ip, "Too many failed attempts, try again later")
+    if err != nil {
+        return nil, nil, err
+    }
+    key := otpGuessesKey(purpose, email)
+    id, previous, err := s.redisRepository.RecordAttempt(ctx, key, time.Now(), maxAge)
+    if err != nil {
+        releaseAttempts(ctx, s.redisRepository, ipAttempt)
+        return nil, nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to record OTP guess")
+    }
+    // The last guess allowed invalidates a wrong code, the guesses made along with
+    // it are refused
+    if len(previous) >= maxOTPGuesses {
+        return nil, nil, utils.NewCustomError(http.StatusUnauthorized, "Too many wrong guesses, request a new OTP")
+    }
+    return ipAttempt, &attemptReservation{key: key, id: id, count: len(previous) + 1}, nil
+}
+
+// wrongOTP answers a wrong guess of an emailed code, invalidating the code at the
+// last guess allowed
+func (s *AuthService) wrongOTP(ctx context.Context, guess *attemptReservation, email string, invalidate func(context.Context, string) error) error {
+    if guess.count < maxOTPGuesses {
+        return utils.NewCustomError(http.StatusUnauthorized, "Invalid OTP")
+    }
+    if err := invalidate(ctx, email); err != nil {
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to delete OTP")
+    }
+    _ = s.redisRepository.ClearAttempts(ctx, guess.key)
+    return utils.NewCustomError(http.StatusUnauthorized, "Too many wrong guesses, request a new OTP")
+}
+
+func loginKey(email string) string {
+    return "login:" + strings.ToLower(email)
+}
+
+func otpSendKey(email string) string {
+    return "otp_send:" + strings.ToLower(email)
+}
+
+func otpGuessesKey(purpose, email string) string {
+    return "otp_guesses:" + purpose + ":" + strings.ToLower(email)
+}
+
+// requireVerifiedEmail refuses the actions reserved to accounts whose email
+// address is confirmed, such as posting jobs and applying to them
+func requireVerifiedEmail(ctx context.Context, userRepository interfaces.UserRepository, userID uuid.UUID) error {
+    user, err := userRepository.GetUserByID(ctx, userID)
+    if err != nil {
+        // The account of the session was deleted
+        if err == sql.ErrNoRows {
+            return utils.NewCustomError(http.StatusUnauthorized, "Session expired or revoked")
+        }
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
+    }
+    if user.EmailVerifiedAt == nil {
+        return utils.NewCustomError(http.StatusForbidden, "Confirm your email address first")
+    }
+    return nil
+}
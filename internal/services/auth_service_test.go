@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAuthService(t *testing.T, users ...*models.User) (*AuthService, *fakeUserRepository, *fakeRedisRepository) {
+	userRepo := &fakeUserRepository{users: map[uuid.UUID]*models.User{}}
+	for _, user := range users {
+		userRepo.users[user.ID] = user
+	}
+	redis := newFakeRedisRepository()
+	cfg := &config.AppConfig{
+		AccessTokenSecret:  "access secret",
+		RefreshTokenSecret: "refresh secret",
+		AccessTokenMaxAge:  15 * time.Minute,
+		RefreshTokenMaxAge: 7 * 24 * time.Hour,
+	}
+	return NewAuthService(userRepo, redis, newFakeTwoFactorRepository(), cfg), userRepo, redis
+}
+
+func newTestUser(t *testing.T, email string) *models.User {
+	password, err := utils.HashPassword("correct horse")
+	assert.NoError(t, err)
+	return &models.User{ID: uuid.New(), Name: "Amina", Email: email, Role: "candidate", Password: password}
+}
+
+func TestLoginErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unknown email and wrong password look alike", func(t *testing.T) {
+		service, _, _ := newTestAuthService(t, newTestUser(t, "amina@example.dz"))
+
+		_, _, _, _, unknown := service.Login(ctx, request.LoginRequest{Email: "nobody@example.dz", Password: "correct horse"}, "test", "10.0.0.1")
+		_, _, _, _, wrong := service.Login(ctx, request.LoginRequest{Email: "amina@example.dz", Password: "wrong password"}, "test", "10.0.0.2")
+		assert.Equal(t, http.StatusUnauthorized, statusOf(unknown))
+		assert.Equal(t, unknown.(*utils.CustomError).Message, wrong.(*utils.CustomError).Message)
+	})
+
+	t.Run("Repository errors are not credential errors", func(t *testing.T) {
+		service, users, redis := newTestAuthService(t)
+		users.err = errors.New("connection reset")
+
+		_, _, _, _, err := service.Login(ctx, request.LoginRequest{Email: "amina@example.dz", Password: "correct horse"}, "test", "10.0.0.1")
+		assert.Equal(t, http.StatusInternalServerError, statusOf(err))
+		assert.Empty(t, redis.attempts[loginKey("amina@example.dz")])
+		assert.Empty(t, redis.attempts["ip:10.0.0.1"])
+	})
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	verified := newTestUser(t, "verified@example.dz")
+	verified.EmailVerifiedAt = &verifiedAt
+	service, _, _ := newTestAuthService(t, verified)
+
+	for _, email := range []string{"nobody@example.dz", "verified@example.dz"} {
+		t.Run(email, func(t *testing.T) {
+			assert.NoError(t, service.ResendVerificationEmail(ctx, email))
+
+			// The cooldown applies to every address alike
+			err := service.ResendVerificationEmail(ctx, email)
+			assert.Equal(t, http.StatusTooManyRequests, statusOf(err))
+		})
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser(t, "amina@example.dz")
+
+	t.Run("Refresh token rotated", func(t *testing.T) {
+		service, _, redis := newTestAuthService(t, user)
+		_, refreshToken, err := service.createSession(ctx, user, false, "laptop", "10.0.0.1")
+		assert.NoError(t, err)
+
+		accessToken, newRefreshToken, err := service.RefreshAccessToken(ctx, refreshToken, "laptop", "10.0.0.2")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEqual(t, refreshToken, newRefreshToken)
+		sessions, _ := redis.GetUserSessions(ctx, user.ID.String())
+		if assert.Len(t, sessions, 1) {
+			assert.Equal(t, utils.HashToken(newRefreshToken), sessions[0].TokenHash)
+			assert.Equal(t, "10.0.0.2", sessions[0].IP)
+		}
+
+		_, _, err = service.RefreshAccessToken(ctx, newRefreshToken, "laptop", "10.0.0.2")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Rotated token reused revokes the session", func(t *testing.T) {
+		service, _, redis := newTestAuthService(t, user)
+		_, refreshToken, err := service.createSession(ctx, user, false, "laptop", "10.0.0.1")
+		assert.NoError(t, err)
+		_, newRefreshToken, err := service.RefreshAccessToken(ctx, refreshToken, "laptop", "10.0.0.1")
+		assert.NoError(t, err)
+
+		_, _, err = service.RefreshAccessToken(ctx, refreshToken, "phone", "10.0.0.9")
+		assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+		assert.Empty(t, redis.sessions)
+
+		_, _, err = service.RefreshAccessToken(ctx, newRefreshToken, "laptop", "10.0.0.1")
+		assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+	})
+
+	t.Run("Access token refused", func(t *testing.T) {
+		service, _, _ := newTestAuthService(t, user)
+		accessToken, _, err := service.createSession(ctx, user, false, "laptop", "10.0.0.1")
+		assert.NoError(t, err)
+
+		_, _, err = service.RefreshAccessToken(ctx, accessToken, "laptop", "10.0.0.1")
+		assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+	})
+}
+
+func TestSessions(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser(t, "amina@example.dz")
+	other := newTestUser(t, "yacine@example.dz")
+	service, _, redis := newTestAuthService(t, user, other)
+
+	_, laptopToken, err := service.createSession(ctx, user, false, "laptop", "10.0.0.1")
+	assert.NoError(t, err)
+	_, phoneToken, err := service.createSession(ctx, user, false, "phone", "10.0.0.2")
+	assert.NoError(t, err)
+	_, _, err = service.createSession(ctx, other, false, "desktop", "10.0.0.3")
+	assert.NoError(t, err)
+	for _, session := range redis.sessions {
+		if session.UserAgent == "laptop" {
+			session.LastUsedAt = session.LastUsedAt.Add(time.Hour)
+		}
+	}
+
+	sessions, err := service.GetSessions(ctx, user.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, sessions, 2) {
+		assert.Equal(t, "laptop", sessions[0].UserAgent)
+		assert.Equal(t, "phone", sessions[1].UserAgent)
+	}
+
+	// Another user's session looks like one that does not exist
+	otherSessions, _ := service.GetSessions(ctx, other.ID)
+	assert.Equal(t, http.StatusNotFound, statusOf(service.RevokeSession(ctx, user.ID, otherSessions[0].ID)))
+
+	assert.NoError(t, service.RevokeSession(ctx, user.ID, sessions[1].ID))
+	_, _, err = service.RefreshAccessToken(ctx, phoneToken, "phone", "10.0.0.2")
+	assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+	_, _, err = service.RefreshAccessToken(ctx, laptopToken, "laptop", "10.0.0.1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, statusOf(service.RevokeSession(ctx, user.ID, sessions[1].ID)))
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Right code confirms the address", func(t *testing.T) {
+		user := newTestUser(t, "amina@example.dz")
+		service, users, redis := newTestAuthService(t, user)
+		redis.otps[user.Email] = "123456"
+
+		assert.NoError(t, service.VerifyEmail(ctx, user.Email, "123456", "10.0.0.1"))
+		assert.NotNil(t, users.users[user.ID].EmailVerifiedAt)
+		assert.Empty(t, redis.otps)
+
+		err := service.VerifyEmail(ctx, user.Email, "123456", "10.0.0.1")
+		assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+	})
+
+	t.Run("Code invalidated after too many wrong guesses", func(t *testing.T) {
+		user := newTestUser(t, "amina@example.dz")
+		service, users, redis := newTestAuthService(t, user)
+		redis.otps[user.Email] = "123456"
+
+		for i := 1; i < maxOTPGuesses; i++ {
+			err := service.VerifyEmail(ctx, user.Email, "000000", "10.0.0.1")
+			assert.Equal(t, "Invalid OTP", err.(*utils.CustomError).Message)
+		}
+		err := service.VerifyEmail(ctx, user.Email, "000000", "10.0.0.1")
+		assert.Equal(t, "Too many wrong guesses, request a new OTP", err.(*utils.CustomError).Message)
+		assert.Empty(t, redis.otps)
+
+		err = service.VerifyEmail(ctx, user.Email, "123456", "10.0.0.1")
+		assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+		assert.Nil(t, users.users[user.ID].EmailVerifiedAt)
+	})
+}
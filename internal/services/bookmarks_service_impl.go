@@ -2,7 +2,7 @@ package services
 
 import (
 	"context"
-	"database/sql"
+	"dz-jobs-api/internal/dto/request"
 	"dz-jobs-api/internal/models"
 	"dz-jobs-api/internal/repositories/interfaces"
 	"dz-jobs-api/pkg/utils"
@@ -35,13 +35,10 @@ func (s *BookmarksService) RemoveBookmark(ctx context.Context, candidateID uuid.
 	return nil
 }
 
-func (s *BookmarksService) GetBookmarks(ctx context.Context, candidateID uuid.UUID) ([]*models.Job, error) {
-	bookmarks, err := s.bookmarksRepository.GetBookmarks(ctx, candidateID)
+func (s *BookmarksService) GetBookmarks(ctx context.Context, candidateID uuid.UUID, page request.PageRequest) ([]*models.Job, *models.PageInfo, error) {
+	bookmarks, pageInfo, err := s.bookmarksRepository.GetBookmarks(ctx, candidateID, page)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
+		return nil, nil, listError(err, "Error fetching Bookmarks")
 	}
-	return bookmarks, nil
+	return bookmarks, pageInfo, nil
 }
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/integrations"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// emailOutboxBatchSize is the number of queued emails claimed at once by the scheduler
+	emailOutboxBatchSize = 100
+	// emailOutboxLease is how long a claimed email is held before it can be
+	// claimed again, when sending it failed
+	emailOutboxLease = 10 * time.Minute
+)
+
+type EmailService struct {
+	emailRepository      interfaces.EmailRepository
+	preferenceRepository interfaces.NotificationPreferenceRepository
+	config               *config.AppConfig
+
+	// queued signals the outbox scheduler that emails are due right away
+	queued chan struct{}
+}
+
+func NewEmailService(emailRepo interfaces.EmailRepository, preferenceRepo interfaces.NotificationPreferenceRepository, cfg *config.AppConfig) *EmailService {
+	return &EmailService{
+		emailRepository:      emailRepo,
+		preferenceRepository: preferenceRepo,
+		config:               cfg,
+		queued:               make(chan struct{}, 1),
+	}
+}
+
+// Send emails a user about an event unless the user turned the emails of the
+// event off. The email is rendered with its unsubscribe link and queued in the
+// outbox, so that the request raising the event does not wait for the mail
+// provider and failed sends are retried. It is due right away, at the next
+// digest for a low priority event when the user wants digests, or once the quiet
+// hours of the user end. Security mail such as OTPs is sent directly, not
+// through Send.
+func (s *EmailService) Send(ctx context.Context, userID uuid.UUID, emailType string, render func(unsubscribeURL string) (*models.Email, error)) error {
+	preferences, err := s.preferenceRepository.GetPreferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !preferences.Allows(emailType, models.NotificationChannelEmail) {
+		return nil
+	}
+
+	email, err := render(s.unsubscribeURL(userID, emailType))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	email.UserID, email.Type, email.CreatedAt = userID, emailType, now
+
+	quietHoursEnd, quiet := helpers.QuietHoursEnd(preferences, now)
+	switch {
+	case preferences.Digest && models.IsLowPriorityNotification(emailType):
+		email.Digest = true
+		email.SendAfter = helpers.NextDigestTime(preferences, now)
+	case quiet:
+		email.SendAfter = quietHoursEnd
+	default:
+		email.SendAfter = now
+	}
+	if err := s.emailRepository.QueueEmail(ctx, email); err != nil {
+		return err
+	}
+	if !email.SendAfter.After(now) {
+		select {
+		case s.queued <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Queued signals that emails due right away were queued, for the outbox
+// scheduler to send them before its next tick
+func (s *EmailService) Queued() <-chan struct{} {
+	return s.queued
+}
+
+// SendQueuedEmails sends the queued emails due at now, with the digest emails of
+// a user batched in a single email. An email is retried once its lease expires
+// when sending it fails.
+func (s *EmailService) SendQueuedEmails(ctx context.Context, now time.Time) error {
+	for {
+		emails, err := s.emailRepository.ClaimDueEmails(ctx, now, emailOutboxLease, emailOutboxBatchSize)
+		if err != nil {
+			return err
+		}
+
+		var userIDs []uuid.UUID
+		userEmails := map[uuid.UUID][]*models.Email{}
+		for _, email := range emails {
+			if userEmails[email.UserID] == nil {
+				userIDs = append(userIDs, email.UserID)
+			}
+			userEmails[email.UserID] = append(userEmails[email.UserID], email)
+		}
+		for _, userID := range userIDs {
+			if err := s.sendUserEmails(ctx, userID, userEmails[userID]); err != nil {
+				log.WithFields(log.Fields{"user_id": userID, "error": err}).Error("Failed to send queued emails")
+			}
+		}
+
+		if len(emails) < emailOutboxBatchSize {
+			return nil
+		}
+	}
+}
+
+// sendUserEmails sends the queued emails of a user and removes them from the
+// outbox. The emails of an event the user turned off since they were queued are
+// dropped.
+func (s *EmailService) sendUserEmails(ctx context.Context, userID uuid.UUID, emails []*models.Email) error {
+	preferences, err := s.preferenceRepository.GetPreferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var done []int64
+	var digest []*models.Email
+	for _, email := range emails {
+		switch {
+		case !preferences.Allows(email.Type, models.NotificationChannelEmail):
+			done = append(done, email.ID)
+		case email.Digest:
+			digest = append(digest, email)
+		default:
+			if err := integrations.SendEmail(email, s.config.ServiceEmail, s.config.SendGridAPIKey); err != nil {
+				log.WithFields(log.Fields{"email_id": email.ID, "error": err}).Error("Failed to send queued email")
+				continue
+			}
+			done = append(done, email.ID)
+		}
+	}
+
+	if len(digest) > 0 {
+		if err := s.sendDigest(userID, digest); err != nil {
+			log.WithFields(log.Fields{"user_id": userID, "error": err}).Error("Failed to send notification digest")
+		} else {
+			for _, email := range digest {
+				done = append(done, email.ID)
+			}
+		}
+	}
+
+	if len(done) == 0 {
+		return nil
+	}
+	return s.emailRepository.DeleteEmails(ctx, done)
+}
+
+// sendDigest sends the digest emails of a user as one email listing each of them
+func (s *EmailService) sendDigest(userID uuid.UUID, emails []*models.Email) error {
+	digest := integrations.NotificationDigest{UnsubscribeURL: s.unsubscribeURL(userID, unsubscribeAll)}
+	for _, email := range emails {
+		digest.Items = append(digest.Items, integrations.NotificationDigestItem{
+			Subject:        email.Subject,
+			Text:           email.PlainText,
+			UnsubscribeURL: email.UnsubscribeURL,
+		})
+	}
+
+	email, err := integrations.RenderNotificationDigestEmail(emails[0].To, digest)
+	if err != nil {
+		return err
+	}
+	email.UserID, email.Type = userID, "digest"
+	return integrations.SendEmail(email, s.config.ServiceEmail, s.config.SendGridAPIKey)
+}
+
+// unsubscribeURL returns the link turning off the emails of an event for a user,
+// signed so that it cannot be forged for another user
+func (s *EmailService) unsubscribeURL(userID uuid.UUID, emailType string) string {
+	token := utils.SignToken(userID.String()+":"+emailType, s.config.UnsubscribeTokenSecret)
+	return fmt.Sprintf("https://%s/v1/notification-preferences/unsubscribe?token=%s", s.config.BackEndDomain, url.QueryEscape(token))
+}
@@ -0,0 +1,758 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// The fakes below embed the repository interfaces they stand for, a test
+// calling a method they do not implement panics on the nil interface.
+
+type fakeJobRepository struct {
+	interfaces.JobRepository
+	listings  []*models.Job
+	jobs      map[int64]*models.Job
+	skills    map[int64][]int64
+	revisions map[int64][]models.JobSnapshot
+	updateErr error
+	// filters of the last GetJobListings call
+	lastFilters request.JobFilters
+}
+
+func newFakeJobRepository(jobs ...*models.Job) *fakeJobRepository {
+	r := &fakeJobRepository{jobs: map[int64]*models.Job{}, skills: map[int64][]int64{}, revisions: map[int64][]models.JobSnapshot{}}
+	for _, job := range jobs {
+		r.jobs[job.ID] = job
+	}
+	return r
+}
+
+func (r *fakeJobRepository) CreateJob(ctx context.Context, job *models.Job, skillIDs []int64) error {
+	job.ID = int64(len(r.jobs) + 1)
+	r.jobs[job.ID] = job
+	r.skills[job.ID] = skillIDs
+	return nil
+}
+
+func (r *fakeJobRepository) ValidateJobOwnership(ctx context.Context, jobID int64, recruiterID uuid.UUID) error {
+	if job, ok := r.jobs[jobID]; !ok || job.RecruiterID != recruiterID {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *fakeJobRepository) GetJobDetails(ctx context.Context, jobID int64, recruiterID uuid.UUID) (*models.Job, error) {
+	if err := r.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return nil, err
+	}
+	copied := *r.jobs[jobID]
+	return &copied, nil
+}
+
+// UpdateJob saves the job and its skills together, or neither when updateErr is set
+func (r *fakeJobRepository) UpdateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job, skillIDs []int64) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	copied := *job
+	copied.ID = jobID
+	r.jobs[jobID] = &copied
+	r.skills[jobID] = skillIDs
+	r.revisions[jobID] = append(r.revisions[jobID], helpers.JobSnapshotOf(&copied))
+	return nil
+}
+
+func (r *fakeJobRepository) RestoreJobRevision(ctx context.Context, jobID int64, recruiterID uuid.UUID, job *models.Job, revision int, skillIDs []int64) error {
+	return r.UpdateJob(ctx, jobID, recruiterID, job, skillIDs)
+}
+
+func (r *fakeJobRepository) RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, repostedAt, expiresAt time.Time) error {
+	job := r.jobs[jobID]
+	job.Status, job.PublishAt, job.PublishedAt, job.ExpiresAt, job.ExpiryReminderSentAt = "open", nil, &repostedAt, &expiresAt, nil
+	return nil
+}
+
+func (r *fakeJobRepository) GetJobRevision(ctx context.Context, jobID int64, revision int) (*models.JobRevision, error) {
+	if revision < 1 || revision > len(r.revisions[jobID]) {
+		return nil, sql.ErrNoRows
+	}
+	return &models.JobRevision{JobID: jobID, Revision: revision, Snapshot: r.revisions[jobID][revision-1]}, nil
+}
+
+func (r *fakeJobRepository) GetJob(ctx context.Context, jobID int64) (*models.Job, error) {
+	job, ok := r.jobs[jobID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (r *fakeJobRepository) GetJobDetailsPublic(ctx context.Context, jobID int64) (*models.Job, error) {
+	job, ok := r.jobs[jobID]
+	if !ok || (job.Status != "open" && job.Status != "closed") {
+		return nil, sql.ErrNoRows
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (r *fakeJobRepository) GetJobListings(ctx context.Context, filters request.JobFilters) ([]*models.Job, *models.PageInfo, error) {
+	r.lastFilters = filters
+	return r.listings, &models.PageInfo{Total: len(r.listings)}, nil
+}
+
+func (r *fakeJobRepository) GetSitemapJobs(ctx context.Context, limit int) ([]*models.Job, error) {
+	return r.listings, nil
+}
+
+type fakeRecruiterRepository struct {
+	interfaces.RecruiterRepository
+	recruiters map[uuid.UUID]*models.Recruiter
+}
+
+func (r *fakeRecruiterRepository) GetRecruiters(ctx context.Context, recruiterIDs []uuid.UUID) (map[uuid.UUID]*models.Recruiter, error) {
+	recruiters := map[uuid.UUID]*models.Recruiter{}
+	for _, id := range recruiterIDs {
+		if recruiter, ok := r.recruiters[id]; ok {
+			recruiters[id] = recruiter
+		}
+	}
+	return recruiters, nil
+}
+
+func (r *fakeRecruiterRepository) GetRecruiter(ctx context.Context, recruiterID uuid.UUID) (*models.Recruiter, error) {
+	recruiter, ok := r.recruiters[recruiterID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return recruiter, nil
+}
+
+type fakeRedisRepository struct {
+	interfaces.RedisRepository
+	feeds     map[string]*utils.FeedCache
+	attempts  map[string][]fakeAttempt
+	lastID    int
+	cooldowns map[string]bool
+	sessions  map[string]*models.Session
+	otps      map[string]string
+}
+
+type fakeAttempt struct {
+	id string
+	at time.Time
+}
+
+func newFakeRedisRepository() *fakeRedisRepository {
+	return &fakeRedisRepository{feeds: map[string]*utils.FeedCache{}, attempts: map[string][]fakeAttempt{}, cooldowns: map[string]bool{}, sessions: map[string]*models.Session{}, otps: map[string]string{}}
+}
+
+func (r *fakeRedisRepository) StoreVerificationOTP(ctx context.Context, email, otp string, expiry time.Duration) error {
+	r.otps[email] = otp
+	return nil
+}
+
+func (r *fakeRedisRepository) GetVerificationOTP(ctx context.Context, email string) (string, error) {
+	otp, ok := r.otps[email]
+	if !ok {
+		return "", redis.Nil
+	}
+	return otp, nil
+}
+
+func (r *fakeRedisRepository) InvalidateVerificationOTP(ctx context.Context, email string) error {
+	delete(r.otps, email)
+	return nil
+}
+
+func (r *fakeRedisRepository) StoreSession(ctx context.Context, session *models.Session, expiry time.Duration) error {
+	copied := *session
+	r.sessions[session.ID.String()] = &copied
+	return nil
+}
+
+func (r *fakeRedisRepository) GetSession(ctx context.Context, sessionID string) (*models.Session, error) {
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return nil, redis.Nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *fakeRedisRepository) GetUserSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	var sessions []*models.Session
+	for _, session := range r.sessions {
+		if session.UserID.String() == userID {
+			copied := *session
+			sessions = append(sessions, &copied)
+		}
+	}
+	return sessions, nil
+}
+
+// RotateSession only replaces a session still holding previousTokenHash, like the
+// watched transaction of the repository
+func (r *fakeRedisRepository) RotateSession(ctx context.Context, session *models.Session, previousTokenHash string, expiry time.Duration) (bool, error) {
+	current, ok := r.sessions[session.ID.String()]
+	if !ok || current.TokenHash != previousTokenHash {
+		return false, nil
+	}
+	return true, r.StoreSession(ctx, session, expiry)
+}
+
+func (r *fakeRedisRepository) InvalidateSession(ctx context.Context, userID, sessionID string) error {
+	delete(r.sessions, sessionID)
+	return nil
+}
+
+func (r *fakeRedisRepository) AcquireVerificationCooldown(ctx context.Context, email string, cooldown time.Duration) (bool, error) {
+	if r.cooldowns[email] {
+		return false, nil
+	}
+	r.cooldowns[email] = true
+	return true, nil
+}
+
+func (r *fakeRedisRepository) RecordAttempt(ctx context.Context, key string, at time.Time, window time.Duration) (string, []time.Time, error) {
+	var previous []time.Time
+	for _, attempt := range r.attempts[key] {
+		previous = append(previous, attempt.at)
+	}
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.attempts[key] = append(r.attempts[key], fakeAttempt{id: id, at: at})
+	return id, previous, nil
+}
+
+func (r *fakeRedisRepository) RemoveAttempt(ctx context.Context, key, attemptID string) error {
+	attempts := r.attempts[key][:0]
+	for _, attempt := range r.attempts[key] {
+		if attempt.id != attemptID {
+			attempts = append(attempts, attempt)
+		}
+	}
+	r.attempts[key] = attempts
+	return nil
+}
+
+func (r *fakeRedisRepository) ClearAttempts(ctx context.Context, key string) error {
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *fakeRedisRepository) GetFeedCache(ctx context.Context, feedKey string) (*utils.FeedCache, error) {
+	return r.feeds[feedKey], nil
+}
+
+func (r *fakeRedisRepository) StoreFeedCache(ctx context.Context, feedKey string, data *utils.FeedCache, expiry time.Duration) error {
+	r.feeds[feedKey] = data
+	return nil
+}
+
+type fakeUserRepository struct {
+	interfaces.UserRepository
+	users map[uuid.UUID]*models.User
+	err   error
+}
+
+func (r *fakeUserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *user
+	copied.Password = ""
+	return &copied, nil
+}
+
+func (r *fakeUserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeUserRepository) MarkEmailVerified(ctx context.Context, userID uuid.UUID) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	return nil
+}
+
+type fakeTwoFactorRepository struct {
+	interfaces.TwoFactorRepository
+	twoFactors    map[uuid.UUID]*models.TwoFactor
+	recoveryCodes map[string]bool
+}
+
+func newFakeTwoFactorRepository() *fakeTwoFactorRepository {
+	return &fakeTwoFactorRepository{twoFactors: map[uuid.UUID]*models.TwoFactor{}, recoveryCodes: map[string]bool{}}
+}
+
+func (r *fakeTwoFactorRepository) GetTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactor, error) {
+	twoFactor, ok := r.twoFactors[userID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *twoFactor
+	return &copied, nil
+}
+
+func (r *fakeTwoFactorRepository) GetTwoFactors(ctx context.Context) ([]*models.TwoFactor, error) {
+	var twoFactors []*models.TwoFactor
+	for _, twoFactor := range r.twoFactors {
+		copied := *twoFactor
+		twoFactors = append(twoFactors, &copied)
+	}
+	return twoFactors, nil
+}
+
+func (r *fakeTwoFactorRepository) ReplaceSecret(ctx context.Context, userID uuid.UUID, oldSecret, newSecret string) error {
+	if twoFactor, ok := r.twoFactors[userID]; ok && twoFactor.Secret == oldSecret {
+		twoFactor.Secret = newSecret
+	}
+	return nil
+}
+
+func (r *fakeTwoFactorRepository) SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	if r.twoFactors[userID].Enabled() {
+		return sql.ErrNoRows
+	}
+	r.twoFactors[userID] = &models.TwoFactor{UserID: userID, Secret: secret}
+	return nil
+}
+
+func (r *fakeTwoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	twoFactor := r.twoFactors[userID]
+	if twoFactor.LastUsedStep >= step {
+		return false, nil
+	}
+	twoFactor.LastUsedStep = step
+	return true, nil
+}
+
+func (r *fakeTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	key := userID.String() + ":" + codeHash
+	if !r.recoveryCodes[key] {
+		return false, nil
+	}
+	delete(r.recoveryCodes, key)
+	return true, nil
+}
+
+func (r *fakeTwoFactorRepository) DisableTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	delete(r.twoFactors, userID)
+	return nil
+}
+
+type fakeSkillCatalogRepository struct {
+	interfaces.SkillCatalogRepository
+	skills  map[string]*models.Skill
+	merged  []int64
+	findErr error
+}
+
+func newFakeSkillCatalogRepository() *fakeSkillCatalogRepository {
+	return &fakeSkillCatalogRepository{skills: map[string]*models.Skill{}}
+}
+
+func (r *fakeSkillCatalogRepository) FindOrCreateSkill(ctx context.Context, name string) (*models.Skill, error) {
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	key := strings.ToLower(name)
+	if skill, ok := r.skills[key]; ok {
+		return skill, nil
+	}
+	skill := &models.Skill{ID: int64(len(r.skills) + 1), Name: name}
+	r.skills[key] = skill
+	return skill, nil
+}
+
+func (r *fakeSkillCatalogRepository) CreateSkill(ctx context.Context, skill *models.Skill) error {
+	for _, existing := range r.skills {
+		if existing.Slug == skill.Slug || slices.Contains(existing.Aliases, skill.Slug) {
+			return interfaces.ErrSkillExists
+		}
+	}
+	skill.ID = int64(len(r.skills) + 1)
+	r.skills[skill.Slug] = skill
+	return nil
+}
+
+func (r *fakeSkillCatalogRepository) MergeSkills(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	r.merged = append(r.merged, sourceIDs...)
+	return nil
+}
+
+func (r *fakeSkillCatalogRepository) GetSkill(ctx context.Context, skillID int64) (*models.Skill, error) {
+	for _, skill := range r.skills {
+		if skill.ID == skillID {
+			return skill, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+type fakePreferenceRepository struct {
+	interfaces.NotificationPreferenceRepository
+	preferences map[uuid.UUID]*models.NotificationPreferences
+}
+
+func (r *fakePreferenceRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	if preferences, ok := r.preferences[userID]; ok {
+		return preferences, nil
+	}
+	return &models.NotificationPreferences{UserID: userID}, nil
+}
+
+func (r *fakePreferenceRepository) UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	r.preferences[preferences.UserID] = preferences
+	return nil
+}
+
+type fakeEmailRepository struct {
+	interfaces.EmailRepository
+	outbox []*models.Email
+}
+
+func (r *fakeEmailRepository) QueueEmail(ctx context.Context, email *models.Email) error {
+	email.ID = int64(len(r.outbox) + 1)
+	r.outbox = append(r.outbox, email)
+	return nil
+}
+
+type fakeNotificationRepository struct {
+	interfaces.NotificationRepository
+	notifications []*models.Notification
+}
+
+func (r *fakeNotificationRepository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	notification.ID = int64(len(r.notifications) + 1)
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+type fakeNotificationBroker struct {
+	published []*models.Notification
+}
+
+func (b *fakeNotificationBroker) Publish(ctx context.Context, notification *models.Notification) error {
+	b.published = append(b.published, notification)
+	return nil
+}
+
+func (b *fakeNotificationBroker) Subscribe(ctx context.Context) (<-chan *models.Notification, error) {
+	return make(chan *models.Notification), nil
+}
+
+type fakeApplicationRepository struct {
+	interfaces.ApplicationRepository
+	applications []*models.Application
+	transitions  []*models.ApplicationTransition
+}
+
+func (r *fakeApplicationRepository) find(jobID int64, candidateID uuid.UUID) int {
+	for i, application := range r.applications {
+		if application.JobID == jobID && application.CandidateID == candidateID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fakeApplicationRepository) GetApplication(ctx context.Context, applicationID int64) (*models.Application, error) {
+	for _, application := range r.applications {
+		if application.ID == applicationID {
+			copied := *application
+			return &copied, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeApplicationRepository) GetApplicationByJobAndCandidate(ctx context.Context, jobID int64, candidateID uuid.UUID) (*models.Application, error) {
+	i := r.find(jobID, candidateID)
+	if i < 0 {
+		return nil, sql.ErrNoRows
+	}
+	copied := *r.applications[i]
+	return &copied, nil
+}
+
+func (r *fakeApplicationRepository) CreateApplication(ctx context.Context, application *models.Application) error {
+	if r.find(application.JobID, application.CandidateID) >= 0 {
+		return interfaces.ErrAlreadyApplied
+	}
+	application.ID = int64(len(r.applications) + 1)
+	copied := *application
+	r.applications = append(r.applications, &copied)
+	return nil
+}
+
+func (r *fakeApplicationRepository) TransitionApplication(ctx context.Context, transition *models.ApplicationTransition, status string) error {
+	for _, application := range r.applications {
+		if application.ID == transition.ApplicationID && application.Stage == transition.FromStage {
+			application.Stage, application.Status = transition.ToStage, status
+			r.transitions = append(r.transitions, transition)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *fakeApplicationRepository) DeleteApplication(ctx context.Context, jobID int64, candidateID uuid.UUID, stage string) error {
+	i := r.find(jobID, candidateID)
+	if i < 0 || r.applications[i].Stage != stage {
+		return sql.ErrNoRows
+	}
+	r.applications = append(r.applications[:i], r.applications[i+1:]...)
+	return nil
+}
+
+type fakePipelineStageRepository struct {
+	interfaces.PipelineStageRepository
+	stages     []models.PipelineStage
+	usedStages []string
+}
+
+func (r *fakePipelineStageRepository) GetStages(ctx context.Context, recruiterID uuid.UUID) ([]models.PipelineStage, error) {
+	return r.stages, nil
+}
+
+func (r *fakePipelineStageRepository) ReplaceStages(ctx context.Context, recruiterID uuid.UUID, stages []models.PipelineStage) error {
+	for _, used := range r.usedStages {
+		kept := false
+		for _, stage := range stages {
+			kept = kept || stage.Name == used
+		}
+		if !kept {
+			return interfaces.ErrStageInUse
+		}
+	}
+	r.stages = stages
+	return nil
+}
+
+type fakeSavedSearchRepository struct {
+	interfaces.SavedSearchRepository
+	searches []*models.SavedSearch
+	claimed  bool
+}
+
+func (r *fakeSavedSearchRepository) CountSavedSearches(ctx context.Context, candidateID uuid.UUID) (int, error) {
+	return len(r.searches), nil
+}
+
+func (r *fakeSavedSearchRepository) ClaimDueSavedSearches(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.SavedSearch, error) {
+	if r.claimed {
+		return nil, nil
+	}
+	r.claimed = true
+	return r.searches, nil
+}
+
+func (r *fakeSavedSearchRepository) MarkSavedSearchRun(ctx context.Context, searchID int64, ranAt, nextRunAt time.Time) error {
+	for _, search := range r.searches {
+		if search.ID == searchID {
+			search.LastRunAt, search.NextRunAt = &ranAt, nextRunAt
+		}
+	}
+	return nil
+}
+
+type fakeInterviewRepository struct {
+	interfaces.InterviewRepository
+	feeds map[uuid.UUID]*models.CalendarFeed
+}
+
+func (r *fakeInterviewRepository) SaveCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error {
+	copied := *feed
+	r.feeds[feed.UserID] = &copied
+	return nil
+}
+
+func (r *fakeInterviewRepository) GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	for _, feed := range r.feeds {
+		if feed.TokenHash == tokenHash {
+			copied := *feed
+			return &copied, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeInterviewRepository) GetUpcomingInterviews(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.Interview, error) {
+	return nil, nil
+}
+
+type fakeCandidateRepository struct {
+	interfaces.CandidateRepository
+	candidates map[uuid.UUID]*models.Candidate
+}
+
+func (r *fakeCandidateRepository) GetCandidate(ctx context.Context, candidateID uuid.UUID) (*models.Candidate, error) {
+	candidate, ok := r.candidates[candidateID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *candidate
+	return &copied, nil
+}
+
+type fakeScreeningQuestionRepository struct {
+	interfaces.ScreeningQuestionRepository
+	questions map[int64][]models.ScreeningQuestion
+}
+
+func (r *fakeScreeningQuestionRepository) GetQuestions(ctx context.Context, jobID int64) ([]models.ScreeningQuestion, error) {
+	return r.questions[jobID], nil
+}
+
+// fakeNotificationService records the notifications instead of delivering them
+type fakeNotificationService struct {
+	serviceInterfaces.NotificationService
+	notified []*models.Notification
+}
+
+func (s *fakeNotificationService) Notify(ctx context.Context, notification *models.Notification) {
+	s.notified = append(s.notified, notification)
+}
+
+type fakeRecommendationRepository struct {
+	interfaces.RecommendationRepository
+	profile   *models.CandidateProfile
+	jobs      []*models.Job
+	jobSkills map[int64][]models.Skill
+}
+
+func (r *fakeRecommendationRepository) GetCandidateProfile(ctx context.Context, candidateID uuid.UUID) (*models.CandidateProfile, error) {
+	if r.profile == nil {
+		return nil, sql.ErrNoRows
+	}
+	return r.profile, nil
+}
+
+func (r *fakeRecommendationRepository) GetOpenJobsForCandidate(ctx context.Context, candidateID uuid.UUID, limit int) ([]*models.Job, error) {
+	return r.jobs, nil
+}
+
+func (r *fakeRecommendationRepository) GetJobSkills(ctx context.Context, jobIDs []int64) (map[int64][]models.Skill, error) {
+	return r.jobSkills, nil
+}
+
+type fakeJobCategoryRepository struct {
+	interfaces.JobCategoryRepository
+	categories []*models.JobCategory
+}
+
+func (r *fakeJobCategoryRepository) GetCategories(ctx context.Context) ([]*models.JobCategory, error) {
+	categories := make([]*models.JobCategory, 0, len(r.categories))
+	for _, category := range r.categories {
+		copied := *category
+		categories = append(categories, &copied)
+	}
+	return categories, nil
+}
+
+func (r *fakeJobCategoryRepository) CreateCategory(ctx context.Context, category *models.JobCategory) error {
+	for _, existing := range r.categories {
+		if existing.Slug == category.Slug {
+			return interfaces.ErrCategoryExists
+		}
+	}
+	category.ID = int64(len(r.categories) + 1)
+	r.categories = append(r.categories, category)
+	return nil
+}
+
+func (r *fakeJobCategoryRepository) UpdateCategory(ctx context.Context, category *models.JobCategory) error {
+	for i, existing := range r.categories {
+		if existing.ID == category.ID {
+			r.categories[i] = category
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+type fakeMessageRepository struct {
+	interfaces.MessageRepository
+	messages []*models.Message
+	reports  []*models.MessageReport
+}
+
+func (r *fakeMessageRepository) CreateMessage(ctx context.Context, message *models.Message) error {
+	message.ID = int64(len(r.messages) + 1)
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *fakeMessageRepository) GetMessage(ctx context.Context, messageID int64) (*models.Message, error) {
+	for _, message := range r.messages {
+		if message.ID == messageID {
+			copied := *message
+			return &copied, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeMessageRepository) CreateReport(ctx context.Context, report *models.MessageReport) error {
+	for _, existing := range r.reports {
+		if existing.MessageID == report.MessageID && existing.ReportedBy == report.ReportedBy {
+			return interfaces.ErrMessageReported
+		}
+	}
+	report.ID = int64(len(r.reports) + 1)
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *fakeMessageRepository) GetReport(ctx context.Context, reportID int64) (*models.MessageReport, error) {
+	for _, report := range r.reports {
+		if report.ID == reportID {
+			copied := *report
+			return &copied, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// ReviewReport only reviews open reports, like the conditional update of the
+// repository
+func (r *fakeMessageRepository) ReviewReport(ctx context.Context, report *models.MessageReport) error {
+	for i, existing := range r.reports {
+		if existing.ID == report.ID && existing.Status == "open" {
+			r.reports[i] = report
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type ApplicationService interface {
+	Apply(ctx context.Context, candidateID uuid.UUID, jobID int64, req request.ApplyToJobRequest) (*models.Application, error)
+	GetCandidateApplication(ctx context.Context, candidateID uuid.UUID, jobID int64) (*models.Application, error)
+	GetCandidateApplications(ctx context.Context, candidateID uuid.UUID, page request.PageRequest) ([]*models.Application, *models.PageInfo, error)
+	WithdrawApplication(ctx context.Context, candidateID uuid.UUID, jobID int64) error
+	GetJobApplications(ctx context.Context, recruiterID uuid.UUID, jobID int64, filters request.JobApplicationFilters) ([]*models.Application, *models.PageInfo, error)
+	GetJobApplication(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) (*models.Application, error)
+}
@@ -4,16 +4,22 @@ import (
     "context"
     "dz-jobs-api/internal/dto/request"
     "dz-jobs-api/internal/models"
+
+    "github.com/google/uuid"
 )
 
 type AuthService interface {
     Register(ctx context.Context, user request.CreateUsersRequest) (*models.User, error)
-    Login(ctx context.Context, req request.LoginRequest) (*models.User, string, string, error)
-    Logout(ctx context.Context, userID, refreshToken string) error
-    RefreshAccessToken(ctx context.Context, email, role, refreshToken string) (string, error)
-    SendOTP(ctx context.Context, email string) error
-    VerifyOTP(ctx context.Context, email, otp string) (string, error)
+    Login(ctx context.Context, req request.LoginRequest, userAgent, ip string) (*models.User, string, string, string, error)
+    VerifyTwoFactor(ctx context.Context, twoFactorToken, code, userAgent, ip string) (*models.User, string, string, error)
+    Logout(ctx context.Context, refreshToken string) error
+    RefreshAccessToken(ctx context.Context, refreshToken, userAgent, ip string) (string, string, error)
+    GetSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
+    RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+    VerifyEmail(ctx context.Context, email, otp, ip string) error
+    ResendVerificationEmail(ctx context.Context, email string) error
+    SendOTP(ctx context.Context, email, ip string) error
+    VerifyOTP(ctx context.Context, email, otp, ip string) (string, error)
     ResetPassword(ctx context.Context, email, resetToken, newPassword string) error
-    ValidateToken(ctx context.Context, token string) (string, string, error)
-    GoogleConnect(ctx context.Context, code string, role string) (*models.User, string, string, string, error)
+    GoogleConnect(ctx context.Context, code string, role string, userAgent, ip string) (*models.User, string, string, string, string, error)
 }
\ No newline at end of file
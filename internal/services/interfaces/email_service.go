@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailService interface {
+	Send(ctx context.Context, userID uuid.UUID, emailType string, render func(unsubscribeURL string) (*models.Email, error)) error
+	SendQueuedEmails(ctx context.Context, now time.Time) error
+	Queued() <-chan struct{}
+}
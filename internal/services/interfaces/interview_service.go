@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InterviewService interface {
+	ProposeInterview(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64, req request.InterviewProposalRequest) (*models.Interview, error)
+	GetApplicationInterviews(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) ([]*models.Interview, error)
+	GetUpcomingInterviews(ctx context.Context, userID uuid.UUID) ([]*models.Interview, error)
+	GetInterview(ctx context.Context, userID uuid.UUID, interviewID int64) (*models.Interview, error)
+	SelectSlot(ctx context.Context, candidateID uuid.UUID, interviewID int64, req request.SelectInterviewSlotRequest) (*models.Interview, error)
+	RescheduleInterview(ctx context.Context, recruiterID uuid.UUID, interviewID int64, req request.InterviewProposalRequest) (*models.Interview, error)
+	CancelInterview(ctx context.Context, userID uuid.UUID, interviewID int64, req request.CancelInterviewRequest) (*models.Interview, error)
+	CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (string, error)
+	GetCalendarFeed(ctx context.Context, token string) ([]byte, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+)
+
+type JobCategoryService interface {
+	GetCategoryTree(ctx context.Context) ([]*models.JobCategory, error)
+	CreateCategory(ctx context.Context, req request.JobCategoryRequest) (*models.JobCategory, error)
+	UpdateCategory(ctx context.Context, categoryID int64, req request.JobCategoryRequest) (*models.JobCategory, error)
+	DeleteCategory(ctx context.Context, categoryID int64) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/pkg/utils"
+)
+
+type JobFeedService interface {
+	GetJobFeed(ctx context.Context, format string, filters request.JobFilters) (*utils.FeedCache, error)
+	GetSitemap(ctx context.Context) (*utils.FeedCache, error)
+}
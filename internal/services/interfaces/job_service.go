@@ -4,19 +4,29 @@ import (
     "context"
     "dz-jobs-api/internal/dto/request"
     "dz-jobs-api/internal/models"
+    "mime/multipart"
+    "time"
 
     "github.com/google/uuid"
 )
 
 type JobService interface {
     PostNewJob(ctx context.Context, recruiterID uuid.UUID, req request.PostNewJobRequest) (*models.Job, error)
+    ImportJobs(ctx context.Context, recruiterID uuid.UUID, file *multipart.FileHeader, format string, dryRun bool) ([]models.JobImportResult, error)
+    ExportJobs(ctx context.Context, recruiterID uuid.UUID, status string, write func(job *models.Job) error) error
     GetJobDetails(ctx context.Context, jobID int64, recruiterID uuid.UUID) (*models.Job, error)
-    GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID) ([]*models.Job, error)
+    GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID, page request.PageRequest) ([]*models.Job, *models.PageInfo, error)
     EditJob(ctx context.Context, jobID int64, req request.EditJobRequest, recruiterID uuid.UUID) (*models.Job, error)
     DeactivateJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) (*models.Job, error)
-    RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) (*models.Job, error)
+    RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, req request.RepostJobRequest) (*models.Job, error)
     DeleteJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) error
-    GetAllJobs(ctx context.Context) ([]*models.Job, error)
-    SearchJobs(ctx context.Context, filters request.JobFilters) ([]*models.Job, error)
+    GetAllJobs(ctx context.Context, page request.PageRequest) ([]*models.Job, *models.PageInfo, error)
+    SearchJobs(ctx context.Context, filters request.JobFilters) ([]*models.Job, *models.PageInfo, *models.JobFacets, error)
     GetJobDetailsPublic(ctx context.Context, jobID int64) (*models.Job, error)
+    GetJobPosting(ctx context.Context, jobID int64) (*models.JobPosting, error)
+    RunJobLifecycle(ctx context.Context, now time.Time) error
+    GetJobRevisions(ctx context.Context, jobID int64, recruiterID uuid.UUID) ([]*models.JobRevision, error)
+    GetJobRevision(ctx context.Context, jobID int64, revision int, recruiterID uuid.UUID) (*models.JobRevision, error)
+    DiffJobRevisions(ctx context.Context, jobID int64, query request.JobRevisionDiffQuery, recruiterID uuid.UUID) ([]models.JobFieldChange, error)
+    RestoreJobRevision(ctx context.Context, jobID int64, revision int, recruiterID uuid.UUID) (*models.Job, error)
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+)
+
+type LocationService interface {
+	GetWilayas(ctx context.Context) []models.Wilaya
+	GetWilaya(ctx context.Context, query string) (*models.Wilaya, error)
+	SearchLocations(ctx context.Context, query string) (*models.Wilaya, *models.Commune, error)
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"mime/multipart"
+
+	"github.com/google/uuid"
+)
+
+type MessageService interface {
+	GetConversations(ctx context.Context, userID uuid.UUID, page request.PageRequest) ([]*models.Conversation, *models.PageInfo, int, error)
+	GetMessages(ctx context.Context, thread request.MessageThread, page request.PageRequest) ([]*models.Message, *models.PageInfo, error)
+	SendMessage(ctx context.Context, thread request.MessageThread, req request.SendMessageRequest, files []*multipart.FileHeader) (*models.Message, error)
+	MarkMessagesRead(ctx context.Context, thread request.MessageThread) (int64, error)
+	ReportMessage(ctx context.Context, thread request.MessageThread, messageID int64, req request.ReportMessageRequest) (*models.MessageReport, error)
+	GetMessageReports(ctx context.Context, filters request.MessageReportFilters) ([]*models.MessageReport, *models.PageInfo, error)
+	ReviewMessageReport(ctx context.Context, adminID uuid.UUID, reportID int64, req request.ReviewMessageReportRequest) (*models.MessageReport, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type NotificationPreferenceService interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req request.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error)
+	DescribeUnsubscribe(ctx context.Context, token string) (string, error)
+	Unsubscribe(ctx context.Context, token string) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	Notify(ctx context.Context, notification *models.Notification)
+	GetNotifications(ctx context.Context, userID uuid.UUID, filters request.NotificationFilters) ([]*models.Notification, *models.PageInfo, int, error)
+	MarkNotificationRead(ctx context.Context, userID uuid.UUID, notificationID int64) (*models.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetMissedNotifications(ctx context.Context, userID uuid.UUID, lastID int64) ([]*models.Notification, error)
+	Subscribe(userID uuid.UUID) (<-chan *models.Notification, func())
+	Listen(ctx context.Context)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type PipelineService interface {
+	GetStages(ctx context.Context, recruiterID uuid.UUID) ([]models.PipelineStage, error)
+	UpdateStages(ctx context.Context, recruiterID uuid.UUID, req request.UpdatePipelineStagesRequest) ([]models.PipelineStage, error)
+	MoveApplication(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64, req request.MoveApplicationRequest) (*models.Application, error)
+	GetApplicationTransitions(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) ([]*models.ApplicationTransition, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type RecommendationService interface {
+	GetJobRecommendations(ctx context.Context, candidateID uuid.UUID, query request.RecommendationQuery) ([]*models.JobRecommendation, error)
+	GetJobCandidateMatches(ctx context.Context, recruiterID uuid.UUID, jobID int64, query request.RecommendationQuery) ([]*models.CandidateMatch, error)
+}
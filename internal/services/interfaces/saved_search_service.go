@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavedSearchService interface {
+	CreateSavedSearch(ctx context.Context, candidateID uuid.UUID, req request.SavedSearchRequest) (*models.SavedSearch, error)
+	GetSavedSearches(ctx context.Context, candidateID uuid.UUID) ([]*models.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, candidateID uuid.UUID, searchID int64, req request.SavedSearchRequest) (*models.SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, candidateID uuid.UUID, searchID int64) error
+	Unsubscribe(ctx context.Context, token string) error
+	RunDueSearches(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type ScreeningQuestionService interface {
+	GetJobQuestions(ctx context.Context, jobID int64) ([]models.ScreeningQuestion, error)
+	GetRecruiterJobQuestions(ctx context.Context, recruiterID uuid.UUID, jobID int64) ([]models.ScreeningQuestion, error)
+	UpdateJobQuestions(ctx context.Context, recruiterID uuid.UUID, jobID int64, req request.UpdateScreeningQuestionsRequest) ([]models.ScreeningQuestion, error)
+}
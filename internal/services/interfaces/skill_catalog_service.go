@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+)
+
+type SkillCatalogService interface {
+	SearchSkills(ctx context.Context, query request.SkillSearchQuery) ([]*models.Skill, error)
+	CreateSkill(ctx context.Context, req request.SkillCatalogRequest) (*models.Skill, error)
+	UpdateSkill(ctx context.Context, skillID int64, req request.SkillCatalogRequest) (*models.Skill, error)
+	MergeSkills(ctx context.Context, targetID int64, req request.MergeSkillsRequest) (*models.Skill, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"dz-jobs-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type TwoFactorService interface {
+	GetTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactor, int, error)
+	SetupTwoFactor(ctx context.Context, userID uuid.UUID) (string, string, error)
+	ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID uuid.UUID, password, code, ip string) error
+}
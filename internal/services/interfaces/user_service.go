@@ -9,9 +9,9 @@ import (
 )
 
 type UserService interface {
-    CreateUser(ctx context.Context, req request.CreateUsersRequest) (*models.User, error)
+    CreateUser(ctx context.Context, req request.AdminCreateUserRequest) (*models.User, error)
     UpdateUser(ctx context.Context, userID uuid.UUID, req request.UpdateUserRequest) (*models.User, error)
     GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error)
-    GetAllUsers(ctx context.Context) ([]*models.User, error)
+    GetAllUsers(ctx context.Context, page request.PageRequest) ([]*models.User, *models.PageInfo, error)
     DeleteUser(ctx context.Context, userID uuid.UUID) error
 }
\ No newline at end of file
@@ -0,0 +1,430 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/integrations"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type InterviewService struct {
+	interviewRepository   interfaces.InterviewRepository
+	applicationRepository interfaces.ApplicationRepository
+	jobRepository         interfaces.JobRepository
+	notificationService   serviceInterfaces.NotificationService
+	emailService          serviceInterfaces.EmailService
+	config                *config.AppConfig
+}
+
+func NewInterviewService(interviewRepo interfaces.InterviewRepository, applicationRepo interfaces.ApplicationRepository, jobRepo interfaces.JobRepository, notificationService serviceInterfaces.NotificationService, emailService serviceInterfaces.EmailService, cfg *config.AppConfig) *InterviewService {
+	return &InterviewService{
+		interviewRepository:   interviewRepo,
+		applicationRepository: applicationRepo,
+		jobRepository:         jobRepo,
+		notificationService:   notificationService,
+		emailService:          emailService,
+		config:                cfg,
+	}
+}
+
+// ProposeInterview creates an interview for an application with the slots the
+// candidate can choose from, and lets the candidate know by email
+func (s *InterviewService) ProposeInterview(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64, req request.InterviewProposalRequest) (*models.Interview, error) {
+	if err := s.checkApplication(ctx, recruiterID, jobID, applicationID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	interview := &models.Interview{
+		ApplicationID: applicationID,
+		Status:        "proposed",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := applyInterviewProposal(interview, req, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.interviewRepository.CreateInterview(ctx, interview); err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to create interview")
+	}
+
+	interview, err := s.getInterview(ctx, interview.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.sendInterviewEmail(ctx, interview, interview.CandidateID, interview.CandidateEmail, models.NotificationInterviewProposed, s.interviewNotice(interview,
+		fmt.Sprintf("Dz Jobs: interview for %q", interview.JobTitle),
+		"Choose a time for your interview",
+		fmt.Sprintf("%s would like to interview you. Select one of the proposed times on Dz Jobs.", interview.CompanyName),
+	))
+	s.notificationService.Notify(ctx, interviewNotification(interview, interview.CandidateID, models.NotificationInterviewProposed,
+		fmt.Sprintf("Interview invitation for %q", interview.JobTitle),
+		fmt.Sprintf("%s would like to interview you, select one of the proposed times.", interview.CompanyName),
+	))
+	return interview, nil
+}
+
+func (s *InterviewService) GetApplicationInterviews(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) ([]*models.Interview, error) {
+	if err := s.checkApplication(ctx, recruiterID, jobID, applicationID); err != nil {
+		return nil, err
+	}
+	interviews, err := s.interviewRepository.GetApplicationInterviews(ctx, applicationID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch interviews")
+	}
+	return interviews, nil
+}
+
+// GetUpcomingInterviews returns the interviews of a user, as a candidate or a
+// recruiter, that are still to come or waiting for the candidate to pick a slot
+func (s *InterviewService) GetUpcomingInterviews(ctx context.Context, userID uuid.UUID) ([]*models.Interview, error) {
+	interviews, err := s.interviewRepository.GetUpcomingInterviews(ctx, userID, time.Now())
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch interviews")
+	}
+	return interviews, nil
+}
+
+func (s *InterviewService) GetInterview(ctx context.Context, userID uuid.UUID, interviewID int64) (*models.Interview, error) {
+	return s.getParticipantInterview(ctx, userID, interviewID)
+}
+
+// SelectSlot schedules an interview at the slot the candidate picked and sends the
+// calendar invite to both participants
+func (s *InterviewService) SelectSlot(ctx context.Context, candidateID uuid.UUID, interviewID int64, req request.SelectInterviewSlotRequest) (*models.Interview, error) {
+	interview, err := s.getParticipantInterview(ctx, candidateID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.CandidateID != candidateID {
+		return nil, utils.NewCustomError(http.StatusForbidden, "Only the candidate can select a slot")
+	}
+	if interview.Status != "proposed" {
+		return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("The interview is %s, no slot can be selected", interview.Status))
+	}
+
+	now := time.Now()
+	var slot *models.InterviewSlot
+	for i := range interview.Slots {
+		if interview.Slots[i].ID == req.SlotID {
+			slot = &interview.Slots[i]
+		}
+	}
+	if slot == nil {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "The slot is not one of the proposed slots")
+	}
+	if !slot.StartsAt.After(now) {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "The slot has already passed")
+	}
+
+	startsAt := slot.StartsAt
+	interview.Status = "scheduled"
+	interview.ScheduledAt = &startsAt
+	interview.Sequence++
+	interview.UpdatedAt = now
+	if err := s.updateInterview(ctx, interview, "proposed", false); err != nil {
+		return nil, err
+	}
+
+	notice := s.interviewNotice(interview,
+		fmt.Sprintf("Dz Jobs: interview for %q on %s", interview.JobTitle, helpers.FormatInterviewTime(startsAt)),
+		"Your interview is scheduled",
+		fmt.Sprintf("The interview of %s for %s is scheduled. The calendar invite is attached.", interview.CandidateName, interview.JobTitle),
+	)
+	s.attachInvite(&notice, interview, "REQUEST", now)
+	s.sendInterviewEmail(ctx, interview, interview.CandidateID, interview.CandidateEmail, models.NotificationInterviewScheduled, notice)
+	s.sendInterviewEmail(ctx, interview, interview.RecruiterID, interview.RecruiterEmail, models.NotificationInterviewScheduled, notice)
+	s.notificationService.Notify(ctx, interviewNotification(interview, interview.RecruiterID, models.NotificationInterviewScheduled,
+		fmt.Sprintf("Interview scheduled for %q", interview.JobTitle),
+		fmt.Sprintf("%s selected %s.", interview.CandidateName, helpers.FormatInterviewTime(startsAt)),
+	))
+	return interview, nil
+}
+
+// RescheduleInterview replaces the slots and details of an interview, which goes
+// back to waiting for the candidate to pick a slot. The invites of a scheduled
+// interview are cancelled.
+func (s *InterviewService) RescheduleInterview(ctx context.Context, recruiterID uuid.UUID, interviewID int64, req request.InterviewProposalRequest) (*models.Interview, error) {
+	interview, err := s.getParticipantInterview(ctx, recruiterID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.RecruiterID != recruiterID {
+		return nil, utils.NewCustomError(http.StatusForbidden, "Only the recruiter can reschedule an interview")
+	}
+	if interview.Status == "cancelled" {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "The interview is cancelled and cannot be rescheduled")
+	}
+
+	now := time.Now()
+	fromStatus := interview.Status
+	previous := *interview
+	previous.Status = "cancelled"
+	previous.Sequence++
+
+	if err := applyInterviewProposal(interview, req, now); err != nil {
+		return nil, err
+	}
+	interview.Status = "proposed"
+	interview.ScheduledAt = nil
+	interview.Sequence = previous.Sequence
+	interview.UpdatedAt = now
+	if err := s.updateInterview(ctx, interview, fromStatus, true); err != nil {
+		return nil, err
+	}
+
+	notice := s.interviewNotice(interview,
+		fmt.Sprintf("Dz Jobs: interview for %q rescheduled", interview.JobTitle),
+		"Your interview is rescheduled",
+		fmt.Sprintf("%s proposed new times for your interview. Select one of them on Dz Jobs.", interview.CompanyName),
+	)
+	if fromStatus == "scheduled" {
+		previous.UpdatedAt = now
+		s.attachInvite(&notice, &previous, "CANCEL", now)
+		recruiterNotice := s.interviewNotice(&previous,
+			fmt.Sprintf("Dz Jobs: interview for %q rescheduled", interview.JobTitle),
+			"The interview is rescheduled",
+			fmt.Sprintf("%s was asked to select one of the new times, the previous invite is cancelled.", interview.CandidateName),
+		)
+		s.attachInvite(&recruiterNotice, &previous, "CANCEL", now)
+		s.sendInterviewEmail(ctx, interview, interview.RecruiterID, interview.RecruiterEmail, models.NotificationInterviewCancelled, recruiterNotice)
+	}
+	s.sendInterviewEmail(ctx, interview, interview.CandidateID, interview.CandidateEmail, models.NotificationInterviewProposed, notice)
+	s.notificationService.Notify(ctx, interviewNotification(interview, interview.CandidateID, models.NotificationInterviewProposed,
+		fmt.Sprintf("Interview for %q rescheduled", interview.JobTitle),
+		fmt.Sprintf("%s proposed new times for your interview, select one of them.", interview.CompanyName),
+	))
+	return interview, nil
+}
+
+// CancelInterview cancels an interview on behalf of its candidate or recruiter and
+// lets both of them know, withdrawing the invites of a scheduled interview
+func (s *InterviewService) CancelInterview(ctx context.Context, userID uuid.UUID, interviewID int64, req request.CancelInterviewRequest) (*models.Interview, error) {
+	interview, err := s.getParticipantInterview(ctx, userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Status == "cancelled" {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "The interview is already cancelled")
+	}
+
+	now := time.Now()
+	fromStatus := interview.Status
+	interview.Status = "cancelled"
+	interview.CancelReason = strings.TrimSpace(req.Reason)
+	interview.Sequence++
+	interview.UpdatedAt = now
+	if err := s.updateInterview(ctx, interview, fromStatus, false); err != nil {
+		return nil, err
+	}
+
+	cancelledBy, otherParticipant := interview.CompanyName, interview.CandidateID
+	if userID == interview.CandidateID {
+		cancelledBy, otherParticipant = interview.CandidateName, interview.RecruiterID
+	}
+	notice := s.interviewNotice(interview,
+		fmt.Sprintf("Dz Jobs: interview for %q cancelled", interview.JobTitle),
+		"The interview is cancelled",
+		fmt.Sprintf("%s cancelled the interview for %s.", cancelledBy, interview.JobTitle),
+	)
+	if fromStatus == "scheduled" {
+		s.attachInvite(&notice, interview, "CANCEL", now)
+	}
+	s.sendInterviewEmail(ctx, interview, interview.CandidateID, interview.CandidateEmail, models.NotificationInterviewCancelled, notice)
+	s.sendInterviewEmail(ctx, interview, interview.RecruiterID, interview.RecruiterEmail, models.NotificationInterviewCancelled, notice)
+
+	body := fmt.Sprintf("%s cancelled the interview.", cancelledBy)
+	if interview.CancelReason != "" {
+		body += " Reason: " + interview.CancelReason
+	}
+	s.notificationService.Notify(ctx, interviewNotification(interview, otherParticipant, models.NotificationInterviewCancelled,
+		fmt.Sprintf("Interview for %q cancelled", interview.JobTitle), body,
+	))
+	return interview, nil
+}
+
+// CreateCalendarFeed returns a new secret URL of the iCalendar feed of the upcoming
+// interviews of a user, the previous one stops working. Only the digest of the
+// token is stored, the URL cannot be retrieved again.
+func (s *InterviewService) CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (string, error) {
+	token := utils.GenerateOpaqueToken(32)
+	feed := &models.CalendarFeed{
+		UserID:    userID,
+		TokenHash: utils.HashToken(token),
+		CreatedAt: time.Now(),
+	}
+	if err := s.interviewRepository.SaveCalendarFeed(ctx, feed); err != nil {
+		return "", utils.NewCustomError(http.StatusInternalServerError, "Failed to create calendar feed")
+	}
+	return fmt.Sprintf("https://%s/v1/calendar/%s.ics", s.config.BackEndDomain, token), nil
+}
+
+// GetCalendarFeed renders the scheduled upcoming interviews of the user owning the
+// feed token as an iCalendar document
+func (s *InterviewService) GetCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.interviewRepository.GetCalendarFeedByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Calendar feed not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch calendar feed")
+	}
+
+	now := time.Now()
+	interviews, err := s.interviewRepository.GetUpcomingInterviews(ctx, feed.UserID, now)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch interviews")
+	}
+	var events []helpers.CalendarEvent
+	for _, interview := range interviews {
+		if interview.Status == "scheduled" {
+			events = append(events, helpers.InterviewCalendarEvent(interview, s.config.BackEndDomain))
+		}
+	}
+	return helpers.RenderCalendar("Dz Jobs interviews", "", events, now), nil
+}
+
+// checkApplication makes sure the application was made to a job of the recruiter
+func (s *InterviewService) checkApplication(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) error {
+	if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+	application, err := s.applicationRepository.GetApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusNotFound, "Application not found")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Error fetching application")
+	}
+	if application.JobID != jobID {
+		return utils.NewCustomError(http.StatusNotFound, "Application not found")
+	}
+	return nil
+}
+
+func (s *InterviewService) getInterview(ctx context.Context, interviewID int64) (*models.Interview, error) {
+	interview, err := s.interviewRepository.GetInterview(ctx, interviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Interview not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching interview")
+	}
+	return interview, nil
+}
+
+// getParticipantInterview returns an interview the user is the candidate or the
+// recruiter of, other users are told it does not exist
+func (s *InterviewService) getParticipantInterview(ctx context.Context, userID uuid.UUID, interviewID int64) (*models.Interview, error) {
+	interview, err := s.getInterview(ctx, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.CandidateID != userID && interview.RecruiterID != userID {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Interview not found")
+	}
+	return interview, nil
+}
+
+func (s *InterviewService) updateInterview(ctx context.Context, interview *models.Interview, fromStatus string, replaceSlots bool) error {
+	if err := s.interviewRepository.UpdateInterview(ctx, interview, fromStatus, replaceSlots); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusConflict, "The interview was changed by someone else, please retry")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to update interview")
+	}
+	return nil
+}
+
+func (s *InterviewService) interviewNotice(interview *models.Interview, subject, title, message string) integrations.InterviewNotice {
+	notice := integrations.InterviewNotice{
+		Subject:         subject,
+		Title:           title,
+		Message:         message,
+		JobTitle:        interview.JobTitle,
+		CompanyName:     interview.CompanyName,
+		DurationMinutes: interview.DurationMinutes,
+		Location:        interview.Location,
+		VideoLink:       interview.VideoLink,
+		Notes:           interview.Notes,
+		CancelReason:    interview.CancelReason,
+	}
+	switch {
+	case interview.ScheduledAt != nil:
+		notice.When = helpers.FormatInterviewTime(*interview.ScheduledAt)
+	case interview.Status == "proposed":
+		for _, slot := range interview.Slots {
+			notice.Slots = append(notice.Slots, helpers.FormatInterviewTime(slot.StartsAt))
+		}
+	}
+	return notice
+}
+
+func (s *InterviewService) attachInvite(notice *integrations.InterviewNotice, interview *models.Interview, method string, now time.Time) {
+	event := helpers.InterviewCalendarEvent(interview, s.config.BackEndDomain)
+	notice.Calendar = helpers.RenderCalendar("", method, []helpers.CalendarEvent{event}, now)
+	notice.CalendarMethod = method
+}
+
+// sendInterviewEmail sends an interview email to a participant as their
+// notification preferences allow, the interview is already saved so a failure is
+// logged rather than reported to the client
+func (s *InterviewService) sendInterviewEmail(ctx context.Context, interview *models.Interview, userID uuid.UUID, email, emailType string, notice integrations.InterviewNotice) {
+	err := s.emailService.Send(ctx, userID, emailType, func(unsubscribeURL string) (*models.Email, error) {
+		notice.UnsubscribeURL = unsubscribeURL
+		return integrations.RenderInterviewEmail(email, notice)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"interview_id": interview.ID, "error": err}).Error("Failed to send interview email")
+	}
+}
+
+// applyInterviewProposal sets the slots and details of an interview from a
+// proposal. Slots must be in the future and are kept in chronological order.
+func applyInterviewProposal(interview *models.Interview, req request.InterviewProposalRequest, now time.Time) error {
+	location, videoLink := strings.TrimSpace(req.Location), strings.TrimSpace(req.VideoLink)
+	if location == "" && videoLink == "" {
+		return utils.NewCustomError(http.StatusBadRequest, "An interview needs a location or a video link")
+	}
+
+	starts := make([]time.Time, 0, len(req.Slots))
+	seen := map[time.Time]bool{}
+	for _, start := range req.Slots {
+		start = start.UTC().Truncate(time.Minute)
+		if !start.After(now) {
+			return utils.NewCustomError(http.StatusBadRequest, "Interview slots must be in the future")
+		}
+		if seen[start] {
+			return utils.NewCustomError(http.StatusBadRequest, "Interview slots must be distinct")
+		}
+		seen[start] = true
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	interview.Slots = make([]models.InterviewSlot, 0, len(starts))
+	for _, start := range starts {
+		interview.Slots = append(interview.Slots, models.InterviewSlot{InterviewID: interview.ID, StartsAt: start})
+	}
+	interview.DurationMinutes = req.DurationMinutes
+	interview.Location = location
+	interview.VideoLink = videoLink
+	interview.Notes = strings.TrimSpace(req.Notes)
+	return nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/models"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCalendarFeed(t *testing.T) {
+	ctx := context.Background()
+	repository := &fakeInterviewRepository{feeds: map[uuid.UUID]*models.CalendarFeed{}}
+	service := &InterviewService{interviewRepository: repository, config: &config.AppConfig{BackEndDomain: "api.dzjobs.example"}}
+	userID := uuid.New()
+
+	first, err := service.CreateCalendarFeed(ctx, userID)
+	require.NoError(t, err)
+	firstToken := strings.TrimSuffix(path.Base(first), ".ics")
+	assert.NotEqual(t, firstToken, repository.feeds[userID].TokenHash, "the token itself is not stored")
+
+	_, err = service.GetCalendarFeed(ctx, firstToken)
+	assert.NoError(t, err)
+
+	second, err := service.CreateCalendarFeed(ctx, userID)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	_, err = service.GetCalendarFeed(ctx, firstToken)
+	assert.Equal(t, http.StatusNotFound, statusOf(err), "the previous URL stops working")
+	_, err = service.GetCalendarFeed(ctx, strings.TrimSuffix(path.Base(second), ".ics"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type JobCategoryService struct {
+	categoryRepo interfaces.JobCategoryRepository
+}
+
+func NewJobCategoryService(categoryRepo interfaces.JobCategoryRepository) *JobCategoryService {
+	return &JobCategoryService{categoryRepo: categoryRepo}
+}
+
+// GetCategoryTree returns the top level categories with their subcategories
+func (s *JobCategoryService) GetCategoryTree(ctx context.Context) ([]*models.JobCategory, error) {
+	tree, err := s.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Roots, nil
+}
+
+func (s *JobCategoryService) CreateCategory(ctx context.Context, req request.JobCategoryRequest) (*models.JobCategory, error) {
+	category, err := newJobCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	if category.ParentID != nil {
+		tree, err := s.categoryTree(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if tree.Get(*category.ParentID) == nil {
+			return nil, utils.NewCustomError(http.StatusBadRequest, "Parent category not found")
+		}
+	}
+	category.CreatedAt = time.Now()
+
+	if err := s.categoryRepo.CreateCategory(ctx, category); err != nil {
+		if errors.Is(err, interfaces.ErrCategoryExists) {
+			return nil, utils.NewCustomError(http.StatusConflict, "A category with this name already exists")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to create category")
+	}
+	return category, nil
+}
+
+// UpdateCategory renames a category and moves it under another parent, which
+// cannot be the category itself or one of its subcategories
+func (s *JobCategoryService) UpdateCategory(ctx context.Context, categoryID int64, req request.JobCategoryRequest) (*models.JobCategory, error) {
+	category, err := newJobCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	category.ID = categoryID
+
+	tree, err := s.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current := tree.Get(categoryID)
+	if current == nil {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Category not found")
+	}
+	if category.ParentID != nil {
+		if tree.Get(*category.ParentID) == nil {
+			return nil, utils.NewCustomError(http.StatusBadRequest, "Parent category not found")
+		}
+		if tree.IsWithin(*category.ParentID, categoryID) {
+			return nil, utils.NewCustomError(http.StatusBadRequest, "A category cannot be moved under itself or one of its subcategories")
+		}
+	}
+	category.CreatedAt = current.CreatedAt
+
+	if err := s.categoryRepo.UpdateCategory(ctx, category); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Category not found")
+		}
+		if errors.Is(err, interfaces.ErrCategoryExists) {
+			return nil, utils.NewCustomError(http.StatusConflict, "A category with this name already exists")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update category")
+	}
+	return category, nil
+}
+
+// DeleteCategory deletes a category that has no subcategories left, its jobs are
+// left without a category
+func (s *JobCategoryService) DeleteCategory(ctx context.Context, categoryID int64) error {
+	if err := s.categoryRepo.DeleteCategory(ctx, categoryID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusNotFound, "Category not found")
+		}
+		if errors.Is(err, interfaces.ErrCategoryHasChildren) {
+			return utils.NewCustomError(http.StatusConflict, "A category with subcategories cannot be deleted")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to delete category")
+	}
+	return nil
+}
+
+func (s *JobCategoryService) categoryTree(ctx context.Context) (*helpers.CategoryTree, error) {
+	categories, err := s.categoryRepo.GetCategories(ctx)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch categories")
+	}
+	return helpers.NewCategoryTree(categories), nil
+}
+
+// newJobCategory builds a category from an admin request, its slug is derived
+// from its name
+func newJobCategory(req request.JobCategoryRequest) (*models.JobCategory, error) {
+	category := &models.JobCategory{
+		ParentID: req.ParentID,
+		Name:     strings.Join(strings.Fields(req.Name), " "),
+		Slug:     helpers.CategorySlug(req.Name),
+	}
+	if category.Slug == "" {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "Category name is required")
+	}
+	return category, nil
+}
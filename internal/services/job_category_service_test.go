@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJobCategoryService() (*JobCategoryService, *fakeJobCategoryRepository) {
+	it, backend := int64(1), int64(2)
+	categories := &fakeJobCategoryRepository{categories: []*models.JobCategory{
+		{ID: 1, Name: "IT", Slug: "it"},
+		{ID: 2, ParentID: &it, Name: "Backend", Slug: "backend"},
+		{ID: 3, ParentID: &backend, Name: "Go", Slug: "go"},
+	}}
+	return NewJobCategoryService(categories), categories
+}
+
+func TestCreateCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Subcategory with a slug", func(t *testing.T) {
+		service, categories := newTestJobCategoryService()
+		parentID := int64(1)
+
+		category, err := service.CreateCategory(ctx, request.JobCategoryRequest{Name: " Data   & AI ", ParentID: &parentID})
+		assert.NoError(t, err)
+		assert.Equal(t, "Data & AI", category.Name)
+		assert.Equal(t, "data-ai", category.Slug)
+		assert.Len(t, categories.categories, 4)
+	})
+
+	t.Run("Unknown parent", func(t *testing.T) {
+		service, _ := newTestJobCategoryService()
+		parentID := int64(9)
+
+		_, err := service.CreateCategory(ctx, request.JobCategoryRequest{Name: "Data", ParentID: &parentID})
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+	})
+
+	t.Run("Name already used", func(t *testing.T) {
+		service, _ := newTestJobCategoryService()
+
+		_, err := service.CreateCategory(ctx, request.JobCategoryRequest{Name: "backend"})
+		assert.Equal(t, http.StatusConflict, statusOf(err))
+	})
+}
+
+func TestUpdateCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Moved under another category", func(t *testing.T) {
+		service, categories := newTestJobCategoryService()
+		parentID := int64(1)
+
+		_, err := service.UpdateCategory(ctx, 3, request.JobCategoryRequest{Name: "Golang", ParentID: &parentID})
+		assert.NoError(t, err)
+		assert.Equal(t, "golang", categories.categories[2].Slug)
+		assert.Equal(t, int64(1), *categories.categories[2].ParentID)
+	})
+
+	t.Run("Moved under its own subcategory", func(t *testing.T) {
+		service, _ := newTestJobCategoryService()
+		parentID := int64(3)
+
+		_, err := service.UpdateCategory(ctx, 1, request.JobCategoryRequest{Name: "IT", ParentID: &parentID})
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+	})
+
+	t.Run("Unknown category", func(t *testing.T) {
+		service, _ := newTestJobCategoryService()
+
+		_, err := service.UpdateCategory(ctx, 9, request.JobCategoryRequest{Name: "Data"})
+		assert.Equal(t, http.StatusNotFound, statusOf(err))
+	})
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// jobFeedSize is the number of jobs listed in a feed when no limit is given
+	jobFeedSize = 100
+	// maxSitemapURLs is the number of URLs a single sitemap file can hold
+	maxSitemapURLs = 50000
+	// feedCacheTTL is how long a rendered feed is served before it is rebuilt
+	feedCacheTTL = 15 * time.Minute
+	feedTitle    = "DZ Jobs"
+)
+
+// jobFeedPaths are the paths the feeds are served from, by format
+var jobFeedPaths = map[string]string{
+	"rss":    "/v1/jobs/feed.rss",
+	"atom":   "/v1/jobs/feed.atom",
+	"indeed": "/v1/jobs/feed.xml",
+}
+
+type JobFeedService struct {
+	jobRepository       interfaces.JobRepository
+	recruiterRepository interfaces.RecruiterRepository
+	redisRepository     interfaces.RedisRepository
+	config              *config.AppConfig
+	now                 func() time.Time
+}
+
+func NewJobFeedService(jobRepo interfaces.JobRepository, recruiterRepo interfaces.RecruiterRepository, redisRepo interfaces.RedisRepository, cfg *config.AppConfig) *JobFeedService {
+	return &JobFeedService{
+		jobRepository:       jobRepo,
+		recruiterRepository: recruiterRepo,
+		redisRepository:     redisRepo,
+		config:              cfg,
+		now:                 time.Now,
+	}
+}
+
+// GetJobFeed renders the open jobs matching filters in the given feed format.
+// Feeds are cached by format and filters, and last modified when they were
+// built: a job closing or dropping out of the feed changes it too, although it
+// is no longer in it to tell.
+func (s *JobFeedService) GetJobFeed(ctx context.Context, format string, filters request.JobFilters) (*utils.FeedCache, error) {
+	if _, ok := jobFeedPaths[format]; !ok {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Feed not found")
+	}
+	if filters.SalaryRangeMax > 0 && filters.SalaryRangeMin > filters.SalaryRangeMax {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "min_salary cannot be greater than max_salary")
+	}
+	filters.Status = "open"
+	if filters.Limit == 0 {
+		filters.Limit = jobFeedSize
+	}
+
+	key, err := json.Marshal(filters)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to build job feed")
+	}
+	cacheKey := fmt.Sprintf("%s:%x", format, sha256.Sum256(key))
+
+	return s.cachedFeed(ctx, cacheKey, func() (*utils.FeedCache, error) {
+		jobs, _, err := s.jobRepository.GetJobListings(ctx, filters)
+		if err != nil {
+			return nil, listError(err, "Failed to build job feed")
+		}
+		companies, err := s.companyNames(ctx, jobs)
+		if err != nil {
+			return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to build job feed")
+		}
+
+		feed := helpers.JobFeed{
+			Title:    feedTitle,
+			Link:     fmt.Sprintf("https://%s/jobs", s.config.FrontEndDomain),
+			SelfLink: fmt.Sprintf("https://%s%s", s.config.BackEndDomain, jobFeedPaths[format]),
+			Updated:  s.now().UTC(),
+		}
+		for _, job := range jobs {
+			feed.Items = append(feed.Items, helpers.JobFeedItem{
+				Job:     job,
+				Company: companies[job.RecruiterID],
+				URL:     s.jobURL(job.ID),
+			})
+		}
+
+		body, err := helpers.RenderJobFeed(format, feed)
+		if err != nil {
+			return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to build job feed")
+		}
+		return &utils.FeedCache{Body: string(body), LastModified: feed.Updated}, nil
+	})
+}
+
+// GetSitemap renders the sitemap of the public pages of the open jobs
+func (s *JobFeedService) GetSitemap(ctx context.Context) (*utils.FeedCache, error) {
+	return s.cachedFeed(ctx, "sitemap", func() (*utils.FeedCache, error) {
+		jobs, err := s.jobRepository.GetSitemapJobs(ctx, maxSitemapURLs)
+		if err != nil {
+			return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to build sitemap")
+		}
+
+		urls := make([]helpers.SitemapURL, 0, len(jobs))
+		for _, job := range jobs {
+			urls = append(urls, helpers.SitemapURL{Loc: s.jobURL(job.ID), LastMod: job.UpdatedAt})
+		}
+		body, err := helpers.RenderSitemap(urls)
+		if err != nil {
+			return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to build sitemap")
+		}
+		return &utils.FeedCache{Body: string(body), LastModified: s.now().UTC()}, nil
+	})
+}
+
+// cachedFeed returns the feed cached under key, building and caching it with
+// build when it is missing. The cache is best effort, feeds are still served
+// when Redis is unavailable.
+func (s *JobFeedService) cachedFeed(ctx context.Context, key string, build func() (*utils.FeedCache, error)) (*utils.FeedCache, error) {
+	cached, err := s.redisRepository.GetFeedCache(ctx, key)
+	if err != nil {
+		log.WithFields(log.Fields{"feed": key, "error": err}).Warn("Failed to read feed cache")
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	feed, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redisRepository.StoreFeedCache(ctx, key, feed, feedCacheTTL); err != nil {
+		log.WithFields(log.Fields{"feed": key, "error": err}).Warn("Failed to cache feed")
+	}
+	return feed, nil
+}
+
+// companyNames returns the company name of the recruiters who posted jobs
+func (s *JobFeedService) companyNames(ctx context.Context, jobs []*models.Job) (map[uuid.UUID]string, error) {
+	names := map[uuid.UUID]string{}
+	if len(jobs) == 0 {
+		return names, nil
+	}
+	recruiterIDs := make([]uuid.UUID, 0, len(jobs))
+	for _, job := range jobs {
+		recruiterIDs = append(recruiterIDs, job.RecruiterID)
+	}
+	recruiters, err := s.recruiterRepository.GetRecruiters(ctx, recruiterIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, recruiter := range recruiters {
+		names[id] = recruiter.CompanyName
+	}
+	return names, nil
+}
+
+func (s *JobFeedService) jobURL(jobID int64) string {
+	return fmt.Sprintf("https://%s/jobs/%d", s.config.FrontEndDomain, jobID)
+}
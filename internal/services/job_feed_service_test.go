@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJobFeedService(jobs *fakeJobRepository, redis *fakeRedisRepository, now *time.Time) *JobFeedService {
+	service := NewJobFeedService(jobs, &fakeRecruiterRepository{}, redis, &config.AppConfig{FrontEndDomain: "dzjobs.example", BackEndDomain: "api.dzjobs.example"})
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func TestGetJobFeedLastModified(t *testing.T) {
+	ctx := context.Background()
+	built := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	recruiterID := uuid.New()
+	closing := &models.Job{ID: 1, Title: "Go developer", Status: "open", JobType: "full-time", RecruiterID: recruiterID, UpdatedAt: built.Add(-time.Hour)}
+	staying := &models.Job{ID: 2, Title: "Designer", Status: "open", JobType: "part-time", RecruiterID: recruiterID, UpdatedAt: built.Add(-2 * time.Hour)}
+
+	for _, change := range []string{"Closed job", "Expired job"} {
+		t.Run(change, func(t *testing.T) {
+			now := built
+			jobs := &fakeJobRepository{listings: []*models.Job{closing, staying}}
+			redis := newFakeRedisRepository()
+			service := newTestJobFeedService(jobs, redis, &now)
+
+			before, err := service.GetJobFeed(ctx, "rss", request.JobFilters{})
+			assert.NoError(t, err)
+			assert.Contains(t, before.Body, "Go developer")
+
+			// The job leaves the feed when it is closed or expires, its new update time
+			// goes with it. The feed is rebuilt once its cache expires.
+			now = built.Add(feedCacheTTL + time.Minute)
+			jobs.listings = []*models.Job{staying}
+			redis.feeds = map[string]*utils.FeedCache{}
+
+			after, err := service.GetJobFeed(ctx, "rss", request.JobFilters{})
+			assert.NoError(t, err)
+			assert.NotContains(t, after.Body, "Go developer")
+			assert.True(t, after.LastModified.Truncate(time.Second).After(before.LastModified),
+				"A client holding the feed with the job should get the new one, not a 304")
+		})
+	}
+}
+
+func TestGetJobFeedCached(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	jobs := &fakeJobRepository{listings: []*models.Job{{ID: 1, Title: "Go developer", Status: "open", JobType: "full-time", RecruiterID: uuid.New(), UpdatedAt: now}}}
+	service := newTestJobFeedService(jobs, newFakeRedisRepository(), &now)
+
+	first, err := service.GetJobFeed(ctx, "atom", request.JobFilters{})
+	assert.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	jobs.listings = nil
+	second, err := service.GetJobFeed(ctx, "atom", request.JobFilters{})
+	assert.NoError(t, err)
+	assert.Equal(t, first, second, "The cached feed should be served until it expires")
+}
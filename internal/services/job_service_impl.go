@@ -3,44 +3,187 @@ package services
 import (
     "context" // Add this import
     "database/sql"
+    "dz-jobs-api/config"
     "dz-jobs-api/internal/dto/request"
+    "dz-jobs-api/internal/helpers"
+    "dz-jobs-api/internal/integrations"
     "dz-jobs-api/internal/models"
     "dz-jobs-api/internal/repositories/interfaces"
+    serviceInterfaces "dz-jobs-api/internal/services/interfaces"
     "dz-jobs-api/pkg/utils"
+    "errors"
+    "fmt"
+    "mime/multipart"
     "net/http"
     "time"
 
     "github.com/google/uuid"
+    log "github.com/sirupsen/logrus"
 )
 
+// ExpiryReminderLead is how long before its expiry the recruiter of a job is reminded
+const ExpiryReminderLead = 3 * 24 * time.Hour
+
+// maxJobImportSize is the size limit of a job import file
+const maxJobImportSize = 5 << 20
+
 type JobService struct {
-    jobRepository interfaces.JobRepository
+    jobRepository       interfaces.JobRepository
+    skillCatalogRepo    interfaces.SkillCatalogRepository
+    recruiterRepo       interfaces.RecruiterRepository
+    categoryRepo        interfaces.JobCategoryRepository
+    bookmarksRepo       interfaces.BookmarksRepository
+    userRepository      interfaces.UserRepository
+    notificationService serviceInterfaces.NotificationService
+    emailService        serviceInterfaces.EmailService
+    config              *config.AppConfig
 }
 
-func NewJobService(jobRepo interfaces.JobRepository) *JobService {
-    return &JobService{jobRepository: jobRepo}
+func NewJobService(jobRepo interfaces.JobRepository, skillCatalogRepo interfaces.SkillCatalogRepository, recruiterRepo interfaces.RecruiterRepository, categoryRepo interfaces.JobCategoryRepository, bookmarksRepo interfaces.BookmarksRepository, userRepo interfaces.UserRepository, notificationService serviceInterfaces.NotificationService, emailService serviceInterfaces.EmailService, cfg *config.AppConfig) *JobService {
+    return &JobService{jobRepository: jobRepo, skillCatalogRepo: skillCatalogRepo, recruiterRepo: recruiterRepo, categoryRepo: categoryRepo, bookmarksRepo: bookmarksRepo, userRepository: userRepo, notificationService: notificationService, emailService: emailService, config: cfg}
 }
 
 func (s *JobService) PostNewJob(ctx context.Context, recruiterID uuid.UUID, req request.PostNewJobRequest) (*models.Job, error) {
+    if err := requireVerifiedEmail(ctx, s.userRepository, recruiterID); err != nil {
+        return nil, err
+    }
+    job, err := s.newJob(ctx, recruiterID, req, time.Now())
+    if err != nil {
+        return nil, err
+    }
+    if err := s.createJob(ctx, job); err != nil {
+        return nil, err
+    }
+    return job, nil
+}
+
+// ImportJobs posts the jobs of a CSV or JSON Lines file. Every row is checked like
+// a posted job and the valid rows are posted even when others are not, a dry run
+// only reports the outcome of each row without posting anything.
+func (s *JobService) ImportJobs(ctx context.Context, recruiterID uuid.UUID, file *multipart.FileHeader, format string, dryRun bool) ([]models.JobImportResult, error) {
+    if file == nil {
+        return nil, utils.NewCustomError(http.StatusBadRequest, "Import file is required")
+    }
+    if file.Size > maxJobImportSize {
+        return nil, utils.NewCustomError(http.StatusBadRequest, "The import file cannot be larger than 5 MB")
+    }
+    format, err := helpers.JobImportFormat(format, file.Filename)
+    if err != nil {
+        return nil, utils.NewCustomError(http.StatusBadRequest, err.Error())
+    }
+    if !dryRun {
+        if err := requireVerifiedEmail(ctx, s.userRepository, recruiterID); err != nil {
+            return nil, err
+        }
+    }
+
+    src, err := file.Open()
+    if err != nil {
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to read import file")
+    }
+    defer src.Close()
+
+    rows, err := helpers.ParseJobImport(src, format)
+    if err != nil {
+        return nil, utils.NewCustomError(http.StatusBadRequest, err.Error())
+    }
+
+    now := time.Now()
+    results := make([]models.JobImportResult, 0, len(rows))
+    for _, row := range rows {
+        result := models.JobImportResult{Line: row.Line, Status: "invalid", Errors: row.Errors}
+        if len(row.Errors) > 0 {
+            results = append(results, result)
+            continue
+        }
+
+        job, err := s.newJob(ctx, recruiterID, row.Request, now)
+        if err == nil && !dryRun {
+            result.Status = "failed"
+            err = s.createJob(ctx, job)
+        }
+        var customErr *utils.CustomError
+        switch {
+        case errors.As(err, &customErr):
+            result.Errors = []string{customErr.Message}
+        case dryRun:
+            result.Status = "valid"
+        default:
+            result.Status, result.JobID = "created", job.ID
+        }
+        results = append(results, result)
+    }
+    return results, nil
+}
+
+// ExportJobs calls write with each of the recruiter's jobs having the given
+// status, or all of them when status is empty
+func (s *JobService) ExportJobs(ctx context.Context, recruiterID uuid.UUID, status string, write func(job *models.Job) error) error {
+    if err := s.jobRepository.ExportJobs(ctx, recruiterID, status, write); err != nil {
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to export jobs")
+    }
+    return nil
+}
+
+// newJob builds a job from a post request, applying the same rules to single
+// posts and imports
+func (s *JobService) newJob(ctx context.Context, recruiterID uuid.UUID, req request.PostNewJobRequest, now time.Time) (*models.Job, error) {
+    if err := helpers.ValidateSalary(req.SalaryMin, req.SalaryMax); err != nil {
+        return nil, utils.NewCustomError(http.StatusBadRequest, err.Error())
+    }
+    if req.Currency == "" {
+        req.Currency = "DZD"
+    }
+    if req.Period == "" {
+        req.Period = "monthly"
+    }
+    if req.WorkMode == "" {
+        req.WorkMode = "onsite"
+    }
+
     job := &models.Job{
         Title:          req.Title,
         Description:    req.Description,
         Location:       req.Location,
-        SalaryRange:    req.SalaryRange,
+        SalaryMin:      req.SalaryMin,
+        SalaryMax:      req.SalaryMax,
+        Currency:       req.Currency,
+        Period:         req.Period,
         RequiredSkills: req.RequiredSkills,
         RecruiterID:    recruiterID,
-        CreatedAt:      time.Now(),
-        UpdatedAt:      time.Now(),
+        CreatedAt:      now,
+        UpdatedAt:      now,
         Status:         req.Status,
         JobType:        req.JobType,
+        WilayaCode:     req.WilayaCode,
+        CommuneCode:    req.CommuneCode,
+        WorkMode:       req.WorkMode,
+        CategoryID:     req.CategoryID,
+        PublishAt:      req.PublishAt,
     }
+    if err := helpers.LocateJob(job); err != nil {
+        return nil, locationError(err)
+    }
+    if err := s.checkJobCategory(ctx, job.CategoryID); err != nil {
+        return nil, err
+    }
+    if err := s.scheduleJob(job, req.ExpiresAt, now); err != nil {
+        return nil, err
+    }
+    return job, nil
+}
 
-    err := s.jobRepository.CreateJob(ctx, job) // Pass context
+// createJob creates a job and maps its required skills together, an import never
+// leaves a job behind a failed row
+func (s *JobService) createJob(ctx context.Context, job *models.Job) error {
+    skillIDs, err := s.resolveSkills(ctx, job.RequiredSkills)
     if err != nil {
-        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to create job")
+        return err
     }
-
-    return job, nil
+    if err := s.jobRepository.CreateJob(ctx, job, skillIDs); err != nil {
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to create job")
+    }
+    return nil
 }
 
 func (s *JobService) GetJobDetails(ctx context.Context, jobID int64, recruiterID uuid.UUID) (*models.Job, error) {
@@ -64,12 +207,12 @@ func (s *JobService) GetJobDetails(ctx context.Context, jobID int64, recruiterID
     return job, nil
 }
 
-func (s *JobService) GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID) ([]*models.Job, error) {
-    jobs, err := s.jobRepository.GetJobListingsByStatus(ctx, status, recruiterID) // Pass context
+func (s *JobService) GetJobListingsByStatus(ctx context.Context, status string, recruiterID uuid.UUID, page request.PageRequest) ([]*models.Job, *models.PageInfo, error) {
+    jobs, pageInfo, err := s.jobRepository.GetJobListingsByStatus(ctx, status, recruiterID, page) // Pass context
     if err != nil {
-        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch jobs by status: "+status)
+        return nil, nil, listError(err, "Failed to fetch jobs by status: "+status)
     }
-    return jobs, nil
+    return jobs, pageInfo, nil
 }
 
 func (s *JobService) EditJob(ctx context.Context, jobID int64, req request.EditJobRequest, recruiterID uuid.UUID) (*models.Job, error) {
@@ -90,16 +233,80 @@ func (s *JobService) EditJob(ctx context.Context, jobID int64, req request.EditJ
     }
 
     updatedJob := &models.Job{
-        Title:          req.Title,
-        Description:    req.Description,
-        Location:       req.Location,
-        SalaryRange:    req.SalaryRange,
-        RequiredSkills: req.RequiredSkills,
-        UpdatedAt:      time.Now(),
-        JobType:        req.JobType,
+        Title:                req.Title,
+        Description:          req.Description,
+        Location:             req.Location,
+        SalaryMin:            job.SalaryMin,
+        SalaryMax:            job.SalaryMax,
+        Currency:             job.Currency,
+        Period:               job.Period,
+        SalaryNeedsReview:    job.SalaryNeedsReview,
+        RequiredSkills:       req.RequiredSkills,
+        RecruiterID:          job.RecruiterID,
+        UpdatedAt:            time.Now(),
+        Status:               job.Status,
+        JobType:              req.JobType,
+        WilayaCode:           job.WilayaCode,
+        CommuneCode:          job.CommuneCode,
+        WorkMode:             job.WorkMode,
+        CategoryID:           job.CategoryID,
+        PublishAt:            job.PublishAt,
+        PublishedAt:          job.PublishedAt,
+        ExpiresAt:            job.ExpiresAt,
+        ExpiryReminderSentAt: job.ExpiryReminderSentAt,
+    }
+    // The wilaya and commune are kept unless new ones are sent, or the location
+    // text changes without them, in which case they are looked up from it again
+    if req.WilayaCode != nil || req.CommuneCode != nil || req.Location != job.Location {
+        updatedJob.WilayaCode, updatedJob.CommuneCode = req.WilayaCode, req.CommuneCode
+    }
+    if req.WorkMode != "" {
+        updatedJob.WorkMode = req.WorkMode
+    }
+    if req.CategoryID != nil {
+        if err := s.checkJobCategory(ctx, req.CategoryID); err != nil {
+            return nil, err
+        }
+        updatedJob.CategoryID = req.CategoryID
+    }
+    if err := helpers.LocateJob(updatedJob); err != nil {
+        return nil, locationError(err)
+    }
+    // Salary fields that are not sent keep their current value, sending any of
+    // them clears the review flag left by the salary migration
+    if req.SalaryMin != nil || req.SalaryMax != nil || req.Currency != "" || req.Period != "" {
+        if req.SalaryMin != nil {
+            updatedJob.SalaryMin = req.SalaryMin
+        }
+        if req.SalaryMax != nil {
+            updatedJob.SalaryMax = req.SalaryMax
+        }
+        if req.Currency != "" {
+            updatedJob.Currency = req.Currency
+        }
+        if req.Period != "" {
+            updatedJob.Period = req.Period
+        }
+        updatedJob.SalaryNeedsReview = false
+    }
+    if req.Status != "" {
+        updatedJob.Status = req.Status
+    }
+    if req.PublishAt != nil {
+        updatedJob.PublishAt = req.PublishAt
+    }
+    if err := helpers.ValidateSalary(updatedJob.SalaryMin, updatedJob.SalaryMax); err != nil {
+        return nil, utils.NewCustomError(http.StatusBadRequest, err.Error())
+    }
+    if err := s.scheduleJob(updatedJob, req.ExpiresAt, updatedJob.UpdatedAt); err != nil {
+        return nil, err
+    }
+    skillIDs, err := s.resolveSkills(ctx, updatedJob.RequiredSkills)
+    if err != nil {
+        return nil, err
     }
 
-    if err := s.jobRepository.UpdateJob(ctx, jobID, recruiterID, updatedJob); err != nil { // Pass context
+    if err := s.jobRepository.UpdateJob(ctx, jobID, recruiterID, updatedJob, skillIDs); err != nil { // Pass context
         if err == sql.ErrNoRows {
             return nil, utils.NewCustomError(http.StatusNotFound, "Job not found")
         }
@@ -136,11 +343,17 @@ func (s *JobService) DeactivateJob(ctx context.Context, jobID int64, recruiterID
     return s.jobRepository.GetJobDetails(ctx, jobID, recruiterID) // Pass context
 }
 
-func (s *JobService) RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID) (*models.Job, error) {
+// RepostJob reopens a job as newly published, for the configured lifetime unless
+// the request sets its expiry. A job cannot be reposted again before the repost
+// cooldown has passed since it was last published.
+func (s *JobService) RepostJob(ctx context.Context, jobID int64, recruiterID uuid.UUID, req request.RepostJobRequest) (*models.Job, error) {
     err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID) // Pass context
     if err != nil {
         return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
     }
+    if err := requireVerifiedEmail(ctx, s.userRepository, recruiterID); err != nil {
+        return nil, err
+    }
     job, err := s.jobRepository.GetJobDetails(ctx, jobID, recruiterID) // Pass context
     if err != nil {
         if err == sql.ErrNoRows {
@@ -153,7 +366,25 @@ func (s *JobService) RepostJob(ctx context.Context, jobID int64, recruiterID uui
         return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
     }
 
-    if err := s.jobRepository.RepostJob(ctx, jobID, recruiterID); err != nil { // Pass context
+    if job.Status != "open" && job.Status != "closed" {
+        return nil, utils.NewCustomError(http.StatusConflict, "Only open or closed jobs can be reposted")
+    }
+    now := time.Now()
+    if job.PublishedAt != nil {
+        if wait := job.PublishedAt.Add(s.config.JobRepostCooldown).Sub(now); wait > 0 {
+            return nil, utils.NewCustomError(http.StatusTooManyRequests,
+                fmt.Sprintf("This job can be reposted again in %s", wait.Round(time.Minute)))
+        }
+    }
+    expiresAt := now.Add(s.config.JobDefaultLifetime)
+    if req.ExpiresAt != nil {
+        if !req.ExpiresAt.After(now) {
+            return nil, utils.NewCustomError(http.StatusBadRequest, "expires_at must be in the future")
+        }
+        expiresAt = *req.ExpiresAt
+    }
+
+    if err := s.jobRepository.RepostJob(ctx, jobID, recruiterID, now, expiresAt); err != nil { // Pass context
         if err == sql.ErrNoRows {
             return nil, utils.NewCustomError(http.StatusNotFound, "Job not found")
         }
@@ -190,20 +421,27 @@ func (s *JobService) DeleteJob(ctx context.Context, jobID int64, recruiterID uui
     return nil
 }
 
-func (s *JobService) GetAllJobs(ctx context.Context) ([]*models.Job, error) {
-    jobs, err := s.jobRepository.GetAllJobs(ctx) // Pass context
+func (s *JobService) GetAllJobs(ctx context.Context, page request.PageRequest) ([]*models.Job, *models.PageInfo, error) {
+    jobs, pageInfo, err := s.jobRepository.GetAllJobs(ctx, page) // Pass context
     if err != nil {
-        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch all jobs")
+        return nil, nil, listError(err, "Failed to fetch all jobs")
     }
-    return jobs, nil
+    return jobs, pageInfo, nil
 }
 
-func (s *JobService) SearchJobs(ctx context.Context, filters request.JobFilters) ([]*models.Job, error) {
-    jobs, err := s.jobRepository.GetJobListings(ctx, filters) // Pass context
+// SearchJobs returns a page of the jobs matching filters along with the facet
+// counts of all of them, a category counting the jobs of its subcategories
+func (s *JobService) SearchJobs(ctx context.Context, filters request.JobFilters) ([]*models.Job, *models.PageInfo, *models.JobFacets, error) {
+    jobs, pageInfo, facets, err := s.jobRepository.SearchJobs(ctx, filters)
     if err != nil {
-        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to search jobs")
+        return nil, nil, nil, listError(err, "Failed to search jobs")
     }
-    return jobs, nil
+    categories, err := s.categoryRepo.GetCategories(ctx)
+    if err != nil {
+        return nil, nil, nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to search jobs")
+    }
+    helpers.LabelJobFacets(facets, helpers.NewCategoryTree(categories))
+    return jobs, pageInfo, facets, nil
 }
 
 func (s *JobService) GetJobDetailsPublic(ctx context.Context, jobID int64) (*models.Job, error) {
@@ -215,4 +453,239 @@ func (s *JobService) GetJobDetailsPublic(ctx context.Context, jobID int64) (*mod
         return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job details")
     }
     return job, nil
-}
\ No newline at end of file
+}
+
+// GetJobPosting returns a public job with the recruiter who posted it, for its
+// schema.org JobPosting
+func (s *JobService) GetJobPosting(ctx context.Context, jobID int64) (*models.JobPosting, error) {
+    job, err := s.GetJobDetailsPublic(ctx, jobID)
+    if err != nil {
+        return nil, err
+    }
+    recruiter, err := s.recruiterRepo.GetRecruiter(ctx, job.RecruiterID)
+    if err != nil {
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job details")
+    }
+    posting := &models.JobPosting{
+        Job:       job,
+        Recruiter: recruiter,
+        URL:       fmt.Sprintf("https://%s/jobs/%d", s.config.FrontEndDomain, job.ID),
+    }
+    if job.WilayaCode != nil {
+        posting.Wilaya = helpers.GetWilaya(*job.WilayaCode)
+    }
+    if job.CommuneCode != nil {
+        posting.Commune = helpers.GetCommune(*job.CommuneCode)
+    }
+    if job.CategoryID != nil {
+        categories, err := s.categoryRepo.GetCategories(ctx)
+        if err != nil {
+            return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job details")
+        }
+        posting.Categories = helpers.NewCategoryTree(categories).Path(*job.CategoryID)
+    }
+    return posting, nil
+}
+
+func (s *JobService) GetJobRevisions(ctx context.Context, jobID int64, recruiterID uuid.UUID) ([]*models.JobRevision, error) {
+    if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+        return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+    }
+    revisions, err := s.jobRepository.GetJobRevisions(ctx, jobID)
+    if err != nil {
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch job revisions")
+    }
+    return revisions, nil
+}
+
+func (s *JobService) GetJobRevision(ctx context.Context, jobID int64, revision int, recruiterID uuid.UUID) (*models.JobRevision, error) {
+    if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+        return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+    }
+    return s.getJobRevision(ctx, jobID, revision)
+}
+
+// DiffJobRevisions lists the fields changed between two revisions of a job
+func (s *JobService) DiffJobRevisions(ctx context.Context, jobID int64, query request.JobRevisionDiffQuery, recruiterID uuid.UUID) ([]models.JobFieldChange, error) {
+    if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+        return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+    }
+    from, err := s.getJobRevision(ctx, jobID, query.From)
+    if err != nil {
+        return nil, err
+    }
+    to, err := s.getJobRevision(ctx, jobID, query.To)
+    if err != nil {
+        return nil, err
+    }
+    return helpers.DiffJobSnapshots(from.Snapshot, to.Snapshot), nil
+}
+
+// RestoreJobRevision brings the content of a job back to one of its revisions,
+// recorded as a new revision. The status and schedule of the job are kept.
+func (s *JobService) RestoreJobRevision(ctx context.Context, jobID int64, revision int, recruiterID uuid.UUID) (*models.Job, error) {
+    if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+        return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+    }
+    job, err := s.jobRepository.GetJobDetails(ctx, jobID, recruiterID)
+    if err != nil {
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job details")
+    }
+    jobRevision, err := s.getJobRevision(ctx, jobID, revision)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := helpers.ApplyJobSnapshot(job, jobRevision.Snapshot); err != nil {
+        return nil, locationError(err)
+    }
+    // The category of the revision may have been deleted since
+    if job.CategoryID != nil {
+        if _, err := s.categoryRepo.GetCategory(ctx, *job.CategoryID); errors.Is(err, sql.ErrNoRows) {
+            job.CategoryID = nil
+        } else if err != nil {
+            return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to restore job revision")
+        }
+    }
+    job.UpdatedAt = time.Now()
+    skillIDs, err := s.resolveSkills(ctx, job.RequiredSkills)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.jobRepository.RestoreJobRevision(ctx, jobID, recruiterID, job, revision, skillIDs); err != nil {
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to restore job revision")
+    }
+
+    return s.jobRepository.GetJobDetails(ctx, jobID, recruiterID)
+}
+
+func (s *JobService) getJobRevision(ctx context.Context, jobID int64, revision int) (*models.JobRevision, error) {
+    jobRevision, err := s.jobRepository.GetJobRevision(ctx, jobID, revision)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return nil, utils.NewCustomError(http.StatusNotFound, fmt.Sprintf("Job revision %d not found", revision))
+        }
+        return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job revision")
+    }
+    return jobRevision, nil
+}
+
+// RunJobLifecycle publishes the scheduled jobs that are due, closes the expired
+// ones and reminds the recruiters and the candidates who bookmarked them of the
+// jobs expiring soon
+func (s *JobService) RunJobLifecycle(ctx context.Context, now time.Time) error {
+    published, err := s.jobRepository.PublishScheduledJobs(ctx, now)
+    if err != nil {
+        return err
+    }
+    expired, err := s.jobRepository.ExpireJobs(ctx, now)
+    if err != nil {
+        return err
+    }
+    if published > 0 || expired > 0 {
+        log.WithFields(log.Fields{"published": published, "expired": expired}).Info("Updated job lifecycle")
+    }
+
+    jobs, err := s.jobRepository.ClaimExpiringJobs(ctx, now, now.Add(ExpiryReminderLead))
+    if err != nil {
+        return err
+    }
+    for _, job := range jobs {
+        reminder := integrations.JobExpiryReminder{
+            JobTitle:  job.Title,
+            ExpiresAt: job.ExpiresAt.Format("January 2, 2006 at 15:04 MST"),
+            JobURL:    fmt.Sprintf("https://%s/jobs/%d", s.config.FrontEndDomain, job.ID),
+        }
+        err := s.emailService.Send(ctx, job.RecruiterID, models.NotificationJobExpiring, func(unsubscribeURL string) (*models.Email, error) {
+            reminder.UnsubscribeURL = unsubscribeURL
+            return integrations.RenderJobExpiryReminderEmail(job.RecruiterEmail, reminder)
+        })
+        if err != nil {
+            log.WithFields(log.Fields{"job_id": job.ID, "error": err}).Error("Failed to send job expiry reminder")
+        }
+        s.notificationService.Notify(ctx, jobExpiringNotification(job))
+        s.notifyBookmarkers(ctx, job)
+    }
+    return nil
+}
+
+// notifyBookmarkers reminds the candidates who bookmarked a job expiring soon and
+// did not apply to it yet
+func (s *JobService) notifyBookmarkers(ctx context.Context, job *models.Job) {
+    candidateIDs, err := s.bookmarksRepo.GetJobBookmarkers(ctx, job.ID)
+    if err != nil {
+        log.WithFields(log.Fields{"job_id": job.ID, "error": err}).Error("Failed to fetch job bookmarkers")
+        return
+    }
+    for _, candidateID := range candidateIDs {
+        s.notificationService.Notify(ctx, bookmarkedJobClosingNotification(job, candidateID))
+    }
+}
+
+// scheduleJob checks the publish and expiry times of a job for its status. Open
+// jobs are published now if they were not yet, and open or scheduled jobs
+// without a valid expiry run for the configured lifetime. The expiry reminder is
+// sent again whenever the expiry changes.
+func (s *JobService) scheduleJob(job *models.Job, expiresAt *time.Time, now time.Time) error {
+    start := now
+    switch job.Status {
+    case "scheduled":
+        if job.PublishAt == nil || !job.PublishAt.After(now) {
+            return utils.NewCustomError(http.StatusBadRequest, "publish_at must be in the future for a scheduled job")
+        }
+        start = *job.PublishAt
+        job.PublishedAt = nil
+    case "open":
+        job.PublishAt = nil
+        if job.PublishedAt == nil {
+            job.PublishedAt = &now
+        }
+    default:
+        job.PublishAt = nil
+    }
+
+    if expiresAt != nil {
+        job.ExpiresAt = expiresAt
+        job.ExpiryReminderSentAt = nil
+    }
+    if job.Status != "scheduled" && job.Status != "open" {
+        return nil
+    }
+    if job.ExpiresAt == nil || (expiresAt == nil && !job.ExpiresAt.After(start)) {
+        defaultExpiry := start.Add(s.config.JobDefaultLifetime)
+        job.ExpiresAt = &defaultExpiry
+        job.ExpiryReminderSentAt = nil
+    }
+    if !job.ExpiresAt.After(start) {
+        return utils.NewCustomError(http.StatusBadRequest, "expires_at must be after the job is published")
+    }
+    return nil
+}
+
+// checkJobCategory makes sure the category assigned to a job exists
+func (s *JobService) checkJobCategory(ctx context.Context, categoryID *int64) error {
+    if categoryID == nil {
+        return nil
+    }
+    if _, err := s.categoryRepo.GetCategory(ctx, *categoryID); err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("Category %d not found", *categoryID))
+        }
+        return utils.NewCustomError(http.StatusInternalServerError, "Failed to check job category")
+    }
+    return nil
+}
+
+// resolveSkills returns the catalog IDs of the required skills of a job, adding
+// the ones missing from the catalog
+func (s *JobService) resolveSkills(ctx context.Context, requiredSkills string) ([]int64, error) {
+    var skillIDs []int64
+    for _, name := range helpers.SplitSkills(requiredSkills) {
+        skill, err := s.skillCatalogRepo.FindOrCreateSkill(ctx, name)
+        if err != nil {
+            return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to save job skills")
+        }
+        skillIDs = append(skillIDs, skill.ID)
+    }
+    return skillIDs, nil
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJobService(jobs *fakeJobRepository, skills *fakeSkillCatalogRepository) *JobService {
+	return NewJobService(jobs, skills, &fakeRecruiterRepository{}, nil, nil, nil, nil, nil, &config.AppConfig{JobDefaultLifetime: 30 * 24 * time.Hour})
+}
+
+func newTestJob(recruiterID uuid.UUID) *models.Job {
+	return &models.Job{ID: 7, Title: "Go developer", Location: "Remote", RequiredSkills: "Go", Status: "draft", JobType: "full-time", WorkMode: "remote", RecruiterID: recruiterID}
+}
+
+func TestEditJobSkills(t *testing.T) {
+	ctx := context.Background()
+	recruiterID := uuid.New()
+	edit := request.EditJobRequest{Title: "Go developer", Location: "Remote", RequiredSkills: "Go, PostgreSQL", JobType: "full-time"}
+
+	t.Run("Skills saved with the job", func(t *testing.T) {
+		jobs := newFakeJobRepository(newTestJob(recruiterID))
+		skills := newFakeSkillCatalogRepository()
+		service := newTestJobService(jobs, skills)
+
+		job, err := service.EditJob(ctx, 7, edit, recruiterID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Go, PostgreSQL", job.RequiredSkills)
+		assert.Equal(t, []int64{skills.skills["go"].ID, skills.skills["postgresql"].ID}, jobs.skills[7])
+	})
+
+	t.Run("Failed update keeps the skills", func(t *testing.T) {
+		jobs := newFakeJobRepository(newTestJob(recruiterID))
+		jobs.skills[7] = []int64{1}
+		jobs.updateErr = errors.New("connection reset")
+		service := newTestJobService(jobs, newFakeSkillCatalogRepository())
+
+		_, err := service.EditJob(ctx, 7, edit, recruiterID)
+		assert.Equal(t, http.StatusInternalServerError, statusOf(err))
+		assert.Equal(t, "Go", jobs.jobs[7].RequiredSkills)
+		assert.Equal(t, []int64{1}, jobs.skills[7])
+	})
+
+	t.Run("Unresolved skills leave the job untouched", func(t *testing.T) {
+		jobs := newFakeJobRepository(newTestJob(recruiterID))
+		skills := newFakeSkillCatalogRepository()
+		skills.findErr = errors.New("connection reset")
+		service := newTestJobService(jobs, skills)
+
+		_, err := service.EditJob(ctx, 7, edit, recruiterID)
+		assert.Equal(t, http.StatusInternalServerError, statusOf(err))
+		assert.Equal(t, "Go", jobs.jobs[7].RequiredSkills)
+	})
+}
+
+func TestRestoreJobRevisionSkills(t *testing.T) {
+	ctx := context.Background()
+	recruiterID := uuid.New()
+	edit := request.EditJobRequest{Title: "Go developer", Location: "Remote", RequiredSkills: "Go, PostgreSQL", JobType: "full-time"}
+
+	t.Run("Skills restored with the job", func(t *testing.T) {
+		original := newTestJob(recruiterID)
+		jobs := newFakeJobRepository(original)
+		jobs.revisions[7] = []models.JobSnapshot{helpers.JobSnapshotOf(original)}
+		skills := newFakeSkillCatalogRepository()
+		service := newTestJobService(jobs, skills)
+		_, err := service.EditJob(ctx, 7, edit, recruiterID)
+		assert.NoError(t, err)
+
+		job, err := service.RestoreJobRevision(ctx, 7, 1, recruiterID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Go", job.RequiredSkills)
+		assert.Equal(t, []int64{skills.skills["go"].ID}, jobs.skills[7])
+	})
+
+	t.Run("Failed restore keeps the skills", func(t *testing.T) {
+		original := newTestJob(recruiterID)
+		jobs := newFakeJobRepository(original)
+		jobs.revisions[7] = []models.JobSnapshot{helpers.JobSnapshotOf(original)}
+		service := newTestJobService(jobs, newFakeSkillCatalogRepository())
+		_, err := service.EditJob(ctx, 7, edit, recruiterID)
+		assert.NoError(t, err)
+		edited := jobs.skills[7]
+
+		jobs.updateErr = errors.New("connection reset")
+		_, err = service.RestoreJobRevision(ctx, 7, 1, recruiterID)
+		assert.Equal(t, http.StatusInternalServerError, statusOf(err))
+		assert.Equal(t, "Go, PostgreSQL", jobs.jobs[7].RequiredSkills)
+		assert.Equal(t, edited, jobs.skills[7])
+	})
+}
+
+func TestDiffJobRevisions(t *testing.T) {
+	ctx := context.Background()
+	recruiterID := uuid.New()
+	jobs := newFakeJobRepository(newTestJob(recruiterID))
+	jobs.revisions[7] = []models.JobSnapshot{
+		{Title: "Go developer", JobType: "full-time"},
+		{Title: "Go developer", JobType: "part-time"},
+	}
+	service := newTestJobService(jobs, newFakeSkillCatalogRepository())
+
+	changes, err := service.DiffJobRevisions(ctx, 7, request.JobRevisionDiffQuery{From: 1, To: 2}, recruiterID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.JobFieldChange{{Field: "job_type", From: "full-time", To: "part-time"}}, changes)
+
+	_, err = service.DiffJobRevisions(ctx, 7, request.JobRevisionDiffQuery{From: 1, To: 3}, recruiterID)
+	assert.Equal(t, http.StatusNotFound, statusOf(err))
+
+	_, err = service.DiffJobRevisions(ctx, 7, request.JobRevisionDiffQuery{From: 1, To: 2}, uuid.New())
+	assert.Equal(t, http.StatusForbidden, statusOf(err))
+}
+
+func TestEditJobSalary(t *testing.T) {
+	ctx := context.Background()
+	recruiterID := uuid.New()
+	salaryMin, salaryMax := 80000.0, 120000.0
+	newJob := func() *models.Job {
+		job := newTestJob(recruiterID)
+		job.SalaryMin, job.SalaryMax = &salaryMin, &salaryMax
+		job.Currency, job.Period = "DZD", "monthly"
+		job.SalaryNeedsReview = true
+		return job
+	}
+	edit := request.EditJobRequest{Title: "Go developer", Location: "Remote", RequiredSkills: "Go", JobType: "full-time"}
+
+	t.Run("Unsent salary fields kept", func(t *testing.T) {
+		jobs := newFakeJobRepository(newJob())
+		service := newTestJobService(jobs, newFakeSkillCatalogRepository())
+
+		raised := 150000.0
+		salaryEdit := edit
+		salaryEdit.SalaryMax = &raised
+		job, err := service.EditJob(ctx, 7, salaryEdit, recruiterID)
+		assert.NoError(t, err)
+		assert.Equal(t, salaryMin, *job.SalaryMin)
+		assert.Equal(t, raised, *job.SalaryMax)
+		assert.Equal(t, "DZD", job.Currency)
+		assert.False(t, job.SalaryNeedsReview)
+	})
+
+	t.Run("Review flag kept without salary fields", func(t *testing.T) {
+		jobs := newFakeJobRepository(newJob())
+		service := newTestJobService(jobs, newFakeSkillCatalogRepository())
+
+		job, err := service.EditJob(ctx, 7, edit, recruiterID)
+		assert.NoError(t, err)
+		assert.True(t, job.SalaryNeedsReview)
+	})
+
+	t.Run("Minimum above the maximum", func(t *testing.T) {
+		jobs := newFakeJobRepository(newJob())
+		service := newTestJobService(jobs, newFakeSkillCatalogRepository())
+
+		lowered := 50000.0
+		salaryEdit := edit
+		salaryEdit.SalaryMax = &lowered
+		_, err := service.EditJob(ctx, 7, salaryEdit, recruiterID)
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+		assert.Equal(t, salaryMax, *jobs.jobs[7].SalaryMax)
+	})
+}
+
+func TestScheduleJob(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	lifetime := 30 * 24 * time.Hour
+	service := newTestJobService(newFakeJobRepository(), newFakeSkillCatalogRepository())
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+
+	t.Run("Open job published now for the default lifetime", func(t *testing.T) {
+		job := &models.Job{Status: "open", PublishAt: at(time.Hour)}
+
+		assert.NoError(t, service.scheduleJob(job, nil, now))
+		assert.Nil(t, job.PublishAt)
+		assert.Equal(t, now, *job.PublishedAt)
+		assert.Equal(t, now.Add(lifetime), *job.ExpiresAt)
+	})
+
+	t.Run("Scheduled job expires after its publish time", func(t *testing.T) {
+		job := &models.Job{Status: "scheduled", PublishAt: at(48 * time.Hour)}
+
+		assert.NoError(t, service.scheduleJob(job, nil, now))
+		assert.Nil(t, job.PublishedAt)
+		assert.Equal(t, now.Add(48*time.Hour+lifetime), *job.ExpiresAt)
+	})
+
+	t.Run("Scheduled job publish time in the past", func(t *testing.T) {
+		job := &models.Job{Status: "scheduled", PublishAt: at(-time.Hour)}
+
+		assert.Equal(t, http.StatusBadRequest, statusOf(service.scheduleJob(job, nil, now)))
+	})
+
+	t.Run("Expiry before the publish time", func(t *testing.T) {
+		job := &models.Job{Status: "scheduled", PublishAt: at(48 * time.Hour)}
+
+		assert.Equal(t, http.StatusBadRequest, statusOf(service.scheduleJob(job, at(24*time.Hour), now)))
+	})
+
+	t.Run("New expiry sends the reminder again", func(t *testing.T) {
+		job := &models.Job{Status: "open", PublishedAt: at(-time.Hour), ExpiresAt: at(time.Hour), ExpiryReminderSentAt: at(-time.Minute)}
+
+		assert.NoError(t, service.scheduleJob(job, at(72*time.Hour), now))
+		assert.Equal(t, now.Add(-time.Hour), *job.PublishedAt)
+		assert.Equal(t, now.Add(72*time.Hour), *job.ExpiresAt)
+		assert.Nil(t, job.ExpiryReminderSentAt)
+	})
+
+	t.Run("Draft job keeps no schedule", func(t *testing.T) {
+		job := &models.Job{Status: "draft", PublishAt: at(time.Hour)}
+
+		assert.NoError(t, service.scheduleJob(job, nil, now))
+		assert.Nil(t, job.PublishAt)
+		assert.Nil(t, job.ExpiresAt)
+	})
+}
+
+func TestRepostJob(t *testing.T) {
+	ctx := context.Background()
+	recruiterID := uuid.New()
+	verifiedAt := time.Now()
+	newService := func(job *models.Job) (*JobService, *fakeJobRepository) {
+		jobs := newFakeJobRepository(job)
+		users := &fakeUserRepository{users: map[uuid.UUID]*models.User{recruiterID: {ID: recruiterID, EmailVerifiedAt: &verifiedAt}}}
+		cfg := &config.AppConfig{JobDefaultLifetime: 30 * 24 * time.Hour, JobRepostCooldown: 24 * time.Hour}
+		return NewJobService(jobs, newFakeSkillCatalogRepository(), &fakeRecruiterRepository{}, nil, nil, users, nil, nil, cfg), jobs
+	}
+	closedJob := func(publishedAgo time.Duration) *models.Job {
+		job := newTestJob(recruiterID)
+		publishedAt := time.Now().Add(-publishedAgo)
+		job.Status, job.PublishedAt = "closed", &publishedAt
+		return job
+	}
+
+	t.Run("Reposted within the cooldown", func(t *testing.T) {
+		service, jobs := newService(closedJob(time.Hour))
+
+		_, err := service.RepostJob(ctx, 7, recruiterID, request.RepostJobRequest{})
+		assert.Equal(t, http.StatusTooManyRequests, statusOf(err))
+		assert.Equal(t, "closed", jobs.jobs[7].Status)
+	})
+
+	t.Run("Reposted after the cooldown for the default lifetime", func(t *testing.T) {
+		service, _ := newService(closedJob(48 * time.Hour))
+
+		job, err := service.RepostJob(ctx, 7, recruiterID, request.RepostJobRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "open", job.Status)
+		assert.WithinDuration(t, time.Now(), *job.PublishedAt, time.Minute)
+		assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), *job.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Reposted with a new expiry", func(t *testing.T) {
+		service, _ := newService(closedJob(48 * time.Hour))
+		expiresAt := time.Now().Add(10 * 24 * time.Hour)
+
+		job, err := service.RepostJob(ctx, 7, recruiterID, request.RepostJobRequest{ExpiresAt: &expiresAt})
+		assert.NoError(t, err)
+		assert.Equal(t, expiresAt, *job.ExpiresAt)
+	})
+
+	t.Run("Expiry in the past", func(t *testing.T) {
+		service, _ := newService(closedJob(48 * time.Hour))
+		expiresAt := time.Now().Add(-time.Hour)
+
+		_, err := service.RepostJob(ctx, 7, recruiterID, request.RepostJobRequest{ExpiresAt: &expiresAt})
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+	})
+
+	t.Run("Draft job cannot be reposted", func(t *testing.T) {
+		service, _ := newService(newTestJob(recruiterID))
+
+		_, err := service.RepostJob(ctx, 7, recruiterID, request.RepostJobRequest{})
+		assert.Equal(t, http.StatusConflict, statusOf(err))
+	})
+}
+
+// newUploadedFile returns the header of a file uploaded in a multipart form
+func newUploadedFile(t *testing.T, filename, content string) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+	return form.File["file"][0]
+}
+
+func TestImportJobs(t *testing.T) {
+	ctx := context.Background()
+	recruiterID := uuid.New()
+	verifiedAt := time.Now()
+	file := "title,description,status,job_type,required_skills\n" +
+		"Go developer,Build APIs,open,full-time,Go\n" +
+		"Designer,Design screens,scheduled,full-time,\n" +
+		"Tester,,draft,part-time,\n"
+	newService := func() (*JobService, *fakeJobRepository) {
+		jobs := newFakeJobRepository()
+		users := &fakeUserRepository{users: map[uuid.UUID]*models.User{recruiterID: {ID: recruiterID, EmailVerifiedAt: &verifiedAt}}}
+		cfg := &config.AppConfig{JobDefaultLifetime: 30 * 24 * time.Hour}
+		return NewJobService(jobs, newFakeSkillCatalogRepository(), &fakeRecruiterRepository{}, nil, nil, users, nil, nil, cfg), jobs
+	}
+
+	t.Run("Dry run posts nothing", func(t *testing.T) {
+		service, jobs := newService()
+
+		results, err := service.ImportJobs(ctx, recruiterID, newUploadedFile(t, "jobs.csv", file), "", true)
+		assert.NoError(t, err)
+		assert.Empty(t, jobs.jobs)
+		if assert.Len(t, results, 3) {
+			assert.Equal(t, models.JobImportResult{Line: 2, Status: "valid"}, results[0])
+			assert.Equal(t, "invalid", results[1].Status)
+			assert.Equal(t, []string{"publish_at must be in the future for a scheduled job"}, results[1].Errors)
+			assert.Equal(t, "invalid", results[2].Status)
+			assert.Equal(t, []string{"Field: Description, Error: required"}, results[2].Errors)
+		}
+	})
+
+	t.Run("Valid rows posted", func(t *testing.T) {
+		service, jobs := newService()
+
+		results, err := service.ImportJobs(ctx, recruiterID, newUploadedFile(t, "jobs.csv", file), "", false)
+		assert.NoError(t, err)
+		if assert.Len(t, jobs.jobs, 1) && assert.Len(t, results, 3) {
+			assert.Equal(t, "created", results[0].Status)
+			assert.Equal(t, "Go developer", jobs.jobs[results[0].JobID].Title)
+			assert.Len(t, jobs.skills[results[0].JobID], 1)
+			assert.Equal(t, "invalid", results[1].Status)
+		}
+	})
+
+	t.Run("Unknown file type", func(t *testing.T) {
+		service, _ := newService()
+
+		_, err := service.ImportJobs(ctx, recruiterID, newUploadedFile(t, "jobs.txt", file), "", true)
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+	})
+}
+
+func TestGetJobPosting(t *testing.T) {
+	ctx := context.Background()
+	recruiter := &models.Recruiter{ID: uuid.New(), CompanyName: "Yassir"}
+	wilayaCode, communeCode := 16, 1601
+	job := newTestJob(recruiter.ID)
+	job.Status, job.WilayaCode, job.CommuneCode = "open", &wilayaCode, &communeCode
+	jobs := newFakeJobRepository(job, &models.Job{ID: 8, Status: "draft", RecruiterID: recruiter.ID})
+	cfg := &config.AppConfig{FrontEndDomain: "dzjobs.example"}
+	service := NewJobService(jobs, nil, &fakeRecruiterRepository{recruiters: map[uuid.UUID]*models.Recruiter{recruiter.ID: recruiter}}, nil, nil, nil, nil, nil, cfg)
+
+	t.Run("Open job with its recruiter and location", func(t *testing.T) {
+		posting, err := service.GetJobPosting(ctx, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, "Go developer", posting.Job.Title)
+		assert.Equal(t, recruiter, posting.Recruiter)
+		assert.Equal(t, "https://dzjobs.example/jobs/7", posting.URL)
+		assert.Equal(t, "Alger", posting.Wilaya.Name.FR)
+		assert.Equal(t, "Alger Centre", posting.Commune.Name.FR)
+		assert.Empty(t, posting.Categories)
+	})
+
+	t.Run("Draft job not published", func(t *testing.T) {
+		_, err := service.GetJobPosting(ctx, 8)
+		assert.Equal(t, http.StatusNotFound, statusOf(err))
+	})
+}
+
+func TestPostNewJobUnverifiedEmail(t *testing.T) {
+	recruiterID := uuid.New()
+	jobs := newFakeJobRepository()
+	users := &fakeUserRepository{users: map[uuid.UUID]*models.User{recruiterID: {ID: recruiterID, Role: "recruiter"}}}
+	service := NewJobService(jobs, newFakeSkillCatalogRepository(), &fakeRecruiterRepository{}, nil, nil, users, nil, nil, &config.AppConfig{})
+	req := request.PostNewJobRequest{Title: "Go developer", Description: "Build APIs", Status: "open", JobType: "full-time"}
+
+	_, err := service.PostNewJob(context.Background(), recruiterID, req)
+	assert.Equal(t, http.StatusForbidden, statusOf(err))
+	assert.Empty(t, jobs.jobs)
+}
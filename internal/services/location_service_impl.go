@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// LocationService serves the reference list of the wilayas and their communes
+type LocationService struct{}
+
+func NewLocationService() *LocationService {
+	return &LocationService{}
+}
+
+func (s *LocationService) GetWilayas(ctx context.Context) []models.Wilaya {
+	return helpers.Wilayas()
+}
+
+// GetWilaya returns a wilaya, given by code or by name, along with its communes
+func (s *LocationService) GetWilaya(ctx context.Context, query string) (*models.Wilaya, error) {
+	wilaya := helpers.FindWilaya(query)
+	if wilaya == nil {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Wilaya not found")
+	}
+	return wilaya, nil
+}
+
+// SearchLocations returns the wilaya or commune named by a free text location,
+// in French, Arabic or English
+func (s *LocationService) SearchLocations(ctx context.Context, query string) (*models.Wilaya, *models.Commune, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil, utils.NewCustomError(http.StatusBadRequest, "Query is required")
+	}
+	wilaya, commune := helpers.ResolveLocation(query)
+	if wilaya == nil {
+		return nil, nil, utils.NewCustomError(http.StatusNotFound, "Location not found")
+	}
+	return wilaya, commune, nil
+}
+
+// locationError maps the error of a location check to a CustomError
+func locationError(err error) error {
+	var locationErr *helpers.LocationError
+	if errors.As(err, &locationErr) {
+		return utils.NewCustomError(http.StatusBadRequest, "Invalid location: "+locationErr.Reason)
+	}
+	return utils.NewCustomError(http.StatusInternalServerError, "Failed to check location")
+}
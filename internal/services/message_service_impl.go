@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/integrations"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	maxMessageAttachments = 5
+	maxAttachmentSize     = 10 << 20
+)
+
+// attachmentTypes maps the content types accepted as message attachments to the
+// kind of Cloudinary upload they get
+var attachmentTypes = map[string]string{
+	"application/pdf": "pdf",
+	"image/jpeg":      "image",
+	"image/png":       "image",
+	"image/gif":       "image",
+	"image/webp":      "image",
+}
+
+type MessageService struct {
+	messageRepository     interfaces.MessageRepository
+	applicationRepository interfaces.ApplicationRepository
+	jobRepository         interfaces.JobRepository
+	notificationService   serviceInterfaces.NotificationService
+}
+
+func NewMessageService(messageRepo interfaces.MessageRepository, applicationRepo interfaces.ApplicationRepository, jobRepo interfaces.JobRepository, notificationService serviceInterfaces.NotificationService) *MessageService {
+	return &MessageService{
+		messageRepository:     messageRepo,
+		applicationRepository: applicationRepo,
+		jobRepository:         jobRepo,
+		notificationService:   notificationService,
+	}
+}
+
+// GetConversations returns the threads a user takes part in along with the total
+// number of messages they have not read yet
+func (s *MessageService) GetConversations(ctx context.Context, userID uuid.UUID, page request.PageRequest) ([]*models.Conversation, *models.PageInfo, int, error) {
+	conversations, pageInfo, err := s.messageRepository.GetConversations(ctx, userID, page)
+	if err != nil {
+		return nil, nil, 0, listError(err, "Failed to fetch conversations")
+	}
+	unread, err := s.messageRepository.CountUnreadMessages(ctx, userID)
+	if err != nil {
+		return nil, nil, 0, utils.NewCustomError(http.StatusInternalServerError, "Failed to count unread messages")
+	}
+	return conversations, pageInfo, unread, nil
+}
+
+func (s *MessageService) GetMessages(ctx context.Context, thread request.MessageThread, page request.PageRequest) ([]*models.Message, *models.PageInfo, error) {
+	application, err := s.getThreadApplication(ctx, thread)
+	if err != nil {
+		return nil, nil, err
+	}
+	messages, pageInfo, err := s.messageRepository.GetMessages(ctx, application.ID, page)
+	if err != nil {
+		return nil, nil, listError(err, "Failed to fetch messages")
+	}
+	return messages, pageInfo, nil
+}
+
+// SendMessage posts a message to a thread, uploading its attachments to Cloudinary
+// first. Only PDFs and images are accepted.
+func (s *MessageService) SendMessage(ctx context.Context, thread request.MessageThread, req request.SendMessageRequest, files []*multipart.FileHeader) (*models.Message, error) {
+	application, err := s.getThreadApplication(ctx, thread)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" && len(files) == 0 {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "A message needs a text or an attachment")
+	}
+	if len(files) > maxMessageAttachments {
+		return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("A message can have at most %d attachments", maxMessageAttachments))
+	}
+
+	kinds := make([]string, len(files))
+	contentTypes := make([]string, len(files))
+	for i, file := range files {
+		if file.Size > maxAttachmentSize {
+			return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("%s is larger than %d MB", file.Filename, maxAttachmentSize>>20))
+		}
+		contentType, err := detectContentType(file)
+		if err != nil {
+			return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("Failed to read %s", file.Filename))
+		}
+		kind, ok := attachmentTypes[contentType]
+		if !ok {
+			return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("%s is not a PDF or an image", file.Filename))
+		}
+		kinds[i], contentTypes[i] = kind, contentType
+	}
+
+	message := &models.Message{
+		ApplicationID: application.ID,
+		SenderID:      thread.UserID,
+		SenderRole:    thread.Role,
+		Body:          body,
+		CreatedAt:     time.Now(),
+	}
+	for i, file := range files {
+		var url string
+		if kinds[i] == "image" {
+			url, err = integrations.UploadImage(file)
+		} else {
+			url, err = integrations.UploadPDF(file)
+		}
+		if err != nil {
+			log.WithError(err).WithField("application_id", application.ID).Error("Failed to upload message attachment")
+			return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to upload attachment")
+		}
+		message.Attachments = append(message.Attachments, models.MessageAttachment{
+			URL:         url,
+			FileName:    file.Filename,
+			ContentType: contentTypes[i],
+			Size:        file.Size,
+		})
+	}
+
+	if err := s.messageRepository.CreateMessage(ctx, message); err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to send message")
+	}
+	s.notifyRecipient(ctx, message, application)
+	return message, nil
+}
+
+// notifyRecipient tells the other participant of the thread about a new message
+func (s *MessageService) notifyRecipient(ctx context.Context, message *models.Message, application *models.Application) {
+	job, err := s.jobRepository.GetJob(ctx, application.JobID)
+	if err != nil {
+		log.WithError(err).WithField("application_id", application.ID).Error("Failed to fetch job to notify message recipient")
+		return
+	}
+	recipientID := job.RecruiterID
+	if message.SenderRole == "recruiter" {
+		recipientID = application.CandidateID
+	}
+	s.notificationService.Notify(ctx, messageNotification(message, application, recipientID, job.Title))
+}
+
+// MarkMessagesRead sets the read receipts of the messages of a thread sent to the
+// user, and returns how many were unread
+func (s *MessageService) MarkMessagesRead(ctx context.Context, thread request.MessageThread) (int64, error) {
+	application, err := s.getThreadApplication(ctx, thread)
+	if err != nil {
+		return 0, err
+	}
+	count, err := s.messageRepository.MarkMessagesRead(ctx, application.ID, thread.UserID, time.Now())
+	if err != nil {
+		return 0, utils.NewCustomError(http.StatusInternalServerError, "Failed to mark messages as read")
+	}
+	return count, nil
+}
+
+// ReportMessage reports a message the user received in a thread for an admin to
+// review
+func (s *MessageService) ReportMessage(ctx context.Context, thread request.MessageThread, messageID int64, req request.ReportMessageRequest) (*models.MessageReport, error) {
+	application, err := s.getThreadApplication(ctx, thread)
+	if err != nil {
+		return nil, err
+	}
+	message, err := s.messageRepository.GetMessage(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Message not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching message")
+	}
+	if message.ApplicationID != application.ID {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Message not found")
+	}
+	if message.SenderID == thread.UserID {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "You cannot report your own message")
+	}
+	if message.RemovedAt != nil {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "The message was already removed")
+	}
+
+	report := &models.MessageReport{
+		MessageID:  message.ID,
+		ReportedBy: thread.UserID,
+		Reason:     strings.TrimSpace(req.Reason),
+		Status:     "open",
+		CreatedAt:  time.Now(),
+		Message:    message,
+	}
+	if err := s.messageRepository.CreateReport(ctx, report); err != nil {
+		if errors.Is(err, interfaces.ErrMessageReported) {
+			return nil, utils.NewCustomError(http.StatusConflict, "You already reported this message")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to report message")
+	}
+	return report, nil
+}
+
+func (s *MessageService) GetMessageReports(ctx context.Context, filters request.MessageReportFilters) ([]*models.MessageReport, *models.PageInfo, error) {
+	reports, pageInfo, err := s.messageRepository.GetReports(ctx, filters.Status, filters.PageRequest)
+	if err != nil {
+		return nil, nil, listError(err, "Failed to fetch message reports")
+	}
+	return reports, pageInfo, nil
+}
+
+// ReviewMessageReport closes an open report. Removing the message hides its
+// content from both participants and closes the other reports of the message.
+func (s *MessageService) ReviewMessageReport(ctx context.Context, adminID uuid.UUID, reportID int64, req request.ReviewMessageReportRequest) (*models.MessageReport, error) {
+	report, err := s.getReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != "open" {
+		return nil, utils.NewCustomError(http.StatusConflict, fmt.Sprintf("The report was already reviewed, it is %s", report.Status))
+	}
+
+	now := time.Now()
+	report.Status = map[string]string{"dismiss": "dismissed", "remove": "removed"}[req.Action]
+	report.ReviewedBy = &adminID
+	report.ReviewNote = strings.TrimSpace(req.Note)
+	report.ReviewedAt = &now
+	if err := s.messageRepository.ReviewReport(ctx, report); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusConflict, "The report was reviewed by someone else")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to review message report")
+	}
+	return s.getReport(ctx, reportID)
+}
+
+// getThreadApplication returns the application of a thread, making sure the user
+// is its candidate or owns its job. Other users are told it does not exist.
+func (s *MessageService) getThreadApplication(ctx context.Context, thread request.MessageThread) (*models.Application, error) {
+	if thread.Role == "candidate" {
+		application, err := s.applicationRepository.GetApplicationByJobAndCandidate(ctx, thread.JobID, thread.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+			}
+			return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching application")
+		}
+		return application, nil
+	}
+
+	if err := s.jobRepository.ValidateJobOwnership(ctx, thread.JobID, thread.UserID); err != nil {
+		return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+	application, err := s.applicationRepository.GetApplication(ctx, thread.ApplicationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching application")
+	}
+	if application.JobID != thread.JobID {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+	}
+	return application, nil
+}
+
+func (s *MessageService) getReport(ctx context.Context, reportID int64) (*models.MessageReport, error) {
+	report, err := s.messageRepository.GetReport(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Message report not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching message report")
+	}
+	return report, nil
+}
+
+// detectContentType sniffs the content type of an uploaded file from its first
+// bytes, the type sent by the client is not trusted
+func detectContentType(file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(head[:n]), ";")
+	return contentType, nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// messageFixture is a message service over fakes, with an application of a
+// candidate to a job of a recruiter and a second job of the same recruiter
+type messageFixture struct {
+	service       *MessageService
+	messages      *fakeMessageRepository
+	notifications *fakeNotificationService
+	application   *models.Application
+	recruiterID   uuid.UUID
+}
+
+func newMessageFixture() *messageFixture {
+	recruiterID := uuid.New()
+	job := newTestJob(recruiterID)
+	otherJob := newTestJob(recruiterID)
+	otherJob.ID = 8
+	application := &models.Application{ID: 1, JobID: job.ID, CandidateID: uuid.New()}
+
+	f := &messageFixture{
+		messages:      &fakeMessageRepository{},
+		notifications: &fakeNotificationService{},
+		application:   application,
+		recruiterID:   recruiterID,
+	}
+	applications := &fakeApplicationRepository{applications: []*models.Application{application}}
+	f.service = NewMessageService(f.messages, applications, newFakeJobRepository(job, otherJob), f.notifications)
+	return f
+}
+
+func (f *messageFixture) candidateThread() request.MessageThread {
+	return request.MessageThread{UserID: f.application.CandidateID, Role: "candidate", JobID: f.application.JobID}
+}
+
+func (f *messageFixture) recruiterThread() request.MessageThread {
+	return request.MessageThread{UserID: f.recruiterID, Role: "recruiter", JobID: f.application.JobID, ApplicationID: f.application.ID}
+}
+
+func TestSendMessage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Recruiter message notifies the candidate", func(t *testing.T) {
+		f := newMessageFixture()
+
+		message, err := f.service.SendMessage(ctx, f.recruiterThread(), request.SendMessageRequest{Body: "  When can you start?  "}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "When can you start?", message.Body)
+		assert.Equal(t, f.application.ID, message.ApplicationID)
+		assert.Len(t, f.messages.messages, 1)
+		require.Len(t, f.notifications.notified, 1)
+		assert.Equal(t, f.application.CandidateID, f.notifications.notified[0].UserID)
+	})
+
+	t.Run("Candidate message notifies the recruiter", func(t *testing.T) {
+		f := newMessageFixture()
+
+		_, err := f.service.SendMessage(ctx, f.candidateThread(), request.SendMessageRequest{Body: "Next week"}, nil)
+		require.NoError(t, err)
+		require.Len(t, f.notifications.notified, 1)
+		assert.Equal(t, f.recruiterID, f.notifications.notified[0].UserID)
+	})
+
+	t.Run("Empty message", func(t *testing.T) {
+		f := newMessageFixture()
+
+		_, err := f.service.SendMessage(ctx, f.candidateThread(), request.SendMessageRequest{Body: " "}, nil)
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+	})
+
+	t.Run("Attachment that is not a PDF or an image", func(t *testing.T) {
+		f := newMessageFixture()
+		files := []*multipart.FileHeader{newUploadedFile(t, "cv.pdf", "#!/bin/sh\necho hello\n")}
+
+		_, err := f.service.SendMessage(ctx, f.candidateThread(), request.SendMessageRequest{}, files)
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+		assert.Empty(t, f.messages.messages)
+	})
+}
+
+func TestMessageThreadAccess(t *testing.T) {
+	ctx := context.Background()
+	f := newMessageFixture()
+	tests := []struct {
+		name       string
+		thread     request.MessageThread
+		wantStatus int
+	}{
+		{"candidate who did not apply", request.MessageThread{UserID: uuid.New(), Role: "candidate", JobID: f.application.JobID}, http.StatusNotFound},
+		{"recruiter of another job", request.MessageThread{UserID: uuid.New(), Role: "recruiter", JobID: f.application.JobID, ApplicationID: f.application.ID}, http.StatusForbidden},
+		{"application of another job", request.MessageThread{UserID: f.recruiterID, Role: "recruiter", JobID: 8, ApplicationID: f.application.ID}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.SendMessage(ctx, tt.thread, request.SendMessageRequest{Body: "Hello"}, nil)
+			assert.Equal(t, tt.wantStatus, statusOf(err))
+		})
+	}
+	assert.Empty(t, f.messages.messages)
+}
+
+func TestReportMessage(t *testing.T) {
+	ctx := context.Background()
+	f := newMessageFixture()
+	message, err := f.service.SendMessage(ctx, f.recruiterThread(), request.SendMessageRequest{Body: "Hello"}, nil)
+	require.NoError(t, err)
+
+	_, err = f.service.ReportMessage(ctx, f.recruiterThread(), message.ID, request.ReportMessageRequest{Reason: "Spam"})
+	assert.Equal(t, http.StatusBadRequest, statusOf(err))
+
+	report, err := f.service.ReportMessage(ctx, f.candidateThread(), message.ID, request.ReportMessageRequest{Reason: " Spam "})
+	require.NoError(t, err)
+	assert.Equal(t, "open", report.Status)
+	assert.Equal(t, "Spam", report.Reason)
+
+	_, err = f.service.ReportMessage(ctx, f.candidateThread(), message.ID, request.ReportMessageRequest{Reason: "Spam"})
+	assert.Equal(t, http.StatusConflict, statusOf(err))
+
+	_, err = f.service.ReportMessage(ctx, f.candidateThread(), 9, request.ReportMessageRequest{Reason: "Spam"})
+	assert.Equal(t, http.StatusNotFound, statusOf(err))
+}
+
+func TestReviewMessageReport(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+	f := newMessageFixture()
+	f.messages.reports = []*models.MessageReport{{ID: 1, MessageID: 1, Status: "open"}}
+
+	report, err := f.service.ReviewMessageReport(ctx, adminID, 1, request.ReviewMessageReportRequest{Action: "remove", Note: " Insults "})
+	require.NoError(t, err)
+	assert.Equal(t, "removed", report.Status)
+	assert.Equal(t, adminID, *report.ReviewedBy)
+	assert.Equal(t, "Insults", report.ReviewNote)
+
+	_, err = f.service.ReviewMessageReport(ctx, adminID, 1, request.ReviewMessageReportRequest{Action: "dismiss"})
+	assert.Equal(t, http.StatusConflict, statusOf(err))
+
+	_, err = f.service.ReviewMessageReport(ctx, adminID, 2, request.ReviewMessageReportRequest{Action: "dismiss"})
+	assert.Equal(t, http.StatusNotFound, statusOf(err))
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// unsubscribeAll is the event type of the unsubscribe links of digests, turning
+// off the emails of every event
+const unsubscribeAll = "all"
+
+type NotificationPreferenceService struct {
+	preferenceRepository interfaces.NotificationPreferenceRepository
+	config               *config.AppConfig
+}
+
+func NewNotificationPreferenceService(preferenceRepo interfaces.NotificationPreferenceRepository, cfg *config.AppConfig) *NotificationPreferenceService {
+	return &NotificationPreferenceService{
+		preferenceRepository: preferenceRepo,
+		config:               cfg,
+	}
+}
+
+func (s *NotificationPreferenceService) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	preferences, err := s.preferenceRepository.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch notification preferences")
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences replaces the quiet hours and digest mode of a user and turns
+// the channels listed on or off
+func (s *NotificationPreferenceService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req request.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	now := time.Now()
+	preferences := &models.NotificationPreferences{
+		UserID:    userID,
+		Digest:    req.Digest,
+		UpdatedAt: &now,
+	}
+
+	for _, channel := range req.Channels {
+		event, ok := notificationEvent(channel.Type)
+		if !ok {
+			return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("Unknown notification type %q", channel.Type))
+		}
+		if !supportsChannel(event, channel.Channel) {
+			return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("%s notifications are not sent by %s", channel.Type, channel.Channel))
+		}
+		preferences.Channels = append(preferences.Channels, models.NotificationChannelPreference{
+			Type:    channel.Type,
+			Channel: channel.Channel,
+			Enabled: *channel.Enabled,
+		})
+	}
+
+	if (req.QuietHoursStart == "") != (req.QuietHoursEnd == "") {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "Quiet hours need both a start and an end")
+	}
+	if req.QuietHoursStart != "" {
+		start, err := helpers.ParseClock(req.QuietHoursStart)
+		if err != nil {
+			return nil, utils.NewCustomError(http.StatusBadRequest, "Invalid quiet hours start")
+		}
+		end, err := helpers.ParseClock(req.QuietHoursEnd)
+		if err != nil {
+			return nil, utils.NewCustomError(http.StatusBadRequest, "Invalid quiet hours end")
+		}
+		if start == end {
+			return nil, utils.NewCustomError(http.StatusBadRequest, "Quiet hours cannot start and end at the same time")
+		}
+		preferences.QuietHoursStart, preferences.QuietHoursEnd = &start, &end
+	}
+
+	if err := s.preferenceRepository.UpdatePreferences(ctx, preferences); err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update notification preferences")
+	}
+	return s.GetPreferences(ctx, userID)
+}
+
+// DescribeUnsubscribe checks an unsubscribe link and tells which emails it turns
+// off, for the page confirming the unsubscribe
+func (s *NotificationPreferenceService) DescribeUnsubscribe(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", utils.NewCustomError(http.StatusBadRequest, "Unsubscribe token is required")
+	}
+	_, emailType, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return "", utils.NewCustomError(http.StatusNotFound, "Invalid unsubscribe link")
+	}
+	if emailType == unsubscribeAll {
+		return "all Dz Jobs notification emails", nil
+	}
+	return fmt.Sprintf("the %s emails of Dz Jobs", strings.ReplaceAll(emailType, "_", " ")), nil
+}
+
+// Unsubscribe turns off the emails of the event an unsubscribe link was signed
+// for, or of every event for the link of a digest
+func (s *NotificationPreferenceService) Unsubscribe(ctx context.Context, token string) error {
+	if token == "" {
+		return utils.NewCustomError(http.StatusBadRequest, "Unsubscribe token is required")
+	}
+	userID, emailType, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return utils.NewCustomError(http.StatusNotFound, "Invalid unsubscribe link")
+	}
+
+	preferences, err := s.preferenceRepository.GetPreferences(ctx, userID)
+	if err != nil {
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to unsubscribe")
+	}
+	now := time.Now()
+	preferences.UpdatedAt = &now
+	preferences.Channels = nil
+	for _, event := range models.NotificationEvents {
+		if (emailType == unsubscribeAll || emailType == event.Type) && supportsChannel(event, models.NotificationChannelEmail) {
+			preferences.Channels = append(preferences.Channels, models.NotificationChannelPreference{
+				Type:    event.Type,
+				Channel: models.NotificationChannelEmail,
+				Enabled: false,
+			})
+		}
+	}
+
+	if err := s.preferenceRepository.UpdatePreferences(ctx, preferences); err != nil {
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to unsubscribe")
+	}
+	return nil
+}
+
+// parseUnsubscribeToken returns the user and event type an unsubscribe link was
+// signed for
+func (s *NotificationPreferenceService) parseUnsubscribeToken(token string) (uuid.UUID, string, error) {
+	payload, err := utils.VerifySignedToken(token, s.config.UnsubscribeTokenSecret)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	id, emailType, ok := strings.Cut(payload, ":")
+	if !ok {
+		return uuid.Nil, "", fmt.Errorf("invalid unsubscribe token payload")
+	}
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if _, ok := notificationEvent(emailType); !ok && emailType != unsubscribeAll {
+		return uuid.Nil, "", fmt.Errorf("unknown notification type %q", emailType)
+	}
+	return userID, emailType, nil
+}
+
+func notificationEvent(notificationType string) (models.NotificationEvent, bool) {
+	for _, event := range models.NotificationEvents {
+		if event.Type == notificationType {
+			return event, true
+		}
+	}
+	return models.NotificationEvent{}, false
+}
+
+func supportsChannel(event models.NotificationEvent, channel string) bool {
+	for _, supported := range event.Channels {
+		if supported == channel {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/pkg/utils"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsubscribeOnlyOnConfirmation(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.AppConfig{UnsubscribeTokenSecret: "unsubscribe secret"}
+	repository := &fakePreferenceRepository{preferences: map[uuid.UUID]*models.NotificationPreferences{}}
+	service := NewNotificationPreferenceService(repository, cfg)
+	userID := uuid.New()
+	token := utils.SignToken(userID.String()+":"+models.NotificationApplicationStatusChanged, cfg.UnsubscribeTokenSecret)
+
+	description, err := service.DescribeUnsubscribe(ctx, token)
+	require.NoError(t, err)
+	assert.Contains(t, description, "application status changed")
+	assert.Empty(t, repository.preferences, "opening the link changes nothing")
+
+	require.NoError(t, service.Unsubscribe(ctx, token))
+	assert.Equal(t, []models.NotificationChannelPreference{{
+		Type:    models.NotificationApplicationStatusChanged,
+		Channel: models.NotificationChannelEmail,
+		Enabled: false,
+	}}, repository.preferences[userID].Channels)
+
+	_, err = service.DescribeUnsubscribe(ctx, token+"x")
+	assert.Equal(t, http.StatusNotFound, statusOf(err))
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/integrations"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// notificationStreamBuffer is how many notifications a stream can lag behind
+	// before it is closed
+	notificationStreamBuffer = 16
+	// missedNotificationsLimit bounds how many notifications a reconnecting stream
+	// catches up on
+	missedNotificationsLimit = 100
+	// brokerRetryInterval is how long to wait before subscribing again to the
+	// broker after the subscription was lost
+	brokerRetryInterval = 5 * time.Second
+)
+
+// ownEmailNotifications are the events the service raising them sends an email of
+// its own for, with content the notification email cannot carry such as calendar
+// invites
+var ownEmailNotifications = map[string]bool{
+	models.NotificationInterviewProposed:  true,
+	models.NotificationInterviewScheduled: true,
+	models.NotificationInterviewCancelled: true,
+	models.NotificationJobExpiring:        true,
+}
+
+type NotificationService struct {
+	notificationRepository interfaces.NotificationRepository
+	broker                 interfaces.NotificationBroker
+	preferenceRepository   interfaces.NotificationPreferenceRepository
+	userRepository         interfaces.UserRepository
+	emailService           serviceInterfaces.EmailService
+	config                 *config.AppConfig
+
+	mu      sync.RWMutex
+	streams map[uuid.UUID]map[chan *models.Notification]struct{}
+}
+
+func NewNotificationService(notificationRepo interfaces.NotificationRepository, broker interfaces.NotificationBroker, preferenceRepo interfaces.NotificationPreferenceRepository, userRepo interfaces.UserRepository, emailService serviceInterfaces.EmailService, cfg *config.AppConfig) *NotificationService {
+	return &NotificationService{
+		notificationRepository: notificationRepo,
+		broker:                 broker,
+		preferenceRepository:   preferenceRepo,
+		userRepository:         userRepo,
+		emailService:           emailService,
+		config:                 cfg,
+		streams:                map[uuid.UUID]map[chan *models.Notification]struct{}{},
+	}
+}
+
+// Notify tells a user about an event on the channels the user did not turn off.
+// The in-app notification is stored and published to the streams of the user on
+// every instance, and the email of events without an email of their own is
+// queued in the outbox.
+// Notifications accompany an action that already succeeded, so a failure is
+// logged rather than returned.
+func (s *NotificationService) Notify(ctx context.Context, notification *models.Notification) {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+	fields := log.Fields{"user_id": notification.UserID, "type": notification.Type}
+
+	preferences, err := s.preferenceRepository.GetPreferences(ctx, notification.UserID)
+	if err != nil {
+		log.WithFields(fields).WithError(err).Error("Failed to fetch notification preferences")
+		preferences = &models.NotificationPreferences{UserID: notification.UserID}
+	}
+	if preferences.Allows(notification.Type, models.NotificationChannelInApp) {
+		if err := s.notificationRepository.CreateNotification(ctx, notification); err != nil {
+			log.WithFields(fields).WithError(err).Error("Failed to create notification")
+		} else if err := s.broker.Publish(ctx, notification); err != nil {
+			log.WithFields(fields).WithError(err).Error("Failed to publish notification")
+		}
+	}
+
+	if !ownEmailNotifications[notification.Type] {
+		if err := s.emailService.Send(ctx, notification.UserID, notification.Type, s.renderNotificationEmail(ctx, notification)); err != nil {
+			log.WithFields(fields).WithError(err).Error("Failed to email notification")
+		}
+	}
+}
+
+func (s *NotificationService) renderNotificationEmail(ctx context.Context, notification *models.Notification) func(string) (*models.Email, error) {
+	return func(unsubscribeURL string) (*models.Email, error) {
+		user, err := s.userRepository.GetUserByID(ctx, notification.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return integrations.RenderNotificationEmail(user.Email, integrations.NotificationEmail{
+			Title:          notification.Title,
+			Body:           notification.Body,
+			URL:            fmt.Sprintf("https://%s/notifications", s.config.FrontEndDomain),
+			UnsubscribeURL: unsubscribeURL,
+		})
+	}
+}
+
+// GetNotifications returns the notifications of a user along with how many of
+// them are unread
+func (s *NotificationService) GetNotifications(ctx context.Context, userID uuid.UUID, filters request.NotificationFilters) ([]*models.Notification, *models.PageInfo, int, error) {
+	notifications, pageInfo, err := s.notificationRepository.GetNotifications(ctx, userID, filters)
+	if err != nil {
+		return nil, nil, 0, listError(err, "Failed to fetch notifications")
+	}
+	unread, err := s.notificationRepository.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, nil, 0, utils.NewCustomError(http.StatusInternalServerError, "Failed to count unread notifications")
+	}
+	return notifications, pageInfo, unread, nil
+}
+
+func (s *NotificationService) MarkNotificationRead(ctx context.Context, userID uuid.UUID, notificationID int64) (*models.Notification, error) {
+	notification, err := s.notificationRepository.MarkNotificationRead(ctx, userID, notificationID, time.Now())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Notification not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to mark notification as read")
+	}
+	return notification, nil
+}
+
+func (s *NotificationService) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := s.notificationRepository.MarkAllNotificationsRead(ctx, userID, time.Now())
+	if err != nil {
+		return 0, utils.NewCustomError(http.StatusInternalServerError, "Failed to mark notifications as read")
+	}
+	return count, nil
+}
+
+// GetMissedNotifications returns the notifications created after lastID, the
+// last one a reconnecting stream received
+func (s *NotificationService) GetMissedNotifications(ctx context.Context, userID uuid.UUID, lastID int64) ([]*models.Notification, error) {
+	notifications, err := s.notificationRepository.GetNotificationsAfter(ctx, userID, lastID, missedNotificationsLimit)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch notifications")
+	}
+	return notifications, nil
+}
+
+// Subscribe opens a stream of the notifications of a user published from now on.
+// The stream must be closed with the returned function once it is no longer read.
+// It is closed early when it falls too far behind, the client then reconnects and
+// catches up from the last notification it received.
+func (s *NotificationService) Subscribe(userID uuid.UUID) (<-chan *models.Notification, func()) {
+	stream := make(chan *models.Notification, notificationStreamBuffer)
+
+	s.mu.Lock()
+	if s.streams[userID] == nil {
+		s.streams[userID] = map[chan *models.Notification]struct{}{}
+	}
+	s.streams[userID][stream] = struct{}{}
+	s.mu.Unlock()
+
+	return stream, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.removeStream(userID, stream)
+	}
+}
+
+// removeStream forgets a stream, s.mu must be held
+func (s *NotificationService) removeStream(userID uuid.UUID, stream chan *models.Notification) {
+	delete(s.streams[userID], stream)
+	if len(s.streams[userID]) == 0 {
+		delete(s.streams, userID)
+	}
+}
+
+// Listen delivers the notifications published by every instance to the streams
+// open on this one until ctx is cancelled, subscribing again whenever the broker
+// subscription is lost
+func (s *NotificationService) Listen(ctx context.Context) {
+	for {
+		notifications, err := s.broker.Subscribe(ctx)
+		if err != nil {
+			log.WithError(err).Error("Failed to subscribe to notifications")
+		} else {
+			for notification := range notifications {
+				s.deliver(notification)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(brokerRetryInterval):
+		}
+	}
+}
+
+// deliver sends a notification to the open streams of its user. A stream too far
+// behind is closed rather than left with a gap, the client reconnects and catches
+// up on what it missed.
+func (s *NotificationService) deliver(notification *models.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for stream := range s.streams[notification.UserID] {
+		select {
+		case stream <- notification:
+		default:
+			log.WithFields(log.Fields{"user_id": notification.UserID, "notification_id": notification.ID}).
+				Warn("Closed a slow notification stream")
+			s.removeStream(notification.UserID, stream)
+			close(stream)
+		}
+	}
+}
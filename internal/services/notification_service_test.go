@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/models"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNotificationService(users ...*models.User) (*NotificationService, *fakeNotificationRepository, *fakeEmailRepository) {
+	userRepo := &fakeUserRepository{users: map[uuid.UUID]*models.User{}}
+	for _, user := range users {
+		userRepo.users[user.ID] = user
+	}
+	cfg := &config.AppConfig{FrontEndDomain: "dzjobs.example", BackEndDomain: "api.dzjobs.example"}
+	preferences := &fakePreferenceRepository{}
+	emails := &fakeEmailRepository{}
+	notifications := &fakeNotificationRepository{}
+	emailService := NewEmailService(emails, preferences, cfg)
+	return NewNotificationService(notifications, &fakeNotificationBroker{}, preferences, userRepo, emailService, cfg), notifications, emails
+}
+
+// chdirRepoRoot runs a test from the root of the repository, where the email
+// templates are read from
+func chdirRepoRoot(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir("../.."))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestNotifyQueuesEmail(t *testing.T) {
+	ctx := context.Background()
+	chdirRepoRoot(t)
+	user := &models.User{ID: uuid.New(), Name: "Amina", Email: "amina@example.dz", Role: "candidate"}
+	service, notifications, emails := newTestNotificationService(user)
+
+	service.Notify(ctx, &models.Notification{UserID: user.ID, Type: models.NotificationMessageReceived, Title: "New message", Body: "You have a new message"})
+
+	assert.Len(t, notifications.notifications, 1)
+	// The email waits in the outbox for the scheduler, which is woken up for it
+	assert.Len(t, emails.outbox, 1)
+	assert.Equal(t, "amina@example.dz", emails.outbox[0].To)
+	assert.False(t, emails.outbox[0].SendAfter.After(time.Now()))
+	select {
+	case <-service.emailService.Queued():
+	default:
+		t.Error("the outbox scheduler was not woken up")
+	}
+}
+
+func TestDeliverClosesSlowStream(t *testing.T) {
+	service, _, _ := newTestNotificationService()
+	userID := uuid.New()
+	slow, closeSlow := service.Subscribe(userID)
+	defer closeSlow()
+	reading, closeReading := service.Subscribe(userID)
+	defer closeReading()
+
+	for id := int64(1); id <= notificationStreamBuffer+1; id++ {
+		service.deliver(&models.Notification{ID: id, UserID: userID})
+		<-reading
+	}
+
+	// The slow stream gets what fit in its buffer, then is closed instead of
+	// silently missing the rest
+	var received []int64
+	for notification := range slow {
+		received = append(received, notification.ID)
+	}
+	assert.Len(t, received, notificationStreamBuffer)
+	assert.Len(t, service.streams[userID], 1)
+
+	service.deliver(&models.Notification{ID: notificationStreamBuffer + 2, UserID: userID})
+	assert.Equal(t, int64(notificationStreamBuffer+2), (<-reading).ID)
+}
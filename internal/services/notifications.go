@@ -0,0 +1,90 @@
+package services
+
+import (
+	"dz-jobs-api/internal/models"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// applicationReceivedNotification tells the recruiter of a job about a new applicant
+func applicationReceivedNotification(job *models.Job, application *models.Application) *models.Notification {
+	return &models.Notification{
+		UserID: job.RecruiterID,
+		Type:   models.NotificationApplicationReceived,
+		Title:  fmt.Sprintf("New application for %q", job.Title),
+		Body:   "A candidate applied to your job, review the application in your pipeline.",
+		Data:   models.NotificationData{JobID: job.ID, ApplicationID: application.ID},
+	}
+}
+
+// applicationStatusNotification tells a candidate about the new status of their
+// application, never about the recruiter's internal stage
+func applicationStatusNotification(application *models.Application, jobTitle string) *models.Notification {
+	return &models.Notification{
+		UserID: application.CandidateID,
+		Type:   models.NotificationApplicationStatusChanged,
+		Title:  fmt.Sprintf("Your application for %q was updated", jobTitle),
+		Body:   fmt.Sprintf("Your application is now %s.", strings.ReplaceAll(application.Status, "_", " ")),
+		Data:   models.NotificationData{JobID: application.JobID, ApplicationID: application.ID},
+	}
+}
+
+// interviewNotification tells a participant of an interview about a change to it
+func interviewNotification(interview *models.Interview, userID uuid.UUID, notificationType, title, body string) *models.Notification {
+	return &models.Notification{
+		UserID: userID,
+		Type:   notificationType,
+		Title:  title,
+		Body:   body,
+		Data: models.NotificationData{
+			JobID:         interview.JobID,
+			ApplicationID: interview.ApplicationID,
+			InterviewID:   interview.ID,
+		},
+	}
+}
+
+// messageNotification tells the other participant of a thread about a new
+// message, without its content
+func messageNotification(message *models.Message, application *models.Application, recipientID uuid.UUID, jobTitle string) *models.Notification {
+	title := fmt.Sprintf("New message from the recruiter for %q", jobTitle)
+	if message.SenderRole == "candidate" {
+		title = fmt.Sprintf("New message from an applicant to %q", jobTitle)
+	}
+	return &models.Notification{
+		UserID: recipientID,
+		Type:   models.NotificationMessageReceived,
+		Title:  title,
+		Body:   "Open the conversation to read it.",
+		Data: models.NotificationData{
+			JobID:         application.JobID,
+			ApplicationID: application.ID,
+			MessageID:     message.ID,
+		},
+	}
+}
+
+// jobExpiringNotification reminds a recruiter that their job is about to be closed
+func jobExpiringNotification(job *models.Job) *models.Notification {
+	return &models.Notification{
+		UserID: job.RecruiterID,
+		Type:   models.NotificationJobExpiring,
+		Title:  fmt.Sprintf("Your job %q expires soon", job.Title),
+		Body:   fmt.Sprintf("It will be closed on %s, repost it to keep receiving applications.", job.ExpiresAt.Format("January 2, 2006 at 15:04 MST")),
+		Data:   models.NotificationData{JobID: job.ID},
+	}
+}
+
+// bookmarkedJobClosingNotification reminds a candidate that a job they bookmarked
+// stops accepting applications soon
+func bookmarkedJobClosingNotification(job *models.Job, candidateID uuid.UUID) *models.Notification {
+	return &models.Notification{
+		UserID: candidateID,
+		Type:   models.NotificationBookmarkedJobClosing,
+		Title:  fmt.Sprintf("%q closes soon", job.Title),
+		Body:   fmt.Sprintf("A job you bookmarked stops accepting applications on %s.", job.ExpiresAt.Format("January 2, 2006 at 15:04 MST")),
+		Data:   models.NotificationData{JobID: job.ID},
+	}
+}
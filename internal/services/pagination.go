@@ -0,0 +1,26 @@
+package services
+
+import (
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+)
+
+// listError maps the error of a paged repository query to a CustomError, invalid
+// paging parameters and search locations are reported to the client as a bad request
+func listError(err error, message string) error {
+	var pageErr *helpers.PageError
+	if errors.As(err, &pageErr) {
+		return utils.NewCustomError(http.StatusBadRequest, "Invalid pagination parameters: "+pageErr.Reason)
+	}
+	var locationErr *helpers.LocationError
+	if errors.As(err, &locationErr) {
+		return utils.NewCustomError(http.StatusBadRequest, "Invalid location: "+locationErr.Reason)
+	}
+	var salaryErr *helpers.SalaryFilterError
+	if errors.As(err, &salaryErr) {
+		return utils.NewCustomError(http.StatusBadRequest, "Invalid salary filter: "+salaryErr.Reason)
+	}
+	return utils.NewCustomError(http.StatusInternalServerError, message)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"dz-jobs-api/internal/helpers"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListError(t *testing.T) {
+	wrapped := fmt.Errorf("repository: failed to fetch jobs: %w", &helpers.PageError{Reason: "bad cursor"})
+	assert.Equal(t, http.StatusBadRequest, statusOf(listError(wrapped, "Failed to fetch jobs")))
+	assert.Equal(t, http.StatusInternalServerError, statusOf(listError(errors.New("connection reset"), "Failed to fetch jobs")))
+}
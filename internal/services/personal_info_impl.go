@@ -4,6 +4,7 @@ import (
     "context" // Add this import
     "database/sql"
     "dz-jobs-api/internal/dto/request"
+    "dz-jobs-api/internal/helpers"
     "dz-jobs-api/internal/models"
     "dz-jobs-api/internal/repositories/interfaces"
     "dz-jobs-api/pkg/utils"
@@ -21,6 +22,10 @@ func NewCandidatePersonalInfoService(repo interfaces.CandidatePersonalInfoReposi
 }
 
 func (s *CandidatePersonalInfoService) UpdatePersonalInfo(ctx context.Context, candidateID uuid.UUID, request request.UpdatePersonalInfoRequest) (*models.CandidatePersonalInfo, error) {
+    wilayaCode, err := helpers.CheckLocation(request.WilayaCode, request.CommuneCode)
+    if err != nil {
+        return nil, locationError(err)
+    }
     info := &models.CandidatePersonalInfo{
         ID:          candidateID,
         Name:        request.Name,
@@ -30,9 +35,11 @@ func (s *CandidatePersonalInfoService) UpdatePersonalInfo(ctx context.Context, c
         DateOfBirth: request.DateOfBirth,
         Gender:      request.Gender,
         Bio:         request.Bio,
+        WilayaCode:  wilayaCode,
+        CommuneCode: request.CommuneCode,
     }
 
-    err := s.candidatePersonalInfoRepo.UpdatePersonalInfo(ctx, info) // Pass context
+    err = s.candidatePersonalInfoRepo.UpdatePersonalInfo(ctx, info) // Pass context
     if err != nil {
         return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update personal info")
     }
@@ -52,6 +59,10 @@ func (s *CandidatePersonalInfoService) GetPersonalInfo(ctx context.Context, cand
 }
 
 func (s *CandidatePersonalInfoService) AddPersonalInfo(ctx context.Context, request request.AddPersonalInfoRequest, candidateID uuid.UUID) (*models.CandidatePersonalInfo, error) {
+    wilayaCode, err := helpers.CheckLocation(request.WilayaCode, request.CommuneCode)
+    if err != nil {
+        return nil, locationError(err)
+    }
     info := &models.CandidatePersonalInfo{
         ID:          candidateID,
         Name:        request.Name,
@@ -61,9 +72,11 @@ func (s *CandidatePersonalInfoService) AddPersonalInfo(ctx context.Context, requ
         DateOfBirth: request.DateOfBirth,
         Gender:      request.Gender,
         Bio:         request.Bio,
+        WilayaCode:  wilayaCode,
+        CommuneCode: request.CommuneCode,
     }
 
-    err := s.candidatePersonalInfoRepo.CreatePersonalInfo(ctx, info) // Pass context
+    err = s.candidatePersonalInfoRepo.CreatePersonalInfo(ctx, info) // Pass context
     if err != nil {
         return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to create personal info")
     }
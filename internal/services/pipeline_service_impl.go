@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type PipelineService struct {
+	pipelineStageRepository interfaces.PipelineStageRepository
+	applicationRepository   interfaces.ApplicationRepository
+	jobRepository           interfaces.JobRepository
+	notificationService     serviceInterfaces.NotificationService
+}
+
+func NewPipelineService(pipelineStageRepo interfaces.PipelineStageRepository, applicationRepo interfaces.ApplicationRepository, jobRepo interfaces.JobRepository, notificationService serviceInterfaces.NotificationService) *PipelineService {
+	return &PipelineService{
+		pipelineStageRepository: pipelineStageRepo,
+		applicationRepository:   applicationRepo,
+		jobRepository:           jobRepo,
+		notificationService:     notificationService,
+	}
+}
+
+func (s *PipelineService) GetStages(ctx context.Context, recruiterID uuid.UUID) ([]models.PipelineStage, error) {
+	stages, err := loadPipelineStages(ctx, s.pipelineStageRepository, recruiterID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch pipeline stages")
+	}
+	return stages, nil
+}
+
+func (s *PipelineService) UpdateStages(ctx context.Context, recruiterID uuid.UUID, req request.UpdatePipelineStagesRequest) ([]models.PipelineStage, error) {
+	stages := make([]models.PipelineStage, 0, len(req.Stages))
+	for i, stage := range req.Stages {
+		stages = append(stages, models.PipelineStage{
+			RecruiterID: recruiterID,
+			Name:        strings.TrimSpace(stage.Name),
+			Category:    stage.Category,
+			Position:    i,
+		})
+	}
+	if err := helpers.ValidateStageSet(stages); err != nil {
+		return nil, utils.NewCustomError(http.StatusBadRequest, err.Error())
+	}
+
+	if err := s.pipelineStageRepository.ReplaceStages(ctx, recruiterID, stages); err != nil {
+		if errors.Is(err, interfaces.ErrStageInUse) {
+			return nil, utils.NewCustomError(http.StatusConflict, "A removed stage still holds applications, move them to another stage first")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update pipeline stages")
+	}
+	return stages, nil
+}
+
+func (s *PipelineService) MoveApplication(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64, req request.MoveApplicationRequest) (*models.Application, error) {
+	application, err := s.getOwnedApplication(ctx, recruiterID, jobID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := loadPipelineStages(ctx, s.pipelineStageRepository, recruiterID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch pipeline stages")
+	}
+	target, err := helpers.ValidateStageTransition(stages, application.Stage, req.Stage)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusBadRequest, err.Error())
+	}
+
+	transition := &models.ApplicationTransition{
+		ApplicationID: application.ID,
+		FromStage:     application.Stage,
+		ToStage:       target.Name,
+		MovedBy:       recruiterID,
+		Reason:        req.Reason,
+		CreatedAt:     time.Now(),
+	}
+	status := helpers.CandidateStatusForCategory(target.Category)
+	if err := s.applicationRepository.TransitionApplication(ctx, transition, status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusConflict, "Application was moved by someone else, please retry")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to move application")
+	}
+
+	previousStatus := application.Status
+	application.Stage = transition.ToStage
+	application.Status = status
+	application.UpdatedAt = transition.CreatedAt
+	if status != previousStatus {
+		s.notifyStatusChange(ctx, recruiterID, application)
+	}
+	return application, nil
+}
+
+// notifyStatusChange tells the candidate about the new status of their
+// application, stage moves within the same status are not shown to candidates
+func (s *PipelineService) notifyStatusChange(ctx context.Context, recruiterID uuid.UUID, application *models.Application) {
+	job, err := s.jobRepository.GetJobDetails(ctx, application.JobID, recruiterID)
+	if err != nil {
+		log.WithFields(log.Fields{"application_id": application.ID, "error": err}).Error("Failed to fetch job to notify status change")
+		return
+	}
+	s.notificationService.Notify(ctx, applicationStatusNotification(application, job.Title))
+}
+
+func (s *PipelineService) GetApplicationTransitions(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) ([]*models.ApplicationTransition, error) {
+	application, err := s.getOwnedApplication(ctx, recruiterID, jobID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := s.applicationRepository.GetApplicationTransitions(ctx, application.ID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch application history")
+	}
+	return transitions, nil
+}
+
+func (s *PipelineService) getOwnedApplication(ctx context.Context, recruiterID uuid.UUID, jobID, applicationID int64) (*models.Application, error) {
+	if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+	application, err := s.applicationRepository.GetApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching application")
+	}
+	if application.JobID != jobID {
+		return nil, utils.NewCustomError(http.StatusNotFound, "Application not found")
+	}
+	return application, nil
+}
+
+// loadPipelineStages returns the recruiter's own stage set, falling back to the default one
+func loadPipelineStages(ctx context.Context, repo interfaces.PipelineStageRepository, recruiterID uuid.UUID) ([]models.PipelineStage, error) {
+	stages, err := repo.GetStages(ctx, recruiterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) > 0 {
+		return stages, nil
+	}
+
+	stages = make([]models.PipelineStage, len(helpers.DefaultPipelineStages))
+	copy(stages, helpers.DefaultPipelineStages)
+	for i := range stages {
+		stages[i].RecruiterID = recruiterID
+	}
+	return stages, nil
+}
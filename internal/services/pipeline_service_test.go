@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func stagesRequest(names ...string) request.UpdatePipelineStagesRequest {
+	categories := map[string]string{"hired": "hired", "rejected": "rejected"}
+	var req request.UpdatePipelineStagesRequest
+	for _, name := range names {
+		category, ok := categories[name]
+		if !ok {
+			category = "review"
+		}
+		req.Stages = append(req.Stages, request.PipelineStageRequest{Name: name, Category: category})
+	}
+	return req
+}
+
+func TestUpdateStagesKeepsStagesInUse(t *testing.T) {
+	repository := &fakePipelineStageRepository{usedStages: []string{"screening"}}
+	service := &PipelineService{pipelineStageRepository: repository}
+
+	_, err := service.UpdateStages(context.Background(), uuid.New(), stagesRequest("phone call", "hired", "rejected"))
+	assert.Equal(t, http.StatusConflict, statusOf(err))
+	assert.Empty(t, repository.stages)
+
+	stages, err := service.UpdateStages(context.Background(), uuid.New(), stagesRequest("screening", "phone call", "hired", "rejected"))
+	assert.NoError(t, err)
+	assert.Len(t, stages, 4)
+	assert.Len(t, repository.stages, 4)
+}
+
+func TestMoveApplication(t *testing.T) {
+	ctx := context.Background()
+	newFixture := func(stage string) (*PipelineService, *applicationFixture) {
+		f := newApplicationFixture()
+		f.applications.applications = []*models.Application{{
+			ID: 1, JobID: f.job.ID, CandidateID: f.candidateID,
+			Stage: stage, Status: helpers.CandidateStatusForCategory(stageCategory(stage)),
+		}}
+		service := NewPipelineService(&fakePipelineStageRepository{}, f.applications, f.jobs, f.notifications)
+		return service, f
+	}
+
+	t.Run("Forward move notifies a status change", func(t *testing.T) {
+		service, f := newFixture("received")
+
+		application, err := service.MoveApplication(ctx, f.job.RecruiterID, f.job.ID, 1, request.MoveApplicationRequest{Stage: "interview"})
+		assert.NoError(t, err)
+		assert.Equal(t, "interviewing", application.Status)
+		assert.Len(t, f.applications.transitions, 1)
+		assert.Len(t, f.notifications.notified, 1)
+	})
+
+	t.Run("Backward move", func(t *testing.T) {
+		service, f := newFixture("interview")
+
+		_, err := service.MoveApplication(ctx, f.job.RecruiterID, f.job.ID, 1, request.MoveApplicationRequest{Stage: "screening"})
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+		assert.Empty(t, f.applications.transitions)
+	})
+
+	t.Run("Rejection from any stage", func(t *testing.T) {
+		service, f := newFixture("offer")
+
+		application, err := service.MoveApplication(ctx, f.job.RecruiterID, f.job.ID, 1, request.MoveApplicationRequest{Stage: "rejected"})
+		assert.NoError(t, err)
+		assert.Equal(t, "not_selected", application.Status)
+	})
+
+	t.Run("No move out of a final stage", func(t *testing.T) {
+		service, f := newFixture("hired")
+
+		_, err := service.MoveApplication(ctx, f.job.RecruiterID, f.job.ID, 1, request.MoveApplicationRequest{Stage: "rejected"})
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+	})
+
+	t.Run("Job of another recruiter", func(t *testing.T) {
+		service, f := newFixture("received")
+
+		_, err := service.MoveApplication(ctx, uuid.New(), f.job.ID, 1, request.MoveApplicationRequest{Stage: "interview"})
+		assert.Equal(t, http.StatusForbidden, statusOf(err))
+	})
+}
+
+// stageCategory returns the category of a default pipeline stage
+func stageCategory(name string) string {
+	for _, stage := range helpers.DefaultPipelineStages {
+		if stage.Name == name {
+			return stage.Category
+		}
+	}
+	return ""
+}
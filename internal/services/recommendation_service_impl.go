@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRecommendationLimit = 10
+	// recommendationPoolSize is the number of jobs or candidates, pre-ranked by
+	// shared skills in the database, that are scored for one request
+	recommendationPoolSize = 200
+)
+
+type RecommendationService struct {
+	recommendationRepository interfaces.RecommendationRepository
+	jobRepository            interfaces.JobRepository
+}
+
+func NewRecommendationService(recommendationRepo interfaces.RecommendationRepository, jobRepo interfaces.JobRepository) *RecommendationService {
+	return &RecommendationService{
+		recommendationRepository: recommendationRepo,
+		jobRepository:            jobRepo,
+	}
+}
+
+func (s *RecommendationService) GetJobRecommendations(ctx context.Context, candidateID uuid.UUID, query request.RecommendationQuery) ([]*models.JobRecommendation, error) {
+	profile, err := s.recommendationRepository.GetCandidateProfile(ctx, candidateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Candidate not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching candidate profile")
+	}
+
+	jobs, err := s.recommendationRepository.GetOpenJobsForCandidate(ctx, candidateID, recommendationPoolSize)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch recommended jobs")
+	}
+	jobIDs := make([]int64, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = job.ID
+	}
+	jobSkills, err := s.recommendationRepository.GetJobSkills(ctx, jobIDs)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch recommended jobs")
+	}
+
+	recommendations := make([]*models.JobRecommendation, 0, len(jobs))
+	for _, job := range jobs {
+		recommendations = append(recommendations, &models.JobRecommendation{
+			Job:   job,
+			Match: helpers.ScoreJobMatch(profile, job, jobSkills[job.ID], query.JobType),
+		})
+	}
+	// Jobs come newest first, the stable sort keeps newer jobs ahead on equal scores
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Match.Score > recommendations[j].Match.Score
+	})
+	return recommendations[:min(len(recommendations), recommendationLimit(query))], nil
+}
+
+func (s *RecommendationService) GetJobCandidateMatches(ctx context.Context, recruiterID uuid.UUID, jobID int64, query request.RecommendationQuery) ([]*models.CandidateMatch, error) {
+	if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+	job, err := s.jobRepository.GetJobDetails(ctx, jobID, recruiterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Job not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job details")
+	}
+
+	jobSkills, err := s.recommendationRepository.GetJobSkills(ctx, []int64{jobID})
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch matching candidates")
+	}
+	profiles, err := s.recommendationRepository.GetCandidateProfilesForJob(ctx, jobID, recommendationPoolSize)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch matching candidates")
+	}
+
+	matches := make([]*models.CandidateMatch, 0, len(profiles))
+	for _, profile := range profiles {
+		matches = append(matches, &models.CandidateMatch{
+			Candidate: profile,
+			Match:     helpers.ScoreJobMatch(profile, job, jobSkills[jobID], ""),
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Match.Score > matches[j].Match.Score
+	})
+	return matches[:min(len(matches), recommendationLimit(query))], nil
+}
+
+func recommendationLimit(query request.RecommendationQuery) int {
+	if query.Limit > 0 {
+		return query.Limit
+	}
+	return defaultRecommendationLimit
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobRecommendations(t *testing.T) {
+	ctx := context.Background()
+	goSkill, javaSkill := models.Skill{ID: 1, Name: "Go"}, models.Skill{ID: 2, Name: "Java"}
+	repository := &fakeRecommendationRepository{
+		profile: &models.CandidateProfile{Skills: []models.Skill{goSkill}},
+		// Newest first, as the repository returns them
+		jobs: []*models.Job{
+			{ID: 3, Title: "Java developer"},
+			{ID: 2, Title: "Go developer"},
+			{ID: 1, Title: "Go engineer"},
+		},
+		jobSkills: map[int64][]models.Skill{1: {goSkill}, 2: {goSkill}, 3: {javaSkill}},
+	}
+	service := NewRecommendationService(repository, nil)
+
+	t.Run("Best matches first, newer first on equal scores", func(t *testing.T) {
+		recommendations, err := service.GetJobRecommendations(ctx, uuid.New(), request.RecommendationQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, recommendations, 2)
+		assert.Equal(t, int64(2), recommendations[0].Job.ID)
+		assert.Equal(t, int64(1), recommendations[1].Job.ID)
+	})
+
+	t.Run("Unknown candidate", func(t *testing.T) {
+		service := NewRecommendationService(&fakeRecommendationRepository{}, nil)
+		_, err := service.GetJobRecommendations(ctx, uuid.New(), request.RecommendationQuery{})
+		assert.Equal(t, http.StatusNotFound, statusOf(err))
+	})
+}
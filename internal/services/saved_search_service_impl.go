@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/integrations"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	serviceInterfaces "dz-jobs-api/internal/services/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	maxSavedSearches = 20
+	// alertDigestSize is the number of jobs listed in one alert email
+	alertDigestSize = 20
+	// alertBatchSize is the number of saved searches claimed at once by the scheduler
+	alertBatchSize = 100
+	// alertLease is how long a claimed saved search is held before it can be
+	// claimed again if its run did not complete
+	alertLease = 15 * time.Minute
+)
+
+type SavedSearchService struct {
+	savedSearchRepository interfaces.SavedSearchRepository
+	jobRepository         interfaces.JobRepository
+	emailService          serviceInterfaces.EmailService
+	config                *config.AppConfig
+}
+
+func NewSavedSearchService(savedSearchRepo interfaces.SavedSearchRepository, jobRepo interfaces.JobRepository, emailService serviceInterfaces.EmailService, cfg *config.AppConfig) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepository: savedSearchRepo,
+		jobRepository:         jobRepo,
+		emailService:          emailService,
+		config:                cfg,
+	}
+}
+
+func (s *SavedSearchService) CreateSavedSearch(ctx context.Context, candidateID uuid.UUID, req request.SavedSearchRequest) (*models.SavedSearch, error) {
+	count, err := s.savedSearchRepository.CountSavedSearches(ctx, candidateID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to save search")
+	}
+	if count >= maxSavedSearches {
+		return nil, utils.NewCustomError(http.StatusConflict, fmt.Sprintf("You cannot save more than %d searches", maxSavedSearches))
+	}
+	if req.Filters.SalaryRangeMax > 0 && req.Filters.SalaryRangeMin > req.Filters.SalaryRangeMax {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "min_salary cannot be greater than max_salary")
+	}
+	if err := helpers.ValidateSalaryFilter(req.Filters.SalaryRangeMin, req.Filters.SalaryRangeMax, "", req.Filters.Currency, req.Filters.Period); err != nil {
+		return nil, listError(err, "Failed to save search")
+	}
+	if _, err := helpers.NewLocationFilter(req.Filters.Wilaya, req.Filters.Commune, req.Filters.RadiusKm); err != nil {
+		return nil, locationError(err)
+	}
+	filters, err := json.Marshal(req.Filters)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to save search")
+	}
+
+	now := time.Now()
+	search := &models.SavedSearch{
+		CandidateID:      candidateID,
+		Name:             req.Name,
+		Filters:          filters,
+		Frequency:        req.Frequency,
+		UnsubscribeToken: utils.GenerateOpaqueToken(32),
+		AlertsEnabled:    req.AlertsEnabled == nil || *req.AlertsEnabled,
+		NextRunAt:        helpers.NextAlertRun(req.Frequency, now),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := s.savedSearchRepository.CreateSavedSearch(ctx, search); err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to save search")
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) GetSavedSearches(ctx context.Context, candidateID uuid.UUID) ([]*models.SavedSearch, error) {
+	searches, err := s.savedSearchRepository.GetSavedSearches(ctx, candidateID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch saved searches")
+	}
+	return searches, nil
+}
+
+func (s *SavedSearchService) UpdateSavedSearch(ctx context.Context, candidateID uuid.UUID, searchID int64, req request.SavedSearchRequest) (*models.SavedSearch, error) {
+	search, err := s.savedSearchRepository.GetSavedSearch(ctx, searchID, candidateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Saved search not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching saved search")
+	}
+	if req.Filters.SalaryRangeMax > 0 && req.Filters.SalaryRangeMin > req.Filters.SalaryRangeMax {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "min_salary cannot be greater than max_salary")
+	}
+	if err := helpers.ValidateSalaryFilter(req.Filters.SalaryRangeMin, req.Filters.SalaryRangeMax, "", req.Filters.Currency, req.Filters.Period); err != nil {
+		return nil, listError(err, "Failed to save search")
+	}
+	if _, err := helpers.NewLocationFilter(req.Filters.Wilaya, req.Filters.Commune, req.Filters.RadiusKm); err != nil {
+		return nil, locationError(err)
+	}
+	filters, err := json.Marshal(req.Filters)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update saved search")
+	}
+
+	// A new frequency is applied from the last run, or from now for a search that never ran
+	ranAt := time.Now()
+	if search.LastRunAt != nil {
+		ranAt = *search.LastRunAt
+	}
+	search.Name = req.Name
+	search.Filters = filters
+	search.Frequency = req.Frequency
+	if req.AlertsEnabled != nil {
+		search.AlertsEnabled = *req.AlertsEnabled
+	}
+	search.NextRunAt = helpers.NextAlertRun(req.Frequency, ranAt)
+	search.UpdatedAt = time.Now()
+
+	if err := s.savedSearchRepository.UpdateSavedSearch(ctx, search); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Saved search not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update saved search")
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) DeleteSavedSearch(ctx context.Context, candidateID uuid.UUID, searchID int64) error {
+	if err := s.savedSearchRepository.DeleteSavedSearch(ctx, searchID, candidateID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusNotFound, "Saved search not found")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to delete saved search")
+	}
+	return nil
+}
+
+func (s *SavedSearchService) Unsubscribe(ctx context.Context, token string) error {
+	if token == "" {
+		return utils.NewCustomError(http.StatusBadRequest, "Unsubscribe token is required")
+	}
+	if err := s.savedSearchRepository.DisableAlerts(ctx, token); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusNotFound, "Invalid unsubscribe link")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to unsubscribe")
+	}
+	return nil
+}
+
+// RunDueSearches runs every saved search due at now against the open jobs created
+// since its last run and emails the matches to the candidate. A search whose run
+// fails is retried once its lease expires.
+func (s *SavedSearchService) RunDueSearches(ctx context.Context, now time.Time) error {
+	for {
+		searches, err := s.savedSearchRepository.ClaimDueSavedSearches(ctx, now, alertLease, alertBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, search := range searches {
+			if err := s.runSavedSearch(ctx, search, now); err != nil {
+				log.WithFields(log.Fields{"saved_search_id": search.ID, "error": err}).Error("Failed to run saved search")
+				continue
+			}
+			if err := s.savedSearchRepository.MarkSavedSearchRun(ctx, search.ID, now, helpers.NextAlertRun(search.Frequency, now)); err != nil {
+				log.WithFields(log.Fields{"saved_search_id": search.ID, "error": err}).Error("Failed to update saved search run")
+			}
+		}
+		if len(searches) < alertBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *SavedSearchService) runSavedSearch(ctx context.Context, search *models.SavedSearch, now time.Time) error {
+	var saved request.SavedSearchFilters
+	if err := json.Unmarshal(search.Filters, &saved); err != nil {
+		return fmt.Errorf("invalid saved search filters: %w", err)
+	}
+	since := search.CreatedAt
+	if search.LastRunAt != nil {
+		since = *search.LastRunAt
+	}
+
+	filters := request.JobFilters{
+		Status:          "open",
+		Location:        saved.Location,
+		SalaryRangeMin:  saved.SalaryRangeMin,
+		SalaryRangeMax:  saved.SalaryRangeMax,
+		Currency:        saved.Currency,
+		Period:          saved.Period,
+		RequiredSkills:  saved.RequiredSkills,
+		Keyword:         saved.Keyword,
+		JobType:         saved.JobType,
+		Wilaya:          saved.Wilaya,
+		Commune:         saved.Commune,
+		RadiusKm:        saved.RadiusKm,
+		WorkMode:        saved.WorkMode,
+		Category:        saved.Category,
+		PageRequest:     request.PageRequest{Limit: alertDigestSize},
+		PublishedAfter:  &since,
+		PublishedBefore: &now,
+	}
+	jobs, pageInfo, err := s.jobRepository.GetJobListings(ctx, filters)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	digest := integrations.JobAlertDigest{
+		SearchName:    search.Name,
+		MoreCount:     pageInfo.Total - len(jobs),
+		StopAlertsURL: fmt.Sprintf("https://%s/v1/saved-searches/unsubscribe?token=%s", s.config.BackEndDomain, url.QueryEscape(search.UnsubscribeToken)),
+	}
+	for _, job := range jobs {
+		digest.Jobs = append(digest.Jobs, integrations.JobAlertItem{
+			Title:    job.Title,
+			Location: job.Location,
+			JobType:  job.JobType,
+			URL:      fmt.Sprintf("https://%s/jobs/%d", s.config.FrontEndDomain, job.ID),
+		})
+	}
+	return s.emailService.Send(ctx, search.CandidateID, models.NotificationJobAlert, func(unsubscribeURL string) (*models.Email, error) {
+		digest.UnsubscribeURL = unsubscribeURL
+		return integrations.RenderJobAlertEmail(search.CandidateEmail, digest)
+	})
+}
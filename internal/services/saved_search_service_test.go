@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSavedSearchSalaryWithoutCurrency(t *testing.T) {
+	service := &SavedSearchService{savedSearchRepository: &fakeSavedSearchRepository{}}
+	req := request.SavedSearchRequest{Filters: request.SavedSearchFilters{SalaryRangeMin: 50000, Period: "monthly"}}
+
+	_, err := service.CreateSavedSearch(context.Background(), uuid.New(), req)
+
+	assert.Equal(t, http.StatusBadRequest, statusOf(err))
+}
+
+func TestCreateSavedSearchLimit(t *testing.T) {
+	searches := &fakeSavedSearchRepository{searches: make([]*models.SavedSearch, maxSavedSearches)}
+	service := &SavedSearchService{savedSearchRepository: searches}
+
+	_, err := service.CreateSavedSearch(context.Background(), uuid.New(), request.SavedSearchRequest{Name: "Go jobs", Frequency: "daily"})
+
+	assert.Equal(t, http.StatusConflict, statusOf(err))
+}
+
+func TestRunDueSearches(t *testing.T) {
+	ctx := context.Background()
+	chdirRepoRoot(t)
+	now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	lastRun := now.Add(-24 * time.Hour)
+	filters, err := json.Marshal(request.SavedSearchFilters{Keyword: "golang", Wilaya: "16"})
+	assert.NoError(t, err)
+
+	newService := func(listings ...*models.Job) (*SavedSearchService, *models.SavedSearch, *fakeJobRepository, *fakeEmailRepository) {
+		search := &models.SavedSearch{
+			ID:               1,
+			CandidateID:      uuid.New(),
+			Name:             "Go in Algiers",
+			Filters:          filters,
+			Frequency:        "daily",
+			UnsubscribeToken: "stop-token",
+			AlertsEnabled:    true,
+			LastRunAt:        &lastRun,
+			CandidateEmail:   "amina@example.dz",
+		}
+		jobs := newFakeJobRepository()
+		jobs.listings = listings
+		emails := &fakeEmailRepository{}
+		cfg := &config.AppConfig{FrontEndDomain: "dzjobs.example", BackEndDomain: "api.dzjobs.example"}
+		emailService := NewEmailService(emails, &fakePreferenceRepository{}, cfg)
+		return NewSavedSearchService(&fakeSavedSearchRepository{searches: []*models.SavedSearch{search}}, jobs, emailService, cfg), search, jobs, emails
+	}
+
+	t.Run("New jobs sent in one alert", func(t *testing.T) {
+		service, search, jobs, emails := newService(&models.Job{ID: 7, Title: "Go developer", Location: "Alger"})
+
+		assert.NoError(t, service.RunDueSearches(ctx, now))
+
+		assert.Equal(t, "golang", jobs.lastFilters.Keyword)
+		assert.Equal(t, "16", jobs.lastFilters.Wilaya)
+		assert.Equal(t, "open", jobs.lastFilters.Status)
+		assert.Equal(t, lastRun, *jobs.lastFilters.PublishedAfter)
+		assert.Equal(t, now, *jobs.lastFilters.PublishedBefore)
+		if assert.Len(t, emails.outbox, 1) {
+			assert.Equal(t, search.CandidateID, emails.outbox[0].UserID)
+			assert.Equal(t, models.NotificationJobAlert, emails.outbox[0].Type)
+			assert.Equal(t, "amina@example.dz", emails.outbox[0].To)
+			assert.Contains(t, emails.outbox[0].PlainText, "https://dzjobs.example/jobs/7")
+		}
+		assert.Equal(t, now, *search.LastRunAt)
+		assert.Equal(t, now.Add(24*time.Hour), search.NextRunAt)
+	})
+
+	t.Run("No alert without new jobs", func(t *testing.T) {
+		service, search, _, emails := newService()
+
+		assert.NoError(t, service.RunDueSearches(ctx, now))
+
+		assert.Empty(t, emails.outbox)
+		assert.Equal(t, now, *search.LastRunAt)
+		assert.Equal(t, now.Add(24*time.Hour), search.NextRunAt)
+	})
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ScreeningQuestionService struct {
+	questionRepository interfaces.ScreeningQuestionRepository
+	jobRepository      interfaces.JobRepository
+}
+
+func NewScreeningQuestionService(questionRepo interfaces.ScreeningQuestionRepository, jobRepo interfaces.JobRepository) *ScreeningQuestionService {
+	return &ScreeningQuestionService{
+		questionRepository: questionRepo,
+		jobRepository:      jobRepo,
+	}
+}
+
+// GetJobQuestions returns the screening questions of a job open to the public
+func (s *ScreeningQuestionService) GetJobQuestions(ctx context.Context, jobID int64) ([]models.ScreeningQuestion, error) {
+	if _, err := s.jobRepository.GetJobDetailsPublic(ctx, jobID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Job not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching job details")
+	}
+	return s.getQuestions(ctx, jobID)
+}
+
+// GetRecruiterJobQuestions returns the screening questions of a job of the recruiter
+func (s *ScreeningQuestionService) GetRecruiterJobQuestions(ctx context.Context, recruiterID uuid.UUID, jobID int64) ([]models.ScreeningQuestion, error) {
+	if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+	return s.getQuestions(ctx, jobID)
+}
+
+// UpdateJobQuestions replaces the screening questions of a job, in the order they
+// are given. Questions sent with their ID keep the answers already given to them.
+func (s *ScreeningQuestionService) UpdateJobQuestions(ctx context.Context, recruiterID uuid.UUID, jobID int64, req request.UpdateScreeningQuestionsRequest) ([]models.ScreeningQuestion, error) {
+	if err := s.jobRepository.ValidateJobOwnership(ctx, jobID, recruiterID); err != nil {
+		return nil, utils.NewCustomError(http.StatusForbidden, "You do not own this job")
+	}
+
+	current, err := s.getQuestions(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	currentIDs := make(map[int64]bool, len(current))
+	for _, question := range current {
+		currentIDs[question.ID] = true
+	}
+
+	questions := make([]models.ScreeningQuestion, 0, len(req.Questions))
+	for i, questionReq := range req.Questions {
+		question := models.ScreeningQuestion{
+			JobID:         jobID,
+			Position:      i,
+			Type:          questionReq.Type,
+			Prompt:        strings.TrimSpace(questionReq.Prompt),
+			Required:      questionReq.Required,
+			Options:       trimStrings(questionReq.Options),
+			RejectAnswers: trimStrings(questionReq.RejectAnswers),
+			RejectBelow:   questionReq.RejectBelow,
+			RejectAbove:   questionReq.RejectAbove,
+		}
+		if questionReq.QuestionID != nil {
+			if !currentIDs[*questionReq.QuestionID] {
+				return nil, utils.NewCustomError(http.StatusBadRequest, fmt.Sprintf("Question %d is not a question of this job", *questionReq.QuestionID))
+			}
+			delete(currentIDs, *questionReq.QuestionID)
+			question.ID = *questionReq.QuestionID
+		}
+		questions = append(questions, question)
+	}
+	if err := helpers.ValidateScreeningQuestions(questions); err != nil {
+		return nil, screeningError(err, "Invalid screening questions")
+	}
+
+	if err := s.questionRepository.ReplaceQuestions(ctx, jobID, questions); err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update screening questions")
+	}
+	return questions, nil
+}
+
+func (s *ScreeningQuestionService) getQuestions(ctx context.Context, jobID int64) ([]models.ScreeningQuestion, error) {
+	questions, err := s.questionRepository.GetQuestions(ctx, jobID)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch screening questions")
+	}
+	return questions, nil
+}
+
+// screeningError maps the error of a screening question or answer check to a
+// CustomError, message introducing the reason of the check failing
+func screeningError(err error, message string) error {
+	var screeningErr *helpers.ScreeningError
+	if errors.As(err, &screeningErr) {
+		return utils.NewCustomError(http.StatusBadRequest, message+": "+screeningErr.Reason)
+	}
+	return utils.NewCustomError(http.StatusInternalServerError, "Failed to check screening questions")
+}
+
+func trimStrings(values []string) []string {
+	var trimmed []string
+	for _, value := range values {
+		trimmed = append(trimmed, strings.TrimSpace(value))
+	}
+	return trimmed
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/helpers"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const defaultSkillSearchLimit = 10
+
+type SkillCatalogService struct {
+	skillCatalogRepo interfaces.SkillCatalogRepository
+}
+
+func NewSkillCatalogService(skillCatalogRepo interfaces.SkillCatalogRepository) *SkillCatalogService {
+	return &SkillCatalogService{skillCatalogRepo: skillCatalogRepo}
+}
+
+func (s *SkillCatalogService) SearchSkills(ctx context.Context, query request.SkillSearchQuery) ([]*models.Skill, error) {
+	if query.Limit == 0 {
+		query.Limit = defaultSkillSearchLimit
+	}
+	skills, err := s.skillCatalogRepo.SearchSkills(ctx, query.Q, query.Limit)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to search skills")
+	}
+	return skills, nil
+}
+
+func (s *SkillCatalogService) CreateSkill(ctx context.Context, req request.SkillCatalogRequest) (*models.Skill, error) {
+	skill, err := newCatalogSkill(req)
+	if err != nil {
+		return nil, err
+	}
+	skill.CreatedAt = time.Now()
+
+	if err := s.skillCatalogRepo.CreateSkill(ctx, skill); err != nil {
+		if errors.Is(err, interfaces.ErrSkillExists) {
+			return nil, utils.NewCustomError(http.StatusConflict, "A skill with this name or alias already exists")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to create skill")
+	}
+	return skill, nil
+}
+
+func (s *SkillCatalogService) UpdateSkill(ctx context.Context, skillID int64, req request.SkillCatalogRequest) (*models.Skill, error) {
+	skill, err := newCatalogSkill(req)
+	if err != nil {
+		return nil, err
+	}
+	skill.ID = skillID
+
+	if err := s.skillCatalogRepo.UpdateSkill(ctx, skill); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Skill not found")
+		}
+		if errors.Is(err, interfaces.ErrSkillExists) {
+			return nil, utils.NewCustomError(http.StatusConflict, "A skill with this name or alias already exists")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to update skill")
+	}
+	return s.getSkill(ctx, skillID)
+}
+
+func (s *SkillCatalogService) MergeSkills(ctx context.Context, targetID int64, req request.MergeSkillsRequest) (*models.Skill, error) {
+	sourceIDs := slices.Clone(req.SourceIDs)
+	slices.Sort(sourceIDs)
+	sourceIDs = slices.Compact(sourceIDs)
+	if slices.Contains(sourceIDs, targetID) {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "A skill cannot be merged into itself")
+	}
+
+	if err := s.skillCatalogRepo.MergeSkills(ctx, targetID, sourceIDs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Skill not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to merge skills")
+	}
+	return s.getSkill(ctx, targetID)
+}
+
+func (s *SkillCatalogService) getSkill(ctx context.Context, skillID int64) (*models.Skill, error) {
+	skill, err := s.skillCatalogRepo.GetSkill(ctx, skillID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusNotFound, "Skill not found")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Error fetching skill")
+	}
+	return skill, nil
+}
+
+// newCatalogSkill builds a catalog skill from an admin request, aliases are
+// stored as slugs and the ones equal to the skill name are dropped
+func newCatalogSkill(req request.SkillCatalogRequest) (*models.Skill, error) {
+	skill := &models.Skill{
+		Name:     strings.Join(strings.Fields(req.Name), " "),
+		Slug:     helpers.NormalizeSkillName(req.Name),
+		Category: strings.ToLower(strings.TrimSpace(req.Category)),
+		Aliases:  []string{},
+	}
+	if skill.Slug == "" {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "Skill name is required")
+	}
+	if skill.Category == "" {
+		skill.Category = "other"
+	}
+	for _, alias := range req.Aliases {
+		slug := helpers.NormalizeSkillName(alias)
+		if slug == "" || slug == skill.Slug || slices.Contains(skill.Aliases, slug) {
+			continue
+		}
+		skill.Aliases = append(skill.Aliases, slug)
+	}
+	return skill, nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/internal/dto/request"
+	"dz-jobs-api/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSkill(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Name and aliases normalized", func(t *testing.T) {
+		service := NewSkillCatalogService(newFakeSkillCatalogRepository())
+
+		skill, err := service.CreateSkill(ctx, request.SkillCatalogRequest{Name: " Node  JS ", Aliases: []string{"NodeJS", "node js", "nodejs"}})
+		require.NoError(t, err)
+		assert.Equal(t, "Node JS", skill.Name)
+		assert.Equal(t, "node js", skill.Slug)
+		assert.Equal(t, "other", skill.Category)
+		assert.Equal(t, []string{"nodejs"}, skill.Aliases)
+	})
+
+	t.Run("Name used as an alias", func(t *testing.T) {
+		skills := newFakeSkillCatalogRepository()
+		skills.skills["javascript"] = &models.Skill{ID: 1, Slug: "javascript", Aliases: []string{"js"}}
+		service := NewSkillCatalogService(skills)
+
+		_, err := service.CreateSkill(ctx, request.SkillCatalogRequest{Name: "JS"})
+		assert.Equal(t, http.StatusConflict, statusOf(err))
+	})
+}
+
+func TestMergeSkills(t *testing.T) {
+	ctx := context.Background()
+	skills := newFakeSkillCatalogRepository()
+	skills.skills["go"] = &models.Skill{ID: 1, Slug: "go"}
+	service := NewSkillCatalogService(skills)
+
+	_, err := service.MergeSkills(ctx, 1, request.MergeSkillsRequest{SourceIDs: []int64{3, 1}})
+	assert.Equal(t, http.StatusBadRequest, statusOf(err))
+
+	_, err = service.MergeSkills(ctx, 1, request.MergeSkillsRequest{SourceIDs: []int64{3, 2, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, skills.merged)
+}
@@ -7,26 +7,40 @@ import (
 	"dz-jobs-api/internal/models"
 	"dz-jobs-api/internal/repositories/interfaces"
 	"dz-jobs-api/pkg/utils"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
 type CandidateSkillsService struct {
 	candidateSkillsRepo interfaces.CandidateSkillsRepository
+	skillCatalogRepo    interfaces.SkillCatalogRepository
 }
 
-func NewCandidateSkillService(repo interfaces.CandidateSkillsRepository) *CandidateSkillsService {
-	return &CandidateSkillsService{candidateSkillsRepo: repo}
+func NewCandidateSkillService(repo interfaces.CandidateSkillsRepository, skillCatalogRepo interfaces.SkillCatalogRepository) *CandidateSkillsService {
+	return &CandidateSkillsService{candidateSkillsRepo: repo, skillCatalogRepo: skillCatalogRepo}
 }
 
 func (s *CandidateSkillsService) AddSkill(ctx context.Context, candidateID uuid.UUID, request request.AddSkillRequest) (*models.CandidateSkills, error) {
+	name := strings.TrimSpace(request.Skill)
+	if name == "" {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "Skill name is required")
+	}
+	catalogSkill, err := s.skillCatalogRepo.FindOrCreateSkill(ctx, name)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to add skill")
+	}
+
 	skill := &models.CandidateSkills{
-		ID:    candidateID,
-		Skill: request.Skill,
+		ID:       candidateID,
+		SkillID:  catalogSkill.ID,
+		Skill:    catalogSkill.Name,
+		Category: catalogSkill.Category,
 	}
 
-	err := s.candidateSkillsRepo.CreateSkill(ctx,skill)
+	err = s.candidateSkillsRepo.CreateSkill(ctx,skill)
 	if err != nil {
 		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to add skill")
 	}
@@ -47,7 +61,15 @@ func (s *CandidateSkillsService) GetSkills(ctx context.Context, candidateID uuid
 }
 
 func (s *CandidateSkillsService) DeleteSkill(ctx context.Context, candidateID uuid.UUID, skill string) error {
-	err := s.candidateSkillsRepo.DeleteSkill(ctx,candidateID, skill)
+	catalogSkill, err := s.skillCatalogRepo.FindSkill(ctx, skill)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusNotFound, "Skill not found")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to delete skill")
+	}
+
+	err = s.candidateSkillsRepo.DeleteSkill(ctx,candidateID, catalogSkill.ID)
 	if err != nil {
 		return utils.NewCustomError(http.StatusInternalServerError, "Failed to delete skill")
 	}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/internal/repositories/interfaces"
+	"dz-jobs-api/pkg/utils"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// totpIssuer names the account in authenticator apps
+	totpIssuer = "DZ Jobs"
+	// recoveryCodeCount is the number of recovery codes issued on enrollment
+	recoveryCodeCount = 10
+)
+
+type TwoFactorService struct {
+	twoFactorRepository interfaces.TwoFactorRepository
+	userRepository      interfaces.UserRepository
+	redisRepository     interfaces.RedisRepository
+	config              *config.AppConfig
+}
+
+func NewTwoFactorService(twoFactorRepo interfaces.TwoFactorRepository, userRepo interfaces.UserRepository, redisRepo interfaces.RedisRepository, cfg *config.AppConfig) *TwoFactorService {
+	return &TwoFactorService{
+		twoFactorRepository: twoFactorRepo,
+		userRepository:      userRepo,
+		redisRepository:     redisRepo,
+		config:              cfg,
+	}
+}
+
+// SealStoredSecrets encrypts the TOTP secrets stored in plaintext before secrets
+// were sealed, it runs once at startup
+func (s *TwoFactorService) SealStoredSecrets(ctx context.Context) error {
+	twoFactors, err := s.twoFactorRepository.GetTwoFactors(ctx)
+	if err != nil {
+		return err
+	}
+	for _, twoFactor := range twoFactors {
+		if utils.IsSealedSecret(twoFactor.Secret) {
+			continue
+		}
+		sealed, err := utils.SealSecret(twoFactor.Secret, s.config.TwoFactorSecretKey)
+		if err != nil {
+			return err
+		}
+		if err := s.twoFactorRepository.ReplaceSecret(ctx, twoFactor.UserID, twoFactor.Secret, sealed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTwoFactor returns the two-factor settings of a user, nil when the user never
+// set it up, and the number of recovery codes left
+func (s *TwoFactorService) GetTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactor, int, error) {
+	twoFactor, err := s.twoFactorRepository.GetTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, nil
+		}
+		return nil, 0, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch two-factor settings")
+	}
+	if !twoFactor.Enabled() {
+		return twoFactor, 0, nil
+	}
+
+	count, err := s.twoFactorRepository.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, 0, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch two-factor settings")
+	}
+	return twoFactor, count, nil
+}
+
+// SetupTwoFactor generates a new pending secret for a user and returns it along
+// with its otpauth:// URI, it is enabled once confirmed with a first code
+func (s *TwoFactorService) SetupTwoFactor(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", utils.NewCustomError(http.StatusNotFound, "User not found")
+		}
+		return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
+	}
+
+	secret := utils.GenerateTOTPSecret()
+	sealed, err := utils.SealSecret(secret, s.config.TwoFactorSecretKey)
+	if err != nil {
+		return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to set up two-factor authentication")
+	}
+	if err := s.twoFactorRepository.SavePendingSecret(ctx, userID, sealed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", utils.NewCustomError(http.StatusConflict, "Two-factor authentication is already enabled")
+		}
+		return "", "", utils.NewCustomError(http.StatusInternalServerError, "Failed to set up two-factor authentication")
+	}
+	return secret, utils.TOTPURI(totpIssuer, user.Email, secret), nil
+}
+
+// ConfirmTwoFactor enables the pending secret of a user with a first code from
+// their authenticator app, and returns the recovery codes of the user. Only their
+// hashes are kept, the codes cannot be shown again.
+func (s *TwoFactorService) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	twoFactor, err := s.twoFactorRepository.GetTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusBadRequest, "Set up two-factor authentication first")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch two-factor settings")
+	}
+	if twoFactor.Enabled() {
+		return nil, utils.NewCustomError(http.StatusConflict, "Two-factor authentication is already enabled")
+	}
+	secret, err := utils.OpenSecret(twoFactor.Secret, s.config.TwoFactorSecretKey)
+	if err != nil {
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to read two-factor secret")
+	}
+	step, ok := utils.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return nil, utils.NewCustomError(http.StatusBadRequest, "Invalid two-factor code")
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code := utils.GenerateOpaqueToken(5)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = utils.HashToken(code)
+	}
+	if err := s.twoFactorRepository.EnableTwoFactor(ctx, userID, step, hashes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.NewCustomError(http.StatusConflict, "Two-factor authentication is already enabled")
+		}
+		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to enable two-factor authentication")
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off for a user who proves it
+// is them again with their password and a code, unless the account is an admin
+// one and admins are required to use it. Wrong guesses count as failed logins,
+// so that a stolen session cannot try passwords and codes without limit.
+func (s *TwoFactorService) DisableTwoFactor(ctx context.Context, userID uuid.UUID, password, code, ip string) error {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.NewCustomError(http.StatusNotFound, "User not found")
+		}
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
+	}
+	if user.Role == "admin" && s.config.RequireAdminTwoFactor {
+		return utils.NewCustomError(http.StatusForbidden, "Two-factor authentication is required for admin accounts")
+	}
+	emailAttempt, ipAttempt, err := reserveLogin(ctx, s.redisRepository, user.Email, ip)
+	if err != nil {
+		return err
+	}
+	// Only the lookup by email loads the password hash
+	user, err = s.userRepository.GetUserByEmail(ctx, user.Email)
+	if err != nil {
+		releaseAttempts(ctx, s.redisRepository, emailAttempt, ipAttempt)
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch user")
+	}
+	if err := utils.VerifyPassword(user.Password, password); err != nil {
+		return utils.NewCustomError(http.StatusUnauthorized, "Invalid password")
+	}
+
+	twoFactor, err := s.twoFactorRepository.GetTwoFactor(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		releaseAttempts(ctx, s.redisRepository, emailAttempt, ipAttempt)
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch two-factor settings")
+	}
+	if !twoFactor.Enabled() {
+		releaseAttempts(ctx, s.redisRepository, emailAttempt, ipAttempt)
+		return utils.NewCustomError(http.StatusBadRequest, "Two-factor authentication is not enabled")
+	}
+	if err := verifyTwoFactorCode(ctx, s.twoFactorRepository, twoFactor, code, s.config.TwoFactorSecretKey); err != nil {
+		if customErr, ok := err.(*utils.CustomError); !ok || customErr.StatusCode != http.StatusUnauthorized {
+			releaseAttempts(ctx, s.redisRepository, emailAttempt, ipAttempt)
+		}
+		return err
+	}
+	releaseAttempts(ctx, s.redisRepository, ipAttempt)
+	_ = s.redisRepository.ClearAttempts(ctx, loginKey(user.Email))
+
+	if err := s.twoFactorRepository.DisableTwoFactor(ctx, userID); err != nil {
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to disable two-factor authentication")
+	}
+	return nil
+}
+
+// verifyTwoFactorCode accepts a TOTP code not used before or an unused recovery
+// code of a user, using it up. The secret is sealed under secretKey.
+func verifyTwoFactorCode(ctx context.Context, twoFactorRepository interfaces.TwoFactorRepository, twoFactor *models.TwoFactor, code, secretKey string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == utils.TOTPDigits {
+		secret, err := utils.OpenSecret(twoFactor.Secret, secretKey)
+		if err != nil {
+			return utils.NewCustomError(http.StatusInternalServerError, "Failed to read two-factor secret")
+		}
+		step, ok := utils.ValidateTOTP(secret, code, time.Now())
+		if !ok {
+			return utils.NewCustomError(http.StatusUnauthorized, "Invalid two-factor code")
+		}
+		fresh, err := twoFactorRepository.UseStep(ctx, twoFactor.UserID, step)
+		if err != nil {
+			return utils.NewCustomError(http.StatusInternalServerError, "Failed to verify two-factor code")
+		}
+		if !fresh {
+			return utils.NewCustomError(http.StatusUnauthorized, "Two-factor code already used")
+		}
+		return nil
+	}
+
+	recoveryCode := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	used, err := twoFactorRepository.UseRecoveryCode(ctx, twoFactor.UserID, utils.HashToken(recoveryCode))
+	if err != nil {
+		return utils.NewCustomError(http.StatusInternalServerError, "Failed to verify two-factor code")
+	}
+	if !used {
+		return utils.NewCustomError(http.StatusUnauthorized, "Invalid two-factor code")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"dz-jobs-api/config"
+	"dz-jobs-api/internal/models"
+	"dz-jobs-api/pkg/utils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTwoFactorKey = "two-factor-key"
+
+type twoFactorFixture struct {
+	service   *TwoFactorService
+	twoFactor *fakeTwoFactorRepository
+	redis     *fakeRedisRepository
+	user      *models.User
+	secret    string
+}
+
+// newTwoFactorFixture returns a service with one user whose two-factor
+// authentication is enabled with a sealed secret
+func newTwoFactorFixture(t *testing.T) *twoFactorFixture {
+	password, err := utils.HashPassword("correct horse")
+	assert.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Email: "amina@example.dz", Role: "candidate", Password: password}
+
+	secret := utils.GenerateTOTPSecret()
+	sealed, err := utils.SealSecret(secret, testTwoFactorKey)
+	assert.NoError(t, err)
+	enabledAt := time.Now()
+	twoFactor := newFakeTwoFactorRepository()
+	twoFactor.twoFactors[user.ID] = &models.TwoFactor{UserID: user.ID, Secret: sealed, EnabledAt: &enabledAt}
+
+	redis := newFakeRedisRepository()
+	users := &fakeUserRepository{users: map[uuid.UUID]*models.User{user.ID: user}}
+	service := NewTwoFactorService(twoFactor, users, redis, &config.AppConfig{TwoFactorSecretKey: testTwoFactorKey})
+	return &twoFactorFixture{service: service, twoFactor: twoFactor, redis: redis, user: user, secret: secret}
+}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+	assert.NoError(t, err)
+	return code
+}
+
+func statusOf(err error) int {
+	if customErr, ok := err.(*utils.CustomError); ok {
+		return customErr.StatusCode
+	}
+	return 0
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Wrong guesses are throttled", func(t *testing.T) {
+		f := newTwoFactorFixture(t)
+		code := currentTOTPCode(t, f.secret)
+
+		for i := 0; i < loginThrottle.FreeAttempts+1; i++ {
+			err := f.service.DisableTwoFactor(ctx, f.user.ID, "wrong password", code, "10.0.0.1")
+			assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+		}
+		err := f.service.DisableTwoFactor(ctx, f.user.ID, "correct horse", code, "10.0.0.1")
+		assert.Equal(t, http.StatusTooManyRequests, statusOf(err))
+		assert.True(t, f.twoFactor.twoFactors[f.user.ID].Enabled())
+	})
+
+	t.Run("Wrong codes count as failed logins", func(t *testing.T) {
+		f := newTwoFactorFixture(t)
+
+		err := f.service.DisableTwoFactor(ctx, f.user.ID, "correct horse", "000000", "10.0.0.1")
+		assert.Equal(t, http.StatusUnauthorized, statusOf(err))
+		assert.Len(t, f.redis.attempts[loginKey(f.user.Email)], 1)
+		assert.Len(t, f.redis.attempts["ip:10.0.0.1"], 1)
+	})
+
+	t.Run("Right password and code", func(t *testing.T) {
+		f := newTwoFactorFixture(t)
+		_ = f.service.DisableTwoFactor(ctx, f.user.ID, "wrong password", "000000", "10.0.0.1")
+
+		err := f.service.DisableTwoFactor(ctx, f.user.ID, "correct horse", currentTOTPCode(t, f.secret), "10.0.0.1")
+		assert.NoError(t, err)
+		assert.NotContains(t, f.twoFactor.twoFactors, f.user.ID)
+		assert.Empty(t, f.redis.attempts[loginKey(f.user.Email)])
+		assert.Len(t, f.redis.attempts["ip:10.0.0.1"], 1)
+	})
+
+	t.Run("Not enabled", func(t *testing.T) {
+		f := newTwoFactorFixture(t)
+		delete(f.twoFactor.twoFactors, f.user.ID)
+
+		err := f.service.DisableTwoFactor(ctx, f.user.ID, "correct horse", "000000", "10.0.0.1")
+		assert.Equal(t, http.StatusBadRequest, statusOf(err))
+		assert.Empty(t, f.redis.attempts[loginKey(f.user.Email)])
+		assert.Empty(t, f.redis.attempts["ip:10.0.0.1"])
+	})
+}
+
+func TestSetupTwoFactorSealsSecret(t *testing.T) {
+	ctx := context.Background()
+	f := newTwoFactorFixture(t)
+	delete(f.twoFactor.twoFactors, f.user.ID)
+
+	secret, _, err := f.service.SetupTwoFactor(ctx, f.user.ID)
+	assert.NoError(t, err)
+	stored := f.twoFactor.twoFactors[f.user.ID].Secret
+	assert.NotEqual(t, secret, stored)
+	opened, err := utils.OpenSecret(stored, testTwoFactorKey)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, opened)
+}
+
+func TestSealStoredSecrets(t *testing.T) {
+	ctx := context.Background()
+	f := newTwoFactorFixture(t)
+	sealed := f.twoFactor.twoFactors[f.user.ID].Secret
+	legacyUser := uuid.New()
+	f.twoFactor.twoFactors[legacyUser] = &models.TwoFactor{UserID: legacyUser, Secret: "JBSWY3DPEHPK3PXP"}
+
+	assert.NoError(t, f.service.SealStoredSecrets(ctx))
+	assert.Equal(t, sealed, f.twoFactor.twoFactors[f.user.ID].Secret)
+	opened, err := utils.OpenSecret(f.twoFactor.twoFactors[legacyUser].Secret, testTwoFactorKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", opened)
+}
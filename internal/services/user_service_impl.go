@@ -21,7 +21,9 @@ func NewUserService(userRepo interfaces.UserRepository) *UserService {
 	return &UserService{userRepository: userRepo}
 }
 
-func (s *UserService) CreateUser(ctx context.Context, req request.CreateUsersRequest) (*models.User, error) {
+// CreateUser creates a user on behalf of an admin. The admin vouches for the
+// email address, which is taken as verified.
+func (s *UserService) CreateUser(ctx context.Context, req request.AdminCreateUserRequest) (*models.User, error) {
 	existingUser, err := s.userRepository.GetUserByEmail(ctx,req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -37,13 +39,15 @@ func (s *UserService) CreateUser(ctx context.Context, req request.CreateUsersReq
 			return nil, utils.NewCustomError(http.StatusInternalServerError, "Password hashing failed")
 		}
 
+		now := time.Now()
 		user := &models.User{
-			Name:      req.Name,
-			Email:     req.Email,
-			Password:  hashedPassword,
-			Role:      req.Role,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			Name:            req.Name,
+			Email:           req.Email,
+			Password:        hashedPassword,
+			Role:            req.Role,
+			EmailVerifiedAt: &now,
+			CreatedAt:       now,
+			UpdatedAt:       now,
 		}
 
 		if err := s.userRepository.CreateUser(ctx,user); err != nil {
@@ -87,15 +91,12 @@ func (s *UserService) UpdateUser(ctx context.Context, userID uuid.UUID, req requ
 	return s.userRepository.GetUserByID(ctx,userID)
 }
 
-func (s *UserService) GetAllUsers(ctx context.Context, ) ([]*models.User, error) {
-	users, err := s.userRepository.GetAllUsers(ctx)
+func (s *UserService) GetAllUsers(ctx context.Context, page request.PageRequest) ([]*models.User, *models.PageInfo, error) {
+	users, pageInfo, err := s.userRepository.GetAllUsers(ctx, page)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, utils.NewCustomError(http.StatusNotFound, "Users not found")
-		}
-		return nil, utils.NewCustomError(http.StatusInternalServerError, "Failed to fetch users")
+		return nil, nil, listError(err, "Failed to fetch users")
 	}
-	return users, nil
+	return users, pageInfo, nil
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
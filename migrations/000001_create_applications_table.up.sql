@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS applications (
+    application_id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES jobs(job_id) ON DELETE CASCADE,
+    candidate_id UUID NOT NULL REFERENCES candidates(candidate_id) ON DELETE CASCADE,
+    resume TEXT NOT NULL,
+    cover_letter TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'received',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT applications_job_candidate_unique UNIQUE (job_id, candidate_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_applications_candidate_id ON applications (candidate_id);
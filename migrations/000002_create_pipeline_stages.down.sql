@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS application_transitions_append_only ON application_transitions;
+DROP FUNCTION IF EXISTS forbid_application_transition_changes();
+DROP TABLE IF EXISTS application_transitions;
+
+UPDATE applications SET status = stage;
+ALTER TABLE applications ALTER COLUMN status SET DEFAULT 'received';
+ALTER TABLE applications DROP COLUMN IF EXISTS stage;
+
+DROP TABLE IF EXISTS pipeline_stages;
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    stage_id BIGSERIAL PRIMARY KEY,
+    recruiter_id UUID NOT NULL REFERENCES recruiters(recruiter_id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('applied', 'review', 'interview', 'offer', 'hired', 'rejected')),
+    position INT NOT NULL,
+    CONSTRAINT pipeline_stages_recruiter_name_unique UNIQUE (recruiter_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_stages_recruiter_id ON pipeline_stages (recruiter_id, position);
+
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS stage VARCHAR(50) NOT NULL DEFAULT 'received';
+
+-- status now holds the candidate-facing status derived from the stage category
+UPDATE applications SET stage = status, status = 'submitted' WHERE status = 'received';
+ALTER TABLE applications ALTER COLUMN status SET DEFAULT 'submitted';
+
+CREATE TABLE IF NOT EXISTS application_transitions (
+    transition_id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    from_stage VARCHAR(50) NOT NULL,
+    to_stage VARCHAR(50) NOT NULL,
+    moved_by UUID NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_transitions_application_id ON application_transitions (application_id, created_at);
+
+-- transitions are an append-only audit log
+CREATE OR REPLACE FUNCTION forbid_application_transition_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'application_transitions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER application_transitions_append_only
+    BEFORE UPDATE ON application_transitions
+    FOR EACH ROW EXECUTE FUNCTION forbid_application_transition_changes();
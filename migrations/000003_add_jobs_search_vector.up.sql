@@ -0,0 +1,15 @@
+-- Each field is indexed with the French and English stemmers plus the 'simple'
+-- configuration, which keeps Arabic (and any other) words unstemmed.
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('french', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('french', coalesce(required_skills, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(required_skills, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(required_skills, '')), 'B') ||
+    setweight(to_tsvector('french', coalesce(description, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON jobs USING GIN (search_vector);
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_jobs_salary_max;
+DROP INDEX IF EXISTS idx_jobs_salary_min;
+
+ALTER TABLE jobs RENAME COLUMN salary_range_legacy TO salary_range;
+
+UPDATE jobs SET salary_range = salary_min::TEXT || ' - ' || salary_max::TEXT || ' ' || currency
+WHERE salary_min IS NOT NULL AND salary_max IS NOT NULL;
+
+ALTER TABLE jobs
+    DROP CONSTRAINT IF EXISTS jobs_salary_bounds_check,
+    DROP CONSTRAINT IF EXISTS jobs_period_check,
+    DROP CONSTRAINT IF EXISTS jobs_currency_check,
+    DROP COLUMN IF EXISTS salary_needs_review,
+    DROP COLUMN IF EXISTS period,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS salary_max,
+    DROP COLUMN IF EXISTS salary_min;
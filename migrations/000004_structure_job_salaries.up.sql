@@ -0,0 +1,35 @@
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS salary_min NUMERIC(12, 2),
+    ADD COLUMN IF NOT EXISTS salary_max NUMERIC(12, 2),
+    ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'DZD',
+    ADD COLUMN IF NOT EXISTS period VARCHAR(10) NOT NULL DEFAULT 'monthly',
+    ADD COLUMN IF NOT EXISTS salary_needs_review BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE jobs
+    ADD CONSTRAINT jobs_currency_check CHECK (currency IN ('DZD', 'EUR', 'USD')),
+    ADD CONSTRAINT jobs_period_check CHECK (period IN ('monthly', 'yearly', 'hourly')),
+    ADD CONSTRAINT jobs_salary_bounds_check CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max);
+
+-- Parse the free text 'min - max DZD' ranges, the currency is optional
+WITH parsed AS (
+    SELECT job_id, regexp_match(salary_range, '^\s*(\d+(?:\.\d+)?)\s*-\s*(\d+(?:\.\d+)?)\s*(DZD|EUR|USD)?\s*$', 'i') AS bounds
+    FROM jobs
+)
+UPDATE jobs SET
+    salary_min = parsed.bounds[1]::NUMERIC,
+    salary_max = parsed.bounds[2]::NUMERIC,
+    currency = COALESCE(UPPER(parsed.bounds[3]), 'DZD')
+FROM parsed
+WHERE jobs.job_id = parsed.job_id
+    AND parsed.bounds IS NOT NULL
+    AND parsed.bounds[1]::NUMERIC <= parsed.bounds[2]::NUMERIC
+    AND parsed.bounds[2]::NUMERIC < 10000000000;
+
+-- Ranges that could not be parsed are kept for the recruiter to re-enter
+UPDATE jobs SET salary_needs_review = TRUE
+WHERE salary_min IS NULL AND COALESCE(TRIM(salary_range), '') <> '';
+
+ALTER TABLE jobs RENAME COLUMN salary_range TO salary_range_legacy;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_salary_min ON jobs (salary_min);
+CREATE INDEX IF NOT EXISTS idx_jobs_salary_max ON jobs (salary_max);
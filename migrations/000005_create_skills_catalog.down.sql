@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS job_skills;
+
+ALTER TABLE candidate_skills DROP CONSTRAINT IF EXISTS candidate_skills_candidate_skill_unique;
+ALTER TABLE candidate_skills DROP COLUMN IF EXISTS skill_id;
+
+DROP TABLE IF EXISTS skill_aliases;
+DROP TABLE IF EXISTS skills;
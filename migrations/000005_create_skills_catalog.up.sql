@@ -0,0 +1,117 @@
+-- Skill names are matched on their slug: lower case, trimmed, single spaced
+CREATE TABLE IF NOT EXISTS skills (
+    skill_id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    category VARCHAR(50) NOT NULL DEFAULT 'other',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS skill_aliases (
+    slug VARCHAR(100) PRIMARY KEY,
+    skill_id BIGINT NOT NULL REFERENCES skills(skill_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_skills_slug_prefix ON skills (slug text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_skill_aliases_slug_prefix ON skill_aliases (slug text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_skill_aliases_skill_id ON skill_aliases (skill_id);
+
+INSERT INTO skills (name, slug, category) VALUES
+    ('Go', 'go', 'programming_language'),
+    ('JavaScript', 'javascript', 'programming_language'),
+    ('TypeScript', 'typescript', 'programming_language'),
+    ('Python', 'python', 'programming_language'),
+    ('Java', 'java', 'programming_language'),
+    ('PHP', 'php', 'programming_language'),
+    ('C#', 'c#', 'programming_language'),
+    ('C++', 'c++', 'programming_language'),
+    ('PostgreSQL', 'postgresql', 'database'),
+    ('MySQL', 'mysql', 'database'),
+    ('MongoDB', 'mongodb', 'database'),
+    ('Redis', 'redis', 'database'),
+    ('React', 'react', 'framework'),
+    ('Node.js', 'node.js', 'framework'),
+    ('Django', 'django', 'framework'),
+    ('Laravel', 'laravel', 'framework'),
+    ('Spring Boot', 'spring boot', 'framework'),
+    ('Docker', 'docker', 'devops'),
+    ('Kubernetes', 'kubernetes', 'devops'),
+    ('Git', 'git', 'tool'),
+    ('Microsoft Excel', 'microsoft excel', 'office'),
+    ('Project Management', 'project management', 'soft_skill'),
+    ('Communication', 'communication', 'soft_skill'),
+    ('French', 'french', 'language'),
+    ('English', 'english', 'language'),
+    ('Arabic', 'arabic', 'language')
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO skill_aliases (slug, skill_id)
+SELECT alias.slug, skills.skill_id
+FROM (VALUES
+    ('golang', 'go'), ('go lang', 'go'),
+    ('js', 'javascript'), ('ts', 'typescript'),
+    ('postgres', 'postgresql'), ('psql', 'postgresql'),
+    ('mongo', 'mongodb'),
+    ('reactjs', 'react'), ('react.js', 'react'),
+    ('node', 'node.js'), ('nodejs', 'node.js'),
+    ('springboot', 'spring boot'),
+    ('k8s', 'kubernetes'),
+    ('excel', 'microsoft excel'),
+    ('francais', 'french'), ('français', 'french'),
+    ('anglais', 'english'),
+    ('arabe', 'arabic'), ('العربية', 'arabic')
+) AS alias (slug, skill_slug)
+JOIN skills ON skills.slug = alias.skill_slug
+ON CONFLICT (slug) DO NOTHING;
+
+-- Every free text skill that is not in the catalog yet becomes its own skill,
+-- duplicates can then be merged by an admin
+CREATE TEMPORARY TABLE legacy_skills AS
+SELECT DISTINCT ON (slug) name, slug FROM (
+    SELECT TRIM(skill) AS name, LOWER(REGEXP_REPLACE(TRIM(skill), '\s+', ' ', 'g')) AS slug
+    FROM candidate_skills
+    UNION ALL
+    SELECT TRIM(skill) AS name, LOWER(REGEXP_REPLACE(TRIM(skill), '\s+', ' ', 'g')) AS slug
+    FROM jobs, REGEXP_SPLIT_TO_TABLE(COALESCE(required_skills, ''), '\s*[,;|]\s*') AS skill
+) AS names
+WHERE slug <> '' AND LENGTH(slug) <= 100;
+
+INSERT INTO skills (name, slug)
+SELECT name, slug FROM legacy_skills
+WHERE slug NOT IN (SELECT slug FROM skill_aliases)
+ON CONFLICT (slug) DO NOTHING;
+
+DROP TABLE legacy_skills;
+
+-- Candidate skills reference the catalog
+ALTER TABLE candidate_skills ADD COLUMN IF NOT EXISTS skill_id BIGINT REFERENCES skills(skill_id) ON DELETE CASCADE;
+
+UPDATE candidate_skills SET skill_id = COALESCE(
+    (SELECT skill_id FROM skills WHERE slug = LOWER(REGEXP_REPLACE(TRIM(candidate_skills.skill), '\s+', ' ', 'g'))),
+    (SELECT skill_id FROM skill_aliases WHERE slug = LOWER(REGEXP_REPLACE(TRIM(candidate_skills.skill), '\s+', ' ', 'g')))
+);
+
+DELETE FROM candidate_skills WHERE skill_id IS NULL;
+DELETE FROM candidate_skills a USING candidate_skills b
+WHERE a.candidate_id = b.candidate_id AND a.skill_id = b.skill_id AND a.ctid > b.ctid;
+
+ALTER TABLE candidate_skills ALTER COLUMN skill_id SET NOT NULL;
+ALTER TABLE candidate_skills ADD CONSTRAINT candidate_skills_candidate_skill_unique UNIQUE (candidate_id, skill_id);
+
+-- Job required skills reference the catalog, jobs.required_skills is kept as the display text
+CREATE TABLE IF NOT EXISTS job_skills (
+    job_id BIGINT NOT NULL REFERENCES jobs(job_id) ON DELETE CASCADE,
+    skill_id BIGINT NOT NULL REFERENCES skills(skill_id) ON DELETE CASCADE,
+    PRIMARY KEY (job_id, skill_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_skills_skill_id ON job_skills (skill_id);
+
+INSERT INTO job_skills (job_id, skill_id)
+SELECT DISTINCT jobs.job_id, COALESCE(skills.skill_id, skill_aliases.skill_id)
+FROM jobs
+CROSS JOIN REGEXP_SPLIT_TO_TABLE(COALESCE(jobs.required_skills, ''), '\s*[,;|]\s*') AS skill
+LEFT JOIN skills ON skills.slug = LOWER(REGEXP_REPLACE(TRIM(skill), '\s+', ' ', 'g'))
+LEFT JOIN skill_aliases ON skill_aliases.slug = LOWER(REGEXP_REPLACE(TRIM(skill), '\s+', ' ', 'g'))
+WHERE COALESCE(skills.skill_id, skill_aliases.skill_id) IS NOT NULL
+ON CONFLICT DO NOTHING;
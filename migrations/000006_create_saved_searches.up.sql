@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS saved_searches (
+    saved_search_id BIGSERIAL PRIMARY KEY,
+    candidate_id UUID NOT NULL REFERENCES candidates(candidate_id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}',
+    frequency VARCHAR(10) NOT NULL,
+    unsubscribe_token VARCHAR(64) NOT NULL,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_run_at TIMESTAMP,
+    next_run_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT saved_searches_frequency_check CHECK (frequency IN ('instant', 'daily', 'weekly')),
+    CONSTRAINT saved_searches_unsubscribe_token_unique UNIQUE (unsubscribe_token)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_candidate_id ON saved_searches (candidate_id);
+
+-- The alert scheduler only looks at the searches that are due
+CREATE INDEX IF NOT EXISTS idx_saved_searches_next_run_at ON saved_searches (next_run_at) WHERE alerts_enabled;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_jobs_expires_at;
+DROP INDEX IF EXISTS idx_jobs_publish_at;
+
+UPDATE jobs SET status = 'closed' WHERE status IN ('draft', 'scheduled');
+
+ALTER TABLE jobs
+    DROP CONSTRAINT IF EXISTS jobs_scheduled_publish_at_check,
+    DROP CONSTRAINT IF EXISTS jobs_status_check;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS expiry_reminder_sent_at,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,17 @@
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS expiry_reminder_sent_at TIMESTAMP;
+
+-- Jobs listed so far were published when they were created
+UPDATE jobs SET published_at = created_at WHERE status = 'open';
+
+-- Existing rows are not checked, a job with another status keeps it until it is edited
+ALTER TABLE jobs
+    ADD CONSTRAINT jobs_status_check CHECK (status IN ('draft', 'scheduled', 'open', 'closed')) NOT VALID,
+    ADD CONSTRAINT jobs_scheduled_publish_at_check CHECK (status <> 'scheduled' OR publish_at IS NOT NULL);
+
+-- The lifecycle worker looks up the jobs due to be published and to expire
+CREATE INDEX IF NOT EXISTS idx_jobs_publish_at ON jobs (publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_jobs_expires_at ON jobs (expires_at) WHERE status = 'open';
@@ -0,0 +1,6 @@
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_job_revision_fkey;
+ALTER TABLE applications DROP COLUMN IF EXISTS job_revision;
+
+DROP TRIGGER IF EXISTS job_revisions_append_only ON job_revisions;
+DROP FUNCTION IF EXISTS forbid_job_revision_changes();
+DROP TABLE IF EXISTS job_revisions;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS job_revisions (
+    job_id BIGINT NOT NULL REFERENCES jobs(job_id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    snapshot JSONB NOT NULL,
+    edited_by UUID NOT NULL,
+    restored_from INT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (job_id, revision)
+);
+
+-- existing jobs start their history from their current content
+INSERT INTO job_revisions (job_id, revision, snapshot, edited_by, created_at)
+SELECT job_id, 1, jsonb_build_object(
+           'title', title,
+           'description', description,
+           'location', location,
+           'salary_min', salary_min,
+           'salary_max', salary_max,
+           'currency', currency,
+           'period', period,
+           'required_skills', required_skills,
+           'job_type', job_type
+       ), recruiter_id, updated_at
+FROM jobs
+ON CONFLICT DO NOTHING;
+
+-- revisions are immutable, they only go away with their job
+CREATE OR REPLACE FUNCTION forbid_job_revision_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'job_revisions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER job_revisions_append_only
+    BEFORE UPDATE ON job_revisions
+    FOR EACH ROW EXECUTE FUNCTION forbid_job_revision_changes();
+
+-- applications sent before revisions existed are attached to the first one
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS job_revision INT;
+UPDATE applications SET job_revision = 1 WHERE job_revision IS NULL;
+ALTER TABLE applications ALTER COLUMN job_revision SET NOT NULL;
+ALTER TABLE applications ADD CONSTRAINT applications_job_revision_fkey
+    FOREIGN KEY (job_id, job_revision) REFERENCES job_revisions (job_id, revision);
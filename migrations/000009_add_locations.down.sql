@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_jobs_coordinates;
+DROP INDEX IF EXISTS idx_jobs_wilaya_code;
+
+ALTER TABLE candidate_personal_info
+    DROP COLUMN IF EXISTS commune_code,
+    DROP COLUMN IF EXISTS wilaya_code;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS work_mode,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS commune_code,
+    DROP COLUMN IF EXISTS wilaya_code;
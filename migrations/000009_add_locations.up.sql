@@ -0,0 +1,22 @@
+-- Wilaya and commune codes refer to the reference dataset embedded in the API,
+-- the coordinates of a job are the centroid of its commune, or of its wilaya
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS wilaya_code SMALLINT CHECK (wilaya_code BETWEEN 1 AND 58),
+    ADD COLUMN IF NOT EXISTS commune_code INT,
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS work_mode VARCHAR(10) NOT NULL DEFAULT 'onsite'
+        CHECK (work_mode IN ('onsite', 'remote', 'hybrid'));
+
+-- Remote used to be a job type, the jobs posted as such are full-time remote jobs
+-- and the searches for them look for remote jobs
+UPDATE jobs SET work_mode = 'remote', job_type = 'full-time' WHERE job_type = 'remote';
+UPDATE saved_searches SET filters = (filters - 'job_type') || '{"work_mode": "remote"}'
+WHERE filters->>'job_type' = 'remote';
+
+ALTER TABLE candidate_personal_info
+    ADD COLUMN IF NOT EXISTS wilaya_code SMALLINT CHECK (wilaya_code BETWEEN 1 AND 58),
+    ADD COLUMN IF NOT EXISTS commune_code INT;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_wilaya_code ON jobs (wilaya_code, commune_code);
+CREATE INDEX IF NOT EXISTS idx_jobs_coordinates ON jobs (latitude, longitude) WHERE latitude IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_jobs_category_id;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS job_categories;
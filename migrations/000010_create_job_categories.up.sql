@@ -0,0 +1,52 @@
+-- Job categories form a tree managed by the admins, the top level categories
+-- are the industries (IT, Health...) and their children the specialities
+CREATE TABLE IF NOT EXISTS job_categories (
+    category_id BIGSERIAL PRIMARY KEY,
+    parent_id BIGINT REFERENCES job_categories(category_id) ON DELETE RESTRICT,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_categories_slug_unique UNIQUE (slug),
+    CONSTRAINT job_categories_parent_check CHECK (parent_id <> category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_categories_parent_id ON job_categories (parent_id);
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS category_id BIGINT REFERENCES job_categories(category_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_category_id ON jobs (category_id);
+
+INSERT INTO job_categories (name, slug) VALUES
+    ('IT', 'it'),
+    ('Health', 'health'),
+    ('Engineering', 'engineering'),
+    ('Finance', 'finance'),
+    ('Sales & Marketing', 'sales-marketing'),
+    ('Education', 'education'),
+    ('Hospitality & Tourism', 'hospitality-tourism'),
+    ('Oil & Gas', 'oil-gas')
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO job_categories (parent_id, name, slug)
+SELECT parent.category_id, child.name, child.slug
+FROM (VALUES
+    ('it', 'Backend', 'backend'),
+    ('it', 'Frontend', 'frontend'),
+    ('it', 'Mobile', 'mobile'),
+    ('it', 'DevOps', 'devops'),
+    ('it', 'Data', 'data'),
+    ('health', 'Nursing', 'nursing'),
+    ('health', 'Medicine', 'medicine'),
+    ('health', 'Pharmacy', 'pharmacy'),
+    ('engineering', 'Civil Engineering', 'civil-engineering'),
+    ('engineering', 'Electrical Engineering', 'electrical-engineering'),
+    ('engineering', 'Mechanical Engineering', 'mechanical-engineering'),
+    ('finance', 'Accounting', 'accounting'),
+    ('finance', 'Banking', 'banking'),
+    ('sales-marketing', 'Sales', 'sales'),
+    ('sales-marketing', 'Marketing', 'marketing'),
+    ('education', 'Teaching', 'teaching'),
+    ('education', 'Training', 'training')
+) AS child (parent_slug, name, slug)
+JOIN job_categories parent ON parent.slug = child.parent_slug
+ON CONFLICT (slug) DO NOTHING;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS job_screening_questions (
+    question_id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES jobs(job_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('yes_no', 'number', 'single_choice', 'multiple_choice', 'text')),
+    prompt TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    -- Auto-reject rules: the answers, or the number bounds, that knock a candidate out
+    reject_answers TEXT[] NOT NULL DEFAULT '{}',
+    reject_below DOUBLE PRECISION,
+    reject_above DOUBLE PRECISION
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_screening_questions_job_id ON job_screening_questions (job_id, position);
+
+-- Answers keep the prompt and type they were given to, so that they stay readable
+-- after the questions of the job change
+CREATE TABLE IF NOT EXISTS application_answers (
+    application_id BIGINT NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    question_id BIGINT REFERENCES job_screening_questions(question_id) ON DELETE SET NULL,
+    position INT NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    prompt TEXT NOT NULL,
+    value TEXT NOT NULL DEFAULT '',
+    number DOUBLE PRECISION,
+    choices TEXT[] NOT NULL DEFAULT '{}',
+    knockout BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_answers_application_id ON application_answers (application_id, position);
+CREATE INDEX IF NOT EXISTS idx_application_answers_question_id ON application_answers (question_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS calendar_feeds;
+DROP TABLE IF EXISTS interview_slots;
+DROP TABLE IF EXISTS interviews;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS interviews (
+    interview_id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'proposed',
+    duration_minutes INT NOT NULL,
+    location TEXT NOT NULL DEFAULT '',
+    video_link TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    -- Start of the slot the candidate selected, set while the interview is scheduled
+    scheduled_at TIMESTAMP,
+    -- iCalendar SEQUENCE of the invites, bumped every time the sent invites change
+    sequence INT NOT NULL DEFAULT 0,
+    cancel_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT interviews_status_check CHECK (status IN ('proposed', 'scheduled', 'cancelled')),
+    CONSTRAINT interviews_duration_check CHECK (duration_minutes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interviews_application_id ON interviews (application_id);
+CREATE INDEX IF NOT EXISTS idx_interviews_scheduled_at ON interviews (scheduled_at) WHERE status = 'scheduled';
+
+CREATE TABLE IF NOT EXISTS interview_slots (
+    slot_id BIGSERIAL PRIMARY KEY,
+    interview_id BIGINT NOT NULL REFERENCES interviews(interview_id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    CONSTRAINT interview_slots_unique UNIQUE (interview_id, starts_at)
+);
+
+-- Secret tokens of the per-user iCalendar feeds of upcoming interviews
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id UUID PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT calendar_feeds_token_unique UNIQUE (token)
+);
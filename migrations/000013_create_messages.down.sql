@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS message_reports;
+DROP TABLE IF EXISTS message_attachments;
+DROP TABLE IF EXISTS messages;
@@ -0,0 +1,42 @@
+-- Messages of the conversation thread between the recruiter and the candidate of an application
+CREATE TABLE IF NOT EXISTS messages (
+    message_id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    body TEXT NOT NULL DEFAULT '',
+    -- Read receipt, set when the other participant of the thread reads the message
+    read_at TIMESTAMP,
+    -- Set when an admin removes the message after a report, its content is no longer shown
+    removed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_application_id ON messages (application_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_messages_unread ON messages (application_id, sender_id) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS message_attachments (
+    attachment_id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_attachments_message_id ON message_attachments (message_id);
+
+CREATE TABLE IF NOT EXISTS message_reports (
+    report_id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE,
+    reported_by UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    reviewed_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT message_reports_status_check CHECK (status IN ('open', 'dismissed', 'removed')),
+    CONSTRAINT message_reports_unique UNIQUE (message_id, reported_by)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_reports_status ON message_reports (status, created_at);
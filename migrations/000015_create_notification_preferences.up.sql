@@ -0,0 +1,36 @@
+-- Quiet hours are minutes after midnight in Algiers time, both set or both unset
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    quiet_hours_start SMALLINT CHECK (quiet_hours_start BETWEEN 0 AND 1439),
+    quiet_hours_end SMALLINT CHECK (quiet_hours_end BETWEEN 0 AND 1439),
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+-- Events are delivered on every channel without a row here
+CREATE TABLE IF NOT EXISTS notification_channel_preferences (
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    channel VARCHAR(10) NOT NULL CHECK (channel IN ('email', 'in_app')),
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type, channel)
+);
+
+-- Emails held back by quiet hours or batched in a digest until send_after
+CREATE TABLE IF NOT EXISTS email_outbox (
+    email_id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    subject TEXT NOT NULL,
+    plain_text TEXT NOT NULL,
+    html TEXT NOT NULL,
+    unsubscribe_url TEXT NOT NULL DEFAULT '',
+    attachments JSONB NOT NULL DEFAULT '[]',
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    send_after TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_send_after ON email_outbox (send_after);
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+
+-- Accounts created before email verification keep posting jobs and applying
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS application_transitions_append_only ON application_transitions;
+
+DELETE FROM application_transitions t
+WHERE NOT EXISTS (SELECT 1 FROM applications a WHERE a.application_id = t.application_id);
+
+ALTER TABLE application_transitions
+    ADD CONSTRAINT application_transitions_application_id_fkey
+    FOREIGN KEY (application_id) REFERENCES applications(application_id) ON DELETE CASCADE;
+
+CREATE TRIGGER application_transitions_append_only
+    BEFORE UPDATE ON application_transitions
+    FOR EACH ROW EXECUTE FUNCTION forbid_application_transition_changes();
@@ -0,0 +1,7 @@
+-- the transition log is append-only, it outlives withdrawn and deleted applications
+ALTER TABLE application_transitions DROP CONSTRAINT IF EXISTS application_transitions_application_id_fkey;
+
+DROP TRIGGER IF EXISTS application_transitions_append_only ON application_transitions;
+CREATE TRIGGER application_transitions_append_only
+    BEFORE UPDATE OR DELETE ON application_transitions
+    FOR EACH ROW EXECUTE FUNCTION forbid_application_transition_changes();
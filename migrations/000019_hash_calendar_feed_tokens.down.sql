@@ -0,0 +1,4 @@
+-- The tokens cannot be recovered from their digest, the feeds have to be created again
+DELETE FROM calendar_feeds;
+ALTER TABLE calendar_feeds RENAME CONSTRAINT calendar_feeds_token_hash_unique TO calendar_feeds_token_unique;
+ALTER TABLE calendar_feeds RENAME COLUMN token_hash TO token;
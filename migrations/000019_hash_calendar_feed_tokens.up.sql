@@ -0,0 +1,5 @@
+-- Calendar feed tokens are stored as their SHA-256 digest, the URLs handed out
+-- so far keep working
+ALTER TABLE calendar_feeds RENAME COLUMN token TO token_hash;
+ALTER TABLE calendar_feeds RENAME CONSTRAINT calendar_feeds_token_unique TO calendar_feeds_token_hash_unique;
+UPDATE calendar_feeds SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex');
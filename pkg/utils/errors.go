@@ -1,5 +1,10 @@
 package utils
 
+import (
+	"net/http"
+	"time"
+)
+
 func ErrorPanic(err error) {
 	if err != nil {
 		panic(err)
@@ -9,6 +14,8 @@ func ErrorPanic(err error) {
 type CustomError struct {
 	StatusCode int
 	Message    string
+	// RetryAfter is sent back as the Retry-After header when set
+	RetryAfter time.Duration
 }
 
 func (c *CustomError) Error() string {
@@ -21,3 +28,20 @@ func NewCustomError(statusCode int, message string) *CustomError {
 		Message:    message,
 	}
 }
+
+func NewRetryAfterError(message string, retryAfter time.Duration) *CustomError {
+	return &CustomError{
+		StatusCode: http.StatusTooManyRequests,
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
+// RetryAfterSeconds returns the Retry-After header value for d, rounded up to whole seconds
+func RetryAfterSeconds(d time.Duration) int {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
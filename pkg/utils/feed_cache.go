@@ -0,0 +1,9 @@
+package utils
+
+import "time"
+
+// FeedCache is a rendered job feed along with the time its content last changed
+type FeedCache struct {
+	Body         string    `json:"body"`
+	LastModified time.Time `json:"last_modified"`
+}
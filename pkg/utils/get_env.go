@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -19,6 +20,12 @@ func GetEnv(key string, valueType string) (interface{}, error) {
 			return nil, fmt.Errorf("failed to parse duration for environment variable %s: %v", key, err)
 		}
 		return duration, nil
+	case "bool":
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bool for environment variable %s: %v", key, err)
+		}
+		return boolean, nil
 	case "string":
 		return value, nil
 	default:
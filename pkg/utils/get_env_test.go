@@ -35,6 +35,21 @@ func TestGetEnv(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to parse duration")
 	})
 
+	t.Run("Bool environment variable", func(t *testing.T) {
+		os.Setenv("TEST_BOOL", "true")
+		defer os.Unsetenv("TEST_BOOL")
+
+		val, err := GetEnv("TEST_BOOL", "bool")
+		assert.NoError(t, err)
+		assert.True(t, val.(bool))
+
+		os.Setenv("TEST_BOOL", "sometimes")
+		val, err = GetEnv("TEST_BOOL", "bool")
+		assert.Nil(t, val, "Value should be nil for an invalid bool")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse bool")
+	})
+
 	t.Run("Unsupported value type", func(t *testing.T) {
 		os.Setenv("TEST_KEY", "test_value")
 		defer os.Unsetenv("TEST_KEY")
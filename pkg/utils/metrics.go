@@ -7,10 +7,11 @@ import (
 )
 
 var (
-	startTime     = time.Now()
-	totalRequests int
-	totalErrors   int
-	mu            sync.Mutex
+	startTime         = time.Now()
+	totalRequests     int
+	totalErrors       int
+	rateLimitFailures int
+	mu                sync.Mutex
 )
 
 // IncrementRequest increments the total request count
@@ -27,6 +28,20 @@ func IncrementError() {
 	totalErrors++
 }
 
+// IncrementRateLimitFailure increments the count of rate limit checks that failed
+func IncrementRateLimitFailure() {
+	mu.Lock()
+	defer mu.Unlock()
+	rateLimitFailures++
+}
+
+// GetRateLimitFailures returns the count of rate limit checks that failed
+func GetRateLimitFailures() int {
+	mu.Lock()
+	defer mu.Unlock()
+	return rateLimitFailures
+}
+
 // GetMetrics returns the calculated metrics
 func GetMetrics() (uptime string, requestCount string, errorRate string) {
 	mu.Lock()
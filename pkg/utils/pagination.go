@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Cursor is the decoded form of an opaque keyset pagination cursor. It points
+// at the row with the given sort value and ID, Backward cursors page towards
+// the start of the list.
+type Cursor struct {
+	Sort     string `json:"s"`
+	Order    string `json:"o"`
+	Value    string `json:"v"`
+	ID       string `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+func EncodeCursor(cursor Cursor) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	var cursor Cursor
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+	if cursor.Sort == "" || cursor.ID == "" || (cursor.Order != "asc" && cursor.Order != "desc") {
+		return cursor, errors.New("invalid cursor")
+	}
+	return cursor, nil
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		cursor := Cursor{Sort: "created_at", Order: "desc", Value: "2024-01-02 10:00:00+00", ID: "42", Backward: true}
+
+		decoded, err := DecodeCursor(EncodeCursor(cursor))
+		assert.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+	})
+
+	t.Run("Malformed Cursor", func(t *testing.T) {
+		_, err := DecodeCursor("not a cursor!")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cursor")
+	})
+
+	t.Run("Incomplete Cursor", func(t *testing.T) {
+		_, err := DecodeCursor(EncodeCursor(Cursor{Sort: "title", Order: "sideways", ID: "1"}))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package utils
+
+import "time"
+
+// RateLimit is a GCRA policy allowing bursts of up to Limit requests, replenished
+// evenly over Period
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+	// FailClosed refuses the requests while the limit cannot be checked, instead
+	// of letting them through
+	FailClosed bool
+}
+
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long to wait before the next request is allowed, 0 when
+	// this one was
+	RetryAfter time.Duration
+	// ResetAfter is how long until the whole limit is available again
+	ResetAfter time.Duration
+}
+
+// Take takes a request out of the limit of a client given the theoretical arrival
+// time stored for it, zero for a new client. It returns the result and the
+// theoretical arrival time to store, unchanged when the request is refused.
+func (l RateLimit) Take(tat, now time.Time) (RateLimitResult, time.Time) {
+	interval := l.Period / time.Duration(l.Limit)
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTAT := tat.Add(interval)
+	allowAt := newTAT.Add(-interval * time.Duration(l.Limit))
+	if now.Before(allowAt) {
+		return RateLimitResult{
+			RetryAfter: allowAt.Sub(now),
+			ResetAfter: tat.Sub(now),
+		}, tat
+	}
+
+	return RateLimitResult{
+		Allowed:    true,
+		Remaining:  int(now.Sub(allowAt) / interval),
+		ResetAfter: newTAT.Sub(now),
+	}, newTAT
+}
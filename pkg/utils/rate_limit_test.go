@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	limit := RateLimit{Limit: 3, Period: 3 * time.Second}
+
+	t.Run("Burst", func(t *testing.T) {
+		var tat time.Time
+		var result RateLimitResult
+		for remaining := 2; remaining >= 0; remaining-- {
+			result, tat = limit.Take(tat, now)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, remaining, result.Remaining)
+		}
+		assert.Equal(t, 3*time.Second, result.ResetAfter)
+
+		refused, refusedTAT := limit.Take(tat, now)
+		assert.False(t, refused.Allowed)
+		assert.Zero(t, refused.Remaining)
+		assert.Equal(t, time.Second, refused.RetryAfter)
+		assert.Equal(t, 3*time.Second, refused.ResetAfter)
+		assert.Equal(t, tat, refusedTAT)
+	})
+
+	t.Run("Replenish", func(t *testing.T) {
+		tat := now.Add(3 * time.Second)
+
+		result, _ := limit.Take(tat, now.Add(time.Second))
+		assert.True(t, result.Allowed)
+		assert.Zero(t, result.Remaining)
+
+		result, _ = limit.Take(tat, now.Add(time.Minute))
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Remaining)
+		assert.Equal(t, time.Second, result.ResetAfter)
+	})
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// sealedSecretPrefix marks the values made by SealSecret, telling them from the
+// plaintext ones stored before
+const sealedSecretPrefix = "v1:"
+
+// SealSecret encrypts a secret that has to be read back, such as a TOTP secret,
+// with AES-256-GCM under a key derived from secretKey
+func SealSecret(secret, secretKey string) (string, error) {
+	aead, err := newSecretAEAD(secretKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := generateRandomBytes(aead.NonceSize())
+	if nonce == nil {
+		return "", fmt.Errorf("failed to generate nonce")
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return sealedSecretPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenSecret decrypts a value made by SealSecret with the same secret key
+func OpenSecret(sealed, secretKey string) (string, error) {
+	encoded, ok := strings.CutPrefix(sealed, sealedSecretPrefix)
+	if !ok {
+		return "", fmt.Errorf("secret is not sealed")
+	}
+	data, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid sealed secret: %w", err)
+	}
+	aead, err := newSecretAEAD(secretKey)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < aead.NonceSize() {
+		return "", fmt.Errorf("invalid sealed secret")
+	}
+	secret, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to open sealed secret: %w", err)
+	}
+	return string(secret), nil
+}
+
+// IsSealedSecret reports whether a stored value was made by SealSecret
+func IsSealedSecret(value string) bool {
+	return strings.HasPrefix(value, sealedSecretPrefix)
+}
+
+func newSecretAEAD(secretKey string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(secretKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealSecret(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		sealed, err := SealSecret("JBSWY3DPEHPK3PXP", "key")
+		assert.NoError(t, err)
+		assert.True(t, IsSealedSecret(sealed))
+		assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+
+		secret, err := OpenSecret(sealed, "key")
+		assert.NoError(t, err)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", secret)
+	})
+
+	t.Run("Fresh Nonce", func(t *testing.T) {
+		first, _ := SealSecret("JBSWY3DPEHPK3PXP", "key")
+		second, _ := SealSecret("JBSWY3DPEHPK3PXP", "key")
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Wrong Key", func(t *testing.T) {
+		sealed, _ := SealSecret("JBSWY3DPEHPK3PXP", "key")
+		_, err := OpenSecret(sealed, "other key")
+		assert.Error(t, err)
+	})
+
+	t.Run("Plaintext Value", func(t *testing.T) {
+		assert.False(t, IsSealedSecret("JBSWY3DPEHPK3PXP"))
+		_, err := OpenSecret("JBSWY3DPEHPK3PXP", "key")
+		assert.Error(t, err)
+	})
+}
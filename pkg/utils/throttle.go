@@ -0,0 +1,60 @@
+package utils
+
+import "time"
+
+// Throttle is a brute-force policy over the attempts made within a sliding window
+type Throttle struct {
+	Window time.Duration
+	// Limit caps the attempts within the window, 0 for no cap
+	Limit int
+	// Past FreeAttempts, each attempt has to wait BaseDelay after the last one,
+	// doubling with every further attempt up to MaxDelay. 0 BaseDelay for no backoff
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	// LockAfter attempts lock out further ones for LockDuration, 0 for no lock
+	LockAfter    int
+	LockDuration time.Duration
+}
+
+// RetryAfter returns how long to wait before another attempt given the times of the
+// attempts within the window, oldest first. 0 when one can be made now
+func (t Throttle) RetryAfter(attempts []time.Time, now time.Time) time.Duration {
+	count := len(attempts)
+	if count == 0 {
+		return 0
+	}
+	last := attempts[count-1]
+
+	var until time.Time
+	if t.BaseDelay > 0 && count > t.FreeAttempts {
+		delay := t.BaseDelay
+		for i := t.FreeAttempts + 1; i < count && (t.MaxDelay == 0 || delay < t.MaxDelay); i++ {
+			delay *= 2
+		}
+		if t.MaxDelay > 0 && delay > t.MaxDelay {
+			delay = t.MaxDelay
+		}
+		until = last.Add(delay)
+	}
+	if t.Locked(count) {
+		if lockedUntil := last.Add(t.LockDuration); lockedUntil.After(until) {
+			until = lockedUntil
+		}
+	}
+	if t.Limit > 0 && count >= t.Limit {
+		if freedAt := attempts[count-t.Limit].Add(t.Window); freedAt.After(until) {
+			until = freedAt
+		}
+	}
+
+	if !until.After(now) {
+		return 0
+	}
+	return until.Sub(now)
+}
+
+// Locked reports whether count attempts lock out further ones
+func (t Throttle) Locked(count int) bool {
+	return t.LockAfter > 0 && count >= t.LockAfter
+}
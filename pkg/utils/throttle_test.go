@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	attemptsAgo := func(ago ...time.Duration) []time.Time {
+		attempts := make([]time.Time, 0, len(ago))
+		for _, d := range ago {
+			attempts = append(attempts, now.Add(-d))
+		}
+		return attempts
+	}
+
+	t.Run("Free Attempts", func(t *testing.T) {
+		throttle := Throttle{Window: 15 * time.Minute, FreeAttempts: 3, BaseDelay: time.Second}
+
+		assert.Zero(t, throttle.RetryAfter(nil, now))
+		assert.Zero(t, throttle.RetryAfter(attemptsAgo(0, 0, 0), now))
+	})
+
+	t.Run("Exponential Backoff", func(t *testing.T) {
+		throttle := Throttle{Window: 15 * time.Minute, FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+		assert.Equal(t, time.Second, throttle.RetryAfter(attemptsAgo(0, 0, 0, 0), now))
+		assert.Equal(t, 2*time.Second, throttle.RetryAfter(attemptsAgo(0, 0, 0, 0, 0), now))
+		assert.Equal(t, 3*time.Second, throttle.RetryAfter(attemptsAgo(0, 0, 0, 0, 0, time.Second), now))
+		assert.Equal(t, 5*time.Second, throttle.RetryAfter(attemptsAgo(0, 0, 0, 0, 0, 0, 0, 0), now))
+		assert.Zero(t, throttle.RetryAfter(attemptsAgo(time.Minute, time.Minute, time.Minute, time.Minute), now))
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		throttle := Throttle{Window: 15 * time.Minute, LockAfter: 3, LockDuration: 10 * time.Minute}
+
+		assert.False(t, throttle.Locked(2))
+		assert.True(t, throttle.Locked(3))
+		assert.Zero(t, throttle.RetryAfter(attemptsAgo(time.Minute, 0), now))
+		assert.Equal(t, 9*time.Minute, throttle.RetryAfter(attemptsAgo(3*time.Minute, 2*time.Minute, time.Minute), now))
+		assert.Zero(t, throttle.RetryAfter(attemptsAgo(12*time.Minute, 11*time.Minute, 10*time.Minute), now))
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		throttle := Throttle{Window: time.Hour, Limit: 2}
+
+		assert.Zero(t, throttle.RetryAfter(attemptsAgo(time.Minute), now))
+		assert.Equal(t, 40*time.Minute, throttle.RetryAfter(attemptsAgo(20*time.Minute, time.Minute), now))
+		assert.Equal(t, 50*time.Minute, throttle.RetryAfter(attemptsAgo(20*time.Minute, 10*time.Minute, time.Minute), now))
+	})
+
+	t.Run("Retry After Seconds", func(t *testing.T) {
+		assert.Equal(t, 1, RetryAfterSeconds(0))
+		assert.Equal(t, 2, RetryAfterSeconds(1500*time.Millisecond))
+		assert.Equal(t, 60, RetryAfterSeconds(time.Minute))
+	})
+}
@@ -1,21 +1,35 @@
 package utils
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
 type TokenClaims struct {
-	ID      string `json:"sub"`
-	Role    string `json:"role"`
-	Purpose string `json:"purpose"`
+	ID        string `json:"sub"`
+	Role      string `json:"role"`
+	Purpose   string `json:"purpose"`
+	SessionID string `json:"sid"`
+	TwoFactor bool   `json:"tfa"`
 	jwt.StandardClaims
 }
 
 func GenerateToken(userID string, ttl time.Duration, purpose string, role string, secretJWTKey string) (string, error) {
+	return GenerateSessionToken(userID, "", false, ttl, purpose, role, secretJWTKey)
+}
+
+// GenerateSessionToken returns a token like GenerateToken, bound to the login
+// session sessionID through its sid claim. twoFactor sets the tfa claim of
+// sessions logged in with a second factor.
+func GenerateSessionToken(userID, sessionID string, twoFactor bool, ttl time.Duration, purpose string, role string, secretJWTKey string) (string, error) {
 
 	token := jwt.New(jwt.SigningMethodHS256)
 	now := time.Now().UTC()
@@ -34,6 +48,12 @@ func GenerateToken(userID string, ttl time.Duration, purpose string, role string
 	if purpose == "access" && role != "" {
 		claims["role"] = role
 	}
+	if sessionID != "" {
+		claims["sid"] = sessionID
+	}
+	if twoFactor {
+		claims["tfa"] = true
+	}
 
 	tokenString, err := token.SignedString([]byte(secretJWTKey))
 	if err != nil {
@@ -77,6 +97,48 @@ func GenerateSecureOTP(length int) string {
 	return string(otp)
 }
 
+// GenerateOpaqueToken returns a random, URL safe token carrying size bytes of entropy
+func GenerateOpaqueToken(size int) string {
+	return hex.EncodeToString(generateRandomBytes(size))
+}
+
+// HashToken returns the SHA-256 digest of a token, for storing tokens that are
+// only ever compared
+func HashToken(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
+
+// SignToken returns a URL safe token carrying payload and its HMAC-SHA256
+// signature, for links that must not be forged but do not expire
+func SignToken(payload, secretKey string) string {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signPayload(encoded, secretKey))
+}
+
+// VerifySignedToken returns the payload of a token made by SignToken with the same secret
+func VerifySignedToken(token, secretKey string) (string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", fmt.Errorf("invalid token format")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, signPayload(encoded, secretKey)) {
+		return "", fmt.Errorf("invalid token signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid token payload: %w", err)
+	}
+	return string(payload), nil
+}
+
+func signPayload(encoded, secretKey string) []byte {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
 func generateRandomBytes(size int) []byte {
 	randomBytes := make([]byte, size)
 	_, err := rand.Read(randomBytes)
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -44,6 +45,17 @@ func TestGenerateToken(t *testing.T) {
 		assert.Contains(t, err.Error(), "token purpose mismatch")
 	})
 
+	t.Run("Session Token", func(t *testing.T) {
+		token, err := GenerateSessionToken("user123", "session456", true, time.Hour, "refresh", "", secret)
+		assert.NoError(t, err)
+
+		claims, err := ValidateToken(token, secret, "refresh")
+		assert.NoError(t, err)
+		assert.Equal(t, "user123", claims.ID)
+		assert.Equal(t, "session456", claims.SessionID)
+		assert.True(t, claims.TwoFactor)
+	})
+
 	t.Run("Invalid Token Signature", func(t *testing.T) {
 		token, err := GenerateToken("user123", time.Hour, "access", "admin", secret)
 		assert.NoError(t, err)
@@ -53,3 +65,47 @@ func TestGenerateToken(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid token")
 	})
 }
+
+func TestGenerateOpaqueToken(t *testing.T) {
+	token := GenerateOpaqueToken(32)
+	assert.Len(t, token, 64)
+	assert.Regexp(t, "^[0-9a-f]+$", token)
+	assert.NotEqual(t, token, GenerateOpaqueToken(32))
+}
+
+func TestSignToken(t *testing.T) {
+	secret := "supersecretkey"
+
+	t.Run("Valid Signed Token", func(t *testing.T) {
+		token := SignToken("user123:job_alert", secret)
+		assert.Regexp(t, "^[A-Za-z0-9_-]+\\.[A-Za-z0-9_-]+$", token)
+
+		payload, err := VerifySignedToken(token, secret)
+		assert.NoError(t, err)
+		assert.Equal(t, "user123:job_alert", payload)
+	})
+
+	t.Run("Invalid Signature", func(t *testing.T) {
+		token := SignToken("user123:job_alert", secret)
+
+		_, err := VerifySignedToken(token, "wrongsecretkey")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid token signature")
+	})
+
+	t.Run("Tampered Payload", func(t *testing.T) {
+		token := SignToken("user123:job_alert", secret)
+		_, signature, _ := strings.Cut(token, ".")
+		forged := SignToken("user456:job_alert", secret)
+		encoded, _, _ := strings.Cut(forged, ".")
+
+		_, err := VerifySignedToken(encoded+"."+signature, secret)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		_, err := VerifySignedToken("not-a-token", secret)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid token format")
+	})
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 appendix B test vectors for SHA1, truncated to 6 digits
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, expected := range vectors {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+
+	_, err := TOTPCode("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret := GenerateTOTPSecret()
+	assert.Len(t, secret, 32)
+	now := time.Unix(1700000000, 0)
+
+	t.Run("Current Code", func(t *testing.T) {
+		code, _ := TOTPCode(secret, TOTPStep(now))
+		step, ok := ValidateTOTP(secret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, TOTPStep(now), step)
+	})
+
+	t.Run("Clock Skew", func(t *testing.T) {
+		code, _ := TOTPCode(secret, TOTPStep(now)-1)
+		step, ok := ValidateTOTP(secret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, TOTPStep(now)-1, step)
+
+		code, _ = TOTPCode(secret, TOTPStep(now)+2)
+		_, ok = ValidateTOTP(secret, code, now)
+		assert.False(t, ok)
+	})
+
+	t.Run("Invalid Code", func(t *testing.T) {
+		_, ok := ValidateTOTP(secret, "12345", now)
+		assert.False(t, ok)
+		_, ok = ValidateTOTP(secret, "abcdef", now)
+		assert.False(t, ok)
+	})
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("DZ Jobs", "user@example.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/DZ%20Jobs:user@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=DZ+Jobs")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}